@@ -336,6 +336,141 @@ func (m *SmartInfoResponse) GetSmartInfo() string {
 	return ""
 }
 
+type WatchDrivesRequest struct {
+	NodeId               string   `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchDrivesRequest) Reset()         { *m = WatchDrivesRequest{} }
+func (m *WatchDrivesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchDrivesRequest) ProtoMessage()    {}
+func (*WatchDrivesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65bf77650f5c7dcf, []int{8}
+}
+
+func (m *WatchDrivesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchDrivesRequest.Unmarshal(m, b)
+}
+func (m *WatchDrivesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchDrivesRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchDrivesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchDrivesRequest.Merge(m, src)
+}
+func (m *WatchDrivesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchDrivesRequest.Size(m)
+}
+func (m *WatchDrivesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchDrivesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchDrivesRequest proto.InternalMessageInfo
+
+func (m *WatchDrivesRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+type DriveEvent struct {
+	// ADDED, MODIFIED or REMOVED
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Drive                *Drive   `protobuf:"bytes,2,opt,name=drive,proto3" json:"drive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DriveEvent) Reset()         { *m = DriveEvent{} }
+func (m *DriveEvent) String() string { return proto.CompactTextString(m) }
+func (*DriveEvent) ProtoMessage()    {}
+func (*DriveEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65bf77650f5c7dcf, []int{9}
+}
+
+func (m *DriveEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DriveEvent.Unmarshal(m, b)
+}
+func (m *DriveEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DriveEvent.Marshal(b, m, deterministic)
+}
+func (m *DriveEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DriveEvent.Merge(m, src)
+}
+func (m *DriveEvent) XXX_Size() int {
+	return xxx_messageInfo_DriveEvent.Size(m)
+}
+func (m *DriveEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DriveEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DriveEvent proto.InternalMessageInfo
+
+func (m *DriveEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *DriveEvent) GetDrive() *Drive {
+	if m != nil {
+		return m.Drive
+	}
+	return nil
+}
+
+type WatchDrivesResponse struct {
+	// snapshot is true for the first response which contains all drives as ADDED events
+	Snapshot             bool          `protobuf:"varint,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Events               []*DriveEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *WatchDrivesResponse) Reset()         { *m = WatchDrivesResponse{} }
+func (m *WatchDrivesResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchDrivesResponse) ProtoMessage()    {}
+func (*WatchDrivesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65bf77650f5c7dcf, []int{10}
+}
+
+func (m *WatchDrivesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchDrivesResponse.Unmarshal(m, b)
+}
+func (m *WatchDrivesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchDrivesResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchDrivesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchDrivesResponse.Merge(m, src)
+}
+func (m *WatchDrivesResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchDrivesResponse.Size(m)
+}
+func (m *WatchDrivesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchDrivesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchDrivesResponse proto.InternalMessageInfo
+
+func (m *WatchDrivesResponse) GetSnapshot() bool {
+	if m != nil {
+		return m.Snapshot
+	}
+	return false
+}
+
+func (m *WatchDrivesResponse) GetEvents() []*DriveEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*DrivesRequest)(nil), "v1api.DrivesRequest")
 	proto.RegisterType((*DrivesResponse)(nil), "v1api.DrivesResponse")
@@ -345,36 +480,45 @@ func init() {
 	proto.RegisterType((*Empty)(nil), "v1api.Empty")
 	proto.RegisterType((*SmartInfoRequest)(nil), "v1api.SmartInfoRequest")
 	proto.RegisterType((*SmartInfoResponse)(nil), "v1api.SmartInfoResponse")
+	proto.RegisterType((*WatchDrivesRequest)(nil), "v1api.WatchDrivesRequest")
+	proto.RegisterType((*DriveEvent)(nil), "v1api.DriveEvent")
+	proto.RegisterType((*WatchDrivesResponse)(nil), "v1api.WatchDrivesResponse")
 }
 
 func init() { proto.RegisterFile("drivemgrsvc.proto", fileDescriptor_65bf77650f5c7dcf) }
 
 var fileDescriptor_65bf77650f5c7dcf = []byte{
-	// 374 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0xc1, 0x4e, 0xc2, 0x40,
-	0x10, 0xa5, 0x98, 0x96, 0x30, 0x80, 0xb1, 0xab, 0x60, 0x6d, 0x3c, 0x90, 0xbd, 0x48, 0xa2, 0x92,
-	0x80, 0x86, 0xa3, 0x09, 0x06, 0x83, 0x24, 0x84, 0x43, 0xb9, 0x71, 0x2b, 0xed, 0x6a, 0x1a, 0x69,
-	0xb7, 0x76, 0x97, 0x26, 0xfc, 0xa8, 0xdf, 0x63, 0xe8, 0xee, 0x42, 0x4b, 0x8d, 0xc7, 0x79, 0xf3,
-	0xe6, 0xcd, 0x9b, 0xb7, 0x0b, 0xa6, 0x9f, 0x04, 0x29, 0x09, 0x3f, 0x13, 0x96, 0x7a, 0xfd, 0x38,
-	0xa1, 0x9c, 0x22, 0x3d, 0x1d, 0xb8, 0x71, 0x60, 0x37, 0xf8, 0x2e, 0x26, 0x4c, 0x60, 0xf8, 0x0e,
-	0x5a, 0x93, 0x3d, 0x91, 0x39, 0xe4, 0x7b, 0x4b, 0x18, 0x47, 0x1d, 0x30, 0x22, 0xea, 0x93, 0x99,
-	0x6f, 0x69, 0x5d, 0xad, 0x57, 0x77, 0x64, 0x85, 0x9f, 0xe1, 0x5c, 0x11, 0x59, 0x4c, 0x23, 0x46,
-	0x10, 0x06, 0xdd, 0x0f, 0xd8, 0x17, 0xb3, 0xb4, 0xee, 0x59, 0xaf, 0x31, 0x6c, 0xf6, 0x33, 0xf9,
-	0x7e, 0xc6, 0x72, 0x44, 0x0b, 0xaf, 0x00, 0x65, 0xf5, 0x9c, 0x7a, 0x2e, 0x27, 0x6a, 0xc7, 0x83,
-	0x74, 0xb7, 0x24, 0x49, 0xe0, 0x6e, 0x16, 0xdb, 0x70, 0x4d, 0x12, 0xb9, 0xae, 0xdc, 0xd8, 0x3b,
-	0x72, 0x3d, 0x1e, 0xd0, 0xc8, 0xaa, 0x76, 0xb5, 0x9e, 0xee, 0xc8, 0x0a, 0x3f, 0xc2, 0x65, 0x41,
-	0x5b, 0xda, 0xea, 0x80, 0xc1, 0xb8, 0xcb, 0xb7, 0x2c, 0x53, 0xd4, 0x1d, 0x59, 0xe1, 0x7b, 0x30,
-	0x17, 0xd4, 0x3f, 0x71, 0x72, 0xd4, 0xd6, 0x0a, 0xda, 0x35, 0xd0, 0xdf, 0xc2, 0x98, 0xef, 0xf0,
-	0x08, 0x2e, 0x96, 0xa1, 0x9b, 0xf0, 0x59, 0xf4, 0x41, 0xd5, 0x10, 0x86, 0x26, 0x2b, 0x3b, 0x2f,
-	0x60, 0x78, 0x00, 0x66, 0x6e, 0x4e, 0x5a, 0xbb, 0x85, 0x3a, 0x53, 0xa0, 0x9c, 0x3a, 0x02, 0xc3,
-	0x9f, 0x2a, 0x34, 0x27, 0xf2, 0xfa, 0x34, 0xf0, 0x08, 0x7a, 0x81, 0xd6, 0x94, 0x70, 0x91, 0xfa,
-	0x3c, 0x60, 0x1c, 0x5d, 0xe5, 0x23, 0x56, 0x2f, 0x66, 0xb7, 0x4f, 0x50, 0xb1, 0x0c, 0x57, 0xd0,
-	0x18, 0x0c, 0x71, 0x2d, 0xba, 0xc9, 0x53, 0x0a, 0x09, 0xd8, 0xf6, 0x5f, 0xad, 0x83, 0xc4, 0x08,
-	0x40, 0x60, 0xfb, 0xe8, 0x90, 0x25, 0xb9, 0xa5, 0x1c, 0x6d, 0xf5, 0xf8, 0x22, 0xb4, 0x0a, 0x7a,
-	0x07, 0x53, 0x59, 0x3f, 0xc4, 0x80, 0xae, 0x25, 0xe9, 0x34, 0x50, 0xdb, 0x2a, 0x37, 0x72, 0x47,
-	0xb4, 0xa7, 0x84, 0x8f, 0x37, 0x1b, 0x71, 0xde, 0x51, 0xad, 0xb0, 0xf2, 0x3f, 0x89, 0xd7, 0xda,
-	0x4a, 0xfc, 0xfc, 0xb5, 0x91, 0xfd, 0xf9, 0xa7, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xa9, 0xec,
-	0x2b, 0xba, 0x1c, 0x03, 0x00, 0x00,
+	// 469 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdf, 0x4f, 0xd4, 0x40,
+	0x10, 0xa6, 0x60, 0x0b, 0xcc, 0x1d, 0xc6, 0x2e, 0x82, 0x75, 0xe3, 0xc3, 0x65, 0x5f, 0x3c, 0x23,
+	0x5e, 0x04, 0x0d, 0x8f, 0x26, 0x98, 0x23, 0x40, 0x42, 0x78, 0x28, 0x0f, 0x26, 0xc4, 0x97, 0xa5,
+	0x5d, 0xa5, 0xf1, 0xda, 0xad, 0x9d, 0xbd, 0x26, 0xfc, 0x37, 0xfe, 0xa9, 0xa6, 0xfb, 0xa3, 0xd7,
+	0x5e, 0x89, 0xf1, 0xa9, 0x9d, 0x99, 0x6f, 0xbe, 0xf9, 0x76, 0xbf, 0xc9, 0x42, 0x98, 0x56, 0x59,
+	0x2d, 0xf2, 0x9f, 0x15, 0xd6, 0xc9, 0xac, 0xac, 0xa4, 0x92, 0xc4, 0xaf, 0x8f, 0x79, 0x99, 0xd1,
+	0x91, 0x7a, 0x2c, 0x05, 0x9a, 0x1c, 0x7b, 0x0b, 0x7b, 0xf3, 0x06, 0x88, 0xb1, 0xf8, 0xbd, 0x14,
+	0xa8, 0xc8, 0x21, 0x04, 0x85, 0x4c, 0xc5, 0x55, 0x1a, 0x79, 0x13, 0x6f, 0xba, 0x1b, 0xdb, 0x88,
+	0x7d, 0x86, 0xe7, 0x0e, 0x88, 0xa5, 0x2c, 0x50, 0x10, 0x06, 0x7e, 0x9a, 0xe1, 0x2f, 0x8c, 0xbc,
+	0xc9, 0xd6, 0x74, 0x74, 0x32, 0x9e, 0x69, 0xfa, 0x99, 0x46, 0xc5, 0xa6, 0xc4, 0xee, 0x80, 0xe8,
+	0xf8, 0x5a, 0x26, 0x5c, 0x09, 0x37, 0xe3, 0xc8, 0xaa, 0xbb, 0x15, 0x55, 0xc6, 0x17, 0x37, 0xcb,
+	0xfc, 0x5e, 0x54, 0x76, 0xdc, 0xb0, 0xd0, 0x28, 0xe2, 0x89, 0xca, 0x64, 0x11, 0x6d, 0x4e, 0xbc,
+	0xa9, 0x1f, 0xdb, 0x88, 0x7d, 0x80, 0xfd, 0x1e, 0xb7, 0x95, 0x75, 0x08, 0x01, 0x2a, 0xae, 0x96,
+	0xa8, 0x19, 0xfd, 0xd8, 0x46, 0xec, 0x3d, 0x84, 0x37, 0x32, 0x5d, 0x53, 0xb2, 0xe2, 0xf6, 0x7a,
+	0xdc, 0xdb, 0xe0, 0x9f, 0xe7, 0xa5, 0x7a, 0x64, 0xa7, 0xf0, 0xe2, 0x36, 0xe7, 0x95, 0xba, 0x2a,
+	0x7e, 0x48, 0xd7, 0xc4, 0x60, 0x8c, 0x43, 0xe5, 0xbd, 0x1c, 0x3b, 0x86, 0xb0, 0xd3, 0x67, 0xa5,
+	0xbd, 0x81, 0x5d, 0x74, 0x49, 0xdb, 0xb5, 0x4a, 0xb0, 0x23, 0x20, 0xdf, 0xb8, 0x4a, 0x1e, 0xfe,
+	0xcf, 0x8f, 0x39, 0x80, 0x06, 0x9e, 0xd7, 0xa2, 0x50, 0x84, 0xc0, 0xb3, 0xc6, 0x55, 0x8b, 0xd1,
+	0xff, 0xda, 0x9f, 0x06, 0xa1, 0xaf, 0x6d, 0xe8, 0x4f, 0xf3, 0x61, 0xdf, 0x61, 0xbf, 0x37, 0xd3,
+	0x0a, 0xa5, 0xb0, 0x83, 0x05, 0x2f, 0xf1, 0x41, 0x2a, 0x4d, 0xb9, 0x13, 0xb7, 0x31, 0x79, 0x07,
+	0x81, 0x68, 0x66, 0x62, 0xb4, 0xa9, 0x7d, 0x0f, 0xbb, 0xbc, 0x5a, 0x4d, 0x6c, 0x01, 0x27, 0x7f,
+	0xb6, 0x60, 0x3c, 0xb7, 0x7e, 0xd6, 0x59, 0x22, 0xc8, 0x17, 0xd8, 0xbb, 0x10, 0xca, 0x0c, 0xbb,
+	0xce, 0x50, 0x91, 0x97, 0xdd, 0x66, 0x77, 0x66, 0x7a, 0xb0, 0x96, 0x35, 0xaa, 0xd8, 0x06, 0x39,
+	0x83, 0xc0, 0xf8, 0x47, 0x5e, 0x77, 0x21, 0x3d, 0x4f, 0x29, 0x7d, 0xaa, 0xd4, 0x52, 0x9c, 0x02,
+	0x98, 0x5c, 0xb3, 0x0c, 0x24, 0xb2, 0xd8, 0xc1, 0x66, 0x50, 0x77, 0x5d, 0x66, 0x0d, 0x36, 0xc8,
+	0x25, 0x84, 0x4e, 0x7a, 0x6b, 0x2c, 0x79, 0x65, 0x41, 0xeb, 0x2b, 0x42, 0xa3, 0x61, 0xa1, 0x73,
+	0x88, 0x83, 0x0b, 0xa1, 0xce, 0x16, 0x0b, 0x73, 0xbc, 0x15, 0x5b, 0x6f, 0xe4, 0x3f, 0x29, 0x2e,
+	0x61, 0xd4, 0xb1, 0xad, 0xbd, 0x8c, 0xe1, 0xfa, 0x50, 0xfa, 0x54, 0xc9, 0xf1, 0x7c, 0xf4, 0xbe,
+	0x6e, 0xdf, 0x99, 0x57, 0xe1, 0x3e, 0xd0, 0xef, 0xc1, 0xa7, 0xbf, 0x03, 0x00, 0x48, 0x96, 0x67,
+	0xdc, 0x38, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -394,6 +538,7 @@ type DriveServiceClient interface {
 	LocateNode(ctx context.Context, in *NodeLocateRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetDriveSmartInfo(ctx context.Context, in *SmartInfoRequest, opts ...grpc.CallOption) (*SmartInfoResponse, error)
 	GetAllDrivesSmartInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SmartInfoResponse, error)
+	WatchDrives(ctx context.Context, in *WatchDrivesRequest, opts ...grpc.CallOption) (DriveService_WatchDrivesClient, error)
 }
 
 type driveServiceClient struct {
@@ -449,6 +594,38 @@ func (c *driveServiceClient) GetAllDrivesSmartInfo(ctx context.Context, in *Empt
 	return out, nil
 }
 
+func (c *driveServiceClient) WatchDrives(ctx context.Context, in *WatchDrivesRequest, opts ...grpc.CallOption) (DriveService_WatchDrivesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DriveService_serviceDesc.Streams[0], "/v1api.DriveService/WatchDrives", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &driveServiceWatchDrivesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DriveService_WatchDrivesClient interface {
+	Recv() (*WatchDrivesResponse, error)
+	grpc.ClientStream
+}
+
+type driveServiceWatchDrivesClient struct {
+	grpc.ClientStream
+}
+
+func (x *driveServiceWatchDrivesClient) Recv() (*WatchDrivesResponse, error) {
+	m := new(WatchDrivesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DriveServiceServer is the server API for DriveService service.
 type DriveServiceServer interface {
 	GetDrivesList(context.Context, *DrivesRequest) (*DrivesResponse, error)
@@ -456,6 +633,7 @@ type DriveServiceServer interface {
 	LocateNode(context.Context, *NodeLocateRequest) (*Empty, error)
 	GetDriveSmartInfo(context.Context, *SmartInfoRequest) (*SmartInfoResponse, error)
 	GetAllDrivesSmartInfo(context.Context, *Empty) (*SmartInfoResponse, error)
+	WatchDrives(*WatchDrivesRequest, DriveService_WatchDrivesServer) error
 }
 
 // UnimplementedDriveServiceServer can be embedded to have forward compatible implementations.
@@ -477,6 +655,9 @@ func (*UnimplementedDriveServiceServer) GetDriveSmartInfo(ctx context.Context, r
 func (*UnimplementedDriveServiceServer) GetAllDrivesSmartInfo(ctx context.Context, req *Empty) (*SmartInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllDrivesSmartInfo not implemented")
 }
+func (*UnimplementedDriveServiceServer) WatchDrives(req *WatchDrivesRequest, srv DriveService_WatchDrivesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDrives not implemented")
+}
 
 func RegisterDriveServiceServer(s *grpc.Server, srv DriveServiceServer) {
 	s.RegisterService(&_DriveService_serviceDesc, srv)
@@ -572,6 +753,27 @@ func _DriveService_GetAllDrivesSmartInfo_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DriveService_WatchDrives_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDrivesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DriveServiceServer).WatchDrives(m, &driveServiceWatchDrivesServer{stream})
+}
+
+type DriveService_WatchDrivesServer interface {
+	Send(*WatchDrivesResponse) error
+	grpc.ServerStream
+}
+
+type driveServiceWatchDrivesServer struct {
+	grpc.ServerStream
+}
+
+func (x *driveServiceWatchDrivesServer) Send(m *WatchDrivesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _DriveService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1api.DriveService",
 	HandlerType: (*DriveServiceServer)(nil),
@@ -597,6 +799,12 @@ var _DriveService_serviceDesc = grpc.ServiceDesc{
 			Handler:    _DriveService_GetAllDrivesSmartInfo_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDrives",
+			Handler:       _DriveService_WatchDrives_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "drivemgrsvc.proto",
 }
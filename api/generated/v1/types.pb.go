@@ -244,8 +244,31 @@ type Volume struct {
 	CSIStatus         string   `protobuf:"bytes,12,opt,name=CSIStatus,proto3" json:"CSIStatus,omitempty"`
 	Usage             string   `protobuf:"bytes,13,opt,name=Usage,proto3" json:"Usage,omitempty"`
 	// inline volumes are not support anymore. need to remove field in the next version
-	Ephemeral            bool     `protobuf:"varint,14,opt,name=Ephemeral,proto3" json:"Ephemeral,omitempty"`
-	StorageGroup         string   `protobuf:"bytes,15,opt,name=StorageGroup,proto3" json:"StorageGroup,omitempty"`
+	Ephemeral    bool   `protobuf:"varint,14,opt,name=Ephemeral,proto3" json:"Ephemeral,omitempty"`
+	StorageGroup string `protobuf:"bytes,15,opt,name=StorageGroup,proto3" json:"StorageGroup,omitempty"`
+	// ID of the volume which content is cloned to the volume
+	SourceVolumeId string `protobuf:"bytes,16,opt,name=SourceVolumeId,proto3" json:"SourceVolumeId,omitempty"`
+	// ID of the snapshot which content is restored to the volume
+	SourceSnapshotId string `protobuf:"bytes,17,opt,name=SourceSnapshotId,proto3" json:"SourceSnapshotId,omitempty"`
+	// Secret with the encryption key in format namespace/name, empty if volume isn't encrypted
+	EncryptionSecret string `protobuf:"bytes,18,opt,name=EncryptionSecret,proto3" json:"EncryptionSecret,omitempty"`
+	// Policy of the volume data destruction on release, empty means signatures wipe
+	WipePolicy string `protobuf:"bytes,19,opt,name=WipePolicy,proto3" json:"WipePolicy,omitempty"`
+	// Additional options of mkfs for the volume file system, options are space separated
+	MkfsOptions string `protobuf:"bytes,20,opt,name=MkfsOptions,proto3" json:"MkfsOptions,omitempty"`
+	// Limits of the volume IO in io.max format, e.g. "riops=1000 wbps=1048576", empty means unlimited
+	IOLimits string `protobuf:"bytes,21,opt,name=IOLimits,proto3" json:"IOLimits,omitempty"`
+	// Number of drives the LV is striped across, 0 means linear LV.
+	// For the request of the striped storage class 0 means all drives of the storage group
+	Stripes int32 `protobuf:"varint,22,opt,name=Stripes,proto3" json:"Stripes,omitempty"`
+	// Size of the stripe of striped LV, e.g. "64k", empty means LVM default
+	StripeSize string `protobuf:"bytes,23,opt,name=StripeSize,proto3" json:"StripeSize,omitempty"`
+	// Size of the cache LV on the fast drives attached to the LV, 0 means LV isn't cached
+	CacheSize int64 `protobuf:"varint,24,opt,name=CacheSize,proto3" json:"CacheSize,omitempty"`
+	// Cache mode of the cached LV: writethrough or writeback
+	CacheMode string `protobuf:"bytes,25,opt,name=CacheMode,proto3" json:"CacheMode,omitempty"`
+	// LVG CR name of the cache LV
+	CacheLocation        string   `protobuf:"bytes,26,opt,name=CacheLocation,proto3" json:"CacheLocation,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -381,6 +404,83 @@ func (m *Volume) GetStorageGroup() string {
 	return ""
 }
 
+func (m *Volume) GetSourceVolumeId() string {
+	if m != nil {
+		return m.SourceVolumeId
+	}
+	return ""
+}
+
+func (m *Volume) GetSourceSnapshotId() string {
+	if m != nil {
+		return m.SourceSnapshotId
+	}
+	return ""
+}
+
+func (m *Volume) GetEncryptionSecret() string {
+	if m != nil {
+		return m.EncryptionSecret
+	}
+	return ""
+}
+
+func (m *Volume) GetWipePolicy() string {
+	if m != nil {
+		return m.WipePolicy
+	}
+	return ""
+}
+
+func (m *Volume) GetMkfsOptions() string {
+	if m != nil {
+		return m.MkfsOptions
+	}
+	return ""
+}
+
+func (m *Volume) GetIOLimits() string {
+	if m != nil {
+		return m.IOLimits
+	}
+	return ""
+}
+
+func (m *Volume) GetStripes() int32 {
+	if m != nil {
+		return m.Stripes
+	}
+	return 0
+}
+
+func (m *Volume) GetStripeSize() string {
+	if m != nil {
+		return m.StripeSize
+	}
+	return ""
+}
+
+func (m *Volume) GetCacheSize() int64 {
+	if m != nil {
+		return m.CacheSize
+	}
+	return 0
+}
+
+func (m *Volume) GetCacheMode() string {
+	if m != nil {
+		return m.CacheMode
+	}
+	return ""
+}
+
+func (m *Volume) GetCacheLocation() string {
+	if m != nil {
+		return m.CacheLocation
+	}
+	return ""
+}
+
 type AvailableCapacity struct {
 	Location             string   `protobuf:"bytes,1,opt,name=Location,proto3" json:"Location,omitempty"`
 	NodeId               string   `protobuf:"bytes,2,opt,name=NodeId,proto3" json:"NodeId,omitempty"`
@@ -606,10 +706,14 @@ func (m *ReservationRequest) GetReservations() []string {
 }
 
 type CapacityRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	StorageClass         string   `protobuf:"bytes,2,opt,name=StorageClass,proto3" json:"StorageClass,omitempty"`
-	Size                 int64    `protobuf:"varint,3,opt,name=Size,proto3" json:"Size,omitempty"`
-	StorageGroup         string   `protobuf:"bytes,4,opt,name=StorageGroup,proto3" json:"StorageGroup,omitempty"`
+	Name         string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	StorageClass string `protobuf:"bytes,2,opt,name=StorageClass,proto3" json:"StorageClass,omitempty"`
+	Size         int64  `protobuf:"varint,3,opt,name=Size,proto3" json:"Size,omitempty"`
+	StorageGroup string `protobuf:"bytes,4,opt,name=StorageGroup,proto3" json:"StorageGroup,omitempty"`
+	// node on which capacity must be reserved, e.g. node of the clone source
+	NodeId string `protobuf:"bytes,5,opt,name=NodeId,proto3" json:"NodeId,omitempty"`
+	// number of drives of the striped LVG, 0 means all drives of the storage group
+	Stripes              int32    `protobuf:"varint,6,opt,name=Stripes,proto3" json:"Stripes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -668,14 +772,34 @@ func (m *CapacityRequest) GetStorageGroup() string {
 	return ""
 }
 
+func (m *CapacityRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *CapacityRequest) GetStripes() int32 {
+	if m != nil {
+		return m.Stripes
+	}
+	return 0
+}
+
 type LogicalVolumeGroup struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	Node                 string   `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
-	Locations            []string `protobuf:"bytes,3,rep,name=Locations,proto3" json:"Locations,omitempty"`
-	Size                 int64    `protobuf:"varint,4,opt,name=Size,proto3" json:"Size,omitempty"`
-	VolumeRefs           []string `protobuf:"bytes,5,rep,name=VolumeRefs,proto3" json:"VolumeRefs,omitempty"`
-	Status               string   `protobuf:"bytes,6,opt,name=Status,proto3" json:"Status,omitempty"`
-	Health               string   `protobuf:"bytes,7,opt,name=Health,proto3" json:"Health,omitempty"`
+	Name       string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Node       string   `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+	Locations  []string `protobuf:"bytes,3,rep,name=Locations,proto3" json:"Locations,omitempty"`
+	Size       int64    `protobuf:"varint,4,opt,name=Size,proto3" json:"Size,omitempty"`
+	VolumeRefs []string `protobuf:"bytes,5,rep,name=VolumeRefs,proto3" json:"VolumeRefs,omitempty"`
+	Status     string   `protobuf:"bytes,6,opt,name=Status,proto3" json:"Status,omitempty"`
+	Health     string   `protobuf:"bytes,7,opt,name=Health,proto3" json:"Health,omitempty"`
+	// name of the thin pool LV, empty if LVG doesn't contain thin pool
+	ThinPool string `protobuf:"bytes,8,opt,name=ThinPool,proto3" json:"ThinPool,omitempty"`
+	// RAID type of LVs (raid1, raid10 or raid5), empty if LVs are linear
+	RaidType string `protobuf:"bytes,9,opt,name=RaidType,proto3" json:"RaidType,omitempty"`
+	// number of drives LVs are striped across, 0 if LVs aren't striped
+	Stripes              int32    `protobuf:"varint,10,opt,name=Stripes,proto3" json:"Stripes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -755,6 +879,27 @@ func (m *LogicalVolumeGroup) GetHealth() string {
 	return ""
 }
 
+func (m *LogicalVolumeGroup) GetThinPool() string {
+	if m != nil {
+		return m.ThinPool
+	}
+	return ""
+}
+
+func (m *LogicalVolumeGroup) GetRaidType() string {
+	if m != nil {
+		return m.RaidType
+	}
+	return ""
+}
+
+func (m *LogicalVolumeGroup) GetStripes() int32 {
+	if m != nil {
+		return m.Stripes
+	}
+	return 0
+}
+
 type Node struct {
 	UUID string `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
 	// key - address type, value - address, align with NodeAddress struct from k8s.io/api/core/v1
@@ -928,6 +1073,105 @@ func (m *StorageGroupStatus) GetPhase() string {
 	return ""
 }
 
+type Snapshot struct {
+	Id string `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	// ID of the source volume
+	VolumeId string `protobuf:"bytes,2,opt,name=VolumeId,proto3" json:"VolumeId,omitempty"`
+	NodeId   string `protobuf:"bytes,3,opt,name=NodeId,proto3" json:"NodeId,omitempty"`
+	// location (LVG) of the source volume
+	Location     string `protobuf:"bytes,4,opt,name=Location,proto3" json:"Location,omitempty"`
+	StorageClass string `protobuf:"bytes,5,opt,name=StorageClass,proto3" json:"StorageClass,omitempty"`
+	// size of the snapshot in bytes
+	Size      int64  `protobuf:"varint,6,opt,name=Size,proto3" json:"Size,omitempty"`
+	CSIStatus string `protobuf:"bytes,7,opt,name=CSIStatus,proto3" json:"CSIStatus,omitempty"`
+	// creation time in unix nanoseconds
+	CreationTime         int64    `protobuf:"varint,8,opt,name=CreationTime,proto3" json:"CreationTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Snapshot) Reset()         { *m = Snapshot{} }
+func (m *Snapshot) String() string { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()    {}
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{12}
+}
+
+func (m *Snapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Snapshot.Unmarshal(m, b)
+}
+func (m *Snapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Snapshot.Marshal(b, m, deterministic)
+}
+func (m *Snapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Snapshot.Merge(m, src)
+}
+func (m *Snapshot) XXX_Size() int {
+	return xxx_messageInfo_Snapshot.Size(m)
+}
+func (m *Snapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_Snapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Snapshot proto.InternalMessageInfo
+
+func (m *Snapshot) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Snapshot) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *Snapshot) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *Snapshot) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *Snapshot) GetStorageClass() string {
+	if m != nil {
+		return m.StorageClass
+	}
+	return ""
+}
+
+func (m *Snapshot) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Snapshot) GetCSIStatus() string {
+	if m != nil {
+		return m.CSIStatus
+	}
+	return ""
+}
+
+func (m *Snapshot) GetCreationTime() int64 {
+	if m != nil {
+		return m.CreationTime
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Drive)(nil), "v1api.Drive")
 	proto.RegisterType((*Volume)(nil), "v1api.Volume")
@@ -943,71 +1187,87 @@ func init() {
 	proto.RegisterType((*DriveSelector)(nil), "v1api.DriveSelector")
 	proto.RegisterMapType((map[string]string)(nil), "v1api.DriveSelector.MatchFieldsEntry")
 	proto.RegisterType((*StorageGroupStatus)(nil), "v1api.StorageGroupStatus")
+	proto.RegisterType((*Snapshot)(nil), "v1api.Snapshot")
 }
 
 func init() { proto.RegisterFile("types.proto", fileDescriptor_d938547f84707355) }
 
 var fileDescriptor_d938547f84707355 = []byte{
-	// 968 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
-	0x10, 0x06, 0xf5, 0x67, 0x6b, 0x64, 0x3b, 0xf6, 0xda, 0x35, 0xb6, 0x86, 0x51, 0x08, 0x04, 0x5a,
-	0x18, 0x45, 0x20, 0xb4, 0xee, 0xa1, 0x41, 0x50, 0x14, 0x8d, 0x2d, 0x27, 0x21, 0x9a, 0x38, 0x02,
-	0x55, 0xe7, 0xd0, 0xdb, 0x9a, 0x9c, 0x5a, 0x44, 0x29, 0x91, 0xdd, 0xa5, 0x14, 0xd0, 0x97, 0xa2,
-	0xaf, 0xd0, 0x67, 0xe8, 0x73, 0xf4, 0x01, 0x7a, 0xed, 0xad, 0x4f, 0x53, 0xcc, 0x2e, 0x45, 0x2e,
-	0x25, 0x5e, 0x72, 0x9b, 0xf9, 0x66, 0x66, 0x67, 0xf8, 0xcd, 0xc7, 0x25, 0x61, 0x90, 0xe5, 0x29,
-	0xaa, 0x51, 0x2a, 0x93, 0x2c, 0x61, 0xdd, 0xd5, 0xd7, 0x22, 0x8d, 0xdc, 0x7f, 0x3b, 0xd0, 0x1d,
-	0xcb, 0x68, 0x85, 0x8c, 0x41, 0xe7, 0xee, 0xce, 0x1b, 0x73, 0x67, 0xe8, 0x5c, 0xf4, 0x7d, 0x6d,
-	0xb3, 0x43, 0x68, 0xbf, 0xf7, 0xc6, 0xbc, 0xa5, 0x21, 0x32, 0x09, 0x99, 0x78, 0x63, 0xde, 0x36,
-	0xc8, 0xc4, 0x1b, 0x33, 0x17, 0xf6, 0xa6, 0x28, 0x23, 0x11, 0xdf, 0x2e, 0xe7, 0xf7, 0x28, 0x79,
-	0x47, 0x87, 0x6a, 0x18, 0x3b, 0x85, 0xde, 0x6b, 0x14, 0x71, 0x36, 0xe3, 0x5d, 0x1d, 0x2d, 0x3c,
-	0xea, 0xf9, 0x53, 0x9e, 0x22, 0xef, 0x99, 0x9e, 0x64, 0x13, 0x36, 0x8d, 0x1e, 0x91, 0xef, 0x0c,
-	0x9d, 0x8b, 0xb6, 0xaf, 0x6d, 0xaa, 0x9f, 0x66, 0x22, 0x5b, 0x2a, 0xbe, 0x6b, 0xea, 0x8d, 0xc7,
-	0x4e, 0xa0, 0x7b, 0xa7, 0xc4, 0x03, 0xf2, 0xbe, 0x86, 0x8d, 0x43, 0xd9, 0xb7, 0x49, 0x88, 0x5e,
-	0xc8, 0xc1, 0x64, 0x1b, 0x8f, 0x4e, 0x9e, 0x88, 0x6c, 0xc6, 0x07, 0xa6, 0x1b, 0xd9, 0xec, 0x1c,
-	0xfa, 0x37, 0x8b, 0x20, 0x4e, 0xd4, 0x52, 0x22, 0xdf, 0xd3, 0x81, 0x0a, 0xd0, 0xb3, 0xc4, 0x49,
-	0xc6, 0xf7, 0x4d, 0x05, 0xd9, 0xc4, 0xc0, 0x95, 0xc8, 0xf9, 0x81, 0x61, 0xe0, 0x4a, 0xe4, 0xec,
-	0x0c, 0x76, 0x5f, 0x46, 0x72, 0xfe, 0x41, 0x48, 0xe4, 0x4f, 0x34, 0x5c, 0xfa, 0xe6, 0xfc, 0x70,
-	0x29, 0xc5, 0x22, 0x40, 0x7e, 0xa8, 0x1f, 0xa9, 0x02, 0xa8, 0xf2, 0xcd, 0xcd, 0x98, 0x1e, 0x06,
-	0xf9, 0x91, 0xa9, 0x5c, 0xfb, 0x14, 0xf3, 0xd4, 0x34, 0x57, 0x19, 0xce, 0x39, 0x1b, 0x3a, 0x17,
-	0xbb, 0x7e, 0xe9, 0x33, 0x0e, 0x3b, 0x9e, 0xba, 0x8e, 0x51, 0x2c, 0xf8, 0xb1, 0x0e, 0xad, 0x5d,
-	0xf6, 0x05, 0x1c, 0x4c, 0x31, 0x58, 0xca, 0x28, 0xcb, 0x0b, 0xc6, 0x4e, 0xf4, 0xb9, 0x1b, 0x28,
-	0x7b, 0x0a, 0x47, 0x37, 0x8b, 0x40, 0xe6, 0x69, 0x16, 0x25, 0x8b, 0x6b, 0x91, 0x8a, 0xfb, 0x18,
-	0xf9, 0x27, 0x3a, 0x75, 0x3b, 0xc0, 0x46, 0xc0, 0x2a, 0x70, 0x42, 0xfa, 0x09, 0x92, 0x98, 0x9f,
-	0xea, 0xf4, 0x86, 0x88, 0xfb, 0x57, 0x1b, 0x7a, 0xef, 0x93, 0x78, 0x39, 0x47, 0x76, 0x00, 0x2d,
-	0x2f, 0x2c, 0x44, 0xd5, 0xf2, 0x42, 0xfd, 0xc8, 0x49, 0x20, 0x28, 0xbd, 0xd0, 0x55, 0xe9, 0x93,
-	0x94, 0xd6, 0xb6, 0x96, 0x85, 0x51, 0x59, 0x0d, 0xd3, 0x72, 0xcb, 0x12, 0x29, 0x1e, 0xf0, 0x3a,
-	0x16, 0x4a, 0x95, 0x72, 0xb3, 0x30, 0x4b, 0x00, 0xdd, 0x9a, 0x00, 0x4e, 0xa1, 0xf7, 0xee, 0xc3,
-	0x02, 0xa5, 0xe2, 0xbd, 0x61, 0x9b, 0x70, 0xe3, 0x35, 0x4a, 0x8e, 0x41, 0xe7, 0x6d, 0x12, 0x62,
-	0x21, 0x38, 0x6d, 0x97, 0x72, 0xed, 0x5b, 0x72, 0xad, 0xa4, 0x0d, 0x35, 0x69, 0x3f, 0x85, 0xa3,
-	0x77, 0x29, 0x4a, 0x3d, 0xb8, 0x88, 0x8b, 0x5d, 0x18, 0xe5, 0x6d, 0x07, 0x48, 0x26, 0xd7, 0x53,
-	0xaf, 0xc8, 0x2a, 0x64, 0x58, 0x02, 0x95, 0xcc, 0xf7, 0x6d, 0x99, 0x93, 0xb4, 0xd2, 0x19, 0xce,
-	0x51, 0x8a, 0x58, 0xcb, 0x71, 0xd7, 0xaf, 0x00, 0x8b, 0xa7, 0x57, 0x32, 0x59, 0xa6, 0x85, 0x30,
-	0x6b, 0x98, 0xfb, 0x3b, 0x1c, 0xbd, 0x58, 0x89, 0x28, 0xa6, 0x1d, 0xd3, 0xaa, 0x83, 0x28, 0xcb,
-	0x6b, 0x0b, 0x72, 0x36, 0x16, 0x54, 0x11, 0xdb, 0xaa, 0x11, 0xeb, 0xc2, 0x9e, 0xb2, 0x97, 0x52,
-	0x2c, 0xce, 0xc6, 0x4a, 0x92, 0x3b, 0x15, 0xc9, 0xee, 0x7f, 0x0e, 0x9c, 0x6f, 0x4d, 0xe0, 0xa3,
-	0x42, 0xb9, 0x32, 0x0d, 0xcf, 0xa1, 0x7f, 0x2b, 0xe6, 0xa8, 0x52, 0x11, 0x60, 0x31, 0x4d, 0x05,
-	0x58, 0xd7, 0x42, 0xab, 0x76, 0x2d, 0x7c, 0x0b, 0x7b, 0x34, 0x98, 0x8f, 0xbf, 0x2d, 0x51, 0x65,
-	0x66, 0x9c, 0xc1, 0xe5, 0xf1, 0x48, 0x5f, 0x79, 0x23, 0x3b, 0xe4, 0xd7, 0x12, 0xd9, 0x8f, 0x70,
-	0x6c, 0x75, 0x2f, 0xeb, 0x3b, 0xc3, 0xf6, 0xc5, 0xe0, 0xf2, 0xd3, 0xa2, 0x7e, 0x3b, 0xc3, 0x6f,
-	0xaa, 0x72, 0x5f, 0xd7, 0xa7, 0xa0, 0x67, 0x29, 0x6c, 0xa4, 0x17, 0x82, 0x04, 0x58, 0x01, 0x44,
-	0xbb, 0x39, 0x04, 0x89, 0x5c, 0x0a, 0x96, 0xbe, 0xfb, 0x08, 0x6c, 0xbb, 0x01, 0xfb, 0x01, 0x9e,
-	0x54, 0x94, 0x69, 0x48, 0x33, 0x34, 0xb8, 0x3c, 0x2d, 0x06, 0xdd, 0x88, 0xfa, 0x9b, 0xe9, 0xb4,
-	0x36, 0xeb, 0x5c, 0x55, 0xf4, 0xad, 0x61, 0xee, 0x1f, 0xce, 0x56, 0x1b, 0x5a, 0x25, 0x2d, 0x61,
-	0xfd, 0xa9, 0x20, 0x7b, 0xeb, 0xbd, 0x6c, 0x35, 0xbc, 0x97, 0x6b, 0x09, 0xb4, 0xad, 0xf7, 0x6c,
-	0x53, 0xa7, 0x9d, 0x06, 0x9d, 0xfe, 0xed, 0x00, 0x7b, 0x93, 0x3c, 0x44, 0x81, 0x88, 0xcd, 0xad,
-	0xa2, 0xe1, 0xc6, 0x31, 0x08, 0xa3, 0xd7, 0xb6, 0x55, 0x60, 0xf4, 0xda, 0x9e, 0x43, 0x7f, 0xad,
-	0x60, 0xd2, 0x82, 0x26, 0xbe, 0x04, 0x9a, 0x74, 0xc9, 0x3e, 0x03, 0x30, 0x8d, 0x7c, 0xfc, 0x45,
-	0xf1, 0xae, 0x2e, 0xb1, 0x10, 0x4b, 0x78, 0xbd, 0x9a, 0xf0, 0xaa, 0xcb, 0x60, 0xc7, 0xbe, 0x0c,
-	0xdc, 0x3f, 0x1d, 0x33, 0x56, 0xe3, 0x47, 0xf6, 0x19, 0xf4, 0x5f, 0x84, 0xa1, 0x44, 0xa5, 0xd0,
-	0xac, 0x60, 0x70, 0x79, 0x66, 0x49, 0x75, 0x54, 0x06, 0x6f, 0x16, 0x99, 0xcc, 0xfd, 0x2a, 0xf9,
-	0xec, 0x3b, 0x38, 0xa8, 0x07, 0xe9, 0xe3, 0xf4, 0x2b, 0xe6, 0xc5, 0xf1, 0x64, 0xd2, 0xdd, 0xb1,
-	0x12, 0xf1, 0x72, 0xcd, 0x88, 0x71, 0x9e, 0xb7, 0x9e, 0x39, 0xee, 0x2d, 0x1c, 0xda, 0x2c, 0x4f,
-	0x53, 0x0c, 0xd8, 0x73, 0xd8, 0x0f, 0xe9, 0x6f, 0x60, 0x8a, 0x31, 0x06, 0x59, 0x22, 0x0b, 0x45,
-	0x9d, 0x14, 0xf3, 0x8c, 0xed, 0x98, 0x5f, 0x4f, 0x75, 0xff, 0x71, 0x60, 0xbf, 0x96, 0xc0, 0xbe,
-	0x82, 0xe3, 0x85, 0xfe, 0x01, 0xd0, 0xb0, 0x9a, 0xa0, 0xd4, 0xbb, 0xa1, 0x33, 0xbb, 0x7e, 0x53,
-	0x88, 0xbd, 0x82, 0xc1, 0x5c, 0x64, 0xc1, 0xec, 0x65, 0x84, 0x71, 0xb8, 0x66, 0xe3, 0xf3, 0xa6,
-	0xee, 0xa3, 0xb7, 0x55, 0x9e, 0x21, 0xc6, 0xae, 0x3c, 0xfb, 0x1e, 0x0e, 0x37, 0x13, 0x3e, 0x8a,
-	0x9c, 0x2f, 0x81, 0xd5, 0xc8, 0x29, 0x2f, 0xe2, 0x74, 0x26, 0xd4, 0x5a, 0x72, 0xc6, 0xb9, 0xda,
-	0xf9, 0xd9, 0xfc, 0x4c, 0xdd, 0xf7, 0xf4, 0xaf, 0xd5, 0x37, 0xff, 0x07, 0x00, 0x00, 0xff, 0xff,
-	0x9b, 0x5f, 0xec, 0x44, 0x69, 0x09, 0x00, 0x00,
+	// 1201 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x97, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0xc7, 0xe5, 0x7c, 0x6d, 0x72, 0xb2, 0xbb, 0xcd, 0xce, 0x96, 0x65, 0x1a, 0xad, 0x50, 0x64,
+	0x01, 0x5a, 0x55, 0x55, 0x04, 0xcb, 0x05, 0x55, 0x85, 0x10, 0xed, 0x66, 0xdb, 0x5a, 0xb4, 0xbb,
+	0x91, 0xd3, 0x16, 0x89, 0xbb, 0xa9, 0x7d, 0xda, 0x58, 0x75, 0x62, 0x33, 0xe3, 0x6c, 0x95, 0xde,
+	0xf0, 0x0a, 0x88, 0x07, 0xe0, 0x25, 0x78, 0x0b, 0x6e, 0xb9, 0xe3, 0x9a, 0x07, 0x41, 0xf3, 0x61,
+	0x7b, 0x26, 0x09, 0x48, 0xdc, 0xcd, 0xf9, 0x9f, 0x33, 0x1f, 0x3e, 0xf3, 0x3b, 0x33, 0x63, 0xe8,
+	0x17, 0xeb, 0x1c, 0xc5, 0x38, 0xe7, 0x59, 0x91, 0x91, 0xf6, 0xcd, 0x97, 0x2c, 0x4f, 0xfc, 0x3f,
+	0x5b, 0xd0, 0x9e, 0xf0, 0xe4, 0x06, 0x09, 0x81, 0xd6, 0xcb, 0x97, 0xc1, 0x84, 0x7a, 0x23, 0xef,
+	0xac, 0x17, 0xaa, 0x36, 0x19, 0x40, 0xf3, 0x55, 0x30, 0xa1, 0x0d, 0x25, 0x35, 0x5f, 0x69, 0x65,
+	0x1a, 0x4c, 0x68, 0x53, 0x2b, 0xd3, 0x60, 0x42, 0x7c, 0xd8, 0x9f, 0x21, 0x4f, 0x58, 0x7a, 0xb5,
+	0x5a, 0xbc, 0x46, 0x4e, 0x5b, 0xca, 0xe5, 0x68, 0xe4, 0x04, 0x3a, 0x4f, 0x91, 0xa5, 0xc5, 0x9c,
+	0xb6, 0x95, 0xd7, 0x58, 0x72, 0xce, 0x17, 0xeb, 0x1c, 0x69, 0x47, 0xcf, 0x29, 0xdb, 0x52, 0x9b,
+	0x25, 0x1f, 0x90, 0xee, 0x8d, 0xbc, 0xb3, 0x66, 0xa8, 0xda, 0xb2, 0xff, 0xac, 0x60, 0xc5, 0x4a,
+	0xd0, 0xae, 0xee, 0xaf, 0x2d, 0x72, 0x1b, 0xda, 0x2f, 0x05, 0x7b, 0x8b, 0xb4, 0xa7, 0x64, 0x6d,
+	0xc8, 0xe8, 0xab, 0x2c, 0xc6, 0x20, 0xa6, 0xa0, 0xa3, 0xb5, 0x25, 0x47, 0x9e, 0xb2, 0x62, 0x4e,
+	0xfb, 0x7a, 0x36, 0xd9, 0x26, 0xa7, 0xd0, 0xbb, 0x5c, 0x46, 0x69, 0x26, 0x56, 0x1c, 0xe9, 0xbe,
+	0x72, 0xd4, 0x82, 0x5a, 0x4b, 0x9a, 0x15, 0xf4, 0x40, 0xf7, 0x90, 0x6d, 0x99, 0x81, 0x47, 0x6c,
+	0x4d, 0x0f, 0x75, 0x06, 0x1e, 0xb1, 0x35, 0x19, 0x42, 0xf7, 0x71, 0xc2, 0x17, 0xef, 0x19, 0x47,
+	0x7a, 0x4b, 0xc9, 0x95, 0xad, 0xc7, 0x8f, 0x57, 0x9c, 0x2d, 0x23, 0xa4, 0x03, 0xf5, 0x49, 0xb5,
+	0x20, 0x7b, 0x3e, 0xbb, 0x9c, 0xc8, 0x8f, 0x41, 0x7a, 0xa4, 0x7b, 0x96, 0xb6, 0xf4, 0x05, 0x62,
+	0xb6, 0x16, 0x05, 0x2e, 0x28, 0x19, 0x79, 0x67, 0xdd, 0xb0, 0xb2, 0x09, 0x85, 0xbd, 0x40, 0x5c,
+	0xa4, 0xc8, 0x96, 0xf4, 0x58, 0xb9, 0x4a, 0x93, 0x7c, 0x0e, 0x87, 0x33, 0x8c, 0x56, 0x3c, 0x29,
+	0xd6, 0x26, 0x63, 0xb7, 0xd5, 0xb8, 0x1b, 0x2a, 0xb9, 0x07, 0x47, 0x97, 0xcb, 0x88, 0xaf, 0xf3,
+	0x22, 0xc9, 0x96, 0x17, 0x2c, 0x67, 0xaf, 0x53, 0xa4, 0x1f, 0xa9, 0xd0, 0x6d, 0x07, 0x19, 0x03,
+	0xa9, 0xc5, 0xa9, 0xe4, 0x27, 0xca, 0x52, 0x7a, 0xa2, 0xc2, 0x77, 0x78, 0xfc, 0xdf, 0x3a, 0xd0,
+	0x79, 0x95, 0xa5, 0xab, 0x05, 0x92, 0x43, 0x68, 0x04, 0xb1, 0x81, 0xaa, 0x11, 0xc4, 0xea, 0x93,
+	0xb3, 0x88, 0xc9, 0x70, 0xc3, 0x55, 0x65, 0x4b, 0x94, 0xca, 0xb6, 0xc2, 0x42, 0x53, 0xe6, 0x68,
+	0x0a, 0xb7, 0x22, 0xe3, 0xec, 0x2d, 0x5e, 0xa4, 0x4c, 0x88, 0x0a, 0x37, 0x4b, 0xb3, 0x00, 0x68,
+	0x3b, 0x00, 0x9c, 0x40, 0xe7, 0xfa, 0xfd, 0x12, 0xb9, 0xa0, 0x9d, 0x51, 0x53, 0xea, 0xda, 0xda,
+	0x89, 0x1c, 0x81, 0xd6, 0xf3, 0x2c, 0x46, 0x03, 0x9c, 0x6a, 0x57, 0xb8, 0xf6, 0x2c, 0x5c, 0x6b,
+	0xb4, 0xc1, 0x41, 0xfb, 0x1e, 0x1c, 0x5d, 0xe7, 0xc8, 0xd5, 0xc2, 0x59, 0x6a, 0xf6, 0x42, 0x93,
+	0xb7, 0xed, 0x90, 0x98, 0x5c, 0xcc, 0x02, 0x13, 0x65, 0x30, 0xac, 0x84, 0x1a, 0xf3, 0x03, 0x1b,
+	0x73, 0x89, 0x56, 0x3e, 0xc7, 0x05, 0x72, 0x96, 0x2a, 0x1c, 0xbb, 0x61, 0x2d, 0x58, 0x79, 0x7a,
+	0xc2, 0xb3, 0x55, 0x6e, 0xc0, 0x74, 0x34, 0x05, 0x4b, 0xb6, 0xe2, 0x11, 0xea, 0xbd, 0x0a, 0x62,
+	0x3a, 0x30, 0xb0, 0x38, 0x2a, 0xb9, 0x0b, 0x03, 0xad, 0xcc, 0x96, 0x2c, 0x17, 0xf3, 0xac, 0x08,
+	0x62, 0x83, 0xeb, 0x96, 0x2e, 0x63, 0x6b, 0x20, 0x66, 0x18, 0x71, 0x2c, 0x14, 0xbe, 0xbd, 0x70,
+	0x4b, 0x27, 0x9f, 0x00, 0xfc, 0x90, 0xe4, 0x38, 0xcd, 0xd2, 0x24, 0x5a, 0x2b, 0x92, 0x7b, 0xa1,
+	0xa5, 0x90, 0x11, 0xf4, 0x9f, 0xbf, 0x7b, 0x23, 0xae, 0x55, 0x9f, 0x92, 0x64, 0x5b, 0x52, 0x45,
+	0x72, 0xfd, 0x2c, 0x59, 0x24, 0x85, 0x30, 0xf4, 0x56, 0xb6, 0x2c, 0x92, 0x59, 0xc1, 0x93, 0x1c,
+	0x85, 0x22, 0xb5, 0x1d, 0x96, 0xa6, 0x9c, 0x57, 0x37, 0xd5, 0xae, 0x7f, 0xac, 0xe7, 0xad, 0x15,
+	0xb5, 0x1b, 0x2c, 0x9a, 0x6b, 0x37, 0xd5, 0x45, 0x5b, 0x09, 0x95, 0x57, 0xe1, 0x71, 0xc7, 0xec,
+	0x55, 0x29, 0x90, 0x4f, 0xe1, 0x40, 0x19, 0x15, 0xe4, 0x43, 0x15, 0xe1, 0x8a, 0xfe, 0xcf, 0x70,
+	0xf4, 0xf0, 0x86, 0x25, 0xa9, 0xac, 0x2e, 0x59, 0x64, 0x51, 0x52, 0xac, 0x9d, 0xd2, 0xf0, 0x36,
+	0x4a, 0xa3, 0x46, 0xba, 0xe1, 0x20, 0xed, 0xc3, 0xbe, 0xb0, 0xcb, 0xc1, 0x94, 0x8c, 0xad, 0x55,
+	0x78, 0xb7, 0x6a, 0xbc, 0xfd, 0xbf, 0x3c, 0x38, 0xdd, 0x5a, 0x41, 0x88, 0x02, 0xf9, 0x8d, 0x9e,
+	0xf0, 0x14, 0x7a, 0x57, 0x6c, 0x81, 0x22, 0x67, 0x11, 0x9a, 0xd5, 0xd4, 0x82, 0x75, 0x20, 0x37,
+	0x9c, 0x03, 0xf9, 0x6b, 0xd8, 0x97, 0x0b, 0x0b, 0xf1, 0xa7, 0x15, 0x8a, 0x42, 0x2f, 0xa7, 0x7f,
+	0x7e, 0x3c, 0x56, 0x97, 0xcd, 0xd8, 0x76, 0x85, 0x4e, 0x20, 0xf9, 0x1e, 0x8e, 0xad, 0xd9, 0xab,
+	0xfe, 0xad, 0x51, 0xf3, 0xac, 0x7f, 0x7e, 0xc7, 0xf4, 0xdf, 0x8e, 0x08, 0x77, 0xf5, 0xf2, 0x9f,
+	0xba, 0xab, 0x90, 0xdf, 0x62, 0xda, 0x28, 0x8f, 0x22, 0x59, 0xfa, 0xb5, 0x20, 0xd3, 0xae, 0x07,
+	0x41, 0x99, 0x5c, 0xe9, 0xac, 0x6c, 0xff, 0x03, 0x90, 0xed, 0x09, 0xc8, 0x77, 0x70, 0xab, 0x4e,
+	0x99, 0x92, 0x54, 0x86, 0xfa, 0xe7, 0x27, 0x66, 0xa1, 0x1b, 0xde, 0x70, 0x33, 0x5c, 0x6e, 0x9b,
+	0x35, 0xae, 0x30, 0xf3, 0x3a, 0x9a, 0xff, 0xbb, 0xb7, 0x35, 0x8d, 0xdc, 0x4a, 0xb9, 0x09, 0xe5,
+	0x25, 0x2d, 0xdb, 0x5b, 0x27, 0x62, 0x63, 0xc7, 0x89, 0x58, 0x22, 0xd0, 0xb4, 0x4e, 0xb8, 0xcd,
+	0x13, 0xa2, 0xb5, 0xe3, 0x84, 0xf8, 0xb7, 0x93, 0xd4, 0xaa, 0xad, 0x8e, 0x53, 0x5b, 0xfe, 0x2f,
+	0x0d, 0x20, 0xcf, 0xb2, 0xb7, 0x49, 0xc4, 0x52, 0x7d, 0x7e, 0xe8, 0x81, 0x76, 0x2d, 0x5c, 0x6a,
+	0xb2, 0x86, 0x1a, 0x46, 0x93, 0xe5, 0x73, 0x0a, 0xbd, 0x92, 0x79, 0x49, 0x8f, 0xda, 0xaa, 0x4a,
+	0xd8, 0x45, 0xb2, 0x2c, 0x66, 0x3d, 0x51, 0x88, 0x6f, 0x04, 0x6d, 0xab, 0x2e, 0x96, 0x62, 0xa1,
+	0xda, 0x71, 0x50, 0xad, 0x0f, 0xee, 0x3d, 0xe7, 0xe0, 0x1e, 0x42, 0xf7, 0xc5, 0x3c, 0x59, 0x4e,
+	0xb3, 0x2c, 0x35, 0x87, 0x7f, 0x65, 0x4b, 0x5f, 0xc8, 0x92, 0xd8, 0xba, 0x04, 0x2a, 0xdb, 0x4e,
+	0x09, 0xb8, 0x29, 0xf9, 0xd5, 0xd3, 0x1f, 0xba, 0xf3, 0x89, 0x75, 0x1f, 0x7a, 0x0f, 0xe3, 0x98,
+	0xa3, 0x10, 0xa8, 0x31, 0xe8, 0x9f, 0x0f, 0xad, 0x72, 0x19, 0x57, 0xce, 0xcb, 0x65, 0xc1, 0xd7,
+	0x61, 0x1d, 0x3c, 0xfc, 0x06, 0x0e, 0x5d, 0xa7, 0x7c, 0x9a, 0xbc, 0xc3, 0xb5, 0x19, 0x5e, 0x36,
+	0xe5, 0xcd, 0x71, 0xc3, 0xd2, 0x55, 0x99, 0x63, 0x6d, 0x3c, 0x68, 0xdc, 0xf7, 0xfc, 0x2b, 0x18,
+	0xd8, 0x3b, 0x3d, 0xcb, 0x31, 0x22, 0x0f, 0xe0, 0x20, 0x96, 0x6f, 0xc1, 0x19, 0xa6, 0x18, 0x15,
+	0x19, 0x37, 0x54, 0xdf, 0x36, 0xeb, 0x99, 0xd8, 0xbe, 0xd0, 0x0d, 0xf5, 0xff, 0xf0, 0xe0, 0xc0,
+	0x09, 0x20, 0x5f, 0xc0, 0xf1, 0x52, 0x3d, 0xff, 0x94, 0x2c, 0xa6, 0xc8, 0xd5, 0x6e, 0x7b, 0x2a,
+	0x39, 0xbb, 0x5c, 0xe4, 0x09, 0xf4, 0x17, 0xac, 0x88, 0xe6, 0x8f, 0x13, 0x4c, 0xe3, 0x32, 0x1b,
+	0x9f, 0xed, 0x9a, 0x7d, 0xfc, 0xbc, 0x8e, 0xd3, 0x89, 0xb1, 0x7b, 0x0e, 0xbf, 0x85, 0xc1, 0x66,
+	0xc0, 0xff, 0x4a, 0xce, 0x5d, 0x20, 0x4e, 0x72, 0xaa, 0x6b, 0x38, 0x9f, 0x33, 0x51, 0x42, 0xac,
+	0x0d, 0xff, 0x6f, 0x0f, 0xba, 0xe5, 0xfd, 0xb7, 0xeb, 0xb5, 0x53, 0xdd, 0xad, 0xe6, 0xb5, 0x53,
+	0xda, 0x56, 0x6d, 0x35, 0x9d, 0xda, 0xb2, 0xaf, 0x81, 0xd6, 0xf6, 0x0b, 0xc9, 0xa9, 0xf5, 0xf6,
+	0x7f, 0xd4, 0x7a, 0xc7, 0x2a, 0x12, 0xe7, 0x7d, 0xb1, 0xb7, 0xf9, 0xbe, 0xf0, 0x61, 0xff, 0x82,
+	0xa3, 0x7e, 0x63, 0x25, 0x0b, 0xfd, 0xe6, 0x69, 0x86, 0x8e, 0xf6, 0x68, 0xef, 0x47, 0xfd, 0xc7,
+	0xf0, 0xba, 0xa3, 0xfe, 0x1f, 0xbe, 0xfa, 0x67, 0x00, 0x4f, 0xe8, 0xa6, 0x92, 0x4e, 0x0c, 0x00,
+	0x00,
 }
@@ -23,6 +23,7 @@ const (
 	LVGKind                          = "LogicalVolumeGroup"
 	DriveKind                        = "Drive"
 	CSIBMNodeKind                    = "Node"
+	SnapshotKind                     = "Snapshot"
 
 	Version            = "v1"
 	CSICRsGroupVersion = "csi-baremetal.dell.com"
@@ -45,6 +46,8 @@ const (
 	HealthGood    = "GOOD"
 	HealthSuspect = "SUSPECT"
 	HealthBad     = "BAD"
+	// HealthDegraded is the health of RAID LVG which lost redundancy because of the BAD or missing member drive
+	HealthDegraded = "DEGRADED"
 
 	// Drive status
 	DriveStatusOnline  = "ONLINE"
@@ -67,6 +70,10 @@ const (
 	DriveAnnotationRemoval            = "removal"
 	DriveAnnotationRemovalReady       = "ready"
 	DriveAnnotationVolumeStatusPrefix = "status"
+	// DriveAnnotationVolumeCopy requests copy of the drive based volumes to a clean drive of the same type
+	// before drive release, value is UUID of the target drive or auto
+	DriveAnnotationVolumeCopy     = "copy"
+	DriveAnnotationVolumeCopyAuto = "auto"
 	// Deprecated annotations
 	DriveAnnotationReplacement = "replacement"
 
@@ -84,6 +91,52 @@ const (
 	VolumeUsageReleased  = DriveUsageReleased
 	VolumeUsageFailed    = DriveUsageFailed
 
+	// Volume wipe policies, define how data of the volume is destroyed when volume is released
+	WipePolicyNone           = "none"
+	WipePolicySignatures     = "signatures"
+	WipePolicyDiscard        = "discard"
+	WipePolicyZero           = "zero"
+	WipePolicyNVMeFormat     = "nvme-format"
+	WipePolicyATASecureErase = "ata-secure-erase"
+
+	// Cache modes of the volume cached on the fast drives
+	CacheModeWritethrough = "writethrough"
+	CacheModeWriteback    = "writeback"
+
+	// Volume wipe annotation and its values
+	VolumeAnnotationWipeStatus = "wipe/status"
+	VolumeWipeInProgress       = "in-progress"
+	VolumeWipeDone             = "done"
+	VolumeWipeFailed           = "failed"
+	// DriveAnnotationWipe holds ID of the volume which data is being wiped, drive usage is RELEASING during wipe
+	DriveAnnotationWipe = "wipe/volume"
+
+	// VolumeAnnotationEncryptionFormatted is set when device of the encrypted volume was formatted with LUKS
+	// on the first stage, device of such volume is never formatted again
+	VolumeAnnotationEncryptionFormatted = "encryption/formatted"
+	VolumeEncryptionFormatted           = "true"
+
+	// Volume copy annotations and copy status values. Target holds UUID of the drive which volume is copied to,
+	// source holds UUID of the drive which volume was copied from, progress is a percentage of the copied data
+	VolumeAnnotationCopyTarget   = "copy/target"
+	VolumeAnnotationCopySource   = "copy/source"
+	VolumeAnnotationCopyStatus   = "copy/status"
+	VolumeAnnotationCopyProgress = "copy/progress"
+	VolumeCopyPending            = "pending"
+	VolumeCopyWaiting            = "waiting-for-unstage"
+	VolumeCopyInProgress         = "in-progress"
+	VolumeCopyDone               = "done"
+	VolumeCopyFailed             = "failed"
+
+	// PVC annotations which override IO limits of the storage class, values are k8s quantities
+	ClaimAnnotationReadBPS   = "io/read-bps"
+	ClaimAnnotationWriteBPS  = "io/write-bps"
+	ClaimAnnotationReadIOPS  = "io/read-iops"
+	ClaimAnnotationWriteIOPS = "io/write-iops"
+	// VolumeAnnotationIOMaxPrefix is a prefix of the annotation with IO limits applied to the pod cgroup,
+	// add pod UID. Value is a line of io.max file - <major>:<minor> <limits>
+	VolumeAnnotationIOMaxPrefix = "io/max-"
+
 	// Release Volume annotations
 	VolumeAnnotationRelease       = "release"
 	VolumeAnnotationReleaseDone   = "done"
@@ -101,6 +154,23 @@ const (
 
 	//LVG annotations
 	LVGFreeSpaceAnnotation = "lvg/free-space"
+	// ratio of the virtual size of thin LVs to the size of the thin pool
+	LVGOvercommitRatioAnnotation = "lvg/overcommit-ratio"
+	// UUID of the LVG drive which data should be moved to the spare drive online,
+	// with auto value the data of the SUSPECT drive is moved
+	LVGMigrateDriveAnnotation = "lvg/migrate-drive"
+	LVGMigrateDriveAuto       = "auto"
+	// set when migration of the LVG drive failed, should be removed to retry
+	LVGMigrationFailedAnnotation = "lvg/migration-failed"
+	// UUIDs of the migrated drive and of the spare drive, set while data is being moved
+	LVGMigrationSourceAnnotation = "lvg/migration-source"
+	LVGMigrationTargetAnnotation = "lvg/migration-target"
+
+	// ThinPoolName is the name of the thin pool LV in LVG of the thin storage class
+	ThinPoolName = "thinpool"
+
+	// EncryptionKeyName is the key of the Secret data and CSI secrets which holds the volume encryption key
+	EncryptionKeyName = "key"
 
 	// Volume location type
 	LocationTypeDrive = "DRIVE"
@@ -123,6 +193,29 @@ const (
 	StorageClassSSDLVG    = "SSDLVG"
 	StorageClassNVMeLVG   = "NVMELVG"
 	StorageClassSystemLVG = "SYSLVG"
+	// Volumes of the thin storage classes are thin LVs in the thin pool of LVG
+	StorageClassHDDLVGThin  = "HDDLVGTHIN"
+	StorageClassSSDLVGThin  = "SSDLVGTHIN"
+	StorageClassNVMeLVGThin = "NVMELVGTHIN"
+	// Volumes of the RAID storage classes are RAID LVs in LVG which spans several drives
+	StorageClassHDDLVGRaid1   = "HDDLVGRAID1"
+	StorageClassHDDLVGRaid10  = "HDDLVGRAID10"
+	StorageClassHDDLVGRaid5   = "HDDLVGRAID5"
+	StorageClassSSDLVGRaid1   = "SSDLVGRAID1"
+	StorageClassSSDLVGRaid10  = "SSDLVGRAID10"
+	StorageClassSSDLVGRaid5   = "SSDLVGRAID5"
+	StorageClassNVMeLVGRaid1  = "NVMELVGRAID1"
+	StorageClassNVMeLVGRaid10 = "NVMELVGRAID10"
+	StorageClassNVMeLVGRaid5  = "NVMELVGRAID5"
+	// Volumes of the striped storage classes are striped LVs in LVG which spans several drives
+	StorageClassHDDLVGStriped  = "HDDLVGSTRIPED"
+	StorageClassSSDLVGStriped  = "SSDLVGSTRIPED"
+	StorageClassNVMeLVGStriped = "NVMELVGSTRIPED"
+
+	// RAID types of LVs, aligned with lvcreate --type
+	RaidType1  = "raid1"
+	RaidType10 = "raid10"
+	RaidType5  = "raid5"
 
 	LocateStart  = int32(0)
 	LocateStop   = int32(1)
@@ -132,6 +225,11 @@ const (
 	LocateStatusOn           = int32(1)
 	LocateStatusNotAvailable = int32(2)
 
+	// Drive event types of WatchDrives stream
+	DriveEventAdded    = "ADDED"
+	DriveEventModified = "MODIFIED"
+	DriveEventRemoved  = "REMOVED"
+
 	DockerImageKernelVersion = "5.4"
 
 	// CSI Drive taint-like label key and value
@@ -141,6 +239,9 @@ const (
 	// CSI StorageGroup label key
 	StorageGroupLabelKey = "drive.csi-baremetal.dell.com/storage-group"
 
+	// Label key of AC of the striped LVG, value is the number of drives in LVG
+	LVGStripesLabelKey = "lvg.csi-baremetal.dell.com/stripes"
+
 	// CSI StorageGroup Status
 	StorageGroupPhaseSyncing  = "SYNCING"
 	StorageGroupPhaseSynced   = "SYNCED"
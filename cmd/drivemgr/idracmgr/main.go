@@ -19,6 +19,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
 	"time"
 
 	dmsetup "github.com/dell/csi-baremetal/cmd/drivemgr"
@@ -27,16 +28,28 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/ipmi"
 	"github.com/dell/csi-baremetal/pkg/base/logger"
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
-	"github.com/dell/csi-baremetal/pkg/drivemgr/idracmgr"
+	"github.com/dell/csi-baremetal/pkg/drivemgr/redfishmgr"
+)
+
+const (
+	// redfishUserEnv and redfishPasswordEnv are environment variables with iDRAC credentials
+	redfishUserEnv     = "REDFISH_USER"
+	redfishPasswordEnv = "REDFISH_PASSWORD"
 )
 
 var (
 	endpoint = flag.String("drivemgrendpoint", base.DefaultDriveMgrEndpoint, "DriveManager Endpoint")
+	bmcURL   = flag.String("bmc-url", "", "iDRAC Redfish service URL, for example https://10.10.10.10. BMC IP from ipmitool is used if empty")
+	timeout  = flag.Duration("bmc-timeout", 10*time.Second, "Timeout for Redfish requests")
+	caBundle = flag.String("bmc-ca-bundle", "", "Path to PEM file with CA certificates to verify iDRAC certificate")
+	pinned   = flag.String("bmc-pinned-cert", "", "SHA-256 fingerprint of trusted iDRAC certificate, CA verification is skipped")
+	insecure = flag.Bool("bmc-insecure-skip-verify", false, "Disable iDRAC certificate verification")
 	logPath  = flag.String("logpath", "", "log path for DriveManager")
 	logLevel = flag.String("loglevel", logger.InfoLevel,
 		fmt.Sprintf("Log level, support values are %s, %s, %s", logger.InfoLevel, logger.DebugLevel, logger.TraceLevel))
 )
 
+// iDRAC drive manager is served by Redfish drive manager since iDRAC is a standard Redfish service
 func main() {
 	flag.Parse()
 
@@ -48,15 +61,29 @@ func main() {
 	// Server is insecure for now because credentials are nil
 	serverRunner := rpc.NewServerRunner(nil, *endpoint, false, logger)
 
-	e := command.NewExecutor(logger)
-
-	ipmiTool := ipmi.NewIPMI(e)
-	ip := ipmiTool.GetBmcIP()
-	if ip == "" {
-		logger.Fatal("IDRAC IP is not found")
+	url := *bmcURL
+	if url == "" {
+		ip := ipmi.NewIPMI(command.NewExecutor(logger)).GetBmcIP()
+		if ip == "" {
+			logger.Fatal("IDRAC IP is not found")
+		}
+		url = "https://" + ip
 	}
 
-	driveMgr := idracmgr.NewIDRACManager(logger, 10*time.Second, "root", "passwd", ip)
+	driveMgr, err := redfishmgr.NewRedfishManager(logger, redfishmgr.Config{
+		Endpoint:           url,
+		User:               os.Getenv(redfishUserEnv),
+		Password:           os.Getenv(redfishPasswordEnv),
+		Timeout:            *timeout,
+		CABundle:           *caBundle,
+		PinnedCertificate:  *pinned,
+		InsecureSkipVerify: *insecure,
+	})
+	if err != nil {
+		logger.Fatalf("Failed to create iDRAC drive manager: %v", err)
+	}
 
 	dmsetup.SetupAndRunDriveMgr(driveMgr, serverRunner, nil, logger)
+	// delete Redfish session after graceful stop
+	driveMgr.Close()
 }
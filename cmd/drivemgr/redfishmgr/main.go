@@ -0,0 +1,88 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	dmsetup "github.com/dell/csi-baremetal/cmd/drivemgr"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/ipmi"
+	"github.com/dell/csi-baremetal/pkg/base/logger"
+	"github.com/dell/csi-baremetal/pkg/base/rpc"
+	"github.com/dell/csi-baremetal/pkg/drivemgr/redfishmgr"
+)
+
+const (
+	// redfishUserEnv and redfishPasswordEnv are environment variables with BMC credentials
+	redfishUserEnv     = "REDFISH_USER"
+	redfishPasswordEnv = "REDFISH_PASSWORD"
+)
+
+var (
+	endpoint = flag.String("drivemgrendpoint", base.DefaultDriveMgrEndpoint, "DriveManager Endpoint")
+	bmcURL   = flag.String("bmc-url", "", "Redfish service URL, for example https://10.10.10.10. BMC IP from ipmitool is used if empty")
+	timeout  = flag.Duration("bmc-timeout", 10*time.Second, "Timeout for Redfish requests")
+	caBundle = flag.String("bmc-ca-bundle", "", "Path to PEM file with CA certificates to verify BMC certificate")
+	pinned   = flag.String("bmc-pinned-cert", "", "SHA-256 fingerprint of trusted BMC certificate, CA verification is skipped")
+	insecure = flag.Bool("bmc-insecure-skip-verify", false, "Disable BMC certificate verification")
+	logPath  = flag.String("logpath", "", "log path for DriveManager")
+	logLevel = flag.String("loglevel", logger.InfoLevel,
+		fmt.Sprintf("Log level, support values are %s, %s, %s", logger.InfoLevel, logger.DebugLevel, logger.TraceLevel))
+)
+
+func main() {
+	flag.Parse()
+
+	logger, err := logger.InitLogger(*logPath, *logLevel)
+	if err != nil {
+		logger.Warnf("Can't set logger's output to %s. Using stdout instead.\n", *logPath)
+	}
+
+	// Server is insecure for now because credentials are nil
+	serverRunner := rpc.NewServerRunner(nil, *endpoint, false, logger)
+
+	url := *bmcURL
+	if url == "" {
+		ip := ipmi.NewIPMI(command.NewExecutor(logger)).GetBmcIP()
+		if ip == "" {
+			logger.Fatal("BMC IP is not found")
+		}
+		url = "https://" + ip
+	}
+
+	driveMgr, err := redfishmgr.NewRedfishManager(logger, redfishmgr.Config{
+		Endpoint:           url,
+		User:               os.Getenv(redfishUserEnv),
+		Password:           os.Getenv(redfishPasswordEnv),
+		Timeout:            *timeout,
+		CABundle:           *caBundle,
+		PinnedCertificate:  *pinned,
+		InsecureSkipVerify: *insecure,
+	})
+	if err != nil {
+		logger.Fatalf("Failed to create Redfish drive manager: %v", err)
+	}
+
+	dmsetup.SetupAndRunDriveMgr(driveMgr, serverRunner, nil, logger)
+	// delete Redfish session after graceful stop
+	driveMgr.Close()
+}
@@ -47,10 +47,12 @@ import (
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cgroup"
 	"github.com/dell/csi-baremetal/pkg/base/logger"
 	"github.com/dell/csi-baremetal/pkg/base/logger/objects"
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
@@ -90,8 +92,12 @@ var (
 		fmt.Sprintf("Log level, support values are %s, %s, %s", logger.InfoLevel, logger.DebugLevel, logger.TraceLevel))
 	metricsAddress = flag.String("metrics-address", "", "The TCP network address where the prometheus metrics endpoint will run"+
 		"(example: :8080 which corresponds to port 8080 on local host). The default is empty string, which means metrics endpoint is disabled.")
-	metricspath = flag.String("metrics-path", "/metrics", "The HTTP path where prometheus metrics will be exposed. Default is /metrics.")
-	smartpath   = flag.String("smart-path", "/smart", "The HTTP path where smart metrics will be exposed. Default is /smart.")
+	metricspath         = flag.String("metrics-path", "/metrics", "The HTTP path where prometheus metrics will be exposed. Default is /metrics.")
+	smartpath           = flag.String("smart-path", "/smart", "The HTTP path where smart metrics will be exposed. Default is /smart.")
+	smartExportInterval = flag.Duration("smart-export-interval", time.Minute,
+		"Interval between exports of parsed SMART attributes as prometheus metrics. Zero value disables the export.")
+	cgroupRoot = flag.String("cgroup-root", cgroup.DefaultRoot,
+		"Mount point of the host cgroup v2 hierarchy inside the container, it is used to apply IO limits of the volumes")
 )
 
 func main() {
@@ -167,10 +173,11 @@ func main() {
 	wrappedK8SClient := k8s.NewKubeClient(k8SClient, logger, objects.NewObjectLogger(), *namespace)
 	csiNodeService := node.NewCSINodeService(
 		clientToDriveMgr, nodeID, *nodeName, logger, wrappedK8SClient, kubeCache, eventRecorder, featureConf)
+	csiNodeService.SetCgroupRoot(*cgroupRoot)
 
 	mgr := prepareCRDControllerManagers(
 		csiNodeService,
-		lvg.NewController(wrappedK8SClient, nodeID, logger),
+		lvg.NewController(wrappedK8SClient, nodeID, eventRecorder, logger),
 		drive.NewController(wrappedK8SClient, nodeID, clientToDriveMgr, eventRecorder, logger),
 		logger)
 
@@ -182,6 +189,10 @@ func main() {
 	go handler.SetupSIGTERMHandler(csiUDSServer)
 
 	_ = enableHTTPServers(*metricsAddress, *metricspath, *smartpath, clientToDriveMgr, csiUDSServer, logger)
+	if *metricsAddress != "" && *metricspath != "" && *smartExportInterval > 0 {
+		logger.Info("Starting SMART metrics exporter ...")
+		go node.NewSmartExporter(clientToDriveMgr, wrappedK8SClient, nodeID, *smartExportInterval, logger).Run(context.Background())
+	}
 	go func() {
 		logger.Info("Starting Node Health server ...")
 		if err := util.SetupAndStartHealthCheckServer(
@@ -196,7 +207,15 @@ func main() {
 			logger.Fatalf("CRD Controller Manager failed with error: %v", err)
 		}
 	}()
-	go Discovering(csiNodeService, logger)
+	// drives changes from DriveManager stream trigger discovering without waiting for next polling
+	drivesChanged := make(chan struct{}, 1)
+	go csiNodeService.WatchDrives(context.Background(), func() {
+		select {
+		case drivesChanged <- struct{}{}:
+		default:
+		}
+	})
+	go Discovering(csiNodeService, drivesChanged, logger)
 
 	// wait for readiness
 	waitForVolumeManagerReadiness(csiNodeService, logger)
@@ -278,14 +297,17 @@ func waitForVolumeManagerReadiness(c *node.CSINodeService, logger *logrus.Logger
 	logger.Fatalf("Number of retries %d exceeded. Exiting...", numberOfRetries)
 }
 
-// Discovering performs Discover method of the Node each 30 seconds
-func Discovering(c *node.CSINodeService, logger *logrus.Logger) {
+// Discovering performs Discover method of the Node each 30 seconds or when drivesChanged receives notification
+func Discovering(c *node.CSINodeService, drivesChanged <-chan struct{}, logger *logrus.Logger) {
 	var err error
 	// set initial delay
 	discoveringWaitTime := 10 * time.Second
 	checker := c.GetLivenessHelper()
 	for {
-		time.Sleep(discoveringWaitTime)
+		select {
+		case <-time.After(discoveringWaitTime):
+		case <-drivesChanged:
+		}
 		logger.Info("Discover is starting")
 		if err = c.Discover(); err != nil {
 			checker.Fail()
@@ -325,6 +347,11 @@ func prepareCRDControllerManagers(volumeCtrl *node.CSINodeService, lvgCtrl *lvg.
 		logrus.Fatal(err)
 	}
 
+	// register Snapshot crd
+	if err = snapshotcrd.AddToSchemeSnapshot(scheme); err != nil {
+		logrus.Fatal(err)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 	})
@@ -337,6 +364,11 @@ func prepareCRDControllerManagers(volumeCtrl *node.CSINodeService, lvgCtrl *lvg.
 		logger.Fatalf("unable to create controller for volume: %v", err)
 	}
 
+	// bind CSINodeService's VolumeManager to K8s Controller Manager as a controller for Snapshot CR
+	if err = volumeCtrl.SetupSnapshotControllerWithManager(mgr); err != nil {
+		logger.Fatalf("unable to create controller for snapshot: %v", err)
+	}
+
 	// bind LVMController to K8s Controller Manager as a controller for LogicalVolumeGroup CR
 	if err = lvgCtrl.SetupWithManager(mgr); err != nil {
 		logger.Fatalf("unable to create controller for LogicalVolumeGroup: %v", err)
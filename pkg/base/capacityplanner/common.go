@@ -57,3 +57,116 @@ func SubtractLVMMetadataSize(size int64) int64 {
 	}
 	return result
 }
+
+const (
+	// thinPoolMaxMetadataSize is the maximum size of the thin pool metadata supported by LVM
+	thinPoolMaxMetadataSize = 16 * int64(util.GBYTE)
+	// thinPoolMetadataDivider - thin pool metadata takes 1/thinPoolMetadataDivider of the VG
+	thinPoolMetadataDivider = 1000
+)
+
+// GetThinPoolMetadataSize returns size of the thin pool metadata LV for VG of the provided size,
+// it's 0.1% of the VG aligned by PE, but not less than PE and not more than LVM maximum
+func GetThinPoolMetadataSize(vgSize int64) int64 {
+	size := AlignSizeByPE(vgSize / thinPoolMetadataDivider)
+	if size > thinPoolMaxMetadataSize {
+		return thinPoolMaxMetadataSize
+	}
+	return size
+}
+
+// GetThinPoolDataSize returns size of the thin pool data for VG of the provided size.
+// LVM keeps metadata LV and spare metadata LV of the same size in VG
+func GetThinPoolDataSize(vgSize int64) int64 {
+	dataSize := vgSize - 2*GetThinPoolMetadataSize(vgSize)
+	if dataSize < 0 {
+		return 0
+	}
+	return dataSize
+}
+
+// GetThinPoolVirtualSize returns total virtual size of thin LVs which might be created in the thin pool
+// with data size dataSize and the overcommit ratio
+func GetThinPoolVirtualSize(dataSize int64, ratio float64) int64 {
+	return AlignSizeByMB(int64(float64(dataSize) * ratio))
+}
+
+// GetThinPoolFreeSpace returns virtual size available for the new thin LVs. It's limited by the virtual size of the pool
+// minus virtual size of allocated LVs and by the free space of the pool multiplied by the overcommit ratio,
+// free space of the pool is calculated based on the data or the metadata usage (in percents) whichever is bigger
+func GetThinPoolFreeSpace(dataSize, allocated int64, dataPercent, metadataPercent, ratio float64) int64 {
+	usedPercent := dataPercent
+	if metadataPercent > usedPercent {
+		usedPercent = metadataPercent
+	}
+	var (
+		virtualFree  = GetThinPoolVirtualSize(dataSize, ratio) - allocated
+		physicalFree = GetThinPoolVirtualSize(int64(float64(dataSize)*(100-usedPercent)/100), ratio)
+	)
+	if physicalFree < virtualFree {
+		virtualFree = physicalFree
+	}
+	if virtualFree < 0 {
+		return 0
+	}
+	return virtualFree
+}
+
+// GetRaidLVGSize returns size available for RAID LVs in LVG which consists of drives of the provided size,
+// each drive holds one image of LV, so the size is limited by the smallest drive multiplied by the number of stripes.
+// Returns 0 if RAID type isn't supported
+func GetRaidLVGSize(raidType string, minDriveSize int64) int64 {
+	layout, ok := util.GetRaidLayout(raidType)
+	if !ok {
+		return 0
+	}
+	size := int64(layout.Stripes) * SubtractLVMMetadataSize(minDriveSize)
+	if size < 0 {
+		return 0
+	}
+	return size
+}
+
+// GetRaidLVSize returns space of RAID LVG which is taken by RAID LV of the provided size.
+// LVM rounds up size of LV to the stripe boundary and allocates metadata sub LV of one extent for each image
+func GetRaidLVSize(raidType string, size int64) int64 {
+	layout, ok := util.GetRaidLayout(raidType)
+	if !ok {
+		return size
+	}
+	stripeSize := int64(layout.Stripes) * DefaultPESize
+	if reminder := size % stripeSize; reminder != 0 {
+		size += stripeSize - reminder
+	}
+	return size + stripeSize
+}
+
+// GetStripedLVGSize returns size available for striped LVs in LVG which consists of the provided number of drives,
+// each LV is split between all drives equally, so the size is limited by the smallest drive
+func GetStripedLVGSize(stripes int, minDriveSize int64) int64 {
+	size := int64(stripes) * SubtractLVMMetadataSize(minDriveSize)
+	if size < 0 {
+		return 0
+	}
+	return size
+}
+
+// GetStripedLVSize returns space of LVG which is taken by striped LV of the provided size.
+// LVM rounds up size of striped LV to the stripe boundary
+func GetStripedLVSize(stripes int, size int64) int64 {
+	if stripes < 2 {
+		return size
+	}
+	stripeSize := int64(stripes) * DefaultPESize
+	if reminder := size % stripeSize; reminder != 0 {
+		size += stripeSize - reminder
+	}
+	return size
+}
+
+// GetCloneSnapshotSize returns size of COW area of the temporary snapshot which is created in LVG of the source
+// volume during cloning. Write rate of the source in use isn't known and copy might take long time,
+// so COW area has the same size as the source and snapshot is never invalidated during copy
+func GetCloneSnapshotSize(sourceSize int64) int64 {
+	return AlignSizeByPE(sourceSize)
+}
@@ -16,7 +16,11 @@ limitations under the License.
 
 package capacityplanner
 
-import "testing"
+import (
+	"testing"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
 
 func TestSubtractLVMMetadataSize(t *testing.T) {
 	type args struct {
@@ -111,3 +115,97 @@ func TestAlignSizeByMB(t *testing.T) {
 		})
 	}
 }
+
+func TestGetThinPoolMetadataSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		vgSize int64
+		want   int64
+	}{
+		{name: "1GiB", vgSize: 1073741824, want: DefaultPESize},
+		{name: "100GiB", vgSize: 107374182400, want: 26 * DefaultPESize},
+		{name: "100TiB", vgSize: 109951162777600, want: thinPoolMaxMetadataSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetThinPoolMetadataSize(tt.vgSize); got != tt.want {
+				t.Errorf("GetThinPoolMetadataSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := GetThinPoolDataSize(1073741824); got != 1073741824-2*DefaultPESize {
+		t.Errorf("GetThinPoolDataSize() = %v, want %v", got, 1073741824-2*DefaultPESize)
+	}
+	if got := GetThinPoolDataSize(DefaultPESize); got != 0 {
+		t.Errorf("GetThinPoolDataSize() = %v, want 0", got)
+	}
+}
+
+func TestGetThinPoolFreeSpace(t *testing.T) {
+	const mib = int64(1048576)
+	type args struct {
+		allocated       int64
+		dataPercent     float64
+		metadataPercent float64
+	}
+	tests := []struct {
+		name string
+		args args
+		want int64
+	}{
+		{
+			name: "Empty pool",
+			args: args{},
+			want: 2000 * mib,
+		},
+		{
+			name: "Limited by virtual size",
+			args: args{allocated: 500 * mib, dataPercent: 10},
+			want: 1500 * mib,
+		},
+		{
+			name: "Limited by data usage",
+			args: args{allocated: 500 * mib, dataPercent: 80},
+			want: 400 * mib,
+		},
+		{
+			name: "Limited by metadata usage",
+			args: args{allocated: 500 * mib, dataPercent: 10, metadataPercent: 90},
+			want: 200 * mib,
+		},
+		{
+			name: "Overcommitted",
+			args: args{allocated: 3000 * mib},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetThinPoolFreeSpace(1000*mib, tt.args.allocated, tt.args.dataPercent, tt.args.metadataPercent, 2)
+			if got != tt.want {
+				t.Errorf("GetThinPoolFreeSpace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRaidLVGSize(t *testing.T) {
+	const gib = int64(1073741824)
+	if got := GetRaidLVGSize(apiV1.RaidType1, gib); got != gib-DefaultPESize {
+		t.Errorf("GetRaidLVGSize() = %v, want %v", got, gib-DefaultPESize)
+	}
+	if got := GetRaidLVGSize(apiV1.RaidType5, gib); got != 2*(gib-DefaultPESize) {
+		t.Errorf("GetRaidLVGSize() = %v, want %v", got, 2*(gib-DefaultPESize))
+	}
+	if got := GetRaidLVGSize("raid6", gib); got != 0 {
+		t.Errorf("GetRaidLVGSize() = %v, want 0", got)
+	}
+
+	if got := GetRaidLVSize(apiV1.RaidType1, gib); got != gib+DefaultPESize {
+		t.Errorf("GetRaidLVSize() = %v, want %v", got, gib+DefaultPESize)
+	}
+	if got := GetRaidLVSize(apiV1.RaidType10, 3*DefaultPESize); got != 6*DefaultPESize {
+		t.Errorf("GetRaidLVSize() = %v, want %v", got, 6*DefaultPESize)
+	}
+}
@@ -19,6 +19,7 @@ package capacityplanner
 import (
 	"fmt"
 	"sort"
+	"strconv"
 
 	genV1 "github.com/dell/csi-baremetal/api/generated/v1"
 	v1 "github.com/dell/csi-baremetal/api/v1"
@@ -112,6 +113,17 @@ func newNodeCapacity(node string, acs []accrd.AvailableCapacity, acrs []acrcrd.A
 	acsOrder[v1.StorageClassHDDLVG] = append(acsOrder[v1.StorageClassHDDLVG], acsOrder[v1.StorageClassHDD]...)
 	acsOrder[v1.StorageClassSSDLVG] = append(acsOrder[v1.StorageClassSSDLVG], acsOrder[v1.StorageClassSSD]...)
 	acsOrder[v1.StorageClassNVMeLVG] = append(acsOrder[v1.StorageClassNVMeLVG], acsOrder[v1.StorageClassNVMe]...)
+	acsOrder[v1.StorageClassHDDLVGThin] = append(acsOrder[v1.StorageClassHDDLVGThin], acsOrder[v1.StorageClassHDD]...)
+	acsOrder[v1.StorageClassSSDLVGThin] = append(acsOrder[v1.StorageClassSSDLVGThin], acsOrder[v1.StorageClassSSD]...)
+	acsOrder[v1.StorageClassNVMeLVGThin] = append(acsOrder[v1.StorageClassNVMeLVGThin], acsOrder[v1.StorageClassNVMe]...)
+	for _, sc := range []string{
+		v1.StorageClassHDDLVGRaid1, v1.StorageClassHDDLVGRaid10, v1.StorageClassHDDLVGRaid5,
+		v1.StorageClassSSDLVGRaid1, v1.StorageClassSSDLVGRaid10, v1.StorageClassSSDLVGRaid5,
+		v1.StorageClassNVMeLVGRaid1, v1.StorageClassNVMeLVGRaid10, v1.StorageClassNVMeLVGRaid5,
+		v1.StorageClassHDDLVGStriped, v1.StorageClassSSDLVGStriped, v1.StorageClassNVMeLVGStriped,
+	} {
+		acsOrder[sc] = append(acsOrder[sc], acsOrder[util.GetSubStorageClass(sc)]...)
+	}
 
 	acMap := buildACMap(acs)
 
@@ -152,15 +164,46 @@ func (nc *nodeCapacity) selectACForVolume(vol *genV1.Volume) *accrd.AvailableCap
 		// TODO: use non default PE size - https://github.com/dell/csi-baremetal/issues/85
 		requiredSize = AlignSizeByPE(vol.GetSize())
 	}
+	raidType := util.GetRaidType(vol.StorageClass)
+	if raidType != "" {
+		requiredSize = GetRaidLVSize(raidType, requiredSize)
+	}
 
 	for _, ac := range nc.acsOrder[vol.StorageClass] {
-		if requiredSize <= nc.acs[ac].Spec.Size && nc.acs[ac].Labels[v1.StorageGroupLabelKey] == vol.StorageGroup {
+		var (
+			acSize         = nc.acs[ac].Spec.Size
+			acRequiredSize = requiredSize
+			stripes        int
+		)
+		switch {
+		case raidType != "":
+			acSize = nc.getACSizeForVolume(nc.acs[ac], raidType)
+		case util.IsStorageClassLVGStriped(vol.StorageClass):
+			// AC isn't suitable if the volume can't be striped across the requested number of drives
+			if stripes = nc.getStripes(nc.acs[ac], vol); stripes < 2 {
+				continue
+			}
+			acRequiredSize = GetStripedLVSize(stripes, requiredSize)
+			if !util.IsStorageClassLVG(nc.acs[ac].Spec.StorageClass) {
+				acSize = GetStripedLVGSize(stripes, acSize)
+			}
+		}
+		if acRequiredSize <= acSize && nc.acs[ac].Labels[v1.StorageGroupLabelKey] == vol.StorageGroup {
 			// check if AC is reserved
 			reservation, ok := nc.reservedACs[ac]
 
 			// reserve AC, if it is not found in reservations
 			if !ok {
 				foundAC := nc.acs[ac]
+				// new RAID or striped LVG requires the other member drives
+				if !util.IsStorageClassLVG(foundAC.Spec.StorageClass) {
+					if raidType != "" && !nc.reserveRaidMembers(foundAC, raidType, acRequiredSize) {
+						continue
+					}
+					if stripes > 0 && !nc.reserveStripedMembers(foundAC, stripes, acRequiredSize) {
+						continue
+					}
+				}
 				nc.reservedACs[foundAC.Name] = &reservedCapacity{
 					Size:         vol.Size,
 					StorageClass: vol.StorageClass,
@@ -173,15 +216,15 @@ func (nc *nodeCapacity) selectACForVolume(vol *genV1.Volume) *accrd.AvailableCap
 				continue
 			}
 
-			// skip AC, if AC was reserved for non-LVG
-			if !util.IsStorageClassLVG(reservation.StorageClass) {
+			// skip AC, if AC was reserved for non-LVG or for LVG of another kind (thin, thick, RAID or striped)
+			if reservation.StorageClass != vol.StorageClass {
 				continue
 			}
 
 			// select AC, if it has enough capacity
-			if reservation.Size+requiredSize <= nc.acs[ac].Spec.Size {
+			if reservation.Size+acRequiredSize <= acSize {
 				foundAC := nc.acs[ac]
-				nc.reservedACs[foundAC.Name].Size += requiredSize
+				nc.reservedACs[foundAC.Name].Size += acRequiredSize
 				return foundAC
 			}
 		}
@@ -190,6 +233,87 @@ func (nc *nodeCapacity) selectACForVolume(vol *genV1.Volume) *accrd.AvailableCap
 	return nil
 }
 
+// getACSizeForVolume returns size of AC available for the volume, AC of the drive provides
+// size of the RAID LVG which will be created on it and the other member drives of the same size
+func (nc *nodeCapacity) getACSizeForVolume(ac *accrd.AvailableCapacity, raidType string) int64 {
+	if raidType == "" || util.IsStorageClassLVG(ac.Spec.StorageClass) {
+		return ac.Spec.Size
+	}
+	return GetRaidLVGSize(raidType, ac.Spec.Size)
+}
+
+// getStripes returns number of drives the striped volume is spread across if it's placed on AC.
+// AC of the striped LVG provides the number of its drives, it must match the requested one if the volume has it.
+// Volume without the requested number is spread across all free drives of its storage group
+func (nc *nodeCapacity) getStripes(ac *accrd.AvailableCapacity, vol *genV1.Volume) int {
+	if util.IsStorageClassLVG(ac.Spec.StorageClass) {
+		stripes, err := strconv.Atoi(ac.Labels[v1.LVGStripesLabelKey])
+		if err != nil || (vol.Stripes > 0 && stripes != int(vol.Stripes)) {
+			return 0
+		}
+		return stripes
+	}
+	if vol.Stripes > 0 {
+		return int(vol.Stripes)
+	}
+	var stripes int
+	for _, name := range nc.acsOrder[ac.Spec.StorageClass] {
+		if _, reserved := nc.reservedACs[name]; reserved || nc.acs[name].Spec.Size == 0 ||
+			nc.acs[name].Labels[v1.StorageGroupLabelKey] != ac.Labels[v1.StorageGroupLabelKey] {
+			continue
+		}
+		stripes++
+	}
+	return stripes
+}
+
+// reserveRaidMembers reserves not reserved ACs of the drives which will be added to the new RAID LVG together
+// with the drive of the primary AC. Returns false if there are not enough suitable drives on the node
+func (nc *nodeCapacity) reserveRaidMembers(primary *accrd.AvailableCapacity, raidType string, requiredSize int64) bool {
+	layout, ok := util.GetRaidLayout(raidType)
+	if !ok {
+		return false
+	}
+	return nc.reserveLVGMembers(primary, layout.Drives, func(size int64) bool {
+		return GetRaidLVGSize(raidType, size) >= requiredSize
+	})
+}
+
+// reserveStripedMembers reserves not reserved ACs of the drives which will be added to the new striped LVG together
+// with the drive of the primary AC. Returns false if there are not enough suitable drives on the node
+func (nc *nodeCapacity) reserveStripedMembers(primary *accrd.AvailableCapacity, stripes int, requiredSize int64) bool {
+	return nc.reserveLVGMembers(primary, stripes, func(size int64) bool {
+		return GetStripedLVGSize(stripes, size) >= requiredSize
+	})
+}
+
+// reserveLVGMembers reserves drives-1 not reserved ACs of the same storage class and storage group as primary AC,
+// which size is suitable for the new LVG. Returns false if there are not enough such ACs on the node
+func (nc *nodeCapacity) reserveLVGMembers(primary *accrd.AvailableCapacity, drives int, isSuitable func(size int64) bool) bool {
+	var members []string
+	for _, ac := range nc.acsOrder[primary.Spec.StorageClass] {
+		if len(members) == drives-1 {
+			break
+		}
+		if _, reserved := nc.reservedACs[ac]; reserved || ac == primary.Name {
+			continue
+		}
+		if nc.acs[ac].Labels[v1.StorageGroupLabelKey] != primary.Labels[v1.StorageGroupLabelKey] ||
+			!isSuitable(nc.acs[ac].Spec.Size) {
+			continue
+		}
+		members = append(members, ac)
+	}
+	if len(members) < drives-1 {
+		return false
+	}
+	// the whole drive is reserved, so member AC can't be selected for another volume
+	for _, ac := range members {
+		nc.reservedACs[ac] = &reservedCapacity{Size: nc.acs[ac].Spec.Size}
+	}
+	return true
+}
+
 func buildACMap(acs []accrd.AvailableCapacity) ACMap {
 	acMap := ACMap{}
 	for i, ac := range acs {
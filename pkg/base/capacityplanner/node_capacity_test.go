@@ -335,6 +335,26 @@ func TestSelectACForVolume(t *testing.T) {
 			},
 			want: &testACHDD2,
 		},
+		{
+			name: "Should reserve non-LVG for thin LVG",
+			args: args{
+				nc: newNodeCapacity(nodeName,
+					[]accrd.AvailableCapacity{testACHDD1},
+					nil),
+				vol: getTestVol(nodeName, testSmallSize, apiV1.StorageClassHDDLVGThin),
+			},
+			want: &testACHDD1,
+		},
+		{
+			name: "Should reject AC reserved for thick LVG for thin LVG",
+			args: args{
+				nc: newNodeCapacity(nodeName,
+					[]accrd.AvailableCapacity{testACHDD2},
+					[]acrcrd.AvailableCapacityReservation{testACRHDDLVG1}),
+				vol: getTestVol(nodeName, testSmallSize, apiV1.StorageClassHDDLVGThin),
+			},
+			want: nil,
+		},
 		{
 			name: "Should respect HDD AC for ANY SC",
 			args: args{
@@ -366,3 +386,44 @@ func Test_nodeCapacity_String(t *testing.T) {
 	str := nc.String()
 	assert.True(t, strings.Contains(str, testACHDD1.Name))
 }
+
+func TestSelectACForRaidVolume(t *testing.T) {
+	var (
+		testACHDD1       = *getTestAC(nodeName, testSmallSize, apiV1.StorageClassHDD)
+		testACHDD2       = *getTestAC(nodeName, testLargeSize, apiV1.StorageClassHDD)
+		testACSSD1       = *getTestAC(nodeName, testLargeSize, apiV1.StorageClassSSD)
+		testACHDDLVGRaid = *getTestAC(nodeName, testLargeSize, apiV1.StorageClassHDDLVGRaid1)
+	)
+
+	t.Run("Should reserve member drives of new RAID LVG", func(t *testing.T) {
+		nc := newNodeCapacity(nodeName, []accrd.AvailableCapacity{testACHDD1, testACHDD2, testACSSD1}, nil)
+		ac := nc.selectACForVolume(getTestVol(nodeName, testSmallSize/2, apiV1.StorageClassHDDLVGRaid1))
+		assert.NotNil(t, ac)
+		assert.Equal(t, testACHDD1.Name, ac.Name)
+		assert.Contains(t, nc.reservedACs, testACHDD2.Name)
+		assert.NotContains(t, nc.reservedACs, testACSSD1.Name)
+
+		// member drive can't be used by another volume
+		assert.Nil(t, nc.selectACForVolume(getTestVol(nodeName, testSmallSize, apiV1.StorageClassHDD)))
+	})
+
+	t.Run("Should reject new RAID LVG without enough drives", func(t *testing.T) {
+		nc := newNodeCapacity(nodeName, []accrd.AvailableCapacity{testACHDD1, testACHDD2, testACSSD1}, nil)
+		assert.Nil(t, nc.selectACForVolume(getTestVol(nodeName, testSmallSize/2, apiV1.StorageClassHDDLVGRaid5)))
+		assert.Empty(t, nc.reservedACs)
+	})
+
+	t.Run("Should reject member drives which are too small", func(t *testing.T) {
+		nc := newNodeCapacity(nodeName, []accrd.AvailableCapacity{testACHDD1, testACHDD2}, nil)
+		assert.Nil(t, nc.selectACForVolume(getTestVol(nodeName, testSmallSize, apiV1.StorageClassHDDLVGRaid1)))
+	})
+
+	t.Run("Should select existing RAID LVG", func(t *testing.T) {
+		nc := newNodeCapacity(nodeName, []accrd.AvailableCapacity{testACHDD1, testACHDDLVGRaid}, nil)
+		ac := nc.selectACForVolume(getTestVol(nodeName, testSmallSize, apiV1.StorageClassHDDLVGRaid1))
+		assert.NotNil(t, ac)
+		assert.Equal(t, testACHDDLVGRaid.Name, ac.Name)
+		// RAID LVG of another type isn't suitable
+		assert.Nil(t, nc.selectACForVolume(getTestVol(nodeName, testSmallSize, apiV1.StorageClassHDDLVGRaid10)))
+	})
+}
@@ -198,6 +198,11 @@ func (cm *CapacityManager) selectCapacityOnNode(ctx context.Context, node string
 	result := VolToACMap{}
 
 	for _, vol := range volumes {
+		// volume is pinned to another node, e.g. clone must be placed on the node of the source volume
+		if vol.NodeId != "" && vol.NodeId != node {
+			logger.Debugf("Vol: %s must be placed on node %s, skip node %s", vol.Id, vol.NodeId, node)
+			return nil
+		}
 		ac := nodeCap.selectACForVolume(vol)
 		if ac == nil {
 			logger.Debugf("AC for vol: %s not found on node %s", vol.Id, node)
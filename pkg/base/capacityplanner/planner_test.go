@@ -222,6 +222,43 @@ func TestCapacityManager(t *testing.T) {
 			assert.ElementsMatch(t, testACS, plan.GetACsForVolumes()[testVols[0]])
 		}
 	})
+	t.Run("Volume with cache", func(t *testing.T) {
+		// volume and its cache are placed on the same node
+		vol := getTestVol("", testLargeSize, apiV1.StorageClassHDDLVG)
+		cacheVol := getTestVol("", testSmallSize, apiV1.StorageClassSSD)
+		testVols := []*genV1.Volume{vol, cacheVol}
+		testACS := []*accrd.AvailableCapacity{
+			getTestAC(testNode1, testLargeSize, apiV1.StorageClassHDD),
+			getTestAC(testNode2, testLargeSize, apiV1.StorageClassHDD),
+			getTestAC(testNode2, testSmallSize, apiV1.StorageClassSSD),
+		}
+		plan, err := callPlanVolumesPlacing(getCapReaderMock(testACS, nil), getResReaderMock(nil, nil), testVols,
+			[]string{testNode1, testNode2})
+		assert.NotNil(t, plan)
+		assert.Nil(t, err)
+		if plan != nil {
+			assert.Nil(t, plan.GetVolumesToACMapping(testNode1))
+			assert.Equal(t, testACS[1], plan.GetACForVolume(testNode2, vol))
+			assert.Equal(t, testACS[2], plan.GetACForVolume(testNode2, cacheVol))
+		}
+	})
+	t.Run("Volume pinned to node", func(t *testing.T) {
+		testVols := []*genV1.Volume{
+			getTestVol(testNode2, testSmallSize, apiV1.StorageClassHDD),
+		}
+		testACS := []*accrd.AvailableCapacity{
+			getTestAC(testNode1, testSmallSize, apiV1.StorageClassHDD),
+			getTestAC(testNode2, testSmallSize, apiV1.StorageClassHDD),
+		}
+		plan, err := callPlanVolumesPlacing(getCapReaderMock(testACS, nil), getResReaderMock(nil, nil), testVols,
+			[]string{testNode1, testNode2})
+		assert.NotNil(t, plan)
+		assert.Nil(t, err)
+		if plan != nil {
+			assert.Nil(t, plan.GetACForVolume(testNode1, testVols[0]))
+			assert.Equal(t, testACS[1], plan.GetACForVolume(testNode2, testVols[0]))
+		}
+	})
 	t.Run("Using sub class for LVG", func(t *testing.T) {
 		testVols := []*genV1.Volume{
 			getTestVol("", testSmallSize, apiV1.StorageClassHDDLVG),
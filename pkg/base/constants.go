@@ -64,6 +64,17 @@ const (
 	StorageTypeKey = "storageType"
 	// SizeKey key from volume_context in CreateVolumeRequest of NodePublishVolumeRequest
 	SizeKey = "size"
+	// StripesKey is a StorageClass parameter key of the number of drives of the striped LVG,
+	// all free drives of the storage group are used if it isn't set
+	StripesKey = "stripes"
+	// StripeSizeKey is a StorageClass parameter key of the stripe size of the striped LVs, e.g. "64k"
+	StripeSizeKey = "stripeSize"
+	// CacheKey is a StorageClass parameter key of the drive type (SSD or NVMe) of the volume cache
+	CacheKey = "cache"
+	// CacheSizeKey is a StorageClass parameter key of the volume cache size, e.g. "10Gi"
+	CacheSizeKey = "cacheSize"
+	// CacheModeKey is a StorageClass parameter key of the volume cache mode, writethrough by default
+	CacheModeKey = "cacheMode"
 	// DefaultNamespace represents default namespace in Kubernetes
 	DefaultNamespace = "default"
 )
@@ -30,6 +30,7 @@ import (
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	errTypes "github.com/dell/csi-baremetal/pkg/base/error"
@@ -52,6 +53,8 @@ type CRHelper interface {
 	GetDriveCRAndLVGCRByVolume(volume *volumecrd.Volume) (*drivecrd.Drive, *lvgcrd.LogicalVolumeGroup, error)
 	GetVGNameByLVGCRName(lvgCRName string) (string, error)
 	GetLVGCRs(node ...string) ([]lvgcrd.LogicalVolumeGroup, error)
+	GetSnapshotCRs(volumeID ...string) ([]snapshotcrd.Snapshot, error)
+	GetSnapshotByID(snapshotID string) (*snapshotcrd.Snapshot, error)
 	UpdateVolumeCRSpec(volName string, namespace string, newSpec api.Volume) error
 	DeleteObjectByName(ctx context.Context, name string, namespace string, obj k8sCl.Object) error
 	UpdateVolumeOpStatus(ctx context.Context, volume *volumecrd.Volume, opStatus string) error
@@ -441,6 +444,52 @@ func (cs *CRHelperImpl) GetLVGCRs(node ...string) ([]lvgcrd.LogicalVolumeGroup,
 	return res, nil
 }
 
+// GetSnapshotCRs collect Snapshot CRs of the source volume, use just volumeID[0] element
+// if volumeID isn't provided - return all snapshot CRs
+// if error occurs - return nil and error
+func (cs *CRHelperImpl) GetSnapshotCRs(volumeID ...string) ([]snapshotcrd.Snapshot, error) {
+	var (
+		snapshotList = &snapshotcrd.SnapshotList{}
+		err          error
+	)
+
+	if err = cs.reader.ReadList(context.Background(), snapshotList); err != nil {
+		return nil, err
+	}
+
+	if len(volumeID) == 0 {
+		return snapshotList.Items, nil
+	}
+
+	// if volume ID was provided, collect snapshots of that volume
+	res := make([]snapshotcrd.Snapshot, 0)
+	for _, s := range snapshotList.Items {
+		if s.Spec.VolumeId == volumeID[0] {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+// GetSnapshotByID reads snapshot CRs and returns snapshot CR if it .Spec.Id == snapshotID
+func (cs *CRHelperImpl) GetSnapshotByID(snapshotID string) (*snapshotcrd.Snapshot, error) {
+	snapshotCRs, err := cs.GetSnapshotCRs()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range snapshotCRs {
+		if s.Spec.Id == snapshotID {
+			return &s, nil
+		}
+	}
+
+	cs.log.WithFields(logrus.Fields{
+		"method":     "GetSnapshotByID",
+		"snapshotID": snapshotID,
+	}).Infof("Snapshot CR not found")
+	return nil, errTypes.ErrorNotFound
+}
+
 // UpdateVolumeCRSpec reads volume CR with name volName and update it's spec to newSpec
 // returns nil or error in case of error
 func (cs *CRHelperImpl) UpdateVolumeCRSpec(volName string, namespace string, newSpec api.Volume) error {
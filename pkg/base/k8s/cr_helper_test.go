@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	api "github.com/dell/csi-baremetal/api/generated/v1"
 	v1 "github.com/dell/csi-baremetal/api/v1"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
@@ -201,6 +202,38 @@ func TestCRHelper_GetVolumeCRs(t *testing.T) {
 	assert.Equal(t, vol1.Spec, currentVs[0].Spec)
 }
 
+func TestCRHelper_GetSnapshotCRs(t *testing.T) {
+	ch := setup()
+	snapshot1 := ch.k8sClient.ConstructSnapshotCR("snapshot-1",
+		api.Snapshot{Id: "snapshot-1", VolumeId: testVolumeCR.Spec.Id})
+	snapshot2 := ch.k8sClient.ConstructSnapshotCR("snapshot-2",
+		api.Snapshot{Id: "snapshot-2", VolumeId: "anotherVolume"})
+
+	err := ch.k8sClient.CreateCR(testCtx, snapshot1.Name, snapshot1)
+	assert.Nil(t, err)
+	err = ch.k8sClient.CreateCR(testCtx, snapshot2.Name, snapshot2)
+	assert.Nil(t, err)
+
+	// volume isn't provided - expected all snapshots
+	currentSnapshots, err := ch.GetSnapshotCRs()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(currentSnapshots))
+
+	// expected one snapshot
+	currentSnapshots, err = ch.GetSnapshotCRs(testVolumeCR.Spec.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(currentSnapshots))
+	assert.Equal(t, snapshot1.Spec, currentSnapshots[0].Spec)
+
+	currentSnapshot, err := ch.GetSnapshotByID(snapshot2.Spec.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, snapshot2.Spec, currentSnapshot.Spec)
+
+	currentSnapshot, err = ch.GetSnapshotByID("")
+	assert.Equal(t, errTypes.ErrorNotFound, err)
+	assert.Nil(t, currentSnapshot)
+}
+
 func TestCRHelper_GetDriveCRs(t *testing.T) {
 	ch := setup()
 	d1 := testDriveCR.DeepCopy()
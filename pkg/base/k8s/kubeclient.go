@@ -39,6 +39,7 @@ import (
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	"github.com/dell/csi-baremetal/api/v1/nodecrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	sgcrd "github.com/dell/csi-baremetal/api/v1/storagegroupcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
@@ -295,6 +296,23 @@ func (k *KubeClient) ConstructCSIBMNodeCR(name string, csiNode api.Node) *nodecr
 	}
 }
 
+// ConstructSnapshotCR constructs Snapshot custom resource from api.Snapshot struct
+// Receives a name for k8s ObjectMeta and an instance of api.Snapshot struct
+// Returns an instance of Snapshot CR struct
+func (k *KubeClient) ConstructSnapshotCR(name string, apiSnapshot api.Snapshot) *snapshotcrd.Snapshot {
+	return &snapshotcrd.Snapshot{
+		TypeMeta: apisV1.TypeMeta{
+			Kind:       crdV1.SnapshotKind,
+			APIVersion: crdV1.APIV1Version,
+		},
+		ObjectMeta: apisV1.ObjectMeta{
+			Name:   name,
+			Labels: constructDefaultAppMap(),
+		},
+		Spec: apiSnapshot,
+	}
+}
+
 // GetPods returns list of pods which names contain mask
 // Receives golang context and mask for pods filtering
 // Returns slice of coreV1.Pod or error if something went wrong
@@ -416,6 +434,11 @@ func PrepareScheme() (*runtime.Scheme, error) {
 		return nil, err
 	}
 
+	// register snapshot crd
+	if err := snapshotcrd.AddToSchemeSnapshot(scheme); err != nil {
+		return nil, err
+	}
+
 	return scheme, nil
 }
 
@@ -0,0 +1,160 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package blkcopy contains code for block-level copy of the device content with progress reporting
+// and checksum verification of the copied data
+package blkcopy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// defaultBufferSize is a size of the chunk which is read from the source and written to the destination at once
+const defaultBufferSize = 4 * 1024 * 1024
+
+// ErrChecksumMismatch is returned when checksum of the copied data doesn't match checksum of the source data
+var ErrChecksumMismatch = errors.New("checksum of the copied data doesn't match the source")
+
+// ProgressFunc is called after each copied chunk with the number of copied bytes and total size of the source
+type ProgressFunc func(copied, total int64)
+
+// WrapBlkCopy is an interface that encapsulates block-level copy of the devices
+type WrapBlkCopy interface {
+	Copy(src, dst string, progress ProgressFunc) error
+}
+
+// BlkCopy is an implementation of WrapBlkCopy interface
+type BlkCopy struct {
+	bufferSize int
+	log        *logrus.Entry
+}
+
+// NewBlkCopy is a constructor for BlkCopy
+func NewBlkCopy(logger *logrus.Logger) *BlkCopy {
+	return &BlkCopy{bufferSize: defaultBufferSize, log: logger.WithField("component", "BlkCopy")}
+}
+
+// Copy copies the whole content of the src device to the dst device, dst must not be smaller than src.
+// SHA-256 checksum of the source data is calculated during copy and is compared with checksum of the data
+// read back from dst media (page cache of dst is dropped before verification),
+// progress is reported after each copied chunk if it isn't nil
+// Returns ErrChecksumMismatch if verification failed or error if something went wrong
+func (b *BlkCopy) Copy(src, dst string, progress ProgressFunc) error {
+	ll := b.log.WithField("method", "Copy")
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("unable to open source %s: %w", src, err)
+	}
+	defer closeFile(srcFile, ll)
+	total, err := getSize(srcFile)
+	if err != nil {
+		return err
+	}
+
+	dstFile, err := os.OpenFile(dst, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("unable to open destination %s: %w", dst, err)
+	}
+	defer closeFile(dstFile, ll)
+	dstSize, err := getSize(dstFile)
+	if err != nil {
+		return err
+	}
+	if dstSize < total {
+		return fmt.Errorf("size of destination %s is %d bytes, it's smaller than size of source %s - %d bytes",
+			dst, dstSize, src, total)
+	}
+
+	ll.Infof("Copying %d bytes from %s to %s", total, src, dst)
+	var (
+		srcHash = sha256.New()
+		buf     = make([]byte, b.bufferSize)
+		copied  int64
+	)
+	for copied < total {
+		n, err := io.ReadFull(srcFile, buf)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("unable to read %s at offset %d: %w", src, copied, err)
+		}
+		srcHash.Write(buf[:n])
+		if _, err = dstFile.Write(buf[:n]); err != nil {
+			return fmt.Errorf("unable to write %s at offset %d: %w", dst, copied, err)
+		}
+		copied += int64(n)
+		if progress != nil {
+			progress(copied, total)
+		}
+	}
+	if err = dstFile.Sync(); err != nil {
+		return fmt.Errorf("unable to sync %s: %w", dst, err)
+	}
+
+	dstSum, err := checksum(dst, total, ll)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(srcHash.Sum(nil), dstSum) {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, dst)
+	}
+	return nil
+}
+
+// getSize returns size of the opened device and moves offset to the start of the device,
+// size of the block device isn't reported by stat, so end of the device is found by seek
+func getSize(file *os.File) (int64, error) {
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get size of %s: %w", file.Name(), err)
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("unable to seek %s: %w", file.Name(), err)
+	}
+	return size, nil
+}
+
+// checksum returns SHA-256 checksum of the first size bytes of the device, synced pages of the device
+// are dropped from page cache before read, so data is read from the media
+func checksum(device string, size int64, ll *logrus.Entry) ([]byte, error) {
+	file, err := os.Open(device)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %s: %w", device, err)
+	}
+	defer closeFile(file, ll)
+	if err = unix.Fadvise(int(file.Fd()), 0, size, unix.FADV_DONTNEED); err != nil {
+		return nil, fmt.Errorf("unable to drop cached pages of %s: %w", device, err)
+	}
+
+	hash := sha256.New()
+	if _, err = io.CopyN(hash, file, size); err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", device, err)
+	}
+	return hash.Sum(nil), nil
+}
+
+func closeFile(file *os.File, ll *logrus.Entry) {
+	if err := file.Close(); err != nil {
+		ll.Errorf("Unable to close %s: %v", file.Name(), err)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blkcopy
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLogger = logrus.New()
+
+func createFile(t *testing.T, name string, content []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.Nil(t, os.WriteFile(path, content, 0600))
+	return path
+}
+
+func TestBlkCopy_Copy(t *testing.T) {
+	b := NewBlkCopy(testLogger)
+	b.bufferSize = 1024
+	data := bytes.Repeat([]byte("csi-baremetal"), 1000)
+
+	t.Run("Success", func(t *testing.T) {
+		src := createFile(t, "src", data)
+		dst := createFile(t, "dst", make([]byte, len(data)+100))
+		var lastCopied, lastTotal int64
+		calls := 0
+		err := b.Copy(src, dst, func(copied, total int64) {
+			lastCopied, lastTotal = copied, total
+			calls++
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(len(data)), lastCopied)
+		assert.Equal(t, int64(len(data)), lastTotal)
+		assert.Equal(t, (len(data)+b.bufferSize-1)/b.bufferSize, calls)
+
+		content, err := os.ReadFile(dst)
+		assert.Nil(t, err)
+		assert.Equal(t, data, content[:len(data)])
+	})
+
+	t.Run("Source doesn't exist", func(t *testing.T) {
+		dst := createFile(t, "dst", nil)
+		assert.NotNil(t, b.Copy(filepath.Join(t.TempDir(), "src"), dst, nil))
+	})
+
+	t.Run("Destination doesn't exist", func(t *testing.T) {
+		src := createFile(t, "src", data)
+		assert.NotNil(t, b.Copy(src, filepath.Join(t.TempDir(), "dst"), nil))
+	})
+
+	t.Run("Destination is smaller than source", func(t *testing.T) {
+		src := createFile(t, "src", data)
+		dst := createFile(t, "dst", make([]byte, len(data)-1))
+		copied := false
+		err := b.Copy(src, dst, func(_, _ int64) { copied = true })
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "smaller")
+		assert.False(t, copied)
+	})
+
+	t.Run("Checksum mismatch", func(t *testing.T) {
+		src := createFile(t, "src", data)
+		dst := createFile(t, "dst", make([]byte, len(data)))
+		// destination is changed after the last chunk is written
+		err := b.Copy(src, dst, func(copied, total int64) {
+			if copied == total {
+				assert.Nil(t, os.WriteFile(dst, make([]byte, len(data)), 0600))
+			}
+		})
+		assert.True(t, errors.Is(err, ErrChecksumMismatch))
+	})
+}
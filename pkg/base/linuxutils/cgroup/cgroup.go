@@ -0,0 +1,252 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cgroup contains code for IO throttling of the pods with io.max file of cgroup v2 io controller
+package cgroup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// DefaultRoot is a mount point of the cgroup v2 hierarchy
+	DefaultRoot = "/sys/fs/cgroup"
+	// IOMaxFile is a file of the cgroup with IO limits of the devices, line format - <major>:<minor> <key>=<value> ...
+	IOMaxFile = "io.max"
+	// unlimited is a value of io.max key which removes the limit
+	unlimited = "max"
+
+	readBPSKey   = "rbps"
+	writeBPSKey  = "wbps"
+	readIOPSKey  = "riops"
+	writeIOPSKey = "wiops"
+)
+
+// podCgroupPatterns are patterns of the pod cgroup paths relative to the root for systemd and cgroupfs drivers
+// of kubelet, pod UID is added to the pattern. Dashes of UID are replaced with underscores for systemd driver
+var podCgroupPatterns = []string{
+	"kubepods.slice/kubepods-pod%s.slice",
+	"kubepods.slice/kubepods-*.slice/kubepods-*-pod%s.slice",
+	"kubepods/pod%s",
+	"kubepods/*/pod%s",
+}
+
+// ErrPodCgroupNotFound means that cgroup of the pod doesn't exist, e.g. pod is already removed
+var ErrPodCgroupNotFound = errors.New("pod cgroup not found")
+
+// IOLimits are limits of the device IO, zero value means unlimited
+type IOLimits struct {
+	ReadBPS   int64
+	WriteBPS  int64
+	ReadIOPS  int64
+	WriteIOPS int64
+}
+
+// NewIOLimits creates IOLimits from read and write bytes per second and IOPS.
+// Values are k8s quantities, e.g. 100Mi, empty value means unlimited
+// Returns error if some value isn't a positive integer
+func NewIOLimits(readBPS, writeBPS, readIOPS, writeIOPS string) (IOLimits, error) {
+	var (
+		limits IOLimits
+		err    error
+	)
+	for _, limit := range []struct {
+		value string
+		dst   *int64
+	}{
+		{readBPS, &limits.ReadBPS},
+		{writeBPS, &limits.WriteBPS},
+		{readIOPS, &limits.ReadIOPS},
+		{writeIOPS, &limits.WriteIOPS},
+	} {
+		if *limit.dst, err = parseLimit(limit.value); err != nil {
+			return IOLimits{}, err
+		}
+	}
+	return limits, nil
+}
+
+// ParseIOLimits parses IOLimits from io.max format, e.g. "riops=1000 wbps=1048576"
+// Returns error if format is wrong
+func ParseIOLimits(str string) (IOLimits, error) {
+	var limits IOLimits
+	for _, field := range strings.Fields(str) {
+		key, value, _ := strings.Cut(field, "=")
+		parsed, err := parseLimit(value)
+		if err != nil {
+			return IOLimits{}, err
+		}
+		switch key {
+		case readBPSKey:
+			limits.ReadBPS = parsed
+		case writeBPSKey:
+			limits.WriteBPS = parsed
+		case readIOPSKey:
+			limits.ReadIOPS = parsed
+		case writeIOPSKey:
+			limits.WriteIOPS = parsed
+		default:
+			return IOLimits{}, fmt.Errorf("unknown io limit %s", key)
+		}
+	}
+	return limits, nil
+}
+
+// parseLimit parses positive integer k8s quantity, empty value means unlimited
+func parseLimit(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("io limit %s isn't valid: %w", value, err)
+	}
+	parsed, ok := quantity.AsInt64()
+	if !ok || parsed <= 0 {
+		return 0, fmt.Errorf("io limit %s must be a positive integer", value)
+	}
+	return parsed, nil
+}
+
+// Merge returns IOLimits with limits which are set in override replaced
+func (l IOLimits) Merge(override IOLimits) IOLimits {
+	if override.ReadBPS != 0 {
+		l.ReadBPS = override.ReadBPS
+	}
+	if override.WriteBPS != 0 {
+		l.WriteBPS = override.WriteBPS
+	}
+	if override.ReadIOPS != 0 {
+		l.ReadIOPS = override.ReadIOPS
+	}
+	if override.WriteIOPS != 0 {
+		l.WriteIOPS = override.WriteIOPS
+	}
+	return l
+}
+
+// IsEmpty returns true if no limit is set
+func (l IOLimits) IsEmpty() bool {
+	return l == IOLimits{}
+}
+
+// String returns limits which are set in io.max format
+func (l IOLimits) String() string {
+	var fields []string
+	for _, limit := range []struct {
+		key   string
+		value int64
+	}{
+		{readBPSKey, l.ReadBPS},
+		{writeBPSKey, l.WriteBPS},
+		{readIOPSKey, l.ReadIOPS},
+		{writeIOPSKey, l.WriteIOPS},
+	} {
+		if limit.value != 0 {
+			fields = append(fields, fmt.Sprintf("%s=%d", limit.key, limit.value))
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// WrapCgroup is an interface that encapsulates IO throttling of the pods
+type WrapCgroup interface {
+	GetDeviceNumbers(device string) (string, error)
+	SetIOMax(podUID, deviceNumbers string, limits IOLimits) error
+	ResetIOMax(podUID, deviceNumbers string) error
+}
+
+// Cgroup is an implementation of WrapCgroup interface which works with cgroup v2 hierarchy
+type Cgroup struct {
+	root string
+	log  *logrus.Entry
+}
+
+// NewCgroup is a constructor for Cgroup, root is a mount point of the cgroup v2 hierarchy
+func NewCgroup(root string, logger *logrus.Logger) *Cgroup {
+	return &Cgroup{root: root, log: logger.WithField("component", "Cgroup")}
+}
+
+// GetDeviceNumbers returns major and minor numbers of the device in format <major>:<minor>
+func (c *Cgroup) GetDeviceNumbers(device string) (string, error) {
+	stat := unix.Stat_t{}
+	if err := unix.Stat(device, &stat); err != nil {
+		return "", fmt.Errorf("unable to stat device %s: %w", device, err)
+	}
+	rdev := uint64(stat.Rdev) //nolint:unconvert // type of Rdev depends on architecture
+	return fmt.Sprintf("%d:%d", unix.Major(rdev), unix.Minor(rdev)), nil
+}
+
+// SetIOMax sets IO limits of the device for the pod
+// Receives UID of the pod, device numbers in format <major>:<minor> and limits
+// Returns ErrPodCgroupNotFound if pod cgroup doesn't exist or error if something went wrong
+func (c *Cgroup) SetIOMax(podUID, deviceNumbers string, limits IOLimits) error {
+	return c.writeIOMax(podUID, fmt.Sprintf("%s %s", deviceNumbers, limits))
+}
+
+// ResetIOMax removes all IO limits of the device for the pod
+// Receives UID of the pod and device numbers in format <major>:<minor>
+// Returns ErrPodCgroupNotFound if pod cgroup doesn't exist or error if something went wrong
+func (c *Cgroup) ResetIOMax(podUID, deviceNumbers string) error {
+	line := deviceNumbers
+	for _, key := range []string{readBPSKey, writeBPSKey, readIOPSKey, writeIOPSKey} {
+		line += fmt.Sprintf(" %s=%s", key, unlimited)
+	}
+	return c.writeIOMax(podUID, line)
+}
+
+func (c *Cgroup) writeIOMax(podUID, line string) error {
+	cgroupPath, err := c.findPodCgroup(podUID)
+	if err != nil {
+		return err
+	}
+	ioMaxPath := filepath.Join(cgroupPath, IOMaxFile)
+	c.log.Infof("Write %q to %s", line, ioMaxPath)
+	// io.max is a kernel interface file, it isn't created if io controller is disabled for the cgroup
+	file, err := os.OpenFile(filepath.Clean(ioMaxPath), os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", ioMaxPath, err)
+	}
+	defer file.Close()
+	if _, err = file.WriteString(line); err != nil {
+		return fmt.Errorf("unable to write %s: %w", ioMaxPath, err)
+	}
+	return nil
+}
+
+// findPodCgroup returns path of the pod cgroup
+func (c *Cgroup) findPodCgroup(podUID string) (string, error) {
+	for _, uid := range []string{strings.ReplaceAll(podUID, "-", "_"), podUID} {
+		for _, pattern := range podCgroupPatterns {
+			matches, err := filepath.Glob(filepath.Join(c.root, fmt.Sprintf(pattern, uid)))
+			if err != nil {
+				return "", err
+			}
+			if len(matches) > 0 {
+				return matches[0], nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: pod %s", ErrPodCgroupNotFound, podUID)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cgroup
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPodUID = "8c7ba4e3-2f5f-4cc3-9a36-ae1c0f5e2b3f"
+
+func TestNewIOLimits(t *testing.T) {
+	limits, err := NewIOLimits("100Mi", "", "1000", "1k")
+	assert.Nil(t, err)
+	assert.Equal(t, IOLimits{ReadBPS: 100 * 1024 * 1024, ReadIOPS: 1000, WriteIOPS: 1000}, limits)
+
+	limits, err = NewIOLimits("", "", "", "")
+	assert.Nil(t, err)
+	assert.True(t, limits.IsEmpty())
+
+	for _, value := range []string{"fast", "-1", "0", "0.5"} {
+		_, err = NewIOLimits(value, "", "", "")
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestParseIOLimits(t *testing.T) {
+	limits := IOLimits{ReadBPS: 1, WriteBPS: 2, ReadIOPS: 3, WriteIOPS: 4}
+	assert.Equal(t, "rbps=1 wbps=2 riops=3 wiops=4", limits.String())
+	parsed, err := ParseIOLimits(limits.String())
+	assert.Nil(t, err)
+	assert.Equal(t, limits, parsed)
+
+	parsed, err = ParseIOLimits("")
+	assert.Nil(t, err)
+	assert.True(t, parsed.IsEmpty())
+
+	_, err = ParseIOLimits("rbps=1 unknown=2")
+	assert.NotNil(t, err)
+	_, err = ParseIOLimits("rbps=max")
+	assert.NotNil(t, err)
+}
+
+func TestIOLimits_Merge(t *testing.T) {
+	limits := IOLimits{ReadBPS: 1, WriteBPS: 2}
+	assert.Equal(t, IOLimits{ReadBPS: 1, WriteBPS: 5, WriteIOPS: 6}, limits.Merge(IOLimits{WriteBPS: 5, WriteIOPS: 6}))
+	assert.Equal(t, limits, limits.Merge(IOLimits{}))
+}
+
+func TestCgroup_GetDeviceNumbers(t *testing.T) {
+	c := NewCgroup(t.TempDir(), logrus.New())
+
+	numbers, err := c.GetDeviceNumbers("/dev/null")
+	assert.Nil(t, err)
+	assert.Equal(t, "1:3", numbers)
+
+	_, err = c.GetDeviceNumbers("/dev/not-existing")
+	assert.NotNil(t, err)
+}
+
+func TestCgroup_SetIOMax(t *testing.T) {
+	for _, podCgroup := range []string{
+		"kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod8c7ba4e3_2f5f_4cc3_9a36_ae1c0f5e2b3f.slice",
+		"kubepods.slice/kubepods-pod8c7ba4e3_2f5f_4cc3_9a36_ae1c0f5e2b3f.slice",
+		"kubepods/besteffort/pod8c7ba4e3-2f5f-4cc3-9a36-ae1c0f5e2b3f",
+	} {
+		root := t.TempDir()
+		c := NewCgroup(root, logrus.New())
+		ioMaxPath := filepath.Join(root, podCgroup, IOMaxFile)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(ioMaxPath), 0o750))
+		assert.Nil(t, os.WriteFile(ioMaxPath, nil, 0o600))
+
+		assert.Nil(t, c.SetIOMax(testPodUID, "8:16", IOLimits{ReadIOPS: 100, WriteBPS: 1024}))
+		content, err := os.ReadFile(ioMaxPath)
+		assert.Nil(t, err)
+		assert.Equal(t, "8:16 wbps=1024 riops=100", string(content))
+
+		assert.Nil(t, c.ResetIOMax(testPodUID, "8:16"))
+		content, err = os.ReadFile(ioMaxPath)
+		assert.Nil(t, err)
+		assert.Equal(t, "8:16 rbps=max wbps=max riops=max wiops=max", string(content))
+	}
+}
+
+func TestCgroup_SetIOMaxFail(t *testing.T) {
+	root := t.TempDir()
+	c := NewCgroup(root, logrus.New())
+
+	// pod cgroup doesn't exist
+	err := c.SetIOMax(testPodUID, "8:16", IOLimits{ReadIOPS: 100})
+	assert.True(t, errors.Is(err, ErrPodCgroupNotFound))
+
+	// io controller is disabled
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "kubepods", "pod"+testPodUID), 0o750))
+	err = c.ResetIOMax(testPodUID, "8:16")
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrPodCgroupNotFound))
+}
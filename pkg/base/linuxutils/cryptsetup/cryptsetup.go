@@ -0,0 +1,180 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cryptsetup contains code for running and interpreting output of system cryptsetup util
+// which manages LUKS encrypted block devices
+package cryptsetup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+const (
+	// cryptsetupCmd is a base CMD for cryptsetup util
+	cryptsetupCmd = "cryptsetup "
+	// keyFromStdin is an option which makes cryptsetup to read the key from stdin
+	keyFromStdin = "--key-file - "
+	// MapperPathTmpl is a path of the opened encrypted device
+	MapperPathTmpl = "/dev/mapper/%s" // add mapper name
+	// IsLuksCmdTmpl checks whether device has LUKS header
+	IsLuksCmdTmpl = cryptsetupCmd + "isLuks %s" // add device
+	// notLuksExitCode is an exit code of isLuks command for the device without LUKS header,
+	// other non-zero codes mean that check failed
+	notLuksExitCode = 1
+	// LuksFormatCmdTmpl formats device with LUKS2, key is read from stdin
+	LuksFormatCmdTmpl = cryptsetupCmd + "luksFormat --batch-mode --type luks2 " + keyFromStdin + "%s" // add device
+	// OpenCmdTmpl opens LUKS device as a mapper device, key is read from stdin
+	OpenCmdTmpl = cryptsetupCmd + "open --type luks " + keyFromStdin + "%s %s" // add device and mapper name
+	// StatusCmdTmpl prints status of the mapper device, fails if device isn't opened
+	StatusCmdTmpl = cryptsetupCmd + "status %s" // add mapper name
+	// CloseCmdTmpl closes mapper device
+	CloseCmdTmpl = cryptsetupCmd + "close %s" // add mapper name
+	// ResizeCmdTmpl resizes opened mapper device to the size of the underlying device
+	ResizeCmdTmpl = cryptsetupCmd + "resize %s%s" // add key option and mapper name
+	// EraseCmdTmpl removes all key slots of the LUKS device, data of the device becomes inaccessible
+	EraseCmdTmpl = cryptsetupCmd + "erase --batch-mode %s" // add device
+)
+
+// WrapCryptsetup is an interface that encapsulates operation with system cryptsetup util
+type WrapCryptsetup interface {
+	IsLuks(device string) (bool, error)
+	LuksFormat(device string, key []byte) error
+	Open(device, name string, key []byte) error
+	IsOpened(name string) bool
+	Close(name string) error
+	Resize(name string, key []byte) error
+	Erase(device string) error
+}
+
+// Cryptsetup is an implementation of WrapCryptsetup interface
+type Cryptsetup struct {
+	e   command.CmdExecutor
+	log *logrus.Entry
+}
+
+// NewCryptsetup is a constructor for Cryptsetup
+func NewCryptsetup(e command.CmdExecutor, logger *logrus.Logger) *Cryptsetup {
+	return &Cryptsetup{e: e, log: logger.WithField("component", "Cryptsetup")}
+}
+
+// GetMapperPath returns path of the opened encrypted device with the name
+func GetMapperPath(name string) string {
+	return fmt.Sprintf(MapperPathTmpl, name)
+}
+
+// exitCoder is implemented by errors of the exited commands, e.g. *exec.ExitError
+type exitCoder interface {
+	ExitCode() int
+}
+
+// IsLuks checks whether device has LUKS header
+// Returns false if device isn't LUKS device or error if check failed (e.g. device isn't accessible),
+// in that case device mustn't be treated as not formatted
+func (c *Cryptsetup) IsLuks(device string) (bool, error) {
+	_, _, err := c.e.RunCmd(fmt.Sprintf(IsLuksCmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(IsLuksCmdTmpl, ""))))
+	if err == nil {
+		return true, nil
+	}
+	var exitErr exitCoder
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == notLuksExitCode {
+		return false, nil
+	}
+	return false, err
+}
+
+// LuksFormat formats device with LUKS2, all data on device becomes inaccessible
+// Receives device path and encryption key
+// Returns error if something went wrong
+func (c *Cryptsetup) LuksFormat(device string, key []byte) error {
+	_, _, err := c.e.RunCmd(cmdWithKey(fmt.Sprintf(LuksFormatCmdTmpl, device), key),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(LuksFormatCmdTmpl, ""))))
+	return err
+}
+
+// Open opens LUKS device as a mapper device /dev/mapper/<name>, does nothing if device is already opened
+// Receives device path, mapper name and encryption key
+// Returns error if something went wrong
+func (c *Cryptsetup) Open(device, name string, key []byte) error {
+	if c.IsOpened(name) {
+		return nil
+	}
+	_, _, err := c.e.RunCmd(cmdWithKey(fmt.Sprintf(OpenCmdTmpl, device, name), key),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(OpenCmdTmpl, "", ""))))
+	return err
+}
+
+// IsOpened checks whether mapper device with the name is active
+func (c *Cryptsetup) IsOpened(name string) bool {
+	_, _, err := c.e.RunCmd(fmt.Sprintf(StatusCmdTmpl, name),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(StatusCmdTmpl, ""))))
+	return err == nil
+}
+
+// Close closes mapper device with the name, does nothing if device isn't opened
+// Returns error if something went wrong
+func (c *Cryptsetup) Close(name string) error {
+	if !c.IsOpened(name) {
+		return nil
+	}
+	_, _, err := c.e.RunCmd(fmt.Sprintf(CloseCmdTmpl, name),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(CloseCmdTmpl, ""))))
+	return err
+}
+
+// Resize resizes opened mapper device with the name up to the size of the underlying device,
+// key might be empty if it isn't required to resize the device
+// Returns error if something went wrong
+func (c *Cryptsetup) Resize(name string, key []byte) error {
+	var cmd interface{} = fmt.Sprintf(ResizeCmdTmpl, "", name)
+	if len(key) > 0 {
+		cmd = cmdWithKey(fmt.Sprintf(ResizeCmdTmpl, keyFromStdin, name), key)
+	}
+	_, _, err := c.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(ResizeCmdTmpl, "", ""))))
+	return err
+}
+
+// Erase removes all key slots of the LUKS device, after that data of the device can't be decrypted
+// Returns error if something went wrong
+func (c *Cryptsetup) Erase(device string) error {
+	_, _, err := c.e.RunCmd(fmt.Sprintf(EraseCmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(EraseCmdTmpl, ""))))
+	return err
+}
+
+// cmdWithKey constructs command which reads the key from stdin, so the key doesn't appear in process list and logs
+func cmdWithKey(cmd string, key []byte) *exec.Cmd {
+	fields := strings.Fields(cmd)
+	cmdObj := exec.Command(fields[0], fields[1:]...)
+	cmdObj.Stdin = bytes.NewReader(key)
+	return cmdObj
+}
@@ -0,0 +1,178 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cryptsetup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+var (
+	testLogger  = logrus.New()
+	testDevice  = "/dev/sda1"
+	testName    = "pvc-aaaa-bbbb"
+	testKey     = []byte("secret-key")
+	expectedErr = errors.New("error")
+)
+
+// onCommandWithKey sets expectation for the command which reads testKey from stdin
+func onCommandWithKey(e *mocks.GoMockExecutor, cmd string) *mock.Call {
+	return e.On(mocks.RunCmd, mock.MatchedBy(func(c *exec.Cmd) bool {
+		stdin, ok := c.Stdin.(*bytes.Reader)
+		if strings.Join(c.Args, " ") != cmd || !ok {
+			return false
+		}
+		key := make([]byte, stdin.Size())
+		_, err := stdin.ReadAt(key, 0)
+		return err == nil && bytes.Equal(key, testKey)
+	}))
+}
+
+func TestCryptsetup_GetMapperPath(t *testing.T) {
+	assert.Equal(t, "/dev/mapper/"+testName, GetMapperPath(testName))
+}
+
+func TestCryptsetup_IsLuks(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		c   = NewCryptsetup(e, testLogger)
+		cmd = fmt.Sprintf(IsLuksCmdTmpl, testDevice)
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	isLuks, err := c.IsLuks(testDevice)
+	assert.Nil(t, err)
+	assert.True(t, isLuks)
+
+	// device isn't LUKS device
+	e.OnCommand(cmd).Return("", "", exitCodeErr(notLuksExitCode)).Times(1)
+	isLuks, err = c.IsLuks(testDevice)
+	assert.Nil(t, err)
+	assert.False(t, isLuks)
+
+	// check failed
+	e.OnCommand(cmd).Return("", "", exitCodeErr(4)).Times(1)
+	_, err = c.IsLuks(testDevice)
+	assert.NotNil(t, err)
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	_, err = c.IsLuks(testDevice)
+	assert.Equal(t, expectedErr, err)
+}
+
+// exitCodeErr is an error of the command exited with the code
+type exitCodeErr int
+
+func (e exitCodeErr) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+func (e exitCodeErr) ExitCode() int {
+	return int(e)
+}
+
+func TestCryptsetup_LuksFormat(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		c   = NewCryptsetup(e, testLogger)
+		cmd = fmt.Sprintf(LuksFormatCmdTmpl, testDevice)
+	)
+
+	onCommandWithKey(e, cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, c.LuksFormat(testDevice, testKey))
+
+	onCommandWithKey(e, cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, c.LuksFormat(testDevice, testKey))
+}
+
+func TestCryptsetup_Open(t *testing.T) {
+	var (
+		e         = &mocks.GoMockExecutor{}
+		c         = NewCryptsetup(e, testLogger)
+		statusCmd = fmt.Sprintf(StatusCmdTmpl, testName)
+		cmd       = fmt.Sprintf(OpenCmdTmpl, testDevice, testName)
+	)
+
+	// already opened
+	e.OnCommand(statusCmd).Return("", "", nil).Times(1)
+	assert.Nil(t, c.Open(testDevice, testName, testKey))
+
+	e.OnCommand(statusCmd).Return("", "", expectedErr).Times(2)
+	onCommandWithKey(e, cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, c.Open(testDevice, testName, testKey))
+
+	onCommandWithKey(e, cmd).Return("", "No key available with this passphrase.", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, c.Open(testDevice, testName, testKey))
+}
+
+func TestCryptsetup_Close(t *testing.T) {
+	var (
+		e         = &mocks.GoMockExecutor{}
+		c         = NewCryptsetup(e, testLogger)
+		statusCmd = fmt.Sprintf(StatusCmdTmpl, testName)
+		cmd       = fmt.Sprintf(CloseCmdTmpl, testName)
+	)
+
+	// isn't opened
+	e.OnCommand(statusCmd).Return("", "", expectedErr).Times(1)
+	assert.Nil(t, c.Close(testName))
+
+	e.OnCommand(statusCmd).Return("", "", nil).Times(2)
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, c.Close(testName))
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, c.Close(testName))
+}
+
+func TestCryptsetup_Resize(t *testing.T) {
+	var (
+		e = &mocks.GoMockExecutor{}
+		c = NewCryptsetup(e, testLogger)
+	)
+
+	e.OnCommand(fmt.Sprintf(ResizeCmdTmpl, "", testName)).Return("", "", nil).Times(1)
+	assert.Nil(t, c.Resize(testName, nil))
+
+	onCommandWithKey(e, strings.TrimSpace(fmt.Sprintf(ResizeCmdTmpl, keyFromStdin, testName))).
+		Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, c.Resize(testName, testKey))
+}
+
+func TestCryptsetup_Erase(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		c   = NewCryptsetup(e, testLogger)
+		cmd = fmt.Sprintf(EraseCmdTmpl, testDevice)
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, c.Erase(testDevice))
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, c.Erase(testDevice))
+}
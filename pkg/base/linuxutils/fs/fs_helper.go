@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/dell/csi-baremetal/pkg/base/command"
@@ -40,6 +41,10 @@ const (
 	EXT4 FileSystem = "ext4"
 	// EXT3 file system
 	EXT3 FileSystem = "ext3"
+	// BTRFS file system
+	BTRFS FileSystem = "btrfs"
+	// F2FS file system, designed for flash devices
+	F2FS FileSystem = "f2fs"
 
 	// wipefs is a system utility
 	wipefs = "wipefs "
@@ -48,11 +53,15 @@ const (
 	// CheckSpaceCmdImpl cmd for getting space on the mounted FS, produce output in megabytes (--block-size=M)
 	CheckSpaceCmdImpl = "df %s --output=target,avail --block-size=M" // add mounted fs part
 	// MkFSCmdTmpl mkfs command template
-	MkFSCmdTmpl = "mkfs.%s %s %s" // args: 1 - fs type, 2 - device/path, 3 - fs uuid option
+	MkFSCmdTmpl = "mkfs.%s %s %s" // args: 1 - fs type, 2 - device/path, 3 - fs uuid option and additional options
 	// XfsUUIDOption option to set uuid for mkfs.xfs
 	XfsUUIDOption = "-m uuid=%s -m rmapbt=0"
 	// ExtUUIDOption option to set uuid for mkfs.ext3(4)
 	ExtUUIDOption = "-U %s"
+	// BtrfsUUIDOption option to set uuid for mkfs.btrfs
+	BtrfsUUIDOption = "-U %s"
+	// F2fsUUIDOption option to set uuid for mkfs.f2fs
+	F2fsUUIDOption = "-U %s"
 	// SpeedUpFsCreationOpts options that could be used for speeds up creation of ext3 and ext4 FS
 	SpeedUpFsCreationOpts = " -E lazy_journal_init=1,lazy_itable_init=1,discard"
 	// MkDirCmdTmpl mkdir template
@@ -85,6 +94,24 @@ const (
 	SetupLoopBackDeviceCmdTmpl = losetupCmd + "-f --show %s"
 	// DetachLoopBackDeviceCmdTmpl cmd for loopback device detach
 	DetachLoopBackDeviceCmdTmpl = losetupCmd + "-d %s"
+	// SetXfsUUIDCmdTmpl cmd for changing UUID of the xfs file system, args: 1 - uuid, 2 - device
+	SetXfsUUIDCmdTmpl = "xfs_admin -U %s %s"
+	// XfsLogReplayDirTmpl is a temporary mount point used to replay log of the xfs file system, args: 1 - uuid
+	XfsLogReplayDirTmpl = "/tmp/xfs-log-replay-%s"
+	// XfsLogReplayMountOpt is a mount option which allows to mount copy of xfs with the same UUID as mounted source
+	XfsLogReplayMountOpt = "-o nouuid"
+	// SetExtUUIDCmdTmpl cmd for changing UUID of the ext3(4) file system, args: 1 - uuid, 2 - device
+	SetExtUUIDCmdTmpl = "tune2fs -f -U %s %s"
+	// SetBtrfsUUIDCmdTmpl cmd for changing UUID of the btrfs file system, args: 1 - uuid, 2 - device
+	SetBtrfsUUIDCmdTmpl = "btrfstune -f -U %s %s"
+	// GrowXfsCmdTmpl cmd for online growth of the mounted xfs file system up to the size of the device, arg - mount point
+	GrowXfsCmdTmpl = "xfs_growfs %s"
+	// ResizeExtCmdTmpl cmd for growth of the ext3(4) file system up to the size of the device, arg - device
+	ResizeExtCmdTmpl = "resize2fs %s"
+	// GrowBtrfsCmdTmpl cmd for online growth of the mounted btrfs file system up to the size of the device, arg - mount point
+	GrowBtrfsCmdTmpl = "btrfs filesystem resize max %s"
+	// GetBlockDeviceSizeCmdTmpl cmd for reading size of the block device in bytes
+	GetBlockDeviceSizeCmdTmpl = "blockdev --getsize64 %s"
 
 	// NoSuchDeviceErrMsg is the err msg in stderr of the cmd output when specified device cannot be found
 	NoSuchDeviceErrMsg = "No such device"
@@ -96,7 +123,7 @@ type WrapFS interface {
 	MkDir(src string) error
 	MkFile(src string) error
 	RmDir(src string) error
-	CreateFS(fsType FileSystem, device, uuid string) error
+	CreateFS(fsType FileSystem, device, uuid string, options ...string) error
 	WipeFS(device string) error
 	GetFSType(device string) (string, error)
 	GetFSUUID(device string) (string, error)
@@ -109,6 +136,20 @@ type WrapFS interface {
 	ReadLoopDevice(device string) (string, error)
 	CreateLoopDevice(src string) (string, error)
 	RemoveLoopDevice(device string) error
+	SetFSUUID(fsType FileSystem, device, uuid string) error
+	GrowFS(fsType FileSystem, device, mountPoint string) error
+	GetFSStats(path string) (*FSStats, error)
+	GetBlockDeviceSize(device string) (int64, error)
+}
+
+// FSStats holds usage statistics of the mounted file system
+type FSStats struct {
+	TotalBytes     int64
+	AvailableBytes int64
+	UsedBytes      int64
+	TotalInodes    int64
+	FreeInodes     int64
+	UsedInodes     int64
 }
 
 // WrapFSImpl is a WrapFS implementer
@@ -211,19 +252,27 @@ func (h *WrapFSImpl) RmDir(src string) error {
 	return nil
 }
 
-// CreateFS creates specified file system on the provided device using mkfs
+// CreateFS creates specified file system on the provided device using mkfs,
+// options are additional mkfs options which should be validated with ParseMkfsOptions
 // Receives file system as a var of FileSystem type and path of the device as a string
 // Returns error if something went wrong
-func (h *WrapFSImpl) CreateFS(fsType FileSystem, device, uuid string) error {
+func (h *WrapFSImpl) CreateFS(fsType FileSystem, device, uuid string, options ...string) error {
 	var cmd string
 	switch fsType {
 	case XFS:
 		cmd = fmt.Sprintf(MkFSCmdTmpl, fsType, device, fmt.Sprintf(XfsUUIDOption, uuid))
 	case EXT3, EXT4:
 		cmd = fmt.Sprintf(MkFSCmdTmpl, fsType, device, fmt.Sprintf(ExtUUIDOption, uuid)) + SpeedUpFsCreationOpts
+	case BTRFS:
+		cmd = fmt.Sprintf(MkFSCmdTmpl, fsType, device, fmt.Sprintf(BtrfsUUIDOption, uuid))
+	case F2FS:
+		cmd = fmt.Sprintf(MkFSCmdTmpl, fsType, device, fmt.Sprintf(F2fsUUIDOption, uuid))
 	default:
 		return fmt.Errorf("unsupported file system %v", fsType)
 	}
+	if len(options) > 0 {
+		cmd += " " + strings.Join(options, " ")
+	}
 
 	if _, _, err := h.e.RunCmd(cmd,
 		command.UseMetrics(true),
@@ -430,3 +479,116 @@ func (h *WrapFSImpl) RemoveLoopDevice(device string) error {
 	}
 	return nil
 }
+
+// SetFSUUID changes UUID of the existing file system on the provided device, file system should be unmounted
+// Log of the xfs copied from the volume in use is dirty and xfs_admin refuses to change UUID,
+// so the log is replayed by the temporary mount before UUID change
+// Receives file system as a var of FileSystem type, path of the device and new UUID
+// Returns error if something went wrong
+func (h *WrapFSImpl) SetFSUUID(fsType FileSystem, device, uuid string) error {
+	var cmdTmpl string
+	switch fsType {
+	case XFS:
+		if err := h.replayXfsLog(device, uuid); err != nil {
+			return err
+		}
+		cmdTmpl = SetXfsUUIDCmdTmpl
+	case EXT3, EXT4:
+		cmdTmpl = SetExtUUIDCmdTmpl
+	case BTRFS:
+		cmdTmpl = SetBtrfsUUIDCmdTmpl
+	default:
+		return fmt.Errorf("unsupported file system %v", fsType)
+	}
+
+	if _, _, err := h.e.RunCmd(fmt.Sprintf(cmdTmpl, uuid, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(cmdTmpl, "", "")))); err != nil {
+		return fmt.Errorf("failed to set UUID of file system on %s: %w", device, err)
+	}
+	return nil
+}
+
+// replayXfsLog mounts xfs on the device to the temporary directory and unmounts it to replay dirty log,
+// nouuid is used because the source file system with the same UUID might be mounted on the node
+func (h *WrapFSImpl) replayXfsLog(device, uuid string) error {
+	dir := fmt.Sprintf(XfsLogReplayDirTmpl, uuid)
+	if err := h.MkDir(dir); err != nil {
+		return err
+	}
+	// directory isn't removed recursively, device might be still mounted there if unmount failed
+	defer func() {
+		_ = os.Remove(dir)
+	}()
+	if err := h.Mount(device, dir, XfsLogReplayMountOpt); err != nil {
+		return fmt.Errorf("failed to mount %s to replay xfs log: %w", device, err)
+	}
+	if err := h.Unmount(dir); err != nil {
+		return fmt.Errorf("failed to unmount %s after xfs log replay: %w", device, err)
+	}
+	return nil
+}
+
+// GetFSStats calls statfs for the provided path and returns usage statistics of the file system mounted there
+// Receives path which belongs to the mounted file system
+// Returns FSStats or error if something went wrong
+func (h *WrapFSImpl) GetFSStats(path string) (*FSStats, error) {
+	statfs := &syscall.Statfs_t{}
+	if err := syscall.Statfs(path, statfs); err != nil {
+		return nil, fmt.Errorf("failed to get statfs for %s: %w", path, err)
+	}
+
+	bsize := statfs.Bsize
+	stats := &FSStats{
+		TotalBytes:     int64(statfs.Blocks) * bsize,
+		AvailableBytes: int64(statfs.Bavail) * bsize,
+		UsedBytes:      (int64(statfs.Blocks) - int64(statfs.Bfree)) * bsize,
+		TotalInodes:    int64(statfs.Files),
+		FreeInodes:     int64(statfs.Ffree),
+		UsedInodes:     int64(statfs.Files) - int64(statfs.Ffree),
+	}
+	return stats, nil
+}
+
+// GetBlockDeviceSize calls blockdev command and returns size of the provided block device
+// Receives path of the block device
+// Returns size in bytes or error if something went wrong
+func (h *WrapFSImpl) GetBlockDeviceSize(device string) (int64, error) {
+	cmd := fmt.Sprintf(GetBlockDeviceSizeCmdTmpl, device)
+	stdout, _, err := h.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(GetBlockDeviceSizeCmdTmpl, ""))))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get size of %s: %w", device, err)
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(stdout), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse size of %s from output %s: %w", device, stdout, err)
+	}
+	return size, nil
+}
+
+// GrowFS grows file system up to the size of the underlying device. xfs and btrfs must be mounted,
+// ext3(4) is resized online if mounted, f2fs can't be grown online. Calling it for already grown file system does nothing
+// Receives file system as a var of FileSystem type, path of the device and mount point of the file system
+// Returns error if something went wrong
+func (h *WrapFSImpl) GrowFS(fsType FileSystem, device, mountPoint string) error {
+	var cmdTmpl, arg string
+	switch fsType {
+	case XFS:
+		cmdTmpl, arg = GrowXfsCmdTmpl, mountPoint
+	case EXT3, EXT4:
+		cmdTmpl, arg = ResizeExtCmdTmpl, device
+	case BTRFS:
+		cmdTmpl, arg = GrowBtrfsCmdTmpl, mountPoint
+	default:
+		return fmt.Errorf("unsupported file system %v", fsType)
+	}
+
+	if _, _, err := h.e.RunCmd(fmt.Sprintf(cmdTmpl, arg),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(cmdTmpl, "")))); err != nil {
+		return fmt.Errorf("failed to grow file system on %s: %w", device, err)
+	}
+	return nil
+}
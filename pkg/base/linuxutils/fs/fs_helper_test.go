@@ -170,6 +170,17 @@ func TestCreateFS(t *testing.T) {
 	err = fh.CreateFS(extType, device, uuid)
 	assert.Nil(t, err)
 
+	// btrfs and f2fs
+	e.OnCommand(fmt.Sprintf(MkFSCmdTmpl, BTRFS, device, fmt.Sprintf(BtrfsUUIDOption, uuid))).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.CreateFS(BTRFS, device, uuid))
+	e.OnCommand(fmt.Sprintf(MkFSCmdTmpl, F2FS, device, fmt.Sprintf(F2fsUUIDOption, uuid))).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.CreateFS(F2FS, device, uuid))
+
+	// additional options
+	e.OnCommand(xfsCmd+" -m reflink=1 -K").Return("", "", nil).Times(1)
+	err = fh.CreateFS(xfsType, device, uuid, "-m", "reflink=1", "-K")
+	assert.Nil(t, err)
+
 	// cmd failed
 	e.OnCommand(xfsCmd).Return("", "", testError).Times(1)
 	err = fh.CreateFS(xfsType, device, uuid)
@@ -376,3 +387,97 @@ func TestCreateLoopDevice(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Empty(t, loopDev)
 }
+
+func TestSetFSUUID(t *testing.T) {
+	var (
+		e      = &mocks.GoMockExecutor{}
+		fh     = NewFSImpl(e)
+		device = "/dev/vg/lv"
+		uuid   = "uuid-1"
+	)
+
+	// xfs log is replayed by mount before UUID change
+	dir := fmt.Sprintf(XfsLogReplayDirTmpl, uuid)
+	e.OnCommand(fmt.Sprintf(MkDirCmdTmpl, dir)).Return("", "", nil).Times(2)
+	e.OnCommand(fmt.Sprintf(MountCmdTmpl, XfsLogReplayMountOpt, device, dir)).Return("", "", nil).Times(1)
+	e.OnCommand(fmt.Sprintf(UnmountCmdTmpl, dir)).Return("", "", nil).Times(1)
+	e.OnCommand(fmt.Sprintf(SetXfsUUIDCmdTmpl, uuid, device)).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.SetFSUUID(XFS, device, uuid))
+
+	// xfs log replay failed
+	e.OnCommand(fmt.Sprintf(MountCmdTmpl, XfsLogReplayMountOpt, device, dir)).Return("", "", testError).Times(1)
+	assert.NotNil(t, fh.SetFSUUID(XFS, device, uuid))
+
+	e.OnCommand(fmt.Sprintf(SetExtUUIDCmdTmpl, uuid, device)).Return("", "", testError).Times(1)
+	assert.NotNil(t, fh.SetFSUUID(EXT4, device, uuid))
+
+	e.OnCommand(fmt.Sprintf(SetBtrfsUUIDCmdTmpl, uuid, device)).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.SetFSUUID(BTRFS, device, uuid))
+
+	// unsupported FS
+	assert.NotNil(t, fh.SetFSUUID("ntfs", device, uuid))
+}
+
+func TestGetFSStats(t *testing.T) {
+	fh := NewFSImpl(&mocks.GoMockExecutor{})
+
+	stats, err := fh.GetFSStats(t.TempDir())
+	assert.Nil(t, err)
+	assert.True(t, stats.TotalBytes > 0)
+	assert.True(t, stats.AvailableBytes <= stats.TotalBytes)
+	assert.Equal(t, stats.TotalInodes-stats.FreeInodes, stats.UsedInodes)
+
+	// path doesn't exist
+	_, err = fh.GetFSStats("/not/existing/path")
+	assert.NotNil(t, err)
+}
+
+func TestGetBlockDeviceSize(t *testing.T) {
+	var (
+		e      = &mocks.GoMockExecutor{}
+		fh     = NewFSImpl(e)
+		device = "/dev/sda"
+		cmd    = fmt.Sprintf(GetBlockDeviceSizeCmdTmpl, device)
+	)
+
+	e.OnCommand(cmd).Return("1073741824\n", "", nil).Times(1)
+	size, err := fh.GetBlockDeviceSize(device)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1073741824), size)
+
+	// wrong output
+	e.OnCommand(cmd).Return("abc", "", nil).Times(1)
+	_, err = fh.GetBlockDeviceSize(device)
+	assert.NotNil(t, err)
+
+	// cmd failed
+	e.OnCommand(cmd).Return("", "", testError).Times(1)
+	_, err = fh.GetBlockDeviceSize(device)
+	assert.NotNil(t, err)
+}
+
+func TestGrowFS(t *testing.T) {
+	var (
+		e          = &mocks.GoMockExecutor{}
+		fh         = NewFSImpl(e)
+		device     = "/dev/vg/lv"
+		mountPoint = "/mnt/lv"
+	)
+
+	e.OnCommand(fmt.Sprintf(GrowXfsCmdTmpl, mountPoint)).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.GrowFS(XFS, device, mountPoint))
+
+	e.OnCommand(fmt.Sprintf(ResizeExtCmdTmpl, device)).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.GrowFS(EXT4, device, mountPoint))
+
+	e.OnCommand(fmt.Sprintf(GrowBtrfsCmdTmpl, mountPoint)).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.GrowFS(BTRFS, device, mountPoint))
+
+	// cmd failed
+	e.OnCommand(fmt.Sprintf(ResizeExtCmdTmpl, device)).Return("", "", testError).Times(1)
+	assert.NotNil(t, fh.GrowFS(EXT3, device, mountPoint))
+
+	// unsupported file system
+	assert.NotNil(t, fh.GrowFS("ntfs", device, mountPoint))
+	assert.NotNil(t, fh.GrowFS(F2FS, device, mountPoint))
+}
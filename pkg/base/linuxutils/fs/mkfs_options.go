@@ -0,0 +1,113 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// mkfsOption describes mkfs option which could be passed from SC
+type mkfsOption struct {
+	// valuePattern is a pattern of the option value, option without value if nil
+	valuePattern *regexp.Regexp
+}
+
+var (
+	mkfsNumberValue = regexp.MustCompile(`^[0-9]+$`)
+	mkfsSizeValue   = regexp.MustCompile(`^[0-9]+[kKmMgG]?$`)
+	// mkfsFeaturesValue is a comma separated list of features, ^ before feature disables it
+	mkfsFeaturesValue = regexp.MustCompile(`^\^?[a-z0-9_]+(,\^?[a-z0-9_]+)*$`)
+)
+
+// subOptionsPattern returns pattern of the comma separated list of the name=value sub options
+func subOptionsPattern(names, value string) *regexp.Regexp {
+	subOption := fmt.Sprintf("(%s)=%s", names, value)
+	return regexp.MustCompile(fmt.Sprintf("^%s(,%s)*$", subOption, subOption))
+}
+
+// supportedMkfsOptions contains allowlist of mkfs options for each file system,
+// options which define UUID or force overwrite of the device are set by driver and can't be passed
+var supportedMkfsOptions = map[FileSystem]map[string]mkfsOption{
+	XFS: {
+		"-m": {valuePattern: subOptionsPattern("reflink|crc|finobt|bigtime|inobtcount", "[01]")},
+		"-d": {valuePattern: subOptionsPattern("agcount|su|sw", "[0-9]+[kKmMgG]?")},
+		"-b": {valuePattern: subOptionsPattern("size", "[0-9]+[kK]?")},
+		"-i": {valuePattern: subOptionsPattern("size|maxpct", "[0-9]+")},
+		"-l": {valuePattern: subOptionsPattern("size|su", "[0-9]+[kKmMgG]?")},
+		"-K": {},
+	},
+	EXT4: extMkfsOptions,
+	EXT3: extMkfsOptions,
+	BTRFS: {
+		"-n": {valuePattern: mkfsSizeValue},
+		"-s": {valuePattern: mkfsSizeValue},
+		"-m": {valuePattern: regexp.MustCompile(`^(single|dup)$`)},
+		"-d": {valuePattern: regexp.MustCompile(`^(single|dup)$`)},
+		"-O": {valuePattern: mkfsFeaturesValue},
+		"-K": {},
+	},
+	F2FS: {
+		"-O": {valuePattern: mkfsFeaturesValue},
+		"-s": {valuePattern: mkfsNumberValue},
+		"-z": {valuePattern: mkfsNumberValue},
+		"-t": {valuePattern: regexp.MustCompile(`^[01]$`)},
+	},
+}
+
+// extMkfsOptions contains allowlist of mkfs options for ext3(4) file system
+var extMkfsOptions = map[string]mkfsOption{
+	// reserved blocks percentage
+	"-m": {valuePattern: regexp.MustCompile(`^[0-9]{1,2}$`)},
+	// bytes per inode ratio
+	"-i": {valuePattern: mkfsNumberValue},
+	"-I": {valuePattern: mkfsNumberValue},
+	"-N": {valuePattern: mkfsNumberValue},
+	"-b": {valuePattern: regexp.MustCompile(`^(1024|2048|4096|65536)$`)},
+	"-O": {valuePattern: mkfsFeaturesValue},
+	"-j": {},
+}
+
+// ParseMkfsOptions splits space separated mkfs options and validates them against allowlist of the file system
+// Receives file system as a var of FileSystem type and options string
+// Returns list of the options and their values which could be passed to CreateFS or error if option isn't allowed
+func ParseMkfsOptions(fsType FileSystem, options string) ([]string, error) {
+	fields := strings.Fields(options)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	allowed, ok := supportedMkfsOptions[fsType]
+	if !ok {
+		return nil, fmt.Errorf("mkfs options aren't supported for file system %q", fsType)
+	}
+
+	for i := 0; i < len(fields); i++ {
+		opt, ok := allowed[fields[i]]
+		if !ok {
+			return nil, fmt.Errorf("mkfs option %s isn't supported for file system %s", fields[i], fsType)
+		}
+		if opt.valuePattern == nil {
+			continue
+		}
+		i++
+		if i == len(fields) || !opt.valuePattern.MatchString(fields[i]) {
+			return nil, fmt.Errorf("mkfs option %s of file system %s has invalid value", fields[i-1], fsType)
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMkfsOptions(t *testing.T) {
+	options, err := ParseMkfsOptions(XFS, " -m reflink=1,crc=1  -d agcount=8 -K ")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"-m", "reflink=1,crc=1", "-d", "agcount=8", "-K"}, options)
+
+	options, err = ParseMkfsOptions(EXT4, "-m 1 -i 65536 -b 4096 -O ^has_journal")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"-m", "1", "-i", "65536", "-b", "4096", "-O", "^has_journal"}, options)
+
+	options, err = ParseMkfsOptions(BTRFS, "-m dup -n 16k")
+	assert.Nil(t, err)
+	assert.Len(t, options, 4)
+
+	options, err = ParseMkfsOptions(F2FS, "-O extra_attr,inode_checksum")
+	assert.Nil(t, err)
+	assert.Len(t, options, 2)
+
+	// empty options are allowed for any file system
+	options, err = ParseMkfsOptions("", "")
+	assert.Nil(t, err)
+	assert.Empty(t, options)
+
+	for _, testCase := range []struct {
+		fsType  FileSystem
+		options string
+	}{
+		{XFS, "-m uuid=123"},         // uuid is set by driver
+		{XFS, "-d agcount=8;reboot"}, // invalid value
+		{EXT4, "-F"},                 // force isn't allowed
+		{EXT3, "-m"},                 // value is missing
+		{F2FS, "-t 2"},               // invalid value
+		{BTRFS, "-d raid1"},          // single device
+		{"ntfs", "-Q"},               // unsupported file system
+	} {
+		_, err = ParseMkfsOptions(testCase.fsType, testCase.options)
+		assert.NotNil(t, err, testCase.options)
+	}
+}
@@ -18,6 +18,8 @@ limitations under the License.
 package ipmi
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -27,11 +29,19 @@ import (
 const (
 	// LanPrintCmd print bmc ip cmd with ipmitool
 	LanPrintCmd = " ipmitool lan print"
+	// ChassisIdentifyCmdTmpl turns chassis identify LED on (force) or off (0) with ipmitool
+	ChassisIdentifyCmdTmpl = "ipmitool chassis identify %s"
+	// noDeviceErr is printed by ipmitool if in-band IPMI device isn't available on the node
+	noDeviceErr = "Could not open device"
 )
 
+// ErrNotAvailable means that in-band IPMI device isn't available on the node
+var ErrNotAvailable = errors.New("IPMI device isn't available")
+
 // WrapIpmi is an interface that encapsulates operation with system ipmi util
 type WrapIpmi interface {
 	GetBmcIP() string
+	ChassisIdentify(on bool) error
 }
 
 // IPMI is implementation for WrapImpi interface
@@ -76,3 +86,23 @@ func (i *IPMI) GetBmcIP() string {
 	}
 	return ip
 }
+
+// ChassisIdentify turns chassis identify LED on indefinitely or off
+// Returns ErrNotAvailable if in-band IPMI device isn't available on the node
+func (i *IPMI) ChassisIdentify(on bool) error {
+	arg := "0"
+	if on {
+		arg = "force"
+	}
+	cmd := fmt.Sprintf(ChassisIdentifyCmdTmpl, arg)
+	_, stderr, err := i.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(fmt.Sprintf(ChassisIdentifyCmdTmpl, "")))
+	if err != nil {
+		if strings.Contains(stderr, noDeviceErr) {
+			return ErrNotAvailable
+		}
+		return fmt.Errorf("unable to set chassis identify LED: %v, stderr: %s", err, stderr)
+	}
+	return nil
+}
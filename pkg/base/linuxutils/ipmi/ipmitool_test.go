@@ -19,6 +19,7 @@ package ipmi
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,3 +46,23 @@ func TestIPMI_GetBmcIP(t *testing.T) {
 	ip = l.GetBmcIP()
 	assert.Equal(t, "", ip)
 }
+
+func TestIPMI_ChassisIdentify(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	l := NewIPMI(e)
+
+	e.OnCommand(fmt.Sprintf(ChassisIdentifyCmdTmpl, "force")).Return("Chassis identify interval: indefinite", "", nil).Times(1)
+	assert.Nil(t, l.ChassisIdentify(true))
+
+	e.OnCommand(fmt.Sprintf(ChassisIdentifyCmdTmpl, "0")).Return("Chassis identify interval: off", "", nil).Times(1)
+	assert.Nil(t, l.ChassisIdentify(false))
+
+	e.OnCommand(fmt.Sprintf(ChassisIdentifyCmdTmpl, "force")).
+		Return("", "Could not open device at /dev/ipmi0 or /dev/ipmi/0 or /dev/ipmidev/0", errors.New("exit status 1")).Times(1)
+	assert.Equal(t, ErrNotAvailable, l.ChassisIdentify(true))
+
+	e.OnCommand(fmt.Sprintf(ChassisIdentifyCmdTmpl, "0")).Return("", "error", errors.New("exit status 1")).Times(1)
+	err := l.ChassisIdentify(false)
+	assert.NotNil(t, err)
+	assert.NotEqual(t, ErrNotAvailable, err)
+}
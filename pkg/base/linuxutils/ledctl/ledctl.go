@@ -0,0 +1,107 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ledctl contains code for manipulating drive locate LED using ledctl util from ledmon package
+package ledctl
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+const (
+	// LedctlCmdImpl is a base CMD for ledctl
+	LedctlCmdImpl = "ledctl"
+	// LedctlLocateCmdImpl is a CMD to enable locate LED of device
+	LedctlLocateCmdImpl = LedctlCmdImpl + " locate=%s"
+	// LedctlLocateOffCmdImpl is a CMD to disable locate LED of device
+	LedctlLocateOffCmdImpl = LedctlCmdImpl + " locate_off=%s"
+	// LedctlGetSlotCmdImpl is a CMD to get LED state of the slot with device for controller type
+	LedctlGetSlotCmdImpl = LedctlCmdImpl + " --get-slot --controller-type=%s --device=%s"
+	// LocateState is a LED state of the slot with enabled locate LED
+	LocateState = "LOCATE"
+)
+
+// controllerTypes are types of controllers which support slot LED management in ledctl
+var controllerTypes = []string{"VMD", "NPEM"}
+
+// ledStateRegexp finds LED state in ledctl --get-slot output
+var ledStateRegexp = regexp.MustCompile(`led state:\s*(\S+)`)
+
+// ErrNotSupported means that device isn't managed by any LED capable controller
+var ErrNotSupported = errors.New("locate LED isn't supported for device")
+
+// WrapLedctl is an interface that encapsulates operation with system ledctl util
+type WrapLedctl interface {
+	GetLocate(path string) (bool, error)
+	SetLocate(path string, on bool) error
+}
+
+// LEDCTL is a wrap for system ledctl util
+type LEDCTL struct {
+	e   command.CmdExecutor
+	log *logrus.Entry
+}
+
+// NewLEDCTL is a constructor for LEDCTL
+func NewLEDCTL(e command.CmdExecutor, logger *logrus.Logger) *LEDCTL {
+	return &LEDCTL{e: e, log: logger.WithField("component", "LEDCTL")}
+}
+
+// GetLocate returns true if locate LED of the slot with device is enabled
+// Returns ErrNotSupported if device slot isn't managed by VMD or NPEM controller
+func (l *LEDCTL) GetLocate(path string) (bool, error) {
+	/*
+	 slot: 1           led state: LOCATE          device: /dev/nvme0n1
+	*/
+	ll := l.log.WithField("method", "GetLocate")
+	for _, controller := range controllerTypes {
+		strOut, _, err := l.e.RunCmd(fmt.Sprintf(LedctlGetSlotCmdImpl, controller, path),
+			command.UseMetrics(true),
+			command.CmdName(strings.TrimSpace(fmt.Sprintf(LedctlGetSlotCmdImpl, controller, ""))))
+		if err != nil {
+			ll.Debugf("Device %s isn't managed by %s controller: %v", path, controller, err)
+			continue
+		}
+		match := ledStateRegexp.FindStringSubmatch(strOut)
+		if match == nil {
+			return false, fmt.Errorf("unable to parse ledctl output for device %s: %s", path, strOut)
+		}
+		return match[1] == LocateState, nil
+	}
+	return false, ErrNotSupported
+}
+
+// SetLocate enables or disables locate LED of the slot with device
+func (l *LEDCTL) SetLocate(path string, on bool) error {
+	cmd := LedctlLocateOffCmdImpl
+	if on {
+		cmd = LedctlLocateCmdImpl
+	}
+	_, stderr, err := l.e.RunCmd(fmt.Sprintf(cmd, path),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(cmd, ""))))
+	if err != nil {
+		return fmt.Errorf("unable to set locate LED of device %s: %v, stderr: %s", path, err, stderr)
+	}
+	return nil
+}
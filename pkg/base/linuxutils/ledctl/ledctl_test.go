@@ -0,0 +1,87 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ledctl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+var (
+	testLogger = logrus.New()
+	testPath   = "/dev/nvme0n1"
+)
+
+func TestLEDCTL_GetLocate(t *testing.T) {
+	t.Run("VMD slot", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		l := NewLEDCTL(e, testLogger)
+		e.On("RunCmd", fmt.Sprintf(LedctlGetSlotCmdImpl, "VMD", testPath)).
+			Return("slot: 1           led state: LOCATE          device: /dev/nvme0n1", "", nil)
+
+		on, err := l.GetLocate(testPath)
+		assert.Nil(t, err)
+		assert.True(t, on)
+	})
+
+	t.Run("NPEM slot", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		l := NewLEDCTL(e, testLogger)
+		e.On("RunCmd", fmt.Sprintf(LedctlGetSlotCmdImpl, "VMD", testPath)).Return("", "", fmt.Errorf("error"))
+		e.On("RunCmd", fmt.Sprintf(LedctlGetSlotCmdImpl, "NPEM", testPath)).
+			Return("slot: 0000:01:00.0 led state: NORMAL          device: /dev/nvme0n1", "", nil)
+
+		on, err := l.GetLocate(testPath)
+		assert.Nil(t, err)
+		assert.False(t, on)
+	})
+
+	t.Run("Not supported", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		l := NewLEDCTL(e, testLogger)
+		e.On("RunCmd", fmt.Sprintf(LedctlGetSlotCmdImpl, "VMD", testPath)).Return("", "", fmt.Errorf("error"))
+		e.On("RunCmd", fmt.Sprintf(LedctlGetSlotCmdImpl, "NPEM", testPath)).Return("", "", fmt.Errorf("error"))
+
+		_, err := l.GetLocate(testPath)
+		assert.Equal(t, ErrNotSupported, err)
+	})
+
+	t.Run("Unexpected output", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		l := NewLEDCTL(e, testLogger)
+		e.On("RunCmd", fmt.Sprintf(LedctlGetSlotCmdImpl, "VMD", testPath)).Return("unexpected", "", nil)
+
+		_, err := l.GetLocate(testPath)
+		assert.NotNil(t, err)
+		assert.NotEqual(t, ErrNotSupported, err)
+	})
+}
+
+func TestLEDCTL_SetLocate(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	l := NewLEDCTL(e, testLogger)
+	e.On("RunCmd", fmt.Sprintf(LedctlLocateCmdImpl, testPath)).Return("", "", nil)
+	e.On("RunCmd", fmt.Sprintf(LedctlLocateOffCmdImpl, testPath)).Return("", "", fmt.Errorf("error"))
+
+	assert.Nil(t, l.SetLocate(testPath, true))
+	assert.NotNil(t, l.SetLocate(testPath, false))
+}
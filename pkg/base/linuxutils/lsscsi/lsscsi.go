@@ -35,13 +35,23 @@ const (
 	SCSIDeviceSizeCmdImpl = LsscsiCmdImpl + " --brief --size %s"
 	// SCSIDeviceCmdImpl is a CMD to get devices information about Vendor, Model and etc
 	SCSIDeviceCmdImpl = LsscsiCmdImpl + " --classic %s"
+	// SCSITransportCmdImpl is a CMD to get transport information (SAS address) of devices
+	SCSITransportCmdImpl = LsscsiCmdImpl + " --transport"
+	// SCSIGenericCmdImpl is a CMD to get devices with their SCSI generic (sg) device names
+	SCSIGenericCmdImpl = LsscsiCmdImpl + " --generic"
 	// SCSIType is a type of devices we search in lsscsi output
 	SCSIType = "disk"
+	// EnclosureType is a type of SES enclosure devices in lsscsi output
+	EnclosureType = "enclosu"
+	// sasTransportPrefix is a prefix of SAS address in lsscsi --transport output
+	sasTransportPrefix = "sas:"
 )
 
 // WrapLsscsi is an interface that encapsulates operation with system lsscsi util
 type WrapLsscsi interface {
 	GetSCSIDevices() ([]*SCSIDevice, error)
+	GetSASAddress(path string) (string, error)
+	GetEnclosures() ([]string, error)
 }
 
 // LSSCSI is a wrap for system lsscsi util
@@ -114,6 +124,56 @@ func (la *LSSCSI) getSCSIDevicesBasicInfo() ([]*SCSIDevice, error) {
 	return devices, nil
 }
 
+// GetSASAddress returns SAS address of the device with path using lsscsi --transport
+// Returns empty string if device isn't attached through SAS transport
+func (la *LSSCSI) GetSASAddress(path string) (string, error) {
+	/*
+	 [0:0:0:0]    disk    sas:0x5000c5008e2bb1b5          /dev/sda
+	 [1:0:0:0]    disk    sata:                           /dev/sdb
+	*/
+	strOut, _, err := la.e.RunCmd(SCSITransportCmdImpl,
+		command.UseMetrics(true),
+		command.CmdName(SCSITransportCmdImpl))
+	if err != nil {
+		return "", fmt.Errorf("unable to get devices transport info: %v", err)
+	}
+	for _, line := range strings.Split(strOut, "\n") {
+		output := strings.Fields(line)
+		if len(output) < 3 || output[len(output)-1] != path {
+			continue
+		}
+		for _, field := range output {
+			if strings.HasPrefix(field, sasTransportPrefix) {
+				return strings.TrimPrefix(field, sasTransportPrefix), nil
+			}
+		}
+		return "", nil
+	}
+	return "", fmt.Errorf("device %s isn't found in lsscsi output", path)
+}
+
+// GetEnclosures returns SCSI generic device names (/dev/sgN) of SES enclosures using lsscsi --generic
+func (la *LSSCSI) GetEnclosures() ([]string, error) {
+	/*
+	 [0:0:0:0]    disk    SEAGATE  ST2000NX0463     NT33  /dev/sda   /dev/sg0
+	 [0:0:8:0]    enclosu DELL     BP14G+           2.25  -          /dev/sg3
+	*/
+	strOut, _, err := la.e.RunCmd(SCSIGenericCmdImpl,
+		command.UseMetrics(true),
+		command.CmdName(SCSIGenericCmdImpl))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get generic devices info: %v", err)
+	}
+	enclosures := make([]string, 0)
+	for _, line := range strings.Split(strOut, "\n") {
+		output := strings.Fields(line)
+		if len(output) > 2 && output[1] == EnclosureType {
+			enclosures = append(enclosures, output[len(output)-1])
+		}
+	}
+	return enclosures, nil
+}
+
 // fillDeviceSize fill information about device size
 // lsscsi --no-nvme --brief --size is easy to parse because size on the last position.
 func (la *LSSCSI) fillDeviceSize(device *SCSIDevice) error {
@@ -171,3 +171,50 @@ func TestLSSCSI_GetSCSIDevicesSizeCMDFail(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(devs))
 }
+
+func TestLSSCSI_GetSASAddress(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	l := NewLSSCSI(e, testLogger)
+
+	output := `[0:0:0:0]    disk    sas:0x5000c5008e2bb1b5          /dev/sda
+		[1:0:0:0]    disk    sata:                           /dev/sdb`
+	e.On("RunCmd", SCSITransportCmdImpl).Return(output, "", nil)
+
+	addr, err := l.GetSASAddress("/dev/sda")
+	assert.Nil(t, err)
+	assert.Equal(t, "0x5000c5008e2bb1b5", addr)
+
+	addr, err = l.GetSASAddress("/dev/sdb")
+	assert.Nil(t, err)
+	assert.Equal(t, "", addr)
+
+	_, err = l.GetSASAddress("/dev/sdc")
+	assert.NotNil(t, err)
+}
+
+func TestLSSCSI_GetSASAddressFail(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	l := NewLSSCSI(e, testLogger)
+
+	e.On("RunCmd", SCSITransportCmdImpl).Return("", "", fmt.Errorf("error"))
+
+	_, err := l.GetSASAddress("/dev/sda")
+	assert.NotNil(t, err)
+}
+
+func TestLSSCSI_GetEnclosures(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	l := NewLSSCSI(e, testLogger)
+
+	output := `[0:0:0:0]    disk    SEAGATE  ST2000NX0463     NT33  /dev/sda   /dev/sg0
+		[0:0:8:0]    enclosu DELL     BP14G+           2.25  -          /dev/sg3`
+	e.On("RunCmd", SCSIGenericCmdImpl).Return(output, "", nil).Once()
+
+	enclosures, err := l.GetEnclosures()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/dev/sg3"}, enclosures)
+
+	e.On("RunCmd", SCSIGenericCmdImpl).Return("", "", fmt.Errorf("error")).Once()
+	_, err = l.GetEnclosures()
+	assert.NotNil(t, err)
+}
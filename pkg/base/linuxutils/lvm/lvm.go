@@ -60,14 +60,54 @@ const (
 	VGFreeSpaceCmdTmpl = "vgs %s --options vg_free --units b --noheadings" // add VG name
 	// LVCreateCmdTmpl create LV on provided VG cmd
 	LVCreateCmdTmpl = lvmPath + "lvcreate --yes --name %s --size %s %s" // add LV name, size and VG name
+	// LVCreateOnPVCmdTmpl create LV on the provided PV of VG cmd
+	LVCreateOnPVCmdTmpl = lvmPath + "lvcreate --yes --name %s --size %s %s %s" // add LV name, size, VG name and PV name
+	// LVSnapshotCmdTmpl create snapshot of LV cmd
+	LVSnapshotCmdTmpl = lvmPath + "lvcreate --yes --snapshot --name %s --size %s %s" // add snapshot name, size and full LV name
 	// LVRemoveCmdTmpl remove LV cmd
 	LVRemoveCmdTmpl = lvmPath + "lvremove --yes %s" // add full LV name
 	// LVsInVGCmdTmpl print LVs in VG cmd
 	LVsInVGCmdTmpl = lvmPath + "lvs --select vg_name=%s -o lv_name --noheadings" // add VG name
 	// PVInfoCmdTmpl returns colon (:) separated output, where pv name on first place and vg on second
 	PVInfoCmdTmpl = lvmPath + "pvdisplay %s --colon" // add PV name
+	// ThinPoolCreateCmdTmpl create thin pool on the whole free space of VG cmd
+	ThinPoolCreateCmdTmpl = lvmPath + "lvcreate --yes --type thin-pool --poolmetadatasize %s --extents 100%%FREE --name %s %s" // add metadata size, pool name and VG name
+	// ThinLVCreateCmdTmpl create thin LV in thin pool cmd
+	ThinLVCreateCmdTmpl = lvmPath + "lvcreate --yes --name %s --virtualsize %s --thinpool %s" // add LV name, virtual size and full pool name
+	// ThinSnapshotCmdTmpl create thin snapshot of thin LV cmd, snapshot is activated as a regular LV
+	ThinSnapshotCmdTmpl = lvmPath + "lvcreate --yes --snapshot --setactivationskip n --name %s %s" // add snapshot name and full LV name
+	// ThinPoolUsageCmdTmpl print size of thin pool data and usage of thin pool data and metadata in percents
+	ThinPoolUsageCmdTmpl = lvmPath + "lvs --options lv_size,data_percent,metadata_percent --units b --nosuffix --noheadings %s" // add full pool name
 	// LVExpandCmdTmpl expand LV
-	LVExpandCmdTmpl = lvmPath + "lvextend --size %sb --resizefs %s" // add full LV name
+	LVExpandCmdTmpl = lvmPath + "lvextend --size %sb %s" // add full LV name
+	// RaidLVCreateCmdTmpl create RAID LV which images are placed on the different PVs of VG cmd
+	RaidLVCreateCmdTmpl = lvmPath + "lvcreate --yes --type %s %s --name %s --size %s %s" // add RAID type, layout options, LV name, size and VG name
+	// StripedLVCreateCmdTmpl create LV which is striped across the PVs of VG cmd
+	StripedLVCreateCmdTmpl = lvmPath + "lvcreate --yes --type striped --stripes %d %s --name %s --size %s %s" // add number of stripes, stripe size option, LV name, size and VG name
+	// LVRepairCmdTmpl replace images of RAID LV on the missing PVs with the new images on the provided PV cmd
+	LVRepairCmdTmpl = lvmPath + "lvconvert --yes --repair %s %s" // add full LV name and PV name
+	// VGExtendCmdTmpl add PV to VG cmd
+	VGExtendCmdTmpl = lvmPath + "vgextend --yes %s %s" // add VG name and PV name
+	// VGReduceMissingCmdTmpl remove missing PVs from VG cmd
+	VGReduceMissingCmdTmpl = lvmPath + "vgreduce --removemissing %s" // add VG name
+	// PVMoveCmdTmpl move allocated physical extents from one PV to another PV of the same VG in background cmd
+	PVMoveCmdTmpl = lvmPath + "pvmove --background %s %s" // add source PV name and destination PV name
+	// PVMoveResumeCmd resume all interrupted pvmoves in background cmd
+	PVMoveResumeCmd = lvmPath + "pvmove --background"
+	// PVMoveProgressCmdTmpl print progress of pvmove in VG in percents, output is empty if nothing is being moved
+	PVMoveProgressCmdTmpl = lvmPath + "lvs --all --options copy_percent --noheadings --select segtype=pvmove %s" // add VG name
+	// VGReduceCmdTmpl remove PV from VG cmd
+	VGReduceCmdTmpl = lvmPath + "vgreduce %s %s" // add VG name and PV name
+	// PVChangeAllocatableCmdTmpl allow or disallow allocation of physical extents of PV cmd
+	PVChangeAllocatableCmdTmpl = lvmPath + "pvchange --allocatable %s %s" // add y or n and PV name
+	// CacheAttachCmdTmpl attach LV as a cache to the origin LV cmd
+	CacheAttachCmdTmpl = lvmPath + "lvconvert --yes --type cache --cachevol %s --cachemode %s %s" // add full cache LV name, cache mode and full LV name
+	// CacheDetachCmdTmpl flush cache of LV and detach it, cache LV is kept cmd
+	CacheDetachCmdTmpl = lvmPath + "lvconvert --yes --splitcache %s" // add full LV name
+	// LVSegTypeCmdTmpl print segment type of LV cmd, it is "cache" for the cached LV
+	LVSegTypeCmdTmpl = lvmPath + "lvs --options segtype --noheadings %s" // add full LV name
+	// cacheSegType is a segment type of the cached LV
+	cacheSegType = "cache"
 	// timeoutBetweenAttempts used for RunCmdWithAttempts as a timeout between calling lvremove
 	timeoutBetweenAttempts = 500 * time.Millisecond
 )
@@ -81,7 +121,9 @@ type WrapLVM interface {
 	VGReactivate(name string) error
 	VGRemove(name string) error
 	LVCreate(name, size, vgName string) error
+	LVCreateOnPV(name, size, vgName, pvName string) error
 	LVRemove(fullLVName string) error
+	LVSnapshot(name, size, fullLVName string) error
 	IsVGContainsLVs(vgName string) bool
 	RemoveOrphanPVs() error
 	GetVgFreeSpace(vgName string) (int64, error)
@@ -89,6 +131,30 @@ type WrapLVM interface {
 	GetLVsInVG(vgName string) ([]string, error)
 	GetVGNameByPVName(pvName string) (string, error)
 	ExpandLV(lvName string, requiredSize int64) error
+	ThinPoolCreate(name, metadataSize, vgName string) error
+	ThinLVCreate(name, size, fullPoolName string) error
+	ThinSnapshot(name, fullLVName string) error
+	GetThinPoolUsage(fullPoolName string) (*ThinPoolUsage, error)
+	RaidLVCreate(name, size, vgName, raidType string) error
+	StripedLVCreate(name, size, vgName string, stripes int, stripeSize string) error
+	RaidLVRepair(fullLVName, pvName string) error
+	VGExtend(name, pvName string) error
+	VGReduceMissing(name string) error
+	PVMove(srcPVName, dstPVName string) error
+	PVMoveResume() error
+	GetPVMoveProgress(vgName string) (float64, bool, error)
+	VGReduce(name, pvName string) error
+	PVChangeAllocatable(pvName string, allocatable bool) error
+	CacheAttach(fullLVName, fullCacheLVName, cacheMode string) error
+	CacheDetach(fullLVName string) error
+	IsLVCached(fullLVName string) (bool, error)
+}
+
+// ThinPoolUsage contains size of the thin pool data and usage of the thin pool data and metadata in percents
+type ThinPoolUsage struct {
+	DataSize        int64
+	DataPercent     float64
+	MetadataPercent float64
 }
 
 // LVM is an implementation of WrapLVM interface and is a wrap for system /sbin/lvm util in
@@ -130,18 +196,19 @@ func (l *LVM) PVRemove(name string) error {
 	return err
 }
 
-// ExpandLV expand logical volume
+// ExpandLV expand logical volume, file system on the logical volume isn't resized. Ignore error if LV already has
+// required size
 // Receives full name of a logical volume and requiredSize to resize
 // Returns error if something went wrong
 func (l *LVM) ExpandLV(lvName string, requiredSize int64) error {
 	cmd := fmt.Sprintf(LVExpandCmdTmpl, strconv.FormatInt(requiredSize, 10), lvName)
-	_, _, err := l.e.RunCmd(cmd,
+	_, stdErr, err := l.e.RunCmd(cmd,
 		command.UseMetrics(true),
 		command.CmdName(strings.TrimSpace(fmt.Sprintf(LVExpandCmdTmpl, "", ""))))
-	if err != nil {
-		return err
+	if err != nil && strings.Contains(stdErr, "matches existing size") {
+		return nil
 	}
-	return nil
+	return err
 }
 
 // VGCreate creates volume group and based on provided physical volumes (pvs). Ignore error if VG already exists
@@ -234,6 +301,21 @@ func (l *LVM) LVCreate(name, size, vgName string) error {
 	return err
 }
 
+// LVCreateOnPV creates logical volume which extents are allocated on the provided physical volume of volume group,
+// ignore error if LV already exists
+// Receives name of created LV, size which is a string like 1.2G, 100M, name of VG and name of PV in this VG
+// Returns error if something went wrong
+func (l *LVM) LVCreateOnPV(name, size, vgName, pvName string) error {
+	cmd := fmt.Sprintf(LVCreateOnPVCmdTmpl, name, size, vgName, pvName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(LVCreateOnPVCmdTmpl, "", "", "", ""))))
+	if err != nil && strings.Contains(stdErr, "already exists") {
+		return nil
+	}
+	return err
+}
+
 // LVRemove removes logical volume, ignore error if LV doesn't exist
 // Receives fullLVName that is a path to LV
 // Returns error if something went wrong
@@ -247,6 +329,301 @@ func (l *LVM) LVRemove(fullLVName string) error {
 	return err
 }
 
+// LVSnapshot creates copy-on-write snapshot of logical volume, ignore error if snapshot already exists
+// Receives name of created snapshot, size of snapshot COW area which is a string like 1.2G, 100M
+// and fullLVName that is a path to origin LV
+// Returns error if something went wrong
+func (l *LVM) LVSnapshot(name, size, fullLVName string) error {
+	cmd := fmt.Sprintf(LVSnapshotCmdTmpl, name, size, fullLVName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(LVSnapshotCmdTmpl, "", "", ""))))
+	if err != nil && strings.Contains(stdErr, "already exists") {
+		return nil
+	}
+	return err
+}
+
+// ThinPoolCreate creates thin pool which takes all free space of the volume group,
+// ignore error if thin pool already exists
+// Receives name of created thin pool, size of the pool metadata which is a string like 100M and name of VG
+// Returns error if something went wrong
+func (l *LVM) ThinPoolCreate(name, metadataSize, vgName string) error {
+	cmd := fmt.Sprintf(ThinPoolCreateCmdTmpl, metadataSize, name, vgName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(ThinPoolCreateCmdTmpl, "", "", ""))))
+	if err != nil && strings.Contains(stdErr, "already exists") {
+		return nil
+	}
+	return err
+}
+
+// ThinLVCreate creates thin logical volume in thin pool, ignore error if LV already exists
+// Receives name of created LV, virtual size which is a string like 1.2G, 100M and fullPoolName like VG/POOL
+// Returns error if something went wrong
+func (l *LVM) ThinLVCreate(name, size, fullPoolName string) error {
+	cmd := fmt.Sprintf(ThinLVCreateCmdTmpl, name, size, fullPoolName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(ThinLVCreateCmdTmpl, "", "", ""))))
+	if err != nil && strings.Contains(stdErr, "already exists") {
+		return nil
+	}
+	return err
+}
+
+// ThinSnapshot creates thin snapshot of thin logical volume in the same thin pool, ignore error if snapshot already exists
+// Receives name of created snapshot and fullLVName that is a path to origin LV
+// Returns error if something went wrong
+func (l *LVM) ThinSnapshot(name, fullLVName string) error {
+	cmd := fmt.Sprintf(ThinSnapshotCmdTmpl, name, fullLVName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(ThinSnapshotCmdTmpl, "", ""))))
+	if err != nil && strings.Contains(stdErr, "already exists") {
+		return nil
+	}
+	return err
+}
+
+// GetThinPoolUsage returns size of the thin pool data and usage of the thin pool data and metadata
+// Receives fullPoolName like VG/POOL
+// Returns ThinPoolUsage or error if something went wrong
+func (l *LVM) GetThinPoolUsage(fullPoolName string) (*ThinPoolUsage, error) {
+	/*
+		Example of output:
+		root@provo-goop:~# lvm lvs --options lv_size,data_percent,metadata_percent --units b --nosuffix --noheadings vg/thinpool
+		  1065353216 12.50  10.84
+	*/
+	cmd := fmt.Sprintf(ThinPoolUsageCmdTmpl, fullPoolName)
+	stdout, _, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(ThinPoolUsageCmdTmpl, ""))))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(stdout)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("unable to parse usage of thin pool %s from output: %s", fullPoolName, stdout)
+	}
+	usage := &ThinPoolUsage{}
+	if usage.DataSize, err = strconv.ParseInt(fields[0], 10, 64); err != nil {
+		return nil, fmt.Errorf("unable to parse size of thin pool %s: %w", fullPoolName, err)
+	}
+	if usage.DataPercent, err = strconv.ParseFloat(fields[1], 64); err != nil {
+		return nil, fmt.Errorf("unable to parse data usage of thin pool %s: %w", fullPoolName, err)
+	}
+	if usage.MetadataPercent, err = strconv.ParseFloat(fields[2], 64); err != nil {
+		return nil, fmt.Errorf("unable to parse metadata usage of thin pool %s: %w", fullPoolName, err)
+	}
+	return usage, nil
+}
+
+// RaidLVCreate creates RAID logical volume in volume group, ignore error if LV already exists
+// Receives name of created LV, size which is a string like 1.2G, 100M, name of VG and RAID type (raid1, raid10, raid5)
+// Returns error if something went wrong
+func (l *LVM) RaidLVCreate(name, size, vgName, raidType string) error {
+	layout, ok := util.GetRaidLayout(raidType)
+	if !ok {
+		return fmt.Errorf("RAID type %s isn't supported", raidType)
+	}
+	var options []string
+	if layout.Stripes > 1 {
+		options = append(options, fmt.Sprintf("--stripes %d", layout.Stripes))
+	}
+	if layout.Mirrors > 0 {
+		options = append(options, fmt.Sprintf("--mirrors %d", layout.Mirrors))
+	}
+	cmd := fmt.Sprintf(RaidLVCreateCmdTmpl, raidType, strings.Join(options, " "), name, size, vgName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(RaidLVCreateCmdTmpl, raidType, "", "", "", ""))))
+	if err != nil && strings.Contains(stdErr, "already exists") {
+		return nil
+	}
+	return err
+}
+
+// StripedLVCreate creates logical volume striped across the provided number of PVs, ignore error if LV already exists
+// Receives name of created LV, size which is a string like 1.2G, 100M, name of VG, number of stripes and
+// size of the stripe like 64k, LVM default is used if stripe size is empty
+// Returns error if something went wrong
+func (l *LVM) StripedLVCreate(name, size, vgName string, stripes int, stripeSize string) error {
+	var stripeSizeOption string
+	if stripeSize != "" {
+		stripeSizeOption = "--stripesize " + stripeSize
+	}
+	cmd := fmt.Sprintf(StripedLVCreateCmdTmpl, stripes, stripeSizeOption, name, size, vgName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(StripedLVCreateCmdTmpl, 0, "", "", "", ""))))
+	if err != nil && strings.Contains(stdErr, "already exists") {
+		return nil
+	}
+	return err
+}
+
+// RaidLVRepair replaces failed images of RAID logical volume with the new images on the provided PV
+// Receives fullLVName like VG/LV and name of PV which should be a member of VG
+// Returns error if something went wrong
+func (l *LVM) RaidLVRepair(fullLVName, pvName string) error {
+	cmd := fmt.Sprintf(LVRepairCmdTmpl, fullLVName, pvName)
+	_, _, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(LVRepairCmdTmpl, "", ""))))
+	return err
+}
+
+// VGExtend adds physical volume to volume group, ignore error if PV is already in VG
+// Receives name of VG and name of PV
+// Returns error if something went wrong
+func (l *LVM) VGExtend(name, pvName string) error {
+	cmd := fmt.Sprintf(VGExtendCmdTmpl, name, pvName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(VGExtendCmdTmpl, "", ""))))
+	if err != nil && strings.Contains(stdErr, "is already in volume group") {
+		return nil
+	}
+	return err
+}
+
+// VGReduceMissing removes missing physical volumes from volume group,
+// fails if some LVs still use the missing physical volumes
+// Receives name of VG
+// Returns error if something went wrong
+func (l *LVM) VGReduceMissing(name string) error {
+	cmd := fmt.Sprintf(VGReduceMissingCmdTmpl, name)
+	_, _, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(VGReduceMissingCmdTmpl, ""))))
+	return err
+}
+
+// PVMove starts moving allocated physical extents from the source physical volume to the destination physical volume
+// in background, logical volumes stay online during the move. Interrupted move must be resumed by PVMoveResume.
+// Ignore error if there is nothing to move
+// Receives names of source and destination PVs
+// Returns error if something went wrong
+func (l *LVM) PVMove(srcPVName, dstPVName string) error {
+	cmd := fmt.Sprintf(PVMoveCmdTmpl, srcPVName, dstPVName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(PVMoveCmdTmpl, "", ""))))
+	if err != nil && strings.Contains(stdErr, "No data to move") {
+		return nil
+	}
+	return err
+}
+
+// PVMoveResume resumes all moves of physical extents which were interrupted (e.g. by restart) in background,
+// moves are continued from the last checkpoint
+// Returns error if something went wrong
+func (l *LVM) PVMoveResume() error {
+	_, _, err := l.e.RunCmd(PVMoveResumeCmd,
+		command.UseMetrics(true),
+		command.CmdName(PVMoveResumeCmd))
+	return err
+}
+
+// GetPVMoveProgress returns progress of pvmove in volume group in percents
+// Receives name of VG
+// Returns progress, false if nothing is being moved or error if something went wrong
+func (l *LVM) GetPVMoveProgress(vgName string) (float64, bool, error) {
+	cmd := fmt.Sprintf(PVMoveProgressCmdTmpl, vgName)
+	stdOut, _, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(PVMoveProgressCmdTmpl, ""))))
+	if err != nil {
+		return 0, false, err
+	}
+	lines := util.SplitAndTrimSpace(stdOut, "\n")
+	if len(lines) == 0 {
+		return 0, false, nil
+	}
+	percent, err := strconv.ParseFloat(lines[0], 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("unable to parse pvmove progress %s: %v", lines[0], err)
+	}
+	return percent, true, nil
+}
+
+// VGReduce removes physical volume from volume group, ignore error if PV isn't in VG
+// Receives name of VG and name of PV
+// Returns error if something went wrong
+func (l *LVM) VGReduce(name, pvName string) error {
+	cmd := fmt.Sprintf(VGReduceCmdTmpl, name, pvName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(VGReduceCmdTmpl, "", ""))))
+	if err != nil && strings.Contains(stdErr, "not in volume group") {
+		return nil
+	}
+	return err
+}
+
+// PVChangeAllocatable allows or disallows allocation of physical extents of physical volume,
+// extents which were already allocated stay untouched
+// Receives name of PV and whether its extents might be allocated
+// Returns error if something went wrong
+func (l *LVM) PVChangeAllocatable(pvName string, allocatable bool) error {
+	value := "n"
+	if allocatable {
+		value = "y"
+	}
+	cmd := fmt.Sprintf(PVChangeAllocatableCmdTmpl, value, pvName)
+	_, _, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(PVChangeAllocatableCmdTmpl, "", ""))))
+	return err
+}
+
+// CacheAttach attaches cache logical volume to the logical volume with the provided cache mode,
+// content of the cache logical volume is destroyed
+// Receives full names of LV and cache LV and cache mode (writethrough or writeback)
+// Returns error if something went wrong
+func (l *LVM) CacheAttach(fullLVName, fullCacheLVName, cacheMode string) error {
+	cmd := fmt.Sprintf(CacheAttachCmdTmpl, fullCacheLVName, cacheMode, fullLVName)
+	_, _, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(CacheAttachCmdTmpl, "", "", ""))))
+	return err
+}
+
+// CacheDetach writes dirty blocks of the cache to the logical volume and detaches the cache,
+// cache logical volume isn't removed. Ignore error if LV doesn't exist
+// Receives full name of LV
+// Returns error if something went wrong
+func (l *LVM) CacheDetach(fullLVName string) error {
+	cmd := fmt.Sprintf(CacheDetachCmdTmpl, fullLVName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(CacheDetachCmdTmpl, ""))))
+	if err != nil && strings.Contains(stdErr, "Failed to find logical volume") {
+		return nil
+	}
+	return err
+}
+
+// IsLVCached checks whether cache is attached to the logical volume, LV which doesn't exist isn't cached
+// Receives full name of LV
+// Returns true if LV is cached or error if something went wrong
+func (l *LVM) IsLVCached(fullLVName string) (bool, error) {
+	cmd := fmt.Sprintf(LVSegTypeCmdTmpl, fullLVName)
+	stdOut, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(LVSegTypeCmdTmpl, ""))))
+	if err != nil {
+		if strings.Contains(stdErr, "Failed to find logical volume") {
+			return false, nil
+		}
+		return false, err
+	}
+	return strings.TrimSpace(stdOut) == cacheSegType, nil
+}
+
 // IsVGContainsLVs checks whether VG vgName contains any LVs or no
 // Receives Volume Group name to check
 // Returns true in case of error to prevent mistaken VG remove
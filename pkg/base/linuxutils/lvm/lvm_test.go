@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	errTypes "github.com/dell/csi-baremetal/pkg/base/error"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -196,6 +197,313 @@ func TestLinuxUtils_LVCreate(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestLinuxUtils_LVSnapshot(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		snap        = "test-snap"
+		size        = "9g"
+		lv          = "/dev/test-lvg/test-lv"
+		cmd         = fmt.Sprintf(LVSnapshotCmdTmpl, snap, size, lv)
+		err         error
+		expectedErr = errors.New("error")
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	err = l.LVSnapshot(snap, size, lv)
+	assert.Nil(t, err)
+
+	e.OnCommand(cmd).Return("", "already exists", expectedErr).Times(1)
+	err = l.LVSnapshot(snap, size, lv)
+	assert.Nil(t, err)
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	err = l.LVSnapshot(snap, size, lv)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestLinuxUtils_ThinPoolCreate(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		cmd         = fmt.Sprintf(ThinPoolCreateCmdTmpl, "104m", "thinpool", "test-lvg")
+		expectedErr = errors.New("error")
+	)
+	assert.Contains(t, cmd, "--extents 100%FREE")
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.ThinPoolCreate("thinpool", "104m", "test-lvg"))
+
+	e.OnCommand(cmd).Return("", "already exists", expectedErr).Times(1)
+	assert.Nil(t, l.ThinPoolCreate("thinpool", "104m", "test-lvg"))
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.ThinPoolCreate("thinpool", "104m", "test-lvg"))
+}
+
+func TestLinuxUtils_ThinLVCreate(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		cmd         = fmt.Sprintf(ThinLVCreateCmdTmpl, "test-lv", "100m", "test-lvg/thinpool")
+		expectedErr = errors.New("error")
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.ThinLVCreate("test-lv", "100m", "test-lvg/thinpool"))
+
+	e.OnCommand(cmd).Return("", "already exists", expectedErr).Times(1)
+	assert.Nil(t, l.ThinLVCreate("test-lv", "100m", "test-lvg/thinpool"))
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.ThinLVCreate("test-lv", "100m", "test-lvg/thinpool"))
+}
+
+func TestLinuxUtils_RaidLVCreate(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		expectedErr = errors.New("error")
+	)
+
+	cmd := "/sbin/lvm lvcreate --yes --type raid1 --mirrors 1 --name test-lv --size 100m test-lvg"
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.RaidLVCreate("test-lv", "100m", "test-lvg", "raid1"))
+
+	e.OnCommand(cmd).Return("", "already exists", expectedErr).Times(1)
+	assert.Nil(t, l.RaidLVCreate("test-lv", "100m", "test-lvg", "raid1"))
+
+	cmd = "/sbin/lvm lvcreate --yes --type raid10 --stripes 2 --mirrors 1 --name test-lv --size 100m test-lvg"
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.RaidLVCreate("test-lv", "100m", "test-lvg", "raid10"))
+
+	cmd = "/sbin/lvm lvcreate --yes --type raid5 --stripes 2 --name test-lv --size 100m test-lvg"
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.RaidLVCreate("test-lv", "100m", "test-lvg", "raid5"))
+
+	assert.NotNil(t, l.RaidLVCreate("test-lv", "100m", "test-lvg", "raid6"))
+}
+
+func TestLinuxUtils_StripedLVCreate(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		expectedErr = errors.New("error")
+	)
+
+	cmd := "/sbin/lvm lvcreate --yes --type striped --stripes 3 --stripesize 64k --name test-lv --size 120m test-lvg"
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.StripedLVCreate("test-lv", "120m", "test-lvg", 3, "64k"))
+
+	e.OnCommand(cmd).Return("", "already exists", expectedErr).Times(1)
+	assert.Nil(t, l.StripedLVCreate("test-lv", "120m", "test-lvg", 3, "64k"))
+
+	cmd = "/sbin/lvm lvcreate --yes --type striped --stripes 2  --name test-lv --size 120m test-lvg"
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.StripedLVCreate("test-lv", "120m", "test-lvg", 2, ""))
+}
+
+func TestLinuxUtils_Cache(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		expectedErr = errors.New("error")
+	)
+
+	cmd := "/sbin/lvm lvconvert --yes --type cache --cachevol ssd-lvg/test-lv-cache --cachemode writeback hdd-lvg/test-lv"
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.CacheAttach("hdd-lvg/test-lv", "ssd-lvg/test-lv-cache", "writeback"))
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.CacheAttach("hdd-lvg/test-lv", "ssd-lvg/test-lv-cache", "writeback"))
+
+	cmd = fmt.Sprintf(CacheDetachCmdTmpl, "hdd-lvg/test-lv")
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.CacheDetach("hdd-lvg/test-lv"))
+	e.OnCommand(cmd).Return("", "Failed to find logical volume \"hdd-lvg/test-lv\"", expectedErr).Times(1)
+	assert.Nil(t, l.CacheDetach("hdd-lvg/test-lv"))
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.CacheDetach("hdd-lvg/test-lv"))
+
+	cmd = fmt.Sprintf(LVSegTypeCmdTmpl, "hdd-lvg/test-lv")
+	e.OnCommand(cmd).Return("  cache\n", "", nil).Times(1)
+	cached, err := l.IsLVCached("hdd-lvg/test-lv")
+	assert.Nil(t, err)
+	assert.True(t, cached)
+	e.OnCommand(cmd).Return("  linear\n", "", nil).Times(1)
+	cached, err = l.IsLVCached("hdd-lvg/test-lv")
+	assert.Nil(t, err)
+	assert.False(t, cached)
+	e.OnCommand(cmd).Return("", "Failed to find logical volume \"hdd-lvg/test-lv\"", expectedErr).Times(1)
+	cached, err = l.IsLVCached("hdd-lvg/test-lv")
+	assert.Nil(t, err)
+	assert.False(t, cached)
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	_, err = l.IsLVCached("hdd-lvg/test-lv")
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestLinuxUtils_LVCreateOnPV(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		expectedErr = errors.New("error")
+	)
+
+	cmd := "/sbin/lvm lvcreate --yes --name test-lv-cache --size 100m hdd-lvg /dev/sdb"
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.LVCreateOnPV("test-lv-cache", "100m", "hdd-lvg", "/dev/sdb"))
+	e.OnCommand(cmd).Return("", "Logical Volume \"test-lv-cache\" already exists", expectedErr).Times(1)
+	assert.Nil(t, l.LVCreateOnPV("test-lv-cache", "100m", "hdd-lvg", "/dev/sdb"))
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.LVCreateOnPV("test-lv-cache", "100m", "hdd-lvg", "/dev/sdb"))
+}
+
+func TestLinuxUtils_PVMove(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		expectedErr = errors.New("error")
+	)
+
+	cmd := "/sbin/lvm pvmove --background /dev/sdb /dev/sdc"
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.PVMove("/dev/sdb", "/dev/sdc"))
+	e.OnCommand(cmd).Return("", "No data to move for test-lvg.", expectedErr).Times(1)
+	assert.Nil(t, l.PVMove("/dev/sdb", "/dev/sdc"))
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.PVMove("/dev/sdb", "/dev/sdc"))
+
+	e.OnCommand("/sbin/lvm pvmove --background").Return("", "", nil).Times(1)
+	assert.Nil(t, l.PVMoveResume())
+	e.OnCommand(PVMoveResumeCmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.PVMoveResume())
+
+	cmd = fmt.Sprintf(PVMoveProgressCmdTmpl, "test-lvg")
+	e.OnCommand(cmd).Return("  45.10\n", "", nil).Times(1)
+	percent, inProgress, err := l.GetPVMoveProgress("test-lvg")
+	assert.Nil(t, err)
+	assert.True(t, inProgress)
+	assert.Equal(t, 45.1, percent)
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	_, inProgress, err = l.GetPVMoveProgress("test-lvg")
+	assert.Nil(t, err)
+	assert.False(t, inProgress)
+	e.OnCommand(cmd).Return("  abc\n", "", nil).Times(1)
+	_, _, err = l.GetPVMoveProgress("test-lvg")
+	assert.NotNil(t, err)
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	_, _, err = l.GetPVMoveProgress("test-lvg")
+	assert.Equal(t, expectedErr, err)
+
+	cmd = fmt.Sprintf(VGReduceCmdTmpl, "test-lvg", "/dev/sdb")
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.VGReduce("test-lvg", "/dev/sdb"))
+	e.OnCommand(cmd).Return("", "Physical Volume \"/dev/sdb\" not in volume group \"test-lvg\"", expectedErr).Times(1)
+	assert.Nil(t, l.VGReduce("test-lvg", "/dev/sdb"))
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.VGReduce("test-lvg", "/dev/sdb"))
+}
+
+func TestLinuxUtils_PVChangeAllocatable(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		expectedErr = errors.New("error")
+	)
+
+	e.OnCommand(fmt.Sprintf(PVChangeAllocatableCmdTmpl, "n", "/dev/sdb")).Return("", "", nil).Times(1)
+	assert.Nil(t, l.PVChangeAllocatable("/dev/sdb", false))
+	e.OnCommand(fmt.Sprintf(PVChangeAllocatableCmdTmpl, "y", "/dev/sdb")).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.PVChangeAllocatable("/dev/sdb", true))
+}
+
+func TestLinuxUtils_RaidLVRepair(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		expectedErr = errors.New("error")
+	)
+
+	e.OnCommand(fmt.Sprintf(VGExtendCmdTmpl, "test-lvg", "/dev/sdc")).Return("", "", nil).Times(1)
+	assert.Nil(t, l.VGExtend("test-lvg", "/dev/sdc"))
+	e.OnCommand(fmt.Sprintf(VGExtendCmdTmpl, "test-lvg", "/dev/sdd")).
+		Return("", "Physical volume '/dev/sdd' is already in volume group 'test-lvg'", expectedErr).Times(1)
+	assert.Nil(t, l.VGExtend("test-lvg", "/dev/sdd"))
+
+	e.OnCommand(fmt.Sprintf(LVRepairCmdTmpl, "test-lvg/test-lv", "/dev/sdc")).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.RaidLVRepair("test-lvg/test-lv", "/dev/sdc"))
+
+	e.OnCommand(fmt.Sprintf(VGReduceMissingCmdTmpl, "test-lvg")).Return("", "", nil).Times(1)
+	assert.Nil(t, l.VGReduceMissing("test-lvg"))
+}
+
+func TestLinuxUtils_ThinSnapshot(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		lv          = "/dev/test-lvg/test-lv"
+		cmd         = fmt.Sprintf(ThinSnapshotCmdTmpl, "test-snap", lv)
+		expectedErr = errors.New("error")
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.ThinSnapshot("test-snap", lv))
+
+	e.OnCommand(cmd).Return("", "already exists", expectedErr).Times(1)
+	assert.Nil(t, l.ThinSnapshot("test-snap", lv))
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.ThinSnapshot("test-snap", lv))
+}
+
+func TestLinuxUtils_GetThinPoolUsage(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		pool        = "test-lvg/thinpool"
+		cmd         = fmt.Sprintf(ThinPoolUsageCmdTmpl, pool)
+		expectedErr = errors.New("error")
+	)
+
+	e.OnCommand(cmd).Return("  1065353216 12.50  10.84\n", "", nil).Times(1)
+	usage, err := l.GetThinPoolUsage(pool)
+	assert.Nil(t, err)
+	assert.Equal(t, &ThinPoolUsage{DataSize: 1065353216, DataPercent: 12.5, MetadataPercent: 10.84}, usage)
+
+	e.OnCommand(cmd).Return("  1065353216 12.50\n", "", nil).Times(1)
+	_, err = l.GetThinPoolUsage(pool)
+	assert.NotNil(t, err)
+
+	e.OnCommand(cmd).Return("  1065353216 abc 10.84\n", "", nil).Times(1)
+	_, err = l.GetThinPoolUsage(pool)
+	assert.NotNil(t, err)
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	_, err = l.GetThinPoolUsage(pool)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestLinuxUtils_ExpandLV(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		fullLVName  = "/dev/test-lvg/test-lv"
+		size        = int64(1024 * 1024 * 100)
+		cmd         = fmt.Sprintf(LVExpandCmdTmpl, strconv.FormatInt(size, 10), fullLVName)
+		expectedErr = errors.New("error")
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.ExpandLV(fullLVName, size))
+
+	// LV has been already expanded
+	e.OnCommand(cmd).Return("", "New size (25 extents) matches existing size (25 extents).", expectedErr).Times(1)
+	assert.Nil(t, l.ExpandLV(fullLVName, size))
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.ExpandLV(fullLVName, size))
+}
+
 func TestLinuxUtils_LVRemove(t *testing.T) {
 	var (
 		e           = &mocks.GoMockExecutor{}
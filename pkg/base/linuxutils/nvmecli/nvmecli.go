@@ -43,6 +43,7 @@ const (
 // WrapNvmecli is an interface that encapsulates operation with system nvme util
 type WrapNvmecli interface {
 	GetNVMDevices() ([]NVMDevice, error)
+	GetSMARTLogJSON(path string) (string, error)
 }
 
 // NVMDevice represents devices from nvme list output
@@ -60,6 +61,11 @@ type NVMDevice struct {
 // SMARTLog represents SMART information for NVMe devices
 type SMARTLog struct {
 	CriticalWarning int `json:"critical_warning,omitempty"`
+	// Temperature is a composite temperature in Kelvin
+	Temperature  *int64 `json:"temperature,omitempty"`
+	PercentUsed  *int64 `json:"percent_used,omitempty"`
+	MediaErrors  *int64 `json:"media_errors,omitempty"`
+	PowerOnHours *int64 `json:"power_on_hours,omitempty"`
 }
 
 // NVMECLI is a wrap for system nvem_cli util
@@ -102,6 +108,21 @@ func (na *NVMECLI) GetNVMDevices() ([]NVMDevice, error) {
 	return devs, nil
 }
 
+// GetSMARTLogJSON gets SMART log of NVMe device by its Path using nvme_cli smart-log util
+// Returns nvme_cli JSON output or error if something went wrong
+func (na *NVMECLI) GetSMARTLogJSON(path string) (string, error) {
+	strOut, _, err := na.e.RunCmd(fmt.Sprintf(NVMeHealthCmdImpl, path),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(NVMeHealthCmdImpl, ""))))
+	if err != nil {
+		return "", err
+	}
+	if !json.Valid([]byte(strOut)) {
+		return "", fmt.Errorf("nvme smart-log output for device %s isn't valid JSON", path)
+	}
+	return strOut, nil
+}
+
 // getNVMDeviceHealth gets information about device health based on critical_warning SMART attribute using nvme_cli smart-log util
 func (na *NVMECLI) getNVMDeviceHealth(path string) string {
 	ll := na.log.WithField("method", "getNVMDeviceHealth")
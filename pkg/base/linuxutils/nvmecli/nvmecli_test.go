@@ -209,6 +209,29 @@ func TestNVMECLI_getNVMDeviceHealthCMDError(t *testing.T) {
 	assert.Equal(t, apiV1.HealthUnknown, deviceHealth)
 }
 
+func TestNVMECLI_GetSMARTLogJSON(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	l := NewNVMECLI(e, testLogger)
+	smartLog := `{
+  		"critical_warning" : 0,
+  		"temperature" : 302,
+  		"percent_used" : 1
+	}
+	`
+	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return(smartLog, "", nil).Once()
+	res, err := l.GetSMARTLogJSON(testPath)
+	assert.Nil(t, err)
+	assert.Equal(t, smartLog, res)
+
+	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return("not a json", "", nil).Once()
+	_, err = l.GetSMARTLogJSON(testPath)
+	assert.NotNil(t, err)
+
+	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return("", "", fmt.Errorf("error")).Once()
+	_, err = l.GetSMARTLogJSON(testPath)
+	assert.NotNil(t, err)
+}
+
 func TestNVMECLI_getNVMDeviceVendorFail(t *testing.T) {
 	e := &mocks.GoMockExecutor{}
 	l := NewNVMECLI(e, testLogger)
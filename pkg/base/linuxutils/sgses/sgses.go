@@ -0,0 +1,89 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sgses contains code for manipulating drive locate LED of SES enclosure slot using sg_ses util
+package sgses
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+const (
+	// SgSesCmdImpl is a base CMD for sg_ses
+	SgSesCmdImpl = "sg_ses"
+	// SgSesGetLocateCmdImpl is a CMD to get locate LED state of enclosure element with device SAS address
+	SgSesGetLocateCmdImpl = SgSesCmdImpl + " --sas-addr=%s --get=locate %s"
+	// SgSesSetLocateCmdImpl is a CMD to enable locate LED of enclosure element with device SAS address
+	SgSesSetLocateCmdImpl = SgSesCmdImpl + " --sas-addr=%s --set=locate %s"
+	// SgSesClearLocateCmdImpl is a CMD to disable locate LED of enclosure element with device SAS address
+	SgSesClearLocateCmdImpl = SgSesCmdImpl + " --sas-addr=%s --clear=locate %s"
+)
+
+// WrapSgSes is an interface that encapsulates operation with system sg_ses util
+type WrapSgSes interface {
+	GetLocate(enclosure, sasAddress string) (bool, error)
+	SetLocate(enclosure, sasAddress string, on bool) error
+}
+
+// SGSES is a wrap for system sg_ses util
+type SGSES struct {
+	e   command.CmdExecutor
+	log *logrus.Entry
+}
+
+// NewSGSES is a constructor for SGSES
+func NewSGSES(e command.CmdExecutor, logger *logrus.Logger) *SGSES {
+	return &SGSES{e: e, log: logger.WithField("component", "SGSES")}
+}
+
+// GetLocate returns true if locate LED of enclosure element with device SAS address is enabled
+// Returns error if enclosure doesn't contain element with SAS address
+func (s *SGSES) GetLocate(enclosure, sasAddress string) (bool, error) {
+	strOut, stderr, err := s.e.RunCmd(fmt.Sprintf(SgSesGetLocateCmdImpl, sasAddress, enclosure),
+		command.UseMetrics(true),
+		command.CmdName(SgSesCmdImpl+" --get=locate"))
+	if err != nil {
+		return false, fmt.Errorf("unable to get locate LED of %s in enclosure %s: %v, stderr: %s", sasAddress, enclosure, err, stderr)
+	}
+	switch strings.TrimSpace(strOut) {
+	case "1":
+		return true, nil
+	case "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unable to parse sg_ses output for %s in enclosure %s: %s", sasAddress, enclosure, strOut)
+	}
+}
+
+// SetLocate enables or disables locate LED of enclosure element with device SAS address
+func (s *SGSES) SetLocate(enclosure, sasAddress string, on bool) error {
+	cmd := SgSesClearLocateCmdImpl
+	if on {
+		cmd = SgSesSetLocateCmdImpl
+	}
+	_, stderr, err := s.e.RunCmd(fmt.Sprintf(cmd, sasAddress, enclosure),
+		command.UseMetrics(true),
+		command.CmdName(SgSesCmdImpl))
+	if err != nil {
+		return fmt.Errorf("unable to set locate LED of %s in enclosure %s: %v, stderr: %s", sasAddress, enclosure, err, stderr)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sgses
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+var (
+	testLogger    = logrus.New()
+	testEnclosure = "/dev/sg3"
+	testSASAddr   = "0x5000c5008e2bb1b5"
+)
+
+func TestSGSES_GetLocate(t *testing.T) {
+	cmd := fmt.Sprintf(SgSesGetLocateCmdImpl, testSASAddr, testEnclosure)
+
+	e := &mocks.GoMockExecutor{}
+	s := NewSGSES(e, testLogger)
+	e.On("RunCmd", cmd).Return("1\n", "", nil).Once()
+	on, err := s.GetLocate(testEnclosure, testSASAddr)
+	assert.Nil(t, err)
+	assert.True(t, on)
+
+	e.On("RunCmd", cmd).Return("0\n", "", nil).Once()
+	on, err = s.GetLocate(testEnclosure, testSASAddr)
+	assert.Nil(t, err)
+	assert.False(t, on)
+
+	e.On("RunCmd", cmd).Return("unexpected", "", nil).Once()
+	_, err = s.GetLocate(testEnclosure, testSASAddr)
+	assert.NotNil(t, err)
+
+	e.On("RunCmd", cmd).Return("", "SAS address not found", fmt.Errorf("error")).Once()
+	_, err = s.GetLocate(testEnclosure, testSASAddr)
+	assert.NotNil(t, err)
+}
+
+func TestSGSES_SetLocate(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	s := NewSGSES(e, testLogger)
+	e.On("RunCmd", fmt.Sprintf(SgSesSetLocateCmdImpl, testSASAddr, testEnclosure)).Return("", "", nil)
+	e.On("RunCmd", fmt.Sprintf(SgSesClearLocateCmdImpl, testSASAddr, testEnclosure)).Return("", "", fmt.Errorf("error"))
+
+	assert.Nil(t, s.SetLocate(testEnclosure, testSASAddr, true))
+	assert.NotNil(t, s.SetLocate(testEnclosure, testSASAddr, false))
+}
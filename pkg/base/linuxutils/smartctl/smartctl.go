@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/nvmecli"
 )
 
 const (
@@ -31,11 +32,28 @@ const (
 	SmartctlDeviceInfoCmdImpl = SmartctlCmdImpl + " --info --json %s"
 	// SmartctlHealthCmdImpl is a CMD to get  SMART status of device in JSON format
 	SmartctlHealthCmdImpl = SmartctlCmdImpl + " --health --json %s"
+	// SmartctlAllInfoCmdImpl is a CMD to get all SMART information about device in JSON format
+	SmartctlAllInfoCmdImpl = SmartctlCmdImpl + " --xall --json %s"
+
+	// nvmeCriticalWarningKey is a key which is present in nvme_cli smart-log JSON output only
+	nvmeCriticalWarningKey = "critical_warning"
+	// kelvinOffset is used to convert temperature reported by nvme_cli to Celsius
+	kelvinOffset = 273
+
+	// smartctlFatalExitStatusMask are bits of smartctl exit status which mean that device wasn't queried,
+	// other bits report problems of the device itself while output is still valid
+	smartctlFatalExitStatusMask = 0x3
+
+	// ataReallocatedSectorsID is an ID of ATA SMART attribute Reallocated_Sector_Ct
+	ataReallocatedSectorsID = 5
+	// ataPendingSectorsID is an ID of ATA SMART attribute Current_Pending_Sector
+	ataPendingSectorsID = 197
 )
 
 // WrapSmartctl is an interface that encapsulates operation with system smartctl util
 type WrapSmartctl interface {
 	GetDriveInfoByPath(path string) (*DeviceSMARTInfo, error)
+	GetSMARTInfoJSON(path string) (string, error)
 }
 
 // DeviceSMARTInfo represents SMART information about device
@@ -45,6 +63,119 @@ type DeviceSMARTInfo struct {
 	Rotation     int             `json:"rotation_rate"`
 }
 
+// SMARTAttributes represents health related SMART attributes of SATA/SAS and NVMe device,
+// nil field means that attribute isn't reported by the device
+type SMARTAttributes struct {
+	Temperature        *int64
+	PowerOnHours       *int64
+	ReallocatedSectors *int64
+	PendingSectors     *int64
+	MediaErrors        *int64
+	PercentageUsed     *int64
+	GrownDefects       *int64
+}
+
+// smartctlExitStatus represents exit status of smartctl reported in JSON output
+type smartctlExitStatus struct {
+	Smartctl struct {
+		ExitStatus int `json:"exit_status"`
+	} `json:"smartctl"`
+}
+
+// smartctlOutput represents fields of smartctl JSON output which are used to fill SMARTAttributes
+type smartctlOutput struct {
+	Temperature *struct {
+		Current *int64 `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime *struct {
+		Hours *int64 `json:"hours"`
+	} `json:"power_on_time"`
+	ATASmartAttributes *struct {
+		Table []struct {
+			ID  int `json:"id"`
+			Raw struct {
+				Value *int64 `json:"value"`
+			} `json:"raw"`
+		} `json:"table"`
+	} `json:"ata_smart_attributes"`
+	NVMeHealthLog *struct {
+		Temperature    *int64 `json:"temperature"`
+		PowerOnHours   *int64 `json:"power_on_hours"`
+		MediaErrors    *int64 `json:"media_errors"`
+		PercentageUsed *int64 `json:"percentage_used"`
+	} `json:"nvme_smart_health_information_log"`
+	SCSIGrownDefectList *int64 `json:"scsi_grown_defect_list"`
+	SCSIPercentageUsed  *int64 `json:"scsi_percentage_used_endurance_indicator"`
+}
+
+// ParseSMARTAttributes parses smartctl JSON output (smartctl --xall --json) of SATA/SAS or NVMe device
+// or nvme_cli JSON output (nvme smart-log --output-format=json) of NVMe device
+// Returns SMARTAttributes or error if output isn't valid JSON
+func ParseSMARTAttributes(data []byte) (*SMARTAttributes, error) {
+	keys := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal SMART info, error: %v", err)
+	}
+	if _, ok := keys[nvmeCriticalWarningKey]; ok {
+		return parseNVMeSMARTLog(data)
+	}
+
+	out := &smartctlOutput{}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal smartctl output, error: %v", err)
+	}
+
+	attrs := &SMARTAttributes{
+		GrownDefects:   out.SCSIGrownDefectList,
+		PercentageUsed: out.SCSIPercentageUsed,
+	}
+	if out.Temperature != nil {
+		attrs.Temperature = out.Temperature.Current
+	}
+	if out.PowerOnTime != nil {
+		attrs.PowerOnHours = out.PowerOnTime.Hours
+	}
+	if out.ATASmartAttributes != nil {
+		for _, attr := range out.ATASmartAttributes.Table {
+			switch attr.ID {
+			case ataReallocatedSectorsID:
+				attrs.ReallocatedSectors = attr.Raw.Value
+			case ataPendingSectorsID:
+				attrs.PendingSectors = attr.Raw.Value
+			}
+		}
+	}
+	if log := out.NVMeHealthLog; log != nil {
+		attrs.MediaErrors = log.MediaErrors
+		attrs.PercentageUsed = log.PercentageUsed
+		if attrs.Temperature == nil {
+			attrs.Temperature = log.Temperature
+		}
+		if attrs.PowerOnHours == nil {
+			attrs.PowerOnHours = log.PowerOnHours
+		}
+	}
+	return attrs, nil
+}
+
+// parseNVMeSMARTLog fills SMARTAttributes from nvme_cli smart-log JSON output
+func parseNVMeSMARTLog(data []byte) (*SMARTAttributes, error) {
+	log := &nvmecli.SMARTLog{}
+	if err := json.Unmarshal(data, log); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal nvme smart-log output, error: %v", err)
+	}
+	attrs := &SMARTAttributes{
+		PowerOnHours:   log.PowerOnHours,
+		MediaErrors:    log.MediaErrors,
+		PercentageUsed: log.PercentUsed,
+	}
+	if log.Temperature != nil {
+		temperature := *log.Temperature - kelvinOffset
+		attrs.Temperature = &temperature
+	}
+	return attrs, nil
+}
+
 // SMARTCTL is a wrap for system smartctl util
 type SMARTCTL struct {
 	e command.CmdExecutor
@@ -76,6 +207,26 @@ func (sa *SMARTCTL) GetDriveInfoByPath(path string) (*DeviceSMARTInfo, error) {
 	return deviceInfo, nil
 }
 
+// GetSMARTInfoJSON gets all SMART information about device by its Path using smartctl util
+// Returns smartctl JSON output or error if device wasn't queried
+func (sa *SMARTCTL) GetSMARTInfoJSON(path string) (string, error) {
+	strOut, _, err := sa.e.RunCmd(fmt.Sprintf(SmartctlAllInfoCmdImpl, path),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(SmartctlAllInfoCmdImpl, ""))))
+	// smartctl returns non zero exit status if device reports problems, so output is checked before error
+	status := &smartctlExitStatus{}
+	if jsonErr := json.Unmarshal([]byte(strOut), status); jsonErr != nil {
+		if err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("unable to unmarshal smartctl output, error: %v", jsonErr)
+	}
+	if status.Smartctl.ExitStatus&smartctlFatalExitStatusMask != 0 {
+		return "", fmt.Errorf("smartctl failed to query device %s, exit status: %d", path, status.Smartctl.ExitStatus)
+	}
+	return strOut, nil
+}
+
 // fillSmartStatus fill smart_status field in DeviceSMARTInfo using smartctl command
 func (sa *SMARTCTL) fillSmartStatus(dev *DeviceSMARTInfo, path string) error {
 	strOut, _, err := sa.e.RunCmd(fmt.Sprintf(SmartctlHealthCmdImpl, path),
@@ -112,3 +112,126 @@ func TestSMARCTL_fillSmartStatusUnmarshallError(t *testing.T) {
 	err := l.fillSmartStatus(&DeviceSMARTInfo{}, "/dev/sdd")
 	assert.NotNil(t, err)
 }
+
+func TestParseSMARTAttributes(t *testing.T) {
+	t.Run("SATA", func(t *testing.T) {
+		output := `{
+			"temperature": {"current": 34},
+			"power_on_time": {"hours": 1200},
+			"ata_smart_attributes": {"table": [
+				{"id": 5, "name": "Reallocated_Sector_Ct", "raw": {"value": 8}},
+				{"id": 9, "name": "Power_On_Hours", "raw": {"value": 1200}},
+				{"id": 197, "name": "Current_Pending_Sector", "raw": {"value": 2}}
+			]}
+		}`
+		attrs, err := ParseSMARTAttributes([]byte(output))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(34), *attrs.Temperature)
+		assert.Equal(t, int64(1200), *attrs.PowerOnHours)
+		assert.Equal(t, int64(8), *attrs.ReallocatedSectors)
+		assert.Equal(t, int64(2), *attrs.PendingSectors)
+		assert.Nil(t, attrs.MediaErrors)
+		assert.Nil(t, attrs.PercentageUsed)
+		assert.Nil(t, attrs.GrownDefects)
+	})
+
+	t.Run("SAS", func(t *testing.T) {
+		output := `{
+			"temperature": {"current": 40},
+			"power_on_time": {"hours": 300, "minutes": 5},
+			"scsi_grown_defect_list": 3,
+			"scsi_percentage_used_endurance_indicator": 7
+		}`
+		attrs, err := ParseSMARTAttributes([]byte(output))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(40), *attrs.Temperature)
+		assert.Equal(t, int64(300), *attrs.PowerOnHours)
+		assert.Equal(t, int64(3), *attrs.GrownDefects)
+		assert.Equal(t, int64(7), *attrs.PercentageUsed)
+		assert.Nil(t, attrs.ReallocatedSectors)
+	})
+
+	t.Run("NVMe", func(t *testing.T) {
+		output := `{
+			"nvme_smart_health_information_log": {
+				"temperature": 45,
+				"percentage_used": 12,
+				"power_on_hours": 5000,
+				"media_errors": 1
+			}
+		}`
+		attrs, err := ParseSMARTAttributes([]byte(output))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(45), *attrs.Temperature)
+		assert.Equal(t, int64(5000), *attrs.PowerOnHours)
+		assert.Equal(t, int64(1), *attrs.MediaErrors)
+		assert.Equal(t, int64(12), *attrs.PercentageUsed)
+		assert.Nil(t, attrs.PendingSectors)
+	})
+
+	t.Run("NVMe smart-log", func(t *testing.T) {
+		output := `{
+			"critical_warning": 0,
+			"temperature": 310,
+			"percent_used": 3,
+			"media_errors": 0,
+			"power_on_hours": 42
+		}`
+		attrs, err := ParseSMARTAttributes([]byte(output))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(37), *attrs.Temperature)
+		assert.Equal(t, int64(42), *attrs.PowerOnHours)
+		assert.Equal(t, int64(0), *attrs.MediaErrors)
+		assert.Equal(t, int64(3), *attrs.PercentageUsed)
+		assert.Nil(t, attrs.GrownDefects)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := ParseSMARTAttributes([]byte("not a json"))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestSMARCTL_GetSMARTInfoJSON(t *testing.T) {
+	cmd := fmt.Sprintf(SmartctlAllInfoCmdImpl, "/dev/sdd")
+
+	t.Run("Success", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		l := NewSMARTCTL(e)
+		output := `{"smartctl": {"exit_status": 0}, "serial_number": "29P4K65PF9NF"}`
+		e.On("RunCmd", cmd).Return(output, "", nil)
+
+		res, err := l.GetSMARTInfoJSON("/dev/sdd")
+		assert.Nil(t, err)
+		assert.Equal(t, output, res)
+	})
+
+	t.Run("Device problems reported", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		l := NewSMARTCTL(e)
+		output := `{"smartctl": {"exit_status": 64}, "serial_number": "29P4K65PF9NF"}`
+		e.On("RunCmd", cmd).Return(output, "", fmt.Errorf("exit status 64"))
+
+		res, err := l.GetSMARTInfoJSON("/dev/sdd")
+		assert.Nil(t, err)
+		assert.Equal(t, output, res)
+	})
+
+	t.Run("Device open failed", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		l := NewSMARTCTL(e)
+		e.On("RunCmd", cmd).Return(`{"smartctl": {"exit_status": 2}}`, "", fmt.Errorf("exit status 2"))
+
+		_, err := l.GetSMARTInfoJSON("/dev/sdd")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Command failed", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		l := NewSMARTCTL(e)
+		e.On("RunCmd", cmd).Return("", "", fmt.Errorf("error"))
+
+		_, err := l.GetSMARTInfoJSON("/dev/sdd")
+		assert.NotNil(t, err)
+	})
+}
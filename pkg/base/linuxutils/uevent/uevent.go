@@ -0,0 +1,106 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package uevent contains code for receiving of kernel uevents from netlink socket
+package uevent
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// kernelGroup is a netlink multicast group of uevents sent by kernel (udevd uses group 2)
+	kernelGroup = 1
+	// bufferSize is enough for any uevent, kernel limits uevent environment to 2048 bytes
+	bufferSize = 8192
+
+	// SubsystemKey and DevTypeKey are keys of uevent environment
+	SubsystemKey = "SUBSYSTEM"
+	DevTypeKey   = "DEVTYPE"
+)
+
+// Event is a kernel uevent, e.g. add@/devices/pci0000:00/0000:00:1f.2/ata1/host0/target0:0:0/0:0:0:0/block/sda
+type Event struct {
+	Action  string
+	DevPath string
+	Env     map[string]string
+}
+
+// Listener reads kernel uevents from NETLINK_KOBJECT_UEVENT socket
+type Listener struct {
+	file *os.File
+}
+
+// NewListener opens netlink socket subscribed to kernel uevents
+// Returns error if socket can't be created, e.g. process isn't in host network namespace
+func NewListener() (*Listener, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC|unix.SOCK_NONBLOCK,
+		unix.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create netlink socket: %v", err)
+	}
+	if err = unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: kernelGroup}); err != nil {
+		_ = unix.Close(fd)
+		return nil, fmt.Errorf("unable to bind netlink socket: %v", err)
+	}
+	// non-blocking file is registered in runtime poller, so Close interrupts blocked Read
+	return &Listener{file: os.NewFile(uintptr(fd), "uevent")}, nil
+}
+
+// Read blocks until next uevent is received
+func (l *Listener) Read() (*Event, error) {
+	buf := make([]byte, bufferSize)
+	for {
+		n, err := l.file.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		if event, err := Parse(buf[:n]); err == nil {
+			return event, nil
+		}
+	}
+}
+
+// Close closes netlink socket
+func (l *Listener) Close() error {
+	return l.file.Close()
+}
+
+// Parse parses uevent message, format - <action>@<devpath>\0<key>=<value>\0...
+func Parse(msg []byte) (*Event, error) {
+	fields := bytes.Split(bytes.TrimRight(msg, "\x00"), []byte{0})
+	header := strings.SplitN(string(fields[0]), "@", 2)
+	if len(header) != 2 {
+		return nil, fmt.Errorf("invalid uevent header %q", fields[0])
+	}
+	event := &Event{Action: header[0], DevPath: header[1], Env: make(map[string]string, len(fields)-1)}
+	for _, field := range fields[1:] {
+		if kv := strings.SplitN(string(field), "=", 2); len(kv) == 2 {
+			event.Env[kv[0]] = kv[1]
+		}
+	}
+	return event, nil
+}
+
+// IsDisk returns true for uevents of block devices which are disks, not partitions
+func (e *Event) IsDisk() bool {
+	return e.Env[SubsystemKey] == "block" && e.Env[DevTypeKey] == "disk"
+}
@@ -0,0 +1,43 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uevent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	msg := []byte("add@/devices/pci0000:00/0000:00:1f.2/ata1/host0/target0:0:0/0:0:0:0/block/sda\x00" +
+		"ACTION=add\x00DEVPATH=/devices/pci0000:00/0000:00:1f.2/ata1/host0/target0:0:0/0:0:0:0/block/sda\x00" +
+		"SUBSYSTEM=block\x00MAJOR=8\x00MINOR=0\x00DEVNAME=sda\x00DEVTYPE=disk\x00SEQNUM=2254\x00")
+	event, err := Parse(msg)
+	assert.Nil(t, err)
+	assert.Equal(t, "add", event.Action)
+	assert.Equal(t, "/devices/pci0000:00/0000:00:1f.2/ata1/host0/target0:0:0/0:0:0:0/block/sda", event.DevPath)
+	assert.Equal(t, "sda", event.Env["DEVNAME"])
+	assert.True(t, event.IsDisk())
+
+	event, err = Parse([]byte("remove@/devices/virtual/block/sda1\x00SUBSYSTEM=block\x00DEVTYPE=partition\x00"))
+	assert.Nil(t, err)
+	assert.Equal(t, "remove", event.Action)
+	assert.False(t, event.IsDisk())
+
+	_, err = Parse([]byte("libudev\x00"))
+	assert.NotNil(t, err)
+}
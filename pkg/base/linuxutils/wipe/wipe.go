@@ -0,0 +1,134 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package wipe contains code for destroying data on block devices with system utils:
+// blkdiscard, nvme format and hdparm
+package wipe
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+const (
+	// blkdiscardCmd is a base CMD for blkdiscard util
+	blkdiscardCmd = "blkdiscard "
+	// hdparmCmd is a base CMD for hdparm util
+	hdparmCmd = "hdparm --user-master u "
+	// securityPasswordLen is a number of random bytes in the temporary password of ATA security feature
+	securityPasswordLen = 8
+
+	// DiscardCmdTmpl discards all sectors of the device
+	DiscardCmdTmpl = blkdiscardCmd + "%s" // add device
+	// ZeroOutCmdTmpl fills the device with zeroes
+	ZeroOutCmdTmpl = blkdiscardCmd + "--zeroout %s" // add device
+	// NVMeFormatCmdTmpl formats NVMe namespace with user data erase
+	NVMeFormatCmdTmpl = "nvme format --ses=1 %s" // add device
+	// ATASetPasswordCmdTmpl enables ATA security feature with the temporary password, it is required for secure erase
+	ATASetPasswordCmdTmpl = hdparmCmd + "--security-set-pass %s %s" // add password and device
+	// ATASecureEraseCmdTmpl runs ATA secure erase of the device
+	ATASecureEraseCmdTmpl = hdparmCmd + "--security-erase %s %s" // add password and device
+	// ATADisablePasswordCmdTmpl disables ATA security feature set with the temporary password
+	ATADisablePasswordCmdTmpl = hdparmCmd + "--security-disable %s %s" // add password and device
+)
+
+// newSecurityPassword generates random temporary password of ATA security feature for each secure erase
+var newSecurityPassword = func() (string, error) {
+	b := make([]byte, securityPasswordLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// WrapWipe is an interface that encapsulates operations which destroy data on block devices
+type WrapWipe interface {
+	Discard(device string) error
+	ZeroOut(device string) error
+	NVMeFormat(device string) error
+	ATASecureErase(device string) error
+}
+
+// Wipe is an implementation of WrapWipe interface
+type Wipe struct {
+	e   command.CmdExecutor
+	log *logrus.Entry
+}
+
+// NewWipe is a constructor for Wipe
+func NewWipe(e command.CmdExecutor, logger *logrus.Logger) *Wipe {
+	return &Wipe{e: e, log: logger.WithField("component", "Wipe")}
+}
+
+// Discard discards all sectors of the device, device must support discard (TRIM/UNMAP)
+// Returns error if something went wrong
+func (w *Wipe) Discard(device string) error {
+	return w.run(fmt.Sprintf(DiscardCmdTmpl, device), fmt.Sprintf(DiscardCmdTmpl, ""))
+}
+
+// ZeroOut fills the whole device with zeroes, might take a long time for the big devices
+// Returns error if something went wrong
+func (w *Wipe) ZeroOut(device string) error {
+	return w.run(fmt.Sprintf(ZeroOutCmdTmpl, device), fmt.Sprintf(ZeroOutCmdTmpl, ""))
+}
+
+// NVMeFormat formats NVMe namespace with user data erase, device must be NVMe namespace
+// Returns error if something went wrong
+func (w *Wipe) NVMeFormat(device string) error {
+	return w.run(fmt.Sprintf(NVMeFormatCmdTmpl, device), fmt.Sprintf(NVMeFormatCmdTmpl, ""))
+}
+
+// ATASecureErase sets random temporary security password and runs ATA secure erase of the device.
+// Password is cleared by the drive after successful erase, security is disabled if erase failed,
+// so the drive isn't left locked with the unknown password
+// Returns error if something went wrong
+func (w *Wipe) ATASecureErase(device string) (err error) {
+	password, err := newSecurityPassword()
+	if err != nil {
+		return fmt.Errorf("unable to generate security password: %w", err)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if dErr := w.run(fmt.Sprintf(ATADisablePasswordCmdTmpl, password, device),
+			fmt.Sprintf(ATADisablePasswordCmdTmpl, "", "")); dErr != nil {
+			w.log.WithField("method", "ATASecureErase").
+				Errorf("Unable to disable security of device %s: %v", device, dErr)
+		}
+	}()
+
+	if err = w.run(fmt.Sprintf(ATASetPasswordCmdTmpl, password, device),
+		fmt.Sprintf(ATASetPasswordCmdTmpl, "", "")); err != nil {
+		return fmt.Errorf("unable to set security password: %w", err)
+	}
+	return w.run(fmt.Sprintf(ATASecureEraseCmdTmpl, password, device),
+		fmt.Sprintf(ATASecureEraseCmdTmpl, "", ""))
+}
+
+// run runs cmd and uses cmdName as a name of the command in metrics
+func (w *Wipe) run(cmd, cmdName string) error {
+	_, _, err := w.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(cmdName)))
+	return err
+}
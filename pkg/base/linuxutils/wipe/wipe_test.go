@@ -0,0 +1,113 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wipe
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+var (
+	testLogger = logrus.New()
+	testDevice = "/dev/sda"
+	testErr    = errors.New("error")
+)
+
+func TestWipe_Discard(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	w := NewWipe(e, testLogger)
+	cmd := fmt.Sprintf(DiscardCmdTmpl, testDevice)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, w.Discard(testDevice))
+
+	e.OnCommand(cmd).Return("", "", testErr).Times(1)
+	assert.Equal(t, testErr, w.Discard(testDevice))
+}
+
+func TestWipe_ZeroOut(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	w := NewWipe(e, testLogger)
+	cmd := fmt.Sprintf(ZeroOutCmdTmpl, testDevice)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, w.ZeroOut(testDevice))
+
+	e.OnCommand(cmd).Return("", "", testErr).Times(1)
+	assert.Equal(t, testErr, w.ZeroOut(testDevice))
+}
+
+func TestWipe_NVMeFormat(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	w := NewWipe(e, testLogger)
+	cmd := fmt.Sprintf(NVMeFormatCmdTmpl, testDevice)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, w.NVMeFormat(testDevice))
+
+	e.OnCommand(cmd).Return("", "", testErr).Times(1)
+	assert.Equal(t, testErr, w.NVMeFormat(testDevice))
+}
+
+func TestWipe_ATASecureErase(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	w := NewWipe(e, testLogger)
+	password := "0123456789abcdef"
+	defer func(f func() (string, error)) { newSecurityPassword = f }(newSecurityPassword)
+	newSecurityPassword = func() (string, error) { return password, nil }
+	setPassCmd := fmt.Sprintf(ATASetPasswordCmdTmpl, password, testDevice)
+	eraseCmd := fmt.Sprintf(ATASecureEraseCmdTmpl, password, testDevice)
+	disableCmd := fmt.Sprintf(ATADisablePasswordCmdTmpl, password, testDevice)
+
+	e.OnCommand(setPassCmd).Return("", "", nil).Times(1)
+	e.OnCommand(eraseCmd).Return("", "", nil).Times(1)
+	assert.Nil(t, w.ATASecureErase(testDevice))
+
+	// unable to set password
+	e.OnCommand(setPassCmd).Return("", "", testErr).Times(1)
+	e.OnCommand(disableCmd).Return("", "", testErr).Times(1)
+	err := w.ATASecureErase(testDevice)
+	assert.True(t, errors.Is(err, testErr))
+
+	// erase failed, security is disabled
+	e.OnCommand(setPassCmd).Return("", "", nil).Times(1)
+	e.OnCommand(eraseCmd).Return("", "", testErr).Times(1)
+	e.OnCommand(disableCmd).Return("", "", nil).Times(1)
+	assert.Equal(t, testErr, w.ATASecureErase(testDevice))
+	e.AssertNumberOfCalls(t, "RunCmd", 7)
+
+	// unable to generate password
+	newSecurityPassword = func() (string, error) { return "", testErr }
+	err = w.ATASecureErase(testDevice)
+	assert.True(t, errors.Is(err, testErr))
+	e.AssertNumberOfCalls(t, "RunCmd", 7)
+}
+
+func TestNewSecurityPassword(t *testing.T) {
+	first, err := newSecurityPassword()
+	assert.Nil(t, err)
+	assert.Len(t, first, 2*securityPasswordLen)
+	second, err := newSecurityPassword()
+	assert.Nil(t, err)
+	assert.NotEqual(t, first, second)
+}
@@ -26,6 +26,9 @@ import (
 	api "github.com/dell/csi-baremetal/api/v1"
 )
 
+// cacheRequestSuffix is added to the name of the volume capacity request to get name of its cache capacity request
+const cacheRequestSuffix = "-cache"
+
 // ConsistentRead returns content of the file and ensure that this content is actual (no one modify file during timeout)
 // Receives absolute path to the file as filename, amount of retries to read and timeout of the operation
 // Returns read file or error in case if there were not twice same read content
@@ -67,9 +70,18 @@ func ConvertStorageClass(strSC string) string {
 		api.StorageClassSSDLVG,
 		api.StorageClassNVMeLVG,
 		api.StorageClassSystemLVG,
+		api.StorageClassHDDLVGThin,
+		api.StorageClassSSDLVGThin,
+		api.StorageClassNVMeLVGThin,
+		api.StorageClassHDDLVGStriped,
+		api.StorageClassSSDLVGStriped,
+		api.StorageClassNVMeLVGStriped,
 		api.StorageClassAny:
 		return sc
 	}
+	if IsStorageClassLVGRaid(sc) {
+		return sc
+	}
 
 	return api.StorageClassAny
 }
@@ -94,11 +106,14 @@ func ConvertDriveTypeToStorageClass(driveType string) string {
 // storage classes that are based on LVM, or empty string
 func GetSubStorageClass(sc string) string {
 	switch sc {
-	case api.StorageClassHDDLVG:
+	case api.StorageClassHDDLVG, api.StorageClassHDDLVGThin, api.StorageClassHDDLVGStriped,
+		api.StorageClassHDDLVGRaid1, api.StorageClassHDDLVGRaid10, api.StorageClassHDDLVGRaid5:
 		return api.StorageClassHDD
-	case api.StorageClassSSDLVG:
+	case api.StorageClassSSDLVG, api.StorageClassSSDLVGThin, api.StorageClassSSDLVGStriped,
+		api.StorageClassSSDLVGRaid1, api.StorageClassSSDLVGRaid10, api.StorageClassSSDLVGRaid5:
 		return api.StorageClassSSD
-	case api.StorageClassNVMeLVG:
+	case api.StorageClassNVMeLVG, api.StorageClassNVMeLVGThin, api.StorageClassNVMeLVGStriped,
+		api.StorageClassNVMeLVGRaid1, api.StorageClassNVMeLVGRaid10, api.StorageClassNVMeLVGRaid5:
 		return api.StorageClassNVMe
 	default:
 		return ""
@@ -110,7 +125,106 @@ func IsStorageClassLVG(sc string) bool {
 	return sc == api.StorageClassHDDLVG ||
 		sc == api.StorageClassSSDLVG ||
 		sc == api.StorageClassNVMeLVG ||
-		sc == api.StorageClassSystemLVG
+		sc == api.StorageClassSystemLVG ||
+		IsStorageClassLVGThin(sc) ||
+		IsStorageClassLVGRaid(sc) ||
+		IsStorageClassLVGStriped(sc)
+}
+
+// IsStorageClassLVGThin returns whether provided sc relates to LVG with thin pool or no
+func IsStorageClassLVGThin(sc string) bool {
+	return sc == api.StorageClassHDDLVGThin ||
+		sc == api.StorageClassSSDLVGThin ||
+		sc == api.StorageClassNVMeLVGThin
+}
+
+// IsStorageClassLVGStriped returns whether provided sc relates to LVG with striped LVs or no
+func IsStorageClassLVGStriped(sc string) bool {
+	return sc == api.StorageClassHDDLVGStriped ||
+		sc == api.StorageClassSSDLVGStriped ||
+		sc == api.StorageClassNVMeLVGStriped
+}
+
+// GetCacheStorageClass returns storage class of the drive for the volume cache of the provided drive type,
+// or empty string if the volume cache can't be placed on such drives
+func GetCacheStorageClass(driveType string) string {
+	switch strings.ToUpper(driveType) {
+	case api.DriveTypeSSD:
+		return api.StorageClassSSD
+	case api.DriveTypeNVMe:
+		return api.StorageClassNVMe
+	default:
+		return ""
+	}
+}
+
+// GetCacheRequestName returns name of the capacity request of the volume cache requested together with the volume
+func GetCacheRequestName(name string) string {
+	return name + cacheRequestSuffix
+}
+
+// IsStorageClassLVGRaid returns whether provided sc relates to LVG with RAID LVs or no
+func IsStorageClassLVGRaid(sc string) bool {
+	return GetRaidType(sc) != ""
+}
+
+// GetRaidType returns RAID type of LVs of the provided sc, or empty string if sc isn't RAID one
+func GetRaidType(sc string) string {
+	switch sc {
+	case api.StorageClassHDDLVGRaid1, api.StorageClassSSDLVGRaid1, api.StorageClassNVMeLVGRaid1:
+		return api.RaidType1
+	case api.StorageClassHDDLVGRaid10, api.StorageClassSSDLVGRaid10, api.StorageClassNVMeLVGRaid10:
+		return api.RaidType10
+	case api.StorageClassHDDLVGRaid5, api.StorageClassSSDLVGRaid5, api.StorageClassNVMeLVGRaid5:
+		return api.RaidType5
+	default:
+		return ""
+	}
+}
+
+// RaidLayout describes how RAID LV is placed on the drives of LVG
+type RaidLayout struct {
+	// Drives is the number of drives (PVs) in LVG, each drive holds one image of LV
+	Drives int
+	// Stripes is the number of data stripes, size of LV is split between them
+	Stripes int
+	// Mirrors is the number of additional copies of each stripe
+	Mirrors int
+}
+
+// raidLayouts contains layouts of the supported RAID types with the minimal number of drives
+var raidLayouts = map[string]RaidLayout{
+	api.RaidType1:  {Drives: 2, Stripes: 1, Mirrors: 1},
+	api.RaidType10: {Drives: 4, Stripes: 2, Mirrors: 1},
+	api.RaidType5:  {Drives: 3, Stripes: 2},
+}
+
+// GetRaidLayout returns layout of the RAID type, ok is false if RAID type isn't supported
+func GetRaidLayout(raidType string) (layout RaidLayout, ok bool) {
+	layout, ok = raidLayouts[raidType]
+	return
+}
+
+// IsWipePolicySupported returns whether provided volume wipe policy is known, empty policy means default one
+func IsWipePolicySupported(policy string) bool {
+	switch policy {
+	case "", api.WipePolicyNone, api.WipePolicySignatures, api.WipePolicyDiscard, api.WipePolicyZero,
+		api.WipePolicyNVMeFormat, api.WipePolicyATASecureErase:
+		return true
+	}
+	return false
+}
+
+// IsWipePolicyErasingData returns whether provided volume wipe policy destroys data of the volume
+// in addition to the file system signatures
+func IsWipePolicyErasingData(policy string) bool {
+	return policy == api.WipePolicyDiscard || policy == api.WipePolicyZero || IsWipePolicyForDrive(policy)
+}
+
+// IsWipePolicyForDrive returns whether provided volume wipe policy erases the whole drive,
+// such policies are applicable only to volumes which consume the whole drive
+func IsWipePolicyForDrive(policy string) bool {
+	return policy == api.WipePolicyNVMeFormat || policy == api.WipePolicyATASecureErase
 }
 
 // ContainsString return true if slice contains string str
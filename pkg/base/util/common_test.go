@@ -109,6 +109,14 @@ var strToSC = []struct {
 	{"ssdlvg", api.StorageClassSSDLVG},
 	{"nvmelvg", api.StorageClassNVMeLVG},
 	{"syslVg", api.StorageClassSystemLVG},
+	{"hddlvgthin", api.StorageClassHDDLVGThin},
+	{"ssdlvgthin", api.StorageClassSSDLVGThin},
+	{"nvmelvgthin", api.StorageClassNVMeLVGThin},
+	{"hddlvgraid1", api.StorageClassHDDLVGRaid1},
+	{"ssdlvgraid10", api.StorageClassSSDLVGRaid10},
+	{"nvmelvgraid5", api.StorageClassNVMeLVGRaid5},
+	{"hddlvgraid6", api.StorageClassAny},
+	{"ssdlvgstriped", api.StorageClassSSDLVGStriped},
 	{"any", api.StorageClassAny},
 	{"random", api.StorageClassAny},
 }
@@ -144,6 +152,58 @@ func TestConvertDriveTypeToStorageClass(t *testing.T) {
 	}
 }
 
+func TestIsStorageClassLVGThin(t *testing.T) {
+	assert.True(t, IsStorageClassLVGThin(api.StorageClassHDDLVGThin))
+	assert.True(t, IsStorageClassLVG(api.StorageClassNVMeLVGThin))
+	assert.False(t, IsStorageClassLVGThin(api.StorageClassHDDLVG))
+	assert.Equal(t, api.StorageClassSSD, GetSubStorageClass(api.StorageClassSSDLVGThin))
+}
+
+func TestIsStorageClassLVGStriped(t *testing.T) {
+	assert.True(t, IsStorageClassLVGStriped(api.StorageClassHDDLVGStriped))
+	assert.True(t, IsStorageClassLVG(api.StorageClassNVMeLVGStriped))
+	assert.False(t, IsStorageClassLVGStriped(api.StorageClassHDDLVG))
+	assert.False(t, IsStorageClassLVGRaid(api.StorageClassSSDLVGStriped))
+	assert.Equal(t, api.StorageClassSSD, GetSubStorageClass(api.StorageClassSSDLVGStriped))
+}
+
+func TestGetCacheStorageClass(t *testing.T) {
+	assert.Equal(t, api.StorageClassSSD, GetCacheStorageClass("ssd"))
+	assert.Equal(t, api.StorageClassNVMe, GetCacheStorageClass(api.DriveTypeNVMe))
+	assert.Empty(t, GetCacheStorageClass(api.DriveTypeHDD))
+	assert.Equal(t, "pvc-1-cache", GetCacheRequestName("pvc-1"))
+}
+
+func TestWipePolicies(t *testing.T) {
+	assert.True(t, IsWipePolicySupported(""))
+	assert.True(t, IsWipePolicySupported(api.WipePolicyZero))
+	assert.False(t, IsWipePolicySupported("shred"))
+
+	assert.False(t, IsWipePolicyErasingData(""))
+	assert.False(t, IsWipePolicyErasingData(api.WipePolicySignatures))
+	assert.True(t, IsWipePolicyErasingData(api.WipePolicyDiscard))
+	assert.True(t, IsWipePolicyErasingData(api.WipePolicyATASecureErase))
+
+	assert.True(t, IsWipePolicyForDrive(api.WipePolicyNVMeFormat))
+	assert.False(t, IsWipePolicyForDrive(api.WipePolicyZero))
+}
+
+func TestGetRaidType(t *testing.T) {
+	assert.Equal(t, api.RaidType1, GetRaidType(api.StorageClassHDDLVGRaid1))
+	assert.Equal(t, api.RaidType10, GetRaidType(api.StorageClassSSDLVGRaid10))
+	assert.Equal(t, api.RaidType5, GetRaidType(api.StorageClassNVMeLVGRaid5))
+	assert.Empty(t, GetRaidType(api.StorageClassHDDLVG))
+	assert.True(t, IsStorageClassLVG(api.StorageClassHDDLVGRaid5))
+	assert.False(t, IsStorageClassLVGThin(api.StorageClassHDDLVGRaid5))
+	assert.Equal(t, api.StorageClassNVMe, GetSubStorageClass(api.StorageClassNVMeLVGRaid10))
+
+	layout, ok := GetRaidLayout(api.RaidType10)
+	assert.True(t, ok)
+	assert.Equal(t, RaidLayout{Drives: 4, Stripes: 2, Mirrors: 1}, layout)
+	_, ok = GetRaidLayout("raid6")
+	assert.False(t, ok)
+}
+
 func TestContainsString(t *testing.T) {
 	var containsStringScenarios = []struct {
 		slice  []string
@@ -36,6 +36,8 @@ const (
 	PodNamespaceKey = "csi.storage.k8s.io/pod.namespace"
 	// PodNameKey to read pod name from PodInfoOnMount feature
 	PodNameKey = "csi.storage.k8s.io/pod.name"
+	// PodUIDKey to read pod UID from PodInfoOnMount feature
+	PodUIDKey = "csi.storage.k8s.io/pod.uid"
 )
 
 // CtxKey variable type uses for keys in context WithValue
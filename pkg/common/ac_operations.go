@@ -19,6 +19,8 @@ package common
 
 import (
 	"context"
+	"os"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -29,6 +31,14 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+const (
+	// thinOvercommitRatioEnv is the name of env variable with the overcommit ratio of the new thin pools
+	thinOvercommitRatioEnv = "THIN_OVERCOMMIT_RATIO"
+	// defaultThinOvercommitRatio means that virtual size of thin LVs is limited by the size of the thin pool
+	defaultThinOvercommitRatio = 1.0
 )
 
 // AvailableCapacityOperations is the interface for interact with AvailableCapacity CRs from Controller
@@ -40,16 +50,36 @@ type AvailableCapacityOperations interface {
 type ACOperationsImpl struct {
 	k8sClient *k8s.KubeClient
 	log       *logrus.Entry
+	// ratio of the virtual size of thin LVs to the size of the thin pool
+	thinOvercommitRatio float64
 }
 
 // NewACOperationsImpl is the constructor for ACOperationsImpl struct
 // Receives an instance of base.KubeClient and logrus logger
 // Returns an instance of ACOperationsImpl
 func NewACOperationsImpl(k8sClient *k8s.KubeClient, l *logrus.Logger) *ACOperationsImpl {
-	return &ACOperationsImpl{
+	a := &ACOperationsImpl{
 		k8sClient: k8sClient,
 		log:       l.WithField("component", "ACOperations"),
 	}
+	a.setThinOvercommitRatio()
+	return a
+}
+
+// setThinOvercommitRatio reads overcommit ratio of the thin pools from env, ratio must not be less than 1
+func (a *ACOperationsImpl) setThinOvercommitRatio() {
+	a.thinOvercommitRatio = defaultThinOvercommitRatio
+	ratioStr, ok := os.LookupEnv(thinOvercommitRatioEnv)
+	if !ok {
+		return
+	}
+	ratio, err := strconv.ParseFloat(ratioStr, 64)
+	if err != nil || ratio < 1 {
+		a.log.Errorf("passed thin overcommit ratio %s is not valid. Used default - %.1f",
+			ratioStr, defaultThinOvercommitRatio)
+		return
+	}
+	a.thinOvercommitRatio = ratio
 }
 
 // RecreateACToLVGSC creates new LVG using locations from provided ACs.
@@ -71,9 +101,13 @@ func (a *ACOperationsImpl) RecreateACToLVGSC(ctx context.Context, newSC, storage
 
 	lvgLocations := make([]string, len(acs))
 	var lvgSize int64
+	minSize := acs[0].Spec.Size
 	for i, ac := range acs {
 		lvgLocations[i] = ac.Spec.Location
 		lvgSize += capacityplanner.SubtractLVMMetadataSize(ac.Spec.Size)
+		if ac.Spec.Size < minSize {
+			minSize = ac.Spec.Size
+		}
 	}
 
 	var (
@@ -89,8 +123,39 @@ func (a *ACOperationsImpl) RecreateACToLVGSC(ctx context.Context, newSC, storage
 		}
 	)
 
+	// LVG of the thin SC contains thin pool, AC of such LVG holds the virtual size available for thin LVs
+	acSize := lvgSize
+	switch {
+	case util.IsStorageClassLVGThin(newSC):
+		apiLVG.ThinPool = apiV1.ThinPoolName
+		acSize = capacityplanner.GetThinPoolVirtualSize(capacityplanner.GetThinPoolDataSize(lvgSize), a.thinOvercommitRatio)
+	case util.IsStorageClassLVGRaid(newSC):
+		// each drive of RAID LVG holds one image of LV, so AC holds the size available for RAID LVs
+		apiLVG.RaidType = util.GetRaidType(newSC)
+		if layout, _ := util.GetRaidLayout(apiLVG.RaidType); len(acs) != layout.Drives {
+			ll.Errorf("%s LVG requires %d drives, got %d", apiLVG.RaidType, layout.Drives, len(acs))
+			return nil
+		}
+		acSize = capacityplanner.GetRaidLVGSize(apiLVG.RaidType, minSize)
+		apiLVG.Size = acSize
+	case util.IsStorageClassLVGStriped(newSC):
+		// each striped LV is split between all drives of LVG equally
+		if len(acs) < 2 {
+			ll.Errorf("Striped LVG requires at least 2 drives, got %d", len(acs))
+			return nil
+		}
+		apiLVG.Stripes = int32(len(acs))
+		acSize = capacityplanner.GetStripedLVGSize(len(acs), minSize)
+		apiLVG.Size = acSize
+	}
+
 	// create LVG CR based on ACs
 	lvg := a.k8sClient.ConstructLVGCR(name, storageGroup, apiLVG)
+	if apiLVG.ThinPool != "" {
+		lvg.Annotations = map[string]string{
+			apiV1.LVGOvercommitRatioAnnotation: strconv.FormatFloat(a.thinOvercommitRatio, 'f', -1, 64),
+		}
+	}
 	if err = a.k8sClient.CreateCR(ctx, name, lvg); err != nil {
 		ll.Errorf("Unable to create LVG CR: %v", err)
 		return nil
@@ -99,22 +164,28 @@ func (a *ACOperationsImpl) RecreateACToLVGSC(ctx context.Context, newSC, storage
 
 	// convert first AC to LVG type
 	updatedAC := &acs[0]
-	updatedAC.Spec.Size = lvgSize
+	updatedAC.Spec.Size = acSize
 	updatedAC.Spec.Location = lvg.Name
 	updatedAC.Spec.StorageClass = newSC
+	if apiLVG.Stripes > 0 {
+		if updatedAC.Labels == nil {
+			updatedAC.Labels = map[string]string{}
+		}
+		updatedAC.Labels[apiV1.LVGStripesLabelKey] = strconv.Itoa(len(acs))
+	}
 	if err = a.k8sClient.UpdateCR(ctx, updatedAC); err != nil {
 		ll.Errorf("Unable to update AC %v, error: %v.", updatedAC, err)
 		return nil
 	}
 
-	// set size of remaining ACs to 0
-	/*for _, ac := range acs[:1] {
+	// set size of remaining ACs to 0, their drives are members of LVG now
+	for i := range acs[1:] {
+		ac := &acs[i+1]
 		ac.Spec.Size = 0
-		// nolint: scopelint
-		if err = a.k8sClient.UpdateCR(ctx, &ac); err != nil {
+		if err = a.k8sClient.UpdateCR(ctx, ac); err != nil {
 			ll.Errorf("Unable to update AC %v, error: %v.", ac, err)
 		}
-	}*/
+	}
 
 	// get recent version
 	// TODO - refactor this code https://github.com/dell/csi-baremetal/issues/371
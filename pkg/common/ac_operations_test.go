@@ -19,7 +19,13 @@ package common
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
 )
 
 var DefaultPESize = capacityplanner.DefaultPESize
@@ -87,6 +93,104 @@ var DefaultPESize = capacityplanner.DefaultPESize
 	return NewACOperationsImpl(k8sClient, testLogger)
 }*/
 
+func Test_RecreateACToLVGSC_Thin(t *testing.T) {
+	t.Setenv(thinOvercommitRatioEnv, "2.5")
+	k8sClient, err := k8s.GetFakeKubeClient(testNS, testLogger)
+	assert.Nil(t, err)
+	ac := testAC1.DeepCopy()
+	assert.Nil(t, k8sClient.CreateCR(testCtx, ac.Name, ac))
+
+	acOp := NewACOperationsImpl(k8sClient, testLogger)
+	newAC := acOp.RecreateACToLVGSC(testCtx, apiV1.StorageClassHDDLVGThin, "", *ac)
+	assert.NotNil(t, newAC)
+
+	lvgList := lvgcrd.LogicalVolumeGroupList{}
+	assert.Nil(t, k8sClient.ReadList(testCtx, &lvgList))
+	assert.Len(t, lvgList.Items, 1)
+	lvg := lvgList.Items[0]
+	assert.Equal(t, apiV1.ThinPoolName, lvg.Spec.ThinPool)
+	assert.Equal(t, "2.5", lvg.Annotations[apiV1.LVGOvercommitRatioAnnotation])
+
+	assert.Equal(t, apiV1.StorageClassHDDLVGThin, newAC.Spec.StorageClass)
+	assert.Equal(t, lvg.Name, newAC.Spec.Location)
+	assert.Equal(t, capacityplanner.GetThinPoolVirtualSize(capacityplanner.GetThinPoolDataSize(lvg.Spec.Size), 2.5),
+		newAC.Spec.Size)
+}
+
+func Test_RecreateACToLVGSC_Raid(t *testing.T) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNS, testLogger)
+	assert.Nil(t, err)
+	ac1 := testAC1.DeepCopy()
+	ac2 := testAC1.DeepCopy()
+	ac2.Name, ac2.Spec.Location, ac2.Spec.Size = "ac-2", testDrive4UUID, ac1.Spec.Size*2
+	for _, ac := range []*accrd.AvailableCapacity{ac1, ac2} {
+		assert.Nil(t, k8sClient.CreateCR(testCtx, ac.Name, ac))
+	}
+
+	acOp := NewACOperationsImpl(k8sClient, testLogger)
+	// RAID1 requires two drives
+	assert.Nil(t, acOp.RecreateACToLVGSC(testCtx, apiV1.StorageClassHDDLVGRaid1, "", *ac1))
+
+	newAC := acOp.RecreateACToLVGSC(testCtx, apiV1.StorageClassHDDLVGRaid1, "", *ac1, *ac2)
+	assert.NotNil(t, newAC)
+
+	lvgList := lvgcrd.LogicalVolumeGroupList{}
+	assert.Nil(t, k8sClient.ReadList(testCtx, &lvgList))
+	assert.Len(t, lvgList.Items, 1)
+	lvg := lvgList.Items[0]
+	assert.Equal(t, apiV1.RaidType1, lvg.Spec.RaidType)
+	assert.Equal(t, []string{ac1.Spec.Location, ac2.Spec.Location}, lvg.Spec.Locations)
+	assert.Equal(t, capacityplanner.GetRaidLVGSize(apiV1.RaidType1, ac1.Spec.Size), lvg.Spec.Size)
+
+	assert.Equal(t, apiV1.StorageClassHDDLVGRaid1, newAC.Spec.StorageClass)
+	assert.Equal(t, lvg.Spec.Size, newAC.Spec.Size)
+	// AC of the member drive isn't available anymore
+	assert.Nil(t, k8sClient.ReadCR(testCtx, ac2.Name, "", ac2))
+	assert.Equal(t, int64(0), ac2.Spec.Size)
+}
+
+func Test_RecreateACToLVGSC_Striped(t *testing.T) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNS, testLogger)
+	assert.Nil(t, err)
+	ac1 := testAC1.DeepCopy()
+	ac2 := testAC1.DeepCopy()
+	ac2.Name, ac2.Spec.Location, ac2.Spec.Size = "ac-2", testDrive4UUID, ac1.Spec.Size*2
+	for _, ac := range []*accrd.AvailableCapacity{ac1, ac2} {
+		assert.Nil(t, k8sClient.CreateCR(testCtx, ac.Name, ac))
+	}
+
+	acOp := NewACOperationsImpl(k8sClient, testLogger)
+	newAC := acOp.RecreateACToLVGSC(testCtx, apiV1.StorageClassHDDLVGStriped, "", *ac1, *ac2)
+	assert.NotNil(t, newAC)
+
+	lvgList := lvgcrd.LogicalVolumeGroupList{}
+	assert.Nil(t, k8sClient.ReadList(testCtx, &lvgList))
+	assert.Len(t, lvgList.Items, 1)
+	lvg := lvgList.Items[0]
+	assert.Equal(t, int32(2), lvg.Spec.Stripes)
+	assert.Equal(t, capacityplanner.GetStripedLVGSize(2, ac1.Spec.Size), lvg.Spec.Size)
+
+	assert.Equal(t, apiV1.StorageClassHDDLVGStriped, newAC.Spec.StorageClass)
+	assert.Equal(t, lvg.Spec.Size, newAC.Spec.Size)
+	assert.Equal(t, "2", newAC.Labels[apiV1.LVGStripesLabelKey])
+	assert.Nil(t, k8sClient.ReadCR(testCtx, ac2.Name, "", ac2))
+	assert.Equal(t, int64(0), ac2.Spec.Size)
+}
+
+func Test_setThinOvercommitRatio(t *testing.T) {
+	acOp := &ACOperationsImpl{log: testLogger.WithField("component", "test")}
+	acOp.setThinOvercommitRatio()
+	assert.Equal(t, defaultThinOvercommitRatio, acOp.thinOvercommitRatio)
+
+	t.Setenv(thinOvercommitRatioEnv, "0.5")
+	acOp.setThinOvercommitRatio()
+	assert.Equal(t, defaultThinOvercommitRatio, acOp.thinOvercommitRatio)
+
+	t.Setenv(thinOvercommitRatioEnv, "3")
+	acOp.setThinOvercommitRatio()
+	assert.Equal(t, 3.0, acOp.thinOvercommitRatio)
+}
+
 func Test_AlignSizeByPE(t *testing.T) {
 	type args struct {
 		size int64
@@ -0,0 +1,280 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+// SnapshotOperations is the interface that unites common Snapshot CRs operations
+type SnapshotOperations interface {
+	CreateSnapshot(ctx context.Context, snapshotID, volumeID string) (*api.Snapshot, error)
+	DeleteSnapshot(ctx context.Context, snapshotID string) error
+	UpdateCRsAfterSnapshotDeletion(ctx context.Context, snapshotID string) error
+	WaitSnapshotStatus(ctx context.Context, snapshotID string, statuses ...string) error
+}
+
+// ErrSnapshotFailed is returned by WaitSnapshotStatus if snapshot has reached Failed status
+var ErrSnapshotFailed = errors.New("snapshot has reached Failed status")
+
+// SnapshotOperationsImpl is the basic implementation of SnapshotOperations interface
+type SnapshotOperationsImpl struct {
+	k8sClient *k8s.KubeClient
+	crHelper  k8s.CRHelper
+	log       *logrus.Entry
+}
+
+// NewSnapshotOperationsImpl is the constructor for SnapshotOperationsImpl struct
+// Receives an instance of base.KubeClient and logrus logger
+// Returns an instance of SnapshotOperationsImpl
+func NewSnapshotOperationsImpl(k8sClient *k8s.KubeClient, logger *logrus.Logger) *SnapshotOperationsImpl {
+	return &SnapshotOperationsImpl{
+		k8sClient: k8sClient,
+		crHelper:  k8s.NewCRHelperImpl(k8sClient, logger),
+		log:       logger.WithField("component", "SnapshotOperationsImpl"),
+	}
+}
+
+// CreateSnapshot creates Snapshot CR for LVM volume and reserves space for snapshot in LVG AC
+// or returns existed Snapshot CR
+// Receives golang context, ID of the snapshot and ID of the source volume
+// Returns api.Snapshot instance or error if something went wrong
+func (so *SnapshotOperationsImpl) CreateSnapshot(ctx context.Context, snapshotID, volumeID string) (*api.Snapshot, error) {
+	ll := so.log.WithFields(logrus.Fields{
+		"method":     "CreateSnapshot",
+		"snapshotID": snapshotID,
+		"volumeID":   volumeID,
+	})
+
+	snapshotCR := &snapshotcrd.Snapshot{}
+	err := so.k8sClient.ReadCR(ctx, snapshotID, "", snapshotCR)
+	switch {
+	case err == nil:
+		return so.handleSnapshotInProgress(ctx, ll, snapshotCR, volumeID)
+	case !k8sError.IsNotFound(err):
+		ll.Errorf("Unable to read snapshot CR: %v", err)
+		return nil, status.Error(codes.Aborted, "unable to check snapshot state")
+	}
+
+	volumeCR, err := so.crHelper.GetVolumeByID(volumeID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "source volume %s doesn't exist", volumeID)
+	}
+	if volumeCR.Spec.LocationType != apiV1.LocationTypeLVM {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"snapshots are supported only for volumes with location type %s", apiV1.LocationTypeLVM)
+	}
+	switch volumeCR.Spec.CSIStatus {
+	case apiV1.Created, apiV1.VolumeReady, apiV1.Published:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"volume in status %s can't be snapshotted", volumeCR.Spec.CSIStatus)
+	}
+
+	// copy-on-write area of the snapshot has the same size as the volume, so snapshot never overflows
+	snapshotSize := capacityplanner.AlignSizeByPE(volumeCR.Spec.Size)
+	ac, err := so.crHelper.GetACByLocation(volumeCR.Spec.Location)
+	if err != nil {
+		ll.Errorf("Failed to get AC by location %s: %v", volumeCR.Spec.Location, err)
+		return nil, status.Error(codes.Internal, "unable to read AC")
+	}
+	if ac.Spec.Size < snapshotSize {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"not enough capacity to create snapshot: requested - %d, available - %d", snapshotSize, ac.Spec.Size)
+	}
+
+	apiSnapshot := api.Snapshot{
+		Id:           snapshotID,
+		VolumeId:     volumeID,
+		NodeId:       volumeCR.Spec.NodeId,
+		Location:     volumeCR.Spec.Location,
+		StorageClass: volumeCR.Spec.StorageClass,
+		Size:         snapshotSize,
+		CSIStatus:    apiV1.Creating,
+		CreationTime: time.Now().UnixNano(),
+	}
+	snapshotCR = so.k8sClient.ConstructSnapshotCR(snapshotID, apiSnapshot)
+	if err = so.k8sClient.CreateCR(ctx, snapshotID, snapshotCR); err != nil {
+		ll.Errorf("Unable to create CR, error: %v", err)
+		return nil, status.Error(codes.Internal, "unable to create snapshot CR")
+	}
+
+	ac.Spec.Size -= snapshotSize
+	if err = so.k8sClient.UpdateCR(ctx, ac); err != nil {
+		ll.Errorf("Unable to set size for AC %s to %d, error: %v", ac.Name, ac.Spec.Size, err)
+		// snapshot without reserved space must not be created, CR is removed to allow next CreateSnapshot call to retry
+		if err = so.k8sClient.DeleteCR(ctx, snapshotCR); err != nil {
+			ll.Errorf("Unable to remove snapshot CR: %v", err)
+		}
+		return nil, status.Error(codes.Internal, "unable to reserve capacity for snapshot")
+	}
+
+	return &snapshotCR.Spec, nil
+}
+
+func (so *SnapshotOperationsImpl) handleSnapshotInProgress(ctx context.Context, log *logrus.Entry,
+	snapshotCR *snapshotcrd.Snapshot, volumeID string) (*api.Snapshot, error) {
+	log.Infof("Snapshot exists, current status: %s.", snapshotCR.Spec.CSIStatus)
+
+	if snapshotCR.Spec.VolumeId != volumeID {
+		return nil, status.Errorf(codes.AlreadyExists,
+			"snapshot %s already exists for volume %s", snapshotCR.Name, snapshotCR.Spec.VolumeId)
+	}
+
+	switch snapshotCR.Spec.CSIStatus {
+	case apiV1.Failed:
+		// snapshot failed on the node doesn't have handle and will never be deleted by DeleteSnapshot,
+		// so its space is returned and CR is removed to allow next CreateSnapshot call to retry
+		if err := so.UpdateCRsAfterSnapshotDeletion(ctx, snapshotCR.Name); err != nil {
+			log.Errorf("Unable to remove failed snapshot: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "corresponding snapshot CR %s has failed status", snapshotCR.Name)
+	case apiV1.Created:
+		return &snapshotCR.Spec, nil
+	case apiV1.Creating:
+		expiredAt := time.Unix(0, snapshotCR.Spec.CreationTime).Add(base.DefaultTimeoutForVolumeOperations)
+		if expiredAt.Before(time.Now()) {
+			log.Errorf("Timeout of %s for snapshot creation exceeded.", base.DefaultTimeoutForVolumeOperations)
+			snapshotCR.Spec.CSIStatus = apiV1.Failed
+			if err := so.k8sClient.UpdateCR(ctx, snapshotCR); err != nil {
+				log.Errorf("Unable to set snapshot status to %s: %v", apiV1.Failed, err)
+			}
+			return nil, status.Error(codes.Internal, "Unable to create snapshot in allocated time")
+		}
+		return &snapshotCR.Spec, nil
+	default:
+		return nil, status.Errorf(codes.Unknown, "unexpected state %s", snapshotCR.Spec.CSIStatus)
+	}
+}
+
+// DeleteSnapshot changes snapshot CR state to Removing and updates it,
+// if snapshot CR doesn't exists returns Not found error and that error should be handled by caller.
+// Receives golang context and a snapshot ID to delete
+// Returns error if something went wrong or Snapshot with snapshotID wasn't found
+func (so *SnapshotOperationsImpl) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	ll := so.log.WithFields(logrus.Fields{
+		"method":     "DeleteSnapshot",
+		"snapshotID": snapshotID,
+	})
+	ll.Info("Processing")
+
+	snapshotCR := &snapshotcrd.Snapshot{}
+	if err := so.k8sClient.ReadCR(ctx, snapshotID, "", snapshotCR); err != nil {
+		return err
+	}
+
+	switch snapshotCR.Spec.CSIStatus {
+	case apiV1.Created, apiV1.Failed:
+	case apiV1.Removing, apiV1.Removed:
+		ll.Debugf("Snapshot has %s status", snapshotCR.Spec.CSIStatus)
+		return nil
+	default:
+		return status.Errorf(codes.FailedPrecondition,
+			"Snapshot CR status hadn't been set to %s, current status - %s, expected - %s",
+			apiV1.Removing, snapshotCR.Spec.CSIStatus, apiV1.Created)
+	}
+
+	snapshotCR.Spec.CSIStatus = apiV1.Removing
+	return so.k8sClient.UpdateCR(ctx, snapshotCR)
+}
+
+// UpdateCRsAfterSnapshotDeletion should be considered as a second step in DeleteSnapshot,
+// returns space of the snapshot to LVG AC and removes Snapshot CR
+func (so *SnapshotOperationsImpl) UpdateCRsAfterSnapshotDeletion(ctx context.Context, snapshotID string) error {
+	ll := so.log.WithFields(logrus.Fields{
+		"method":     "UpdateCRsAfterSnapshotDeletion",
+		"snapshotID": snapshotID,
+	})
+
+	snapshotCR := &snapshotcrd.Snapshot{}
+	if err := so.k8sClient.ReadCR(ctx, snapshotID, "", snapshotCR); err != nil {
+		if k8sError.IsNotFound(err) {
+			// snapshot CR was removed, no need to return error
+			return nil
+		}
+		return fmt.Errorf("unable to read snapshot CR %s: %w. Snapshot CR will not be removed", snapshotID, err)
+	}
+
+	acCR, err := so.crHelper.GetACByLocation(snapshotCR.Spec.Location)
+	if err != nil {
+		return fmt.Errorf("AC not found for Snapshot %s by location %s: %w", snapshotCR.Name, snapshotCR.Spec.Location, err)
+	}
+	acCR.Spec.Size += snapshotCR.Spec.Size
+	ll.Debugf("Add %d to size of AC %s", snapshotCR.Spec.Size, acCR.Name)
+	if err = so.k8sClient.UpdateCR(ctx, acCR); err != nil {
+		return fmt.Errorf("unable to update AC CR %s: %w", acCR.Name, err)
+	}
+
+	if err = so.k8sClient.DeleteCR(ctx, snapshotCR); err != nil {
+		return fmt.Errorf("unable to delete snapshot CR %s: %w", snapshotID, err)
+	}
+	return nil
+}
+
+// WaitSnapshotStatus check snapshot status until it will be reached one of the statuses
+// return error if context is done or snapshot reaches failed status, return nil if reached status != failed
+func (so *SnapshotOperationsImpl) WaitSnapshotStatus(ctx context.Context, snapshotID string, statuses ...string) error {
+	ll := so.log.WithFields(logrus.Fields{
+		"method":     "WaitSnapshotStatus",
+		"snapshotID": snapshotID,
+	})
+
+	ll.Infof("Pulling snapshot status")
+
+	var (
+		snapshot            = &snapshotcrd.Snapshot{}
+		timeoutBetweenCheck = time.Second
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			ll.Warnf("Context is done but snapshot still not reach one of the expected status: %v", statuses)
+			return fmt.Errorf("snapshot context is done")
+		case <-time.After(timeoutBetweenCheck):
+			if err := so.k8sClient.ReadCR(ctx, snapshotID, "", snapshot); err != nil {
+				ll.Errorf("Unable to read snapshot CR: %v", err)
+				if k8sError.IsNotFound(err) {
+					return fmt.Errorf("snapshot isn't found")
+				}
+				continue
+			}
+			for _, s := range statuses {
+				if snapshot.Spec.CSIStatus == s {
+					if s == apiV1.Failed {
+						return ErrSnapshotFailed
+					}
+					return nil
+				}
+			}
+		}
+	}
+}
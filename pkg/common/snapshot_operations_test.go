@@ -0,0 +1,178 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+var testSnapshotName = "snapshot-1111"
+
+// creates fake k8s client and returns instance of SnapshotOperationsImpl based on it
+func setupSnapshotOperationsTest(t *testing.T) *SnapshotOperationsImpl {
+	k8sClient, err := k8s.GetFakeKubeClient(namespace, testLogger)
+	assert.Nil(t, err)
+	assert.NotNil(t, k8sClient)
+
+	return NewSnapshotOperationsImpl(k8sClient, testLogger)
+}
+
+func TestSnapshotOperationsImpl_CreateSnapshot(t *testing.T) {
+	var (
+		svc = setupSnapshotOperationsTest(t)
+		vol = testVolumeLVG1.DeepCopy()
+		ac  = testAC4.DeepCopy()
+		err error
+	)
+
+	// volume doesn't exist
+	_, err = svc.CreateSnapshot(testCtx, testSnapshotName, vol.Name)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// volume isn't created yet
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, vol.Name, vol))
+	_, err = svc.CreateSnapshot(testCtx, testSnapshotName, vol.Name)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// not enough capacity
+	vol.Spec.CSIStatus = apiV1.Created
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, vol))
+	ac.Spec.Size = vol.Spec.Size - 1
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, ac.Name, ac))
+	_, err = svc.CreateSnapshot(testCtx, testSnapshotName, vol.Name)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// snapshot is created
+	ac.Spec.Size = vol.Spec.Size * 2
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, ac))
+	snapshot, err := svc.CreateSnapshot(testCtx, testSnapshotName, vol.Name)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.Creating, snapshot.CSIStatus)
+	assert.Equal(t, vol.Spec.NodeId, snapshot.NodeId)
+	assert.Equal(t, vol.Spec.Location, snapshot.Location)
+	assert.Equal(t, capacityplanner.AlignSizeByPE(vol.Spec.Size), snapshot.Size)
+
+	updatedAC := &accrd.AvailableCapacity{}
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, ac.Name, "", updatedAC))
+	assert.Equal(t, ac.Spec.Size-snapshot.Size, updatedAC.Spec.Size)
+
+	// repeated request
+	snapshot, err = svc.CreateSnapshot(testCtx, testSnapshotName, vol.Name)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.Creating, snapshot.CSIStatus)
+
+	// same name for another volume
+	_, err = svc.CreateSnapshot(testCtx, testSnapshotName, testVolume1Name)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// failed snapshot returns space to AC and is removed to allow retry
+	failed := &snapshotcrd.Snapshot{}
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, testSnapshotName, "", failed))
+	failed.Spec.CSIStatus = apiV1.Failed
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, failed))
+	_, err = svc.CreateSnapshot(testCtx, testSnapshotName, vol.Name)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.True(t, k8sError.IsNotFound(svc.k8sClient.ReadCR(testCtx, testSnapshotName, "", failed)))
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, ac.Name, "", updatedAC))
+	assert.Equal(t, ac.Spec.Size, updatedAC.Spec.Size)
+
+	snapshot, err = svc.CreateSnapshot(testCtx, testSnapshotName, vol.Name)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.Creating, snapshot.CSIStatus)
+}
+
+func TestSnapshotOperationsImpl_CreateSnapshot_DriveVolume(t *testing.T) {
+	var (
+		svc = setupSnapshotOperationsTest(t)
+		vol = testVolume1.DeepCopy()
+	)
+
+	vol.Spec.CSIStatus = apiV1.Created
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, vol.Name, vol))
+	_, err := svc.CreateSnapshot(testCtx, testSnapshotName, vol.Name)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSnapshotOperationsImpl_DeleteSnapshot(t *testing.T) {
+	var (
+		svc      = setupSnapshotOperationsTest(t)
+		ac       = testAC4.DeepCopy()
+		snapshot = svc.k8sClient.ConstructSnapshotCR(testSnapshotName, getTestSnapshot(apiV1.Creating))
+		current  = &snapshotcrd.Snapshot{}
+		err      error
+	)
+
+	// snapshot doesn't exist
+	err = svc.DeleteSnapshot(testCtx, testSnapshotName)
+	assert.True(t, k8sError.IsNotFound(err))
+
+	// snapshot is being created
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, snapshot.Name, snapshot))
+	err = svc.DeleteSnapshot(testCtx, testSnapshotName)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// status is set to Removing
+	snapshot.Spec.CSIStatus = apiV1.Created
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, snapshot))
+	assert.Nil(t, svc.DeleteSnapshot(testCtx, testSnapshotName))
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, testSnapshotName, "", current))
+	assert.Equal(t, apiV1.Removing, current.Spec.CSIStatus)
+
+	// AC size is returned and CR is removed
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, ac.Name, ac))
+	assert.Nil(t, svc.UpdateCRsAfterSnapshotDeletion(testCtx, testSnapshotName))
+	updatedAC := &accrd.AvailableCapacity{}
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, ac.Name, "", updatedAC))
+	assert.Equal(t, ac.Spec.Size+snapshot.Spec.Size, updatedAC.Spec.Size)
+	err = svc.k8sClient.ReadCR(testCtx, testSnapshotName, "", current)
+	assert.True(t, k8sError.IsNotFound(err))
+
+	// CR was already removed
+	assert.Nil(t, svc.UpdateCRsAfterSnapshotDeletion(testCtx, testSnapshotName))
+}
+
+func TestSnapshotOperationsImpl_WaitSnapshotStatus(t *testing.T) {
+	var (
+		svc      = setupSnapshotOperationsTest(t)
+		snapshot = svc.k8sClient.ConstructSnapshotCR(testSnapshotName, getTestSnapshot(apiV1.Created))
+	)
+
+	// snapshot CR wasn't found
+	assert.NotNil(t, svc.WaitSnapshotStatus(testCtx, testSnapshotName, apiV1.Created))
+
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, snapshot.Name, snapshot))
+	ctx, closeFn := context.WithTimeout(context.Background(), 10*time.Second)
+	defer closeFn()
+	assert.Nil(t, svc.WaitSnapshotStatus(ctx, testSnapshotName, apiV1.Failed, apiV1.Created))
+
+	snapshot.Spec.CSIStatus = apiV1.Failed
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, snapshot))
+	assert.Equal(t, ErrSnapshotFailed, svc.WaitSnapshotStatus(ctx, testSnapshotName, apiV1.Failed, apiV1.Created))
+}
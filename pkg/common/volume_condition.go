@@ -0,0 +1,46 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+// GetVolumeCondition converts Health and OperationalStatus of the volume to CSI VolumeCondition
+func GetVolumeCondition(volume *api.Volume) *csi.VolumeCondition {
+	switch {
+	case volume.Health == apiV1.HealthBad || volume.Health == apiV1.HealthSuspect:
+		return &csi.VolumeCondition{
+			Abnormal: true,
+			Message:  fmt.Sprintf("volume health is %s", volume.Health),
+		}
+	case volume.OperationalStatus == apiV1.OperationalStatusMissing ||
+		volume.OperationalStatus == apiV1.OperationalStatusInoperative:
+		return &csi.VolumeCondition{
+			Abnormal: true,
+			Message:  fmt.Sprintf("volume operational status is %s", volume.OperationalStatus),
+		}
+	}
+	return &csi.VolumeCondition{
+		Message: fmt.Sprintf("volume health is %s, operational status is %s", volume.Health, volume.OperationalStatus),
+	}
+}
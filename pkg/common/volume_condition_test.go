@@ -0,0 +1,42 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+func TestGetVolumeCondition(t *testing.T) {
+	condition := GetVolumeCondition(&api.Volume{
+		Health: apiV1.HealthGood, OperationalStatus: apiV1.OperationalStatusOperative})
+	assert.False(t, condition.GetAbnormal())
+
+	condition = GetVolumeCondition(&api.Volume{
+		Health: apiV1.HealthSuspect, OperationalStatus: apiV1.OperationalStatusOperative})
+	assert.True(t, condition.GetAbnormal())
+	assert.Contains(t, condition.GetMessage(), apiV1.HealthSuspect)
+
+	condition = GetVolumeCondition(&api.Volume{
+		Health: apiV1.HealthGood, OperationalStatus: apiV1.OperationalStatusMissing})
+	assert.True(t, condition.GetAbnormal())
+	assert.Contains(t, condition.GetMessage(), apiV1.OperationalStatusMissing)
+}
@@ -20,6 +20,7 @@ package common
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -36,12 +37,15 @@ import (
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/cache"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	fc "github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cgroup"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/metrics"
 )
@@ -152,6 +156,12 @@ func (vo *VolumeOperationsImpl) CreateVolume(ctx context.Context, v api.Volume)
 
 func (vo *VolumeOperationsImpl) handleVolumeCreation(ctx context.Context, log *logrus.Entry, v api.Volume,
 	podNamespace string, reservationName string) (*api.Volume, error) {
+	// volume with content source must be placed on the node of the source
+	source, err := vo.getContentSource(&v)
+	if err != nil {
+		return nil, err
+	}
+
 	// read volume reservation
 	podReservation, volumeReservationNum, err := vo.getVolumeReservation(ctx, log, podNamespace, reservationName)
 	if err != nil {
@@ -159,62 +169,96 @@ func (vo *VolumeOperationsImpl) handleVolumeCreation(ctx context.Context, log *l
 	}
 
 	var (
-		isFound           = false
-		ac                = &accrd.AvailableCapacity{}
 		volumeReservation = podReservation.Spec.ReservationRequests[volumeReservationNum]
 		claimLabels       map[string]string
 	)
-	// scheduler extender reserves capacity on different nodes during filter stage since 'reserve' API is not available
-	// need to find capacity on requested node
-	for _, capacityName := range volumeReservation.Reservations {
-		// read available capacity
-		err = vo.k8sClient.ReadCR(ctx, capacityName, "", ac)
-		if err != nil {
-			log.Errorf("Failed to read capacity %s: %v", capacityName, err)
-			return nil, err
-		}
-		// break when found
-		if ac.Spec.NodeId == v.NodeId {
-			isFound = true
-			break
-		}
+	ac, err := vo.getReservedAC(ctx, log, volumeReservation, v.NodeId)
+	if err != nil {
+		return nil, err
 	}
 	// capacity must be found when reservation exists
-	if !isFound {
+	if ac == nil {
 		return nil, status.Error(codes.ResourceExhausted,
 			fmt.Sprintf("there is no suitable drive for volume %s", v.Id))
 	}
 
 	if ac.Spec.StorageClass != v.StorageClass && util.IsStorageClassLVG(v.StorageClass) {
 		// AC needs to be converted to LogicalVolumeGroup AC, LogicalVolumeGroup doesn't exist yet
-		if ac = vo.acProvider.RecreateACToLVGSC(ctx, v.StorageClass, ac.Labels[apiV1.StorageGroupLabelKey], *ac); ac == nil {
+		acs := []accrd.AvailableCapacity{*ac}
+		var members []accrd.AvailableCapacity
+		switch {
+		case util.IsStorageClassLVGRaid(v.StorageClass):
+			members, err = vo.getRaidMemberACs(ctx, ac, v.StorageClass, v.Size)
+		case util.IsStorageClassLVGStriped(v.StorageClass):
+			members, err = vo.getStripedMemberACs(ctx, ac, int(v.Stripes), v.Size)
+		}
+		if err != nil {
+			log.Errorf("Unable to select member drives of LVG: %v", err)
+			return nil, err
+		}
+		acs = append(acs, members...)
+		if ac = vo.acProvider.RecreateACToLVGSC(ctx, v.StorageClass, ac.Labels[apiV1.StorageGroupLabelKey], acs...); ac == nil {
 			return nil, status.Errorf(codes.Internal,
 				"unable to prepare underlying storage for storage class %s", v.StorageClass)
 		}
 	}
 	log.Infof("AC %v was selected", ac)
 
+	// cache of the volume is reserved together with the volume on the same node
+	var cacheAC *accrd.AvailableCapacity
+	if v.CacheSize > 0 {
+		if cacheAC, err = vo.getCacheAC(ctx, log, podNamespace, reservationName, ac.Spec.NodeId); err != nil {
+			return nil, err
+		}
+		log.Infof("AC %v was selected for volume cache", cacheAC)
+	}
+
 	// if sc was parsed as an ANY then we can choose AC with any storage class and then
 	// volume should be created with that particular SC
 	var (
 		sc             = ac.Spec.StorageClass
 		allocatedBytes int64
 		locationType   string
+		stripes        int
 	)
 
 	if util.IsStorageClassLVG(sc) {
 		allocatedBytes = capacityplanner.AlignSizeByPE(v.Size)
 		locationType = apiV1.LocationTypeLVM
+		// striped LV is spread across all drives of LVG
+		if util.IsStorageClassLVGStriped(sc) {
+			stripes, _ = strconv.Atoi(ac.Labels[apiV1.LVGStripesLabelKey])
+			allocatedBytes = capacityplanner.GetStripedLVSize(stripes, allocatedBytes)
+		}
 	} else {
 		allocatedBytes = capacityplanner.AlignSizeByMB(ac.Spec.Size)
 		locationType = apiV1.LocationTypeDrive
 	}
 
-	claimLabels, err = vo.getPersistentVolumeClaimLabels(ctx, reservationName, podNamespace)
-	if err != nil {
+	if source != nil {
+		// content is copied block by block, so source and destination must be of the same kind
+		if locationType != source.LocationType {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"volume with location type %s can't be created from source with location type %s",
+				locationType, source.LocationType)
+		}
+		if allocatedBytes < source.Size {
+			return nil, status.Errorf(codes.ResourceExhausted,
+				"AC %s is too small for content of source volume %s", ac.Name, source.Id)
+		}
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err = vo.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: reservationName, Namespace: podNamespace}, pvc); err != nil {
 		log.Errorf("Unable to get related PVC, error: %v", err)
 		return nil, status.Errorf(codes.Internal, "unable to get related PVC")
 	}
+	claimLabels = getPersistentVolumeClaimLabels(pvc)
+	ioLimits, err := getIOLimits(v.IOLimits, pvc)
+	if err != nil {
+		log.Errorf("Unable to get IO limits of the volume: %v", err)
+		return nil, err
+	}
 
 	// create volume CR
 	apiVolume := api.Volume{
@@ -231,6 +275,19 @@ func (vo *VolumeOperationsImpl) handleVolumeCreation(ctx context.Context, log *l
 		Usage:             apiV1.VolumeUsageInUse,
 		Mode:              v.Mode,
 		Type:              v.Type,
+		SourceVolumeId:    v.SourceVolumeId,
+		SourceSnapshotId:  v.SourceSnapshotId,
+		EncryptionSecret:  v.EncryptionSecret,
+		WipePolicy:        v.WipePolicy,
+		MkfsOptions:       v.MkfsOptions,
+		IOLimits:          ioLimits,
+		Stripes:           int32(stripes),
+		StripeSize:        v.StripeSize,
+	}
+	if cacheAC != nil {
+		apiVolume.CacheSize = v.CacheSize
+		apiVolume.CacheMode = v.CacheMode
+		apiVolume.CacheLocation = cacheAC.Spec.Location
 	}
 	volumeCR := vo.k8sClient.ConstructVolumeCR(v.Id, podNamespace, claimLabels, apiVolume)
 
@@ -242,7 +299,7 @@ func (vo *VolumeOperationsImpl) handleVolumeCreation(ctx context.Context, log *l
 
 	// decrease AC size
 	if util.IsStorageClassLVG(sc) {
-		ac.Spec.Size -= allocatedBytes
+		ac.Spec.Size -= getLVGSpaceOfVolume(sc, allocatedBytes)
 	} else {
 		ac.Spec.Size = 0
 	}
@@ -255,9 +312,156 @@ func (vo *VolumeOperationsImpl) handleVolumeCreation(ctx context.Context, log *l
 		return nil, err
 	}
 
+	if cacheAC != nil {
+		cacheAC.Spec.Size = 0
+		if err = vo.k8sClient.UpdateCR(ctx, cacheAC); err != nil {
+			log.Errorf("Unable to set size for AC %s to %d, error: %v", cacheAC.Name, cacheAC.Spec.Size, err)
+		}
+		cacheReservation, cacheReservationNum, err := vo.getVolumeReservation(ctx, log, podNamespace,
+			util.GetCacheRequestName(reservationName))
+		if err != nil {
+			return nil, err
+		}
+		if err = vo.deleteVolumeReservation(ctx, cacheReservation, cacheReservationNum); err != nil {
+			return nil, err
+		}
+	}
+
 	return &volumeCR.Spec, nil
 }
 
+// getReservedAC returns AC reserved by the request on the node, scheduler extender reserves capacity on different
+// nodes during filter stage since 'reserve' API is not available. Returns nil if there is no AC on the node
+func (vo *VolumeOperationsImpl) getReservedAC(ctx context.Context, log *logrus.Entry, request *api.ReservationRequest,
+	nodeID string) (*accrd.AvailableCapacity, error) {
+	for _, capacityName := range request.Reservations {
+		ac := &accrd.AvailableCapacity{}
+		if err := vo.k8sClient.ReadCR(ctx, capacityName, "", ac); err != nil {
+			log.Errorf("Failed to read capacity %s: %v", capacityName, err)
+			return nil, err
+		}
+		if ac.Spec.NodeId == nodeID {
+			return ac, nil
+		}
+	}
+	return nil, nil
+}
+
+// getCacheAC returns AC of the drive reserved for the cache of the volume on the node, the drive is added to VG of
+// the volume since cache LV must be in the same VG as the cached LV
+func (vo *VolumeOperationsImpl) getCacheAC(ctx context.Context, log *logrus.Entry, podNamespace, reservationName,
+	nodeID string) (*accrd.AvailableCapacity, error) {
+	reservation, requestNum, err := vo.getVolumeReservation(ctx, log, podNamespace,
+		util.GetCacheRequestName(reservationName))
+	if err != nil {
+		return nil, err
+	}
+	request := reservation.Spec.ReservationRequests[requestNum]
+	ac, err := vo.getReservedAC(ctx, log, request, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if ac == nil {
+		return nil, status.Errorf(codes.ResourceExhausted, "there is no suitable drive for cache of volume %s",
+			reservationName)
+	}
+
+	if sc := request.CapacityRequest.StorageClass; ac.Spec.StorageClass != sc {
+		return nil, status.Errorf(codes.ResourceExhausted, "AC %s with storage class %s can't be used for cache of "+
+			"volume %s, storage class %s is required", ac.Name, ac.Spec.StorageClass, reservationName, sc)
+	}
+	return ac, nil
+}
+
+// getContentSource returns spec of the volume which content should be copied to the volume v
+// (source volume of the clone or source volume of the snapshot) or nil if v doesn't have content source.
+// Pins v to the node of the source and fills size and file system type of v if they weren't provided
+func (vo *VolumeOperationsImpl) getContentSource(v *api.Volume) (*api.Volume, error) {
+	sourceVolumeID := v.SourceVolumeId
+	switch {
+	case v.SourceSnapshotId != "":
+		snapshot, err := vo.crHelper.GetSnapshotByID(v.SourceSnapshotId)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "source snapshot %s doesn't exist", v.SourceSnapshotId)
+		}
+		if snapshot.Spec.CSIStatus != apiV1.Created {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"source snapshot %s isn't ready, current status - %s", v.SourceSnapshotId, snapshot.Spec.CSIStatus)
+		}
+		sourceVolumeID = snapshot.Spec.VolumeId
+	case sourceVolumeID == "":
+		return nil, nil
+	}
+
+	sourceCR, err := vo.crHelper.GetVolumeByID(sourceVolumeID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "source volume %s doesn't exist", sourceVolumeID)
+	}
+	source := &sourceCR.Spec
+
+	// content is copied on block level, so it could be neither decrypted nor encrypted with another key
+	if v.EncryptionSecret != "" || source.EncryptionSecret != "" {
+		return nil, status.Error(codes.InvalidArgument, "content of encrypted volumes can't be copied")
+	}
+	// copy must get own file system UUID, f2fs-tools can't change it
+	if fs.FileSystem(source.Type) == fs.F2FS && source.Mode != apiV1.ModeRAW && source.Mode != apiV1.ModeRAWPART {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"content of volume %s with file system %s can't be copied", source.Id, source.Type)
+	}
+
+	switch source.CSIStatus {
+	case apiV1.Created, apiV1.VolumeReady, apiV1.Published:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"source volume %s in status %s can't be copied", source.Id, source.CSIStatus)
+	}
+	// drive doesn't support snapshots, so content of the drive based volume is consistent only if it isn't in use
+	if v.SourceVolumeId != "" && source.LocationType == apiV1.LocationTypeDrive && source.CSIStatus != apiV1.Created {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"drive based volume %s can be cloned only when it isn't used by pods, current status - %s",
+			source.Id, source.CSIStatus)
+	}
+	// temporary snapshot of LVM source volume is created in its LVG and must fit into free capacity of LVG
+	if v.SourceVolumeId != "" && source.LocationType == apiV1.LocationTypeLVM && !util.IsStorageClassLVGThin(source.StorageClass) {
+		ac, err := vo.crHelper.GetACByLocation(source.Location)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to read AC of source volume %s", source.Id)
+		}
+		if snapshotSize := capacityplanner.GetCloneSnapshotSize(source.Size); ac.Spec.Size < snapshotSize {
+			return nil, status.Errorf(codes.ResourceExhausted,
+				"not enough capacity for temporary snapshot of source volume %s: requested - %d, available - %d",
+				source.Id, snapshotSize, ac.Spec.Size)
+		}
+	}
+	if v.Mode != source.Mode {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"volume mode %s doesn't match mode %s of source volume %s", v.Mode, source.Mode, source.Id)
+	}
+	if v.Type == "" {
+		v.Type = source.Type
+	}
+	if v.Type != source.Type {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"file system %s doesn't match file system %s of source volume %s", v.Type, source.Type, source.Id)
+	}
+	if v.Size == 0 {
+		v.Size = source.Size
+	}
+	// size of the drive based volume is a size of the whole drive, it is checked when AC is selected
+	if source.LocationType == apiV1.LocationTypeLVM && capacityplanner.AlignSizeByPE(v.Size) < source.Size {
+		return nil, status.Errorf(codes.OutOfRange,
+			"requested size %d is less than size %d of source volume %s", v.Size, source.Size, source.Id)
+	}
+	if v.NodeId != "" && v.NodeId != source.NodeId {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"volume must be created on node %s of source volume %s, requested node - %s",
+			source.NodeId, source.Id, v.NodeId)
+	}
+	v.NodeId = source.NodeId
+
+	return source, nil
+}
+
 func (vo *VolumeOperationsImpl) handleVolumeInProgress(ctx context.Context, log *logrus.Entry, volumeCR *volumecrd.Volume,
 	podNamespace string, reservationName string) (*api.Volume, error) {
 	log.Infof("Volume exists, current status: %s.", volumeCR.Spec.CSIStatus)
@@ -348,6 +552,117 @@ func (vo *VolumeOperationsImpl) deleteVolumeReservation(ctx context.Context,
 	return nil
 }
 
+// getRaidMemberACs selects ACs of the drives which are added to the new RAID LVG together with the drive of the primary AC.
+// Returns ACs of the member drives or ResourceExhausted error if there are not enough suitable drives
+func (vo *VolumeOperationsImpl) getRaidMemberACs(ctx context.Context, primary *accrd.AvailableCapacity, sc string,
+	size int64) ([]accrd.AvailableCapacity, error) {
+	raidType := util.GetRaidType(sc)
+	layout, ok := util.GetRaidLayout(raidType)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "RAID type of storage class %s isn't supported", sc)
+	}
+
+	requiredSize := capacityplanner.GetRaidLVSize(raidType, capacityplanner.AlignSizeByPE(size))
+	candidates, err := vo.getFreeDriveACs(ctx, primary, func(acSize int64) bool {
+		return capacityplanner.GetRaidLVGSize(raidType, acSize) >= requiredSize
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) < layout.Drives-1 {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"%s LVG requires %d drives, there are only %d suitable drives on node %s",
+			raidType, layout.Drives, len(candidates)+1, primary.Spec.NodeId)
+	}
+	return candidates[:layout.Drives-1], nil
+}
+
+// getStripedMemberACs selects ACs of the drives which are added to the new striped LVG together with the drive of
+// the primary AC. If the number of stripes isn't set, all free drives of the primary AC storage group are used.
+// Returns ACs of the member drives or ResourceExhausted error if there are not enough suitable drives
+func (vo *VolumeOperationsImpl) getStripedMemberACs(ctx context.Context, primary *accrd.AvailableCapacity, stripes int,
+	size int64) ([]accrd.AvailableCapacity, error) {
+	candidates, err := vo.getFreeDriveACs(ctx, primary, func(acSize int64) bool {
+		return acSize > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	if stripes == 0 {
+		stripes = len(candidates) + 1
+	}
+	if stripes < 2 || len(candidates) < stripes-1 {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"striped LVG requires %d drives, there are only %d suitable drives on node %s",
+			stripes, len(candidates)+1, primary.Spec.NodeId)
+	}
+	// drives are sorted by size, so LVG is limited by the smallest one
+	members := candidates[:stripes-1]
+	minSize := primary.Spec.Size
+	if members[0].Spec.Size < minSize {
+		minSize = members[0].Spec.Size
+	}
+	requiredSize := capacityplanner.GetStripedLVSize(stripes, capacityplanner.AlignSizeByPE(size))
+	if capacityplanner.GetStripedLVGSize(stripes, minSize) < requiredSize {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"drives on node %s are too small for volume of size %d striped across %d drives",
+			primary.Spec.NodeId, size, stripes)
+	}
+	return members, nil
+}
+
+// getFreeDriveACs returns not reserved ACs of the drives which might be added to LVG together with the drive of
+// the primary AC. Drives must be on the same node, of the same type and storage group, must not be tainted
+// and their size must be suitable. ACs are sorted by size, the smallest first
+func (vo *VolumeOperationsImpl) getFreeDriveACs(ctx context.Context, primary *accrd.AvailableCapacity,
+	isSuitable func(size int64) bool) ([]accrd.AvailableCapacity, error) {
+	acList := &accrd.AvailableCapacityList{}
+	if err := vo.k8sClient.ReadList(ctx, acList); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to read available capacities: %v", err)
+	}
+	acrList := &acrcrd.AvailableCapacityReservationList{}
+	if err := vo.k8sClient.ReadList(ctx, acrList); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to read available capacity reservations: %v", err)
+	}
+	reserved := map[string]bool{}
+	for _, acr := range acrList.Items {
+		for _, request := range acr.Spec.ReservationRequests {
+			for _, name := range request.Reservations {
+				reserved[name] = true
+			}
+		}
+	}
+
+	candidates := make([]accrd.AvailableCapacity, 0)
+	for _, ac := range acList.Items {
+		if ac.Name == primary.Name || reserved[ac.Name] ||
+			ac.Spec.NodeId != primary.Spec.NodeId ||
+			ac.Spec.StorageClass != primary.Spec.StorageClass ||
+			ac.Labels[apiV1.StorageGroupLabelKey] != primary.Labels[apiV1.StorageGroupLabelKey] ||
+			ac.Labels[apiV1.DriveTaintKey] == apiV1.DriveTaintValue ||
+			!isSuitable(ac.Spec.Size) {
+			continue
+		}
+		candidates = append(candidates, ac)
+	}
+	// the smallest drives are used to keep the bigger ones for the bigger volumes
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Spec.Size != candidates[j].Spec.Size {
+			return candidates[i].Spec.Size < candidates[j].Spec.Size
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates, nil
+}
+
+// getLVGSpaceOfVolume returns space of LVG which is taken by volume of the provided size and storage class
+func getLVGSpaceOfVolume(sc string, size int64) int64 {
+	if raidType := util.GetRaidType(sc); raidType != "" {
+		return capacityplanner.GetRaidLVSize(raidType, size)
+	}
+	return size
+}
+
 // DeleteVolume changes volume CR state and updates it,
 // if volume CR doesn't exists return Not found error and that error should be handled by caller.
 // Receives golang context and a volume ID to delete
@@ -376,6 +691,15 @@ func (vo *VolumeOperationsImpl) DeleteVolume(ctx context.Context, volumeID strin
 
 	switch volumeCR.Spec.CSIStatus {
 	case apiV1.Created:
+		// LVM snapshots depend on the origin LV and are removed together with it
+		snapshots, err := vo.crHelper.GetSnapshotCRs(volumeID)
+		if err != nil {
+			ll.Errorf("Unable to read snapshots of the volume: %v", err)
+			return status.Error(codes.Internal, "unable to read volume snapshots")
+		}
+		if len(snapshots) > 0 {
+			return status.Errorf(codes.FailedPrecondition, "volume has %d snapshot(s)", len(snapshots))
+		}
 	case apiV1.Failed:
 		return status.Error(codes.Internal, "volume has reached failed status")
 	case apiV1.Removed:
@@ -456,8 +780,9 @@ func (vo *VolumeOperationsImpl) UpdateCRsAfterVolumeDeletion(ctx context.Context
 
 	if volumeCR.Spec.Health == apiV1.HealthGood {
 		// Increase size of AC using volume size
-		acCR.Spec.Size += volumeCR.Spec.Size
-		ll.Debugf("Add %d to size of AC %s", volumeCR.Spec.Size, acCR.Name)
+		size := getLVGSpaceOfVolume(volumeCR.Spec.StorageClass, volumeCR.Spec.Size)
+		acCR.Spec.Size += size
+		ll.Debugf("Add %d to size of AC %s", size, acCR.Name)
 		acAction = update
 	}
 
@@ -469,6 +794,12 @@ func (vo *VolumeOperationsImpl) UpdateCRsAfterVolumeDeletion(ctx context.Context
 		return fmt.Errorf("unable to update LVG CR %s: %w", lvgCR.Name, err)
 	}
 
+	if volumeCR.Spec.CacheLocation != "" {
+		if err = vo.releaseVolumeCache(ctx, ll, &volumeCR); err != nil {
+			return err
+		}
+	}
+
 	if err = vo.k8sClient.DeleteCR(ctx, &volumeCR); err != nil {
 		return fmt.Errorf("unable to delete volume CR %s: %w", volumeID, err)
 	}
@@ -477,6 +808,27 @@ func (vo *VolumeOperationsImpl) UpdateCRsAfterVolumeDeletion(ctx context.Context
 	return nil
 }
 
+// releaseVolumeCache returns the cache drive of the volume to its AC, the drive is removed from VG of the volume
+// during the volume release on the node
+func (vo *VolumeOperationsImpl) releaseVolumeCache(ctx context.Context, ll *logrus.Entry, volumeCR *volumecrd.Volume) error {
+	acCR, err := vo.crHelper.GetACByLocation(volumeCR.Spec.CacheLocation)
+	if err != nil {
+		return fmt.Errorf("AC not found for cache of Volume %s by location %s: %w",
+			volumeCR.Name, volumeCR.Spec.CacheLocation, err)
+	}
+	driveCR := &drivecrd.Drive{}
+	if err = vo.k8sClient.ReadCR(ctx, volumeCR.Spec.CacheLocation, "", driveCR); err != nil {
+		return fmt.Errorf("unable to read Drive CR of cache of Volume %s: %w", volumeCR.Name, err)
+	}
+
+	acCR.Spec.Size = driveCR.Spec.GetSize()
+	ll.Debugf("Set size of AC %s to %d", acCR.Name, acCR.Spec.Size)
+	if err = vo.DoAction(ctx, ll, acCR, update, "AC"); err != nil {
+		return fmt.Errorf("unable to update AC CR %s: %w", acCR.Name, err)
+	}
+	return nil
+}
+
 // DoAction do UpdateCR or DeleteCR with CR
 // return error if k8sClient action done with err
 func (vo *VolumeOperationsImpl) DoAction(ctx context.Context, log *logrus.Entry, obj k8sCl.Object, action uint8,
@@ -545,7 +897,8 @@ func (vo *VolumeOperationsImpl) WaitStatus(ctx context.Context, volumeID string,
 }
 
 // ExpandVolume updates Volume status to Resizing to trigger expansion in reconcile, if volume has already had status
-// Resizing or Resized, function doesn't do anything. In case of statuses beside VolumeReady, Created, Published function return error
+// Resizing or Resized, function doesn't do anything. In case of statuses beside VolumeReady, Created, Published function return error.
+// LVG based volume reserves additional space in AC, drive based volume could be expanded up to the drive size
 // Receive golang context, volume CR, requiredBytes as int
 // Return volume spec, error
 func (vo *VolumeOperationsImpl) ExpandVolume(ctx context.Context, volume *volumecrd.Volume, requiredBytes int64) error {
@@ -559,25 +912,36 @@ func (vo *VolumeOperationsImpl) ExpandVolume(ctx context.Context, volume *volume
 	case apiV1.Resizing, apiV1.Resized:
 		ll.Debug("Volume is already expanding")
 	case apiV1.VolumeReady, apiV1.Created, apiV1.Published:
-		if !util.IsStorageClassLVG(volume.Spec.StorageClass) {
-			return status.Error(codes.FailedPrecondition,
-				fmt.Sprintf("StorageClass %s doesn't support resizing", volume.Spec.StorageClass))
-		}
-		capacity, err := vo.crHelper.GetACByLocation(volume.Spec.Location)
-		if err != nil {
-			ll.Errorf("Failed to get AC by location %s", volume.Spec.Location)
-			return status.Error(codes.Internal, "Unable to read AC")
-		}
+		if util.IsStorageClassLVG(volume.Spec.StorageClass) {
+			capacity, err := vo.crHelper.GetACByLocation(volume.Spec.Location)
+			if err != nil {
+				ll.Errorf("Failed to get AC by location %s", volume.Spec.Location)
+				return status.Error(codes.Internal, "Unable to read AC")
+			}
+			// LVM rounds up size of striped LV to the stripe boundary
+			requiredBytes = capacityplanner.GetStripedLVSize(int(volume.Spec.Stripes), requiredBytes)
 
-		acSize := requiredBytes - volume.Spec.Size
-		if capacity.Spec.Size < acSize {
-			return status.Error(codes.OutOfRange,
-				fmt.Sprintf("Not enough capacity to expand volume: requested - %d, available - %d", requiredBytes, capacity.Spec.Size))
-		}
-		capacity.Spec.Size -= acSize
-		if err := vo.k8sClient.UpdateCR(ctx, capacity); err != nil {
-			ll.Errorf("Failed to update AC, error: %v", err)
-			return status.Error(codes.Internal, "Unable to reserve AC")
+			acSize := requiredBytes - volume.Spec.Size
+			if capacity.Spec.Size < acSize {
+				return status.Error(codes.OutOfRange,
+					fmt.Sprintf("Not enough capacity to expand volume: requested - %d, available - %d", requiredBytes, capacity.Spec.Size))
+			}
+			capacity.Spec.Size -= acSize
+			if err := vo.k8sClient.UpdateCR(ctx, capacity); err != nil {
+				ll.Errorf("Failed to update AC, error: %v", err)
+				return status.Error(codes.Internal, "Unable to reserve AC")
+			}
+		} else {
+			// drive based volume owns the whole drive and AC of the drive is already empty
+			drive, err := vo.crHelper.GetDriveCRByVolume(volume)
+			if err != nil {
+				ll.Errorf("Failed to get drive by location %s", volume.Spec.Location)
+				return status.Error(codes.Internal, "Unable to read drive")
+			}
+			if drive.Spec.Size < requiredBytes {
+				return status.Error(codes.OutOfRange,
+					fmt.Sprintf("Not enough capacity to expand volume: requested - %d, drive size - %d", requiredBytes, drive.Spec.Size))
+			}
 		}
 
 		if volume.Annotations == nil {
@@ -629,6 +993,10 @@ func (vo *VolumeOperationsImpl) UpdateCRsAfterVolumeExpansion(ctx context.Contex
 			return
 		}
 		volume.Spec.Size = capacity
+		if !util.IsStorageClassLVG(volume.Spec.StorageClass) {
+			// AC of the drive wasn't changed during expansion
+			break
+		}
 		ac, err := vo.crHelper.GetACByLocation(volume.Spec.Location)
 		if err != nil {
 			ll.Errorf("Failed to read AC: %v", err)
@@ -670,22 +1038,25 @@ func (vo *VolumeOperationsImpl) fillCache() {
 	}
 }
 
-// VolumeOperationsImpl returns PVC labels: release, app.kubernetes.io/name, storagegroup and adds short app label
-func (vo *VolumeOperationsImpl) getPersistentVolumeClaimLabels(ctx context.Context, pvcName, pvcNamespace string) (
-	map[string]string, error) {
-	ll := vo.log.WithFields(logrus.Fields{
-		"method": "getVolumeAppLabel",
-	})
+// getIOLimits returns IO limits of the volume in io.max format, limits of the storage class are overridden
+// by the annotations of the PVC
+func getIOLimits(scLimits string, pvc *corev1.PersistentVolumeClaim) (string, error) {
+	limits, err := cgroup.ParseIOLimits(scLimits)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
 
-	pvc := &corev1.PersistentVolumeClaim{}
-	if err := vo.k8sClient.Get(ctx, k8sCl.ObjectKey{
-		Name:      pvcName,
-		Namespace: pvcNamespace,
-	}, pvc); err != nil {
-		ll.Errorf("Failed to get PVC %s in namespace %s, error %v", pvcName, pvcNamespace, err)
-		return nil, err
+	annotations := pvc.GetAnnotations()
+	override, err := cgroup.NewIOLimits(annotations[apiV1.ClaimAnnotationReadBPS], annotations[apiV1.ClaimAnnotationWriteBPS],
+		annotations[apiV1.ClaimAnnotationReadIOPS], annotations[apiV1.ClaimAnnotationWriteIOPS])
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "PVC %s has invalid IO limits: %v", pvc.Name, err)
 	}
+	return limits.Merge(override).String(), nil
+}
 
+// getPersistentVolumeClaimLabels returns PVC labels: release, app.kubernetes.io/name, storagegroup and adds short app label
+func getPersistentVolumeClaimLabels(pvc *corev1.PersistentVolumeClaim) map[string]string {
 	// need to get release, app and storagegroup labels
 	labels := map[string]string{}
 	if value, ok := pvc.GetLabels()[k8s.ReleaseLabelKey]; ok {
@@ -699,5 +1070,5 @@ func (vo *VolumeOperationsImpl) getPersistentVolumeClaimLabels(ctx context.Conte
 		labels[apiV1.StorageGroupLabelKey] = value
 	}
 
-	return labels, nil
+	return labels
 }
@@ -40,8 +40,10 @@ import (
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base/cache"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 )
 
@@ -60,17 +62,6 @@ func setupVOOperationsTest(t *testing.T) *VolumeOperationsImpl {
 }
 
 func Test_getPersistentVolumeClaimLabels(t *testing.T) {
-	var (
-		svc     = setupVOOperationsTest(t)
-		ctx     = context.TODO()
-		pvcName = "my-pvc"
-	)
-	// no PVC
-	labels, err := svc.getPersistentVolumeClaimLabels(ctx, pvcName, namespace)
-	assert.Nil(t, labels)
-	assert.NotNil(t, err)
-
-	// create PVC
 	var (
 		appName     = "my-app"
 		releaseName = "my-release"
@@ -79,21 +70,122 @@ func Test_getPersistentVolumeClaimLabels(t *testing.T) {
 			k8s.AppLabelKey:            appName,
 			k8s.ReleaseLabelKey:        releaseName,
 			apiV1.StorageGroupLabelKey: sgName,
+			"other":                    "value",
 		}
-		pvc = &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: pvcName, Namespace: namespace,
+		pvc = &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "my-pvc", Namespace: namespace,
 			Labels: pvcLabels}}
 	)
-	err = svc.k8sClient.Create(ctx, pvc)
-	assert.Nil(t, err)
 
 	// check labels
-	labels, err = svc.getPersistentVolumeClaimLabels(ctx, pvcName, namespace)
-	assert.NotNil(t, labels)
-	assert.Nil(t, err)
+	labels := getPersistentVolumeClaimLabels(pvc)
+	assert.Len(t, labels, 4)
 	assert.Equal(t, labels[k8s.AppLabelKey], appName)
 	assert.Equal(t, labels[k8s.AppLabelShortKey], appName)
 	assert.Equal(t, labels[k8s.ReleaseLabelKey], releaseName)
 	assert.Equal(t, labels[apiV1.StorageGroupLabelKey], sgName)
+
+	// PVC without labels
+	assert.Empty(t, getPersistentVolumeClaimLabels(&v1.PersistentVolumeClaim{}))
+}
+
+func Test_getIOLimits(t *testing.T) {
+	pvc := &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "my-pvc", Namespace: namespace,
+		Annotations: map[string]string{
+			apiV1.ClaimAnnotationWriteBPS: "10Mi",
+			apiV1.ClaimAnnotationReadIOPS: "500",
+		}}}
+
+	// PVC overrides storage class limits
+	limits, err := getIOLimits("rbps=1024 riops=1000", pvc)
+	assert.Nil(t, err)
+	assert.Equal(t, "rbps=1024 wbps=10485760 riops=500", limits)
+
+	// invalid storage class limits
+	_, err = getIOLimits("rbps=fast", pvc)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// invalid PVC annotation
+	pvc.Annotations[apiV1.ClaimAnnotationWriteIOPS] = "fast"
+	_, err = getIOLimits("", pvc)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_getRaidMemberACs(t *testing.T) {
+	var (
+		svc = setupVOOperationsTest(t)
+		ctx = context.TODO()
+	)
+	getAC := func(name, node, sc string, size int64) *accrd.AvailableCapacity {
+		ac := testAC1.DeepCopy()
+		ac.Name, ac.Spec.Location, ac.Spec.NodeId, ac.Spec.StorageClass, ac.Spec.Size = name, name, node, sc, size
+		return ac
+	}
+	var (
+		primary  = getAC("primary", testNode1Name, apiV1.StorageClassHDD, int64(util.GBYTE)*10)
+		small    = getAC("small", testNode1Name, apiV1.StorageClassHDD, int64(util.GBYTE))
+		member1  = getAC("member-1", testNode1Name, apiV1.StorageClassHDD, int64(util.GBYTE)*20)
+		member2  = getAC("member-2", testNode1Name, apiV1.StorageClassHDD, int64(util.GBYTE)*10)
+		ssd      = getAC("ssd", testNode1Name, apiV1.StorageClassSSD, int64(util.GBYTE)*10)
+		anotherN = getAC("another-node", testNode2Name, apiV1.StorageClassHDD, int64(util.GBYTE)*10)
+		reserved = getAC("reserved", testNode1Name, apiV1.StorageClassHDD, int64(util.GBYTE)*10)
+	)
+	for _, ac := range []*accrd.AvailableCapacity{primary, small, member1, member2, ssd, anotherN, reserved} {
+		assert.Nil(t, svc.k8sClient.CreateCR(ctx, ac.Name, ac))
+	}
+	acr := getTestACR(int64(util.GBYTE), apiV1.StorageClassHDD, "acr", namespace, []*accrd.AvailableCapacity{reserved})
+	assert.Nil(t, svc.k8sClient.CreateCR(ctx, acr.Name, acr))
+
+	members, err := svc.getRaidMemberACs(ctx, primary, apiV1.StorageClassHDDLVGRaid1, int64(util.GBYTE)*5)
+	assert.Nil(t, err)
+	assert.Len(t, members, 1)
+	assert.Equal(t, member2.Name, members[0].Name)
+
+	members, err = svc.getRaidMemberACs(ctx, primary, apiV1.StorageClassHDDLVGRaid5, int64(util.GBYTE)*5)
+	assert.Nil(t, err)
+	assert.Len(t, members, 2)
+
+	_, err = svc.getRaidMemberACs(ctx, primary, apiV1.StorageClassHDDLVGRaid10, int64(util.GBYTE)*5)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func Test_getStripedMemberACs(t *testing.T) {
+	var (
+		svc = setupVOOperationsTest(t)
+		ctx = context.TODO()
+	)
+	getAC := func(name, node, sc string, size int64) *accrd.AvailableCapacity {
+		ac := testAC1.DeepCopy()
+		ac.Name, ac.Spec.Location, ac.Spec.NodeId, ac.Spec.StorageClass, ac.Spec.Size = name, name, node, sc, size
+		return ac
+	}
+	var (
+		primary = getAC("primary", testNode1Name, apiV1.StorageClassHDD, int64(util.GBYTE)*10)
+		small   = getAC("small", testNode1Name, apiV1.StorageClassHDD, int64(util.GBYTE))
+		member  = getAC("member", testNode1Name, apiV1.StorageClassHDD, int64(util.GBYTE)*20)
+		used    = getAC("used", testNode1Name, apiV1.StorageClassHDD, 0)
+		ssd     = getAC("ssd", testNode1Name, apiV1.StorageClassSSD, int64(util.GBYTE)*10)
+	)
+	for _, ac := range []*accrd.AvailableCapacity{primary, small, member, used, ssd} {
+		assert.Nil(t, svc.k8sClient.CreateCR(ctx, ac.Name, ac))
+	}
+
+	// the smallest drive is selected if it's enough for the volume
+	members, err := svc.getStripedMemberACs(ctx, primary, 2, int64(util.GBYTE))
+	assert.Nil(t, err)
+	assert.Len(t, members, 1)
+	assert.Equal(t, small.Name, members[0].Name)
+
+	// all free drives are used if the number of stripes isn't set
+	members, err = svc.getStripedMemberACs(ctx, primary, 0, int64(util.GBYTE))
+	assert.Nil(t, err)
+	assert.Len(t, members, 2)
+
+	// volume doesn't fit to LVG limited by the smallest drive
+	_, err = svc.getStripedMemberACs(ctx, primary, 3, int64(util.GBYTE)*5)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = svc.getStripedMemberACs(ctx, primary, 4, int64(util.GBYTE))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
 }
 
 func TestVolumeOperationsImpl_CreateVolume_VolumeExists(t *testing.T) {
@@ -265,6 +357,256 @@ func TestVolumeOperationsImpl_CreateVolume_HDDLVGVolumeCreated(t *testing.T) {
 	assert.Equal(t, expectedVolume, createdVolume)
 }
 
+// Volume CR was created with the cache, both reservation requests were released
+func TestVolumeOperationsImpl_CreateVolume_HDDLVGVolumeCached(t *testing.T) {
+	var (
+		svc           = setupVOOperationsTest(t)
+		volumeID      = "pvc-aaaa-cccc"
+		requiredBytes = int64(util.GBYTE)
+		cacheBytes    = capacityplanner.DefaultPESize * 2
+		testPVC       = testPVC1.DeepCopy()
+		ctxWithID     = context.WithValue(testCtx, base.RequestUUID, volumeID)
+		acToReturn    = &accrd.AvailableCapacity{
+			TypeMeta:   k8smetav1.TypeMeta{Kind: "AvailableCapacity", APIVersion: apiV1.APIV1Version},
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "aaaa-1111"},
+			Spec: api.AvailableCapacity{
+				StorageClass: apiV1.StorageClassHDDLVG,
+				Size:         requiredBytes,
+				Location:     "hdd-lvg",
+			},
+		}
+		cacheACToReturn = &accrd.AvailableCapacity{
+			TypeMeta:   k8smetav1.TypeMeta{Kind: "AvailableCapacity", APIVersion: apiV1.APIV1Version},
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "aaaa-2222"},
+			Spec: api.AvailableCapacity{
+				StorageClass: apiV1.StorageClassSSD,
+				Size:         requiredBytes,
+				Location:     "ssd-drive",
+			},
+		}
+		acrToReturn = getTestACR(requiredBytes, apiV1.StorageClassHDDLVG, volumeID, testNS,
+			[]*accrd.AvailableCapacity{acToReturn})
+	)
+	acrToReturn.Spec.ReservationRequests = append(acrToReturn.Spec.ReservationRequests, &api.ReservationRequest{
+		CapacityRequest: &api.CapacityRequest{
+			StorageClass: apiV1.StorageClassSSD,
+			Size:         cacheBytes,
+			Name:         util.GetCacheRequestName(volumeID),
+		},
+		Reservations: []string{cacheACToReturn.Name},
+	})
+	testPVC.ObjectMeta.Name = volumeID
+	assert.Nil(t, svc.k8sClient.Create(ctxWithID, testPVC))
+	assert.Nil(t, svc.k8sClient.CreateCR(ctxWithID, acToReturn.Name, acToReturn))
+	assert.Nil(t, svc.k8sClient.CreateCR(ctxWithID, cacheACToReturn.Name, cacheACToReturn))
+	assert.Nil(t, svc.k8sClient.CreateCR(ctxWithID, acrToReturn.Name, acrToReturn))
+
+	ctx := context.WithValue(testCtx, util.VolumeInfoKey, &util.VolumeInfo{Name: volumeID, Namespace: testNS})
+	createdVolume, err := svc.CreateVolume(ctx, api.Volume{
+		Id:           volumeID,
+		StorageClass: apiV1.StorageClassHDDLVG,
+		Size:         requiredBytes,
+		CacheSize:    cacheBytes,
+		CacheMode:    apiV1.CacheModeWriteback,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, acToReturn.Spec.Location, createdVolume.Location)
+	assert.Equal(t, cacheACToReturn.Spec.Location, createdVolume.CacheLocation)
+	assert.Equal(t, cacheBytes, createdVolume.CacheSize)
+	assert.Equal(t, apiV1.CacheModeWriteback, createdVolume.CacheMode)
+
+	ac := &accrd.AvailableCapacity{}
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, cacheACToReturn.Name, "", ac))
+	assert.Equal(t, int64(0), ac.Spec.Size)
+	acr := &acrcrd.AvailableCapacityReservation{}
+	assert.True(t, k8sError.IsNotFound(svc.k8sClient.ReadCR(testCtx, acrToReturn.Name, "", acr)))
+}
+
+// Volume CR was successfully created on the node of the source volume
+func TestVolumeOperationsImpl_CreateVolume_Clone(t *testing.T) {
+	var (
+		svc           = setupVOOperationsTest(t)
+		requiredSC    = apiV1.StorageClassHDDLVG
+		volumeID      = "pvc-aaaa-bbbb"
+		acName        = "aaaa-1111"
+		requiredBytes = int64(util.GBYTE)
+		testPVC       = testPVC1.DeepCopy()
+		source        = testVolumeLVG1.DeepCopy()
+		acToReturn    = &accrd.AvailableCapacity{
+			TypeMeta:   k8smetav1.TypeMeta{Kind: "AvailableCapacity", APIVersion: apiV1.APIV1Version},
+			ObjectMeta: k8smetav1.ObjectMeta{Name: acName, CreationTimestamp: k8smetav1.NewTime(time.Now())},
+			Spec: api.AvailableCapacity{
+				StorageClass: requiredSC,
+				Size:         requiredBytes,
+				NodeId:       source.Spec.NodeId,
+				Location:     source.Spec.Location,
+			},
+		}
+		acrToReturn = &acrcrd.AvailableCapacityReservation{
+			TypeMeta:   k8smetav1.TypeMeta{Kind: "AvailableCapacityReservation", APIVersion: apiV1.APIV1Version},
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "test-ac", CreationTimestamp: k8smetav1.NewTime(time.Now())},
+			Spec: api.AvailableCapacityReservation{
+				Namespace: testNS,
+				Status:    apiV1.ReservationConfirmed,
+				ReservationRequests: []*api.ReservationRequest{
+					{
+						CapacityRequest: &api.CapacityRequest{
+							StorageClass: requiredSC,
+							Size:         requiredBytes,
+							Name:         volumeID,
+							NodeId:       source.Spec.NodeId,
+						},
+						Reservations: []string{acName}},
+				},
+			},
+		}
+	)
+	source.Spec.CSIStatus = apiV1.Created
+	source.Spec.Size = requiredBytes
+	source.Spec.Type = "xfs"
+	testPVC.ObjectMeta.Name = volumeID
+	assert.Nil(t, svc.k8sClient.Create(testCtx, testPVC))
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, source.Name, source))
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, acToReturn.Name, acToReturn))
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, acrToReturn.Name, acrToReturn))
+
+	ctx := context.WithValue(testCtx, util.VolumeInfoKey, &util.VolumeInfo{Name: volumeID, Namespace: testNS})
+	createdVolume, err := svc.CreateVolume(ctx, api.Volume{
+		Id:             volumeID,
+		StorageClass:   requiredSC,
+		Size:           requiredBytes,
+		SourceVolumeId: source.Spec.Id,
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, createdVolume)
+	assert.Equal(t, source.Spec.NodeId, createdVolume.NodeId)
+	assert.Equal(t, source.Spec.Type, createdVolume.Type)
+	assert.Equal(t, source.Spec.Id, createdVolume.SourceVolumeId)
+}
+
+func TestVolumeOperationsImpl_getContentSource(t *testing.T) {
+	var (
+		svc      = setupVOOperationsTest(t)
+		source   = testVolumeLVG1.DeepCopy()
+		snapshot = svc.k8sClient.ConstructSnapshotCR("snapshot-1111", getTestSnapshot(apiV1.Creating))
+		v        *api.Volume
+		err      error
+	)
+	source.Spec.Type = "xfs"
+	newVolume := func() *api.Volume {
+		return &api.Volume{Id: "pvc-clone", Size: source.Spec.Size, SourceVolumeId: source.Spec.Id}
+	}
+
+	// volume without content source
+	result, err := svc.getContentSource(&api.Volume{Id: "pvc-clone"})
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+
+	// source volume doesn't exist
+	_, err = svc.getContentSource(newVolume())
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// source volume isn't created yet
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, source.Name, source))
+	_, err = svc.getContentSource(newVolume())
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	source.Spec.CSIStatus = apiV1.Published
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, source))
+
+	// AC of source volume doesn't exist
+	_, err = svc.getContentSource(newVolume())
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// not enough capacity in LVG for temporary snapshot
+	ac := testAC4.DeepCopy()
+	ac.Spec.Size = capacityplanner.GetCloneSnapshotSize(source.Spec.Size) - capacityplanner.DefaultPESize
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, ac.Name, ac))
+	_, err = svc.getContentSource(newVolume())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	ac.Spec.Size = testAC4.Spec.Size
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, ac))
+
+	// mode mismatch
+	v = newVolume()
+	v.Mode = apiV1.ModeRAW
+	_, err = svc.getContentSource(v)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// file system mismatch
+	v = newVolume()
+	v.Type = "ext4"
+	_, err = svc.getContentSource(v)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// content of encrypted volume can't be copied
+	v = newVolume()
+	v.EncryptionSecret = "default/volume-key"
+	_, err = svc.getContentSource(v)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// UUID of f2fs can't be changed, so its content can't be copied
+	source.Spec.Type = string(fs.F2FS)
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, source))
+	_, err = svc.getContentSource(newVolume())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	source.Spec.Type = "xfs"
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, source))
+
+	// requested size is too small
+	v = newVolume()
+	v.Size = source.Spec.Size - capacityplanner.DefaultPESize
+	_, err = svc.getContentSource(v)
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+
+	// requested node differs from the node of source
+	v = newVolume()
+	v.NodeId = testNode1Name
+	_, err = svc.getContentSource(v)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// volume is pinned to the node of source
+	v = newVolume()
+	v.Size = 0
+	result, err = svc.getContentSource(v)
+	assert.Nil(t, err)
+	assert.Equal(t, source.Spec, *result)
+	assert.Equal(t, source.Spec.NodeId, v.NodeId)
+	assert.Equal(t, source.Spec.Type, v.Type)
+	assert.Equal(t, source.Spec.Size, v.Size)
+
+	// source snapshot doesn't exist
+	v = &api.Volume{Id: "pvc-restore", SourceSnapshotId: snapshot.Name}
+	_, err = svc.getContentSource(v)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// source snapshot isn't ready
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, snapshot.Name, snapshot))
+	_, err = svc.getContentSource(v)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	snapshot.Spec.CSIStatus = apiV1.Created
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, snapshot))
+	result, err = svc.getContentSource(v)
+	assert.Nil(t, err)
+	assert.Equal(t, source.Spec.Id, result.Id)
+	assert.Equal(t, source.Spec.NodeId, v.NodeId)
+
+	// drive based source volume is in use
+	driveSource := testVolume1.DeepCopy()
+	driveSource.Spec.CSIStatus = apiV1.Published
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, driveSource.Name, driveSource))
+	_, err = svc.getContentSource(&api.Volume{Id: "pvc-clone", SourceVolumeId: driveSource.Spec.Id})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	driveSource.Spec.CSIStatus = apiV1.Created
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, driveSource))
+	result, err = svc.getContentSource(&api.Volume{Id: "pvc-clone", SourceVolumeId: driveSource.Spec.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, driveSource.Spec.Id, result.Id)
+}
+
 // Volume CR exists and has "failed" CSIStatus
 func TestVolumeOperationsImpl_CreateVolume_FaileCauseExist(t *testing.T) {
 	var (
@@ -483,6 +825,23 @@ func TestVolumeOperationsImpl_DeleteVolume_SetStatus(t *testing.T) {
 	assert.Equal(t, apiV1.Removing, updatedVol.Spec.CSIStatus)
 }
 
+func TestVolumeOperationsImpl_DeleteVolume_HasSnapshots(t *testing.T) {
+	var (
+		svc      = setupVOOperationsTest(t)
+		v        = testVolumeLVG1.DeepCopy()
+		snapshot = svc.k8sClient.ConstructSnapshotCR("snapshot-1111", getTestSnapshot(apiV1.Created))
+		err      error
+	)
+
+	v.Spec.CSIStatus = apiV1.Created
+	svc.cache.Set(v.Name, v.Namespace)
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, v.Name, v))
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, snapshot.Name, snapshot))
+
+	err = svc.DeleteVolume(testCtx, v.Name)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
 func TestVolumeOperationsImpl_WaitStatus_Success(t *testing.T) {
 	var (
 		svc = setupVOOperationsTest(t)
@@ -612,6 +971,42 @@ func TestVolumeOperationsImpl_UpdateCRsAfterVolumeDeletion(t *testing.T) {
 		assert.Equal(t, ACUpdated.Spec.StorageClass, util.ConvertDriveTypeToStorageClass(testDriveCR4.Spec.Type))
 	})
 
+	t.Run("volume with cache", func(t *testing.T) {
+		svc = setupVOOperationsTest(t)
+		volume := testVolumeLVG1.DeepCopy()
+		cacheDrive := testDriveCR1.DeepCopy()
+		cacheDrive.Spec.Type = apiV1.DriveTypeSSD
+		volume.Spec.CacheLocation = cacheDrive.Name
+		volume.Spec.CacheSize = capacityplanner.DefaultPESize
+		lvg := testLVG.DeepCopy()
+		lvg.Spec.VolumeRefs = []string{volume.Name}
+		cacheAC := testAC1.DeepCopy()
+		cacheAC.Spec.Location = cacheDrive.Name
+		cacheAC.Spec.StorageClass = apiV1.StorageClassSSD
+		cacheAC.Spec.Size = 0
+
+		ac := testAC4.DeepCopy()
+		ac.ResourceVersion = ""
+		drive := testDriveCR4.DeepCopy()
+		drive.ResourceVersion = ""
+
+		assert.Nil(t, svc.k8sClient.CreateCR(testCtx, ac.Name, ac))
+		assert.Nil(t, svc.k8sClient.CreateCR(testCtx, drive.Name, drive))
+		assert.Nil(t, svc.k8sClient.CreateCR(testCtx, lvg.Name, lvg))
+		assert.Nil(t, svc.k8sClient.CreateCR(testCtx, volume.Name, volume))
+		assert.Nil(t, svc.k8sClient.CreateCR(testCtx, cacheAC.Name, cacheAC))
+		assert.Nil(t, svc.k8sClient.CreateCR(testCtx, cacheDrive.Name, cacheDrive))
+		svc.cache.Set(volume.Name, volume.Namespace)
+
+		assert.Nil(t, svc.UpdateCRsAfterVolumeDeletion(testCtx, volume.Name))
+
+		// check that AC of the cache drive got its capacity back
+		assert.Nil(t, svc.k8sClient.ReadCR(testCtx, cacheAC.Name, "", ACUpdated))
+		assert.Equal(t, cacheDrive.Spec.Size, ACUpdated.Spec.Size)
+		assert.Equal(t, cacheDrive.Name, ACUpdated.Spec.Location)
+		assert.Equal(t, apiV1.StorageClassSSD, ACUpdated.Spec.StorageClass)
+	})
+
 	t.Run("volume not in cache", func(t *testing.T) {
 		svc = setupVOOperationsTest(t)
 
@@ -751,6 +1146,48 @@ func TestVolumeOperationsImpl_ExpandVolume_DifferentStatuses(t *testing.T) {
 	}
 }
 
+func TestVolumeOperationsImpl_ExpandVolume_DriveBased(t *testing.T) {
+	var (
+		svc      = setupVOOperationsTest(t)
+		volumeCR = testVolume1.DeepCopy()
+		ac       = testAC1.DeepCopy()
+		capacity = testDriveCR1.Spec.Size
+		prevSize = testDriveCR1.Spec.Size / 2
+	)
+
+	volumeCR.Spec.CSIStatus = apiV1.Published
+	volumeCR.Spec.Size = prevSize
+	ac.Spec.Size = 0
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, volumeCR.Name, volumeCR))
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, ac.Name, ac))
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, testDriveCR1.Name, testDriveCR1.DeepCopy()))
+
+	assert.Nil(t, svc.ExpandVolume(testCtx, volumeCR, capacity))
+
+	uVol := &volumecrd.Volume{}
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, volumeCR.Name, testNS, uVol))
+	assert.Equal(t, apiV1.Resizing, uVol.Spec.CSIStatus)
+	assert.Equal(t, capacity, uVol.Spec.Size)
+	assert.Equal(t, apiV1.Published, uVol.Annotations[apiV1.VolumePreviousStatus])
+
+	// AC of the drive isn't changed
+	uAC, err := svc.crHelper.GetACByLocation(testDrive1UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), uAC.Spec.Size)
+
+	// AC isn't changed when expansion has failed
+	svc.cache.Set(volumeCR.Name, testNS)
+	uVol.Spec.CSIStatus = apiV1.Failed
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, uVol))
+	svc.UpdateCRsAfterVolumeExpansion(testCtx, volumeCR.Name, capacity)
+
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, volumeCR.Name, testNS, uVol))
+	assert.Equal(t, prevSize, uVol.Spec.Size)
+	uAC, err = svc.crHelper.GetACByLocation(testDrive1UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), uAC.Spec.Size)
+}
+
 func TestVolumeOperationsImpl_ExpandVolume_Fail(t *testing.T) {
 	var (
 		svc      *VolumeOperationsImpl
@@ -771,19 +1208,25 @@ func TestVolumeOperationsImpl_ExpandVolume_Fail(t *testing.T) {
 		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
 	}
 
-	// Storage class is not lvg
+	// Storage class is not lvg, drive doesn't exist
 	volumeCR.Spec.CSIStatus = apiV1.Created
 	volumeCR.ObjectMeta.ResourceVersion = ""
 	assert.NotNil(t, svc.k8sClient.UpdateCR(testCtx, volumeCR))
-	if err := svc.ExpandVolume(testCtx, volumeCR, capacity); err != nil {
-		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
-	}
+	err := svc.ExpandVolume(testCtx, volumeCR, capacity)
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// Storage class is not lvg, required capacity is more than size of the drive
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, testDriveCR1.Name, testDriveCR1.DeepCopy()))
+	err = svc.ExpandVolume(testCtx, volumeCR, capacity)
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
 
 	// Failed to get AC
 	volumeCR.ObjectMeta.ResourceVersion = ""
 	volumeCR.Spec.StorageClass = apiV1.StorageClassSystemLVG
 	assert.NotNil(t, svc.k8sClient.UpdateCR(testCtx, volumeCR))
-	err := svc.ExpandVolume(testCtx, volumeCR, capacity)
+	err = svc.ExpandVolume(testCtx, volumeCR, capacity)
 	assert.NotNil(t, err)
 	assert.Equal(t, codes.Internal, status.Code(err))
 
@@ -812,6 +1255,7 @@ func TestVolumeOperationsImpl_UpdateCRsAfterVolumeExpansion(t *testing.T) {
 
 	svc.cache.Set(volumeCR.Spec.Id, volumeCR.Namespace)
 	volumeCR.Spec.CSIStatus = apiV1.Failed
+	volumeCR.Spec.StorageClass = apiV1.StorageClassSystemLVG
 	err = svc.k8sClient.CreateCR(testCtx, volumeCR.Spec.Id, volumeCR)
 	volAC := &accrd.AvailableCapacity{
 		TypeMeta:   k8smetav1.TypeMeta{Kind: "AvailableCapacity", APIVersion: apiV1.APIV1Version},
@@ -868,6 +1312,19 @@ func TestVolumeOperationsImpl_UpdateCRsAfterVolumeExpansion(t *testing.T) {
 	assert.Equal(t, apiV1.Created, volumeCR.Spec.CSIStatus)
 }
 
+func getTestSnapshot(csiStatus string) api.Snapshot {
+	return api.Snapshot{
+		Id:           "snapshot-1111",
+		VolumeId:     testVolumeLVG1Name,
+		NodeId:       testVolumeLVG1.Spec.NodeId,
+		Location:     testVolumeLVG1.Spec.Location,
+		StorageClass: testVolumeLVG1.Spec.StorageClass,
+		Size:         capacityplanner.AlignSizeByPE(testVolumeLVG1.Spec.Size),
+		CSIStatus:    csiStatus,
+		CreationTime: time.Now().UnixNano(),
+	}
+}
+
 func getTestACR(size int64, sc, name, podNamespace string,
 	acList []*accrd.AvailableCapacity) *acrcrd.AvailableCapacityReservation {
 	acNames := make([]string, len(acList))
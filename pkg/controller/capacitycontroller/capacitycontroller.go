@@ -234,7 +234,11 @@ func (d *Controller) createOrUpdateLVGCapacity(ctx context.Context, lvg *lvgcrd.
 	ac, err := d.cachedCrHelper.GetACByLocation(location)
 	switch {
 	case err == nil:
-		if ac.Spec.Size != size {
+		switch {
+		// free space of the thin pool and RAID LVG is calculated on the node based on the allocated volumes
+		case util.IsStorageClassLVGThin(ac.Spec.StorageClass) || util.IsStorageClassLVGRaid(ac.Spec.StorageClass):
+			ac.Spec.Size = size
+		case ac.Spec.Size != size:
 			ac.Spec.Size += size
 		}
 		updateAvailableCapacityLabelsWhenNecessary(&drive, ac)
@@ -243,6 +247,10 @@ func (d *Controller) createOrUpdateLVGCapacity(ctx context.Context, lvg *lvgcrd.
 			return err
 		}
 		return nil
+	case err == errTypes.ErrorNotFound && (lvg.Spec.ThinPool != "" || lvg.Spec.RaidType != ""):
+		// AC of the thin or RAID LVG is created by controller during LVG creation, it's not the system LVG
+		ll.Infof("There is no AC for LVG %s", location)
+		return nil
 	case err == errTypes.ErrorNotFound:
 		if size > capacityplanner.AcSizeMinThresholdBytes {
 			ac, err := d.cachedCrHelper.GetACByLocation(driveUUID)
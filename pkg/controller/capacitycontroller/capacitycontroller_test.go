@@ -640,6 +640,56 @@ func TestController_createOrUpdateLVGCapacity(t *testing.T) {
 		err = controller.createOrUpdateLVGCapacity(k8s.UpdateFailCtx, &testLVG, testLVG.Spec.Size)
 		assert.NotNil(t, err)
 	})
+
+	t.Run("Thin LVG AC size is replaced", func(t *testing.T) {
+		kubeClient, err := k8s.GetFakeKubeClient(ns, testLogger)
+		assert.Nil(t, err)
+		controller := NewCapacityController(kubeClient, kubeClient, testLogger)
+		assert.NotNil(t, controller)
+		testLVG := lvgCR1.DeepCopy()
+		testLVG.Spec.ThinPool = apiV1.ThinPoolName
+		err = kubeClient.Create(tCtx, testLVG)
+		assert.Nil(t, err)
+		testAC := acCR1.DeepCopy()
+		testAC.Spec.Location = testLVG.Name
+		testAC.Spec.StorageClass = apiV1.StorageClassHDDLVGThin
+		testAC.Spec.Size = int64(10 * util.GBYTE)
+		err = kubeClient.Create(tCtx, testAC)
+		assert.Nil(t, err)
+
+		err = controller.createOrUpdateLVGCapacity(tCtx, testLVG, int64(3*util.GBYTE))
+		assert.Nil(t, err)
+		acList := &accrd.AvailableCapacityList{}
+		err = kubeClient.ReadList(tCtx, acList)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(acList.Items))
+		assert.Equal(t, int64(3*util.GBYTE), acList.Items[0].Spec.Size)
+	})
+
+	t.Run("RAID LVG AC size is replaced", func(t *testing.T) {
+		kubeClient, err := k8s.GetFakeKubeClient(ns, testLogger)
+		assert.Nil(t, err)
+		controller := NewCapacityController(kubeClient, kubeClient, testLogger)
+		assert.NotNil(t, controller)
+		testLVG := lvgCR1.DeepCopy()
+		testLVG.Spec.RaidType = apiV1.RaidType1
+		err = kubeClient.Create(tCtx, testLVG)
+		assert.Nil(t, err)
+		testAC := acCR1.DeepCopy()
+		testAC.Spec.Location = testLVG.Name
+		testAC.Spec.StorageClass = apiV1.StorageClassHDDLVGRaid1
+		testAC.Spec.Size = int64(10 * util.GBYTE)
+		err = kubeClient.Create(tCtx, testAC)
+		assert.Nil(t, err)
+
+		err = controller.createOrUpdateLVGCapacity(tCtx, testLVG, int64(4*util.GBYTE))
+		assert.Nil(t, err)
+		acList := &accrd.AvailableCapacityList{}
+		err = kubeClient.ReadList(tCtx, acList)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(acList.Items))
+		assert.Equal(t, int64(4*util.GBYTE), acList.Items[0].Spec.Size)
+	})
 }
 func TestController_createOrUpdateCapacity(t *testing.T) {
 	t.Run("UpdateCR failure", func(t *testing.T) {
@@ -19,7 +19,10 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,15 +34,21 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	k8sError "k8s.io/apimachinery/pkg/api/errors"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/cache"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cgroup"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/common"
 	"github.com/dell/csi-baremetal/pkg/controller/mountoptions"
@@ -58,6 +67,26 @@ const (
 	RawPartModeValue = "true"
 )
 
+// EncryptionSecretKey is a parameter key of the Secret with the volume encryption key,
+// value is namespace/name of the Secret or name of the Secret in the PVC namespace
+const EncryptionSecretKey = "encryptionSecret"
+
+// WipePolicyKey is a parameter key of the policy of the volume data destruction on release
+const WipePolicyKey = "wipePolicy"
+
+// MkfsOptionsKey is a parameter key of the additional mkfs options, value is space separated options
+// which are validated against allowlist of the file system
+const MkfsOptionsKey = "mkfsOptions"
+
+// Parameter keys of the volume IO limits, values are k8s quantities. Limits are applied to the pods
+// with cgroup v2 io.max and could be overridden by PVC annotations
+const (
+	ReadBPSKey   = "readBPS"
+	WriteBPSKey  = "writeBPS"
+	ReadIOPSKey  = "readIOPS"
+	WriteIOPSKey = "writeIOPS"
+)
+
 // CSIControllerService is the implementation of ControllerServer interface from GO CSI specification
 type CSIControllerService struct {
 	k8sclient *k8s.KubeClient
@@ -68,6 +97,8 @@ type CSIControllerService struct {
 	log *logrus.Entry
 
 	svc common.VolumeOperations
+	// snapshot operations
+	snapshotSvc common.SnapshotOperations
 
 	// to track node health status
 	nodeServicesStateMonitor *node.ServicesStateMonitor
@@ -91,6 +122,7 @@ func NewControllerService(k8sClient *k8s.KubeClient, logger *logrus.Logger,
 		k8sclient:                k8sClient,
 		log:                      logger.WithField("component", "CSIControllerService"),
 		svc:                      common.NewVolumeOperationsImpl(k8sClient, logger, cache.NewMemCache(), featureConf),
+		snapshotSvc:              common.NewSnapshotOperationsImpl(k8sClient, logger),
 		nodeServicesStateMonitor: node.NewNodeServicesStateMonitor(k8sClient, logger),
 		IdentityServer:           NewIdentityServer(base.PluginName, base.PluginVersion),
 		crHelper:                 k8s.NewCRHelperImpl(k8sClient, logger),
@@ -195,12 +227,22 @@ func (c *CSIControllerService) CreateVolume(ctx context.Context, req *csi.Create
 	}
 
 	var (
-		fsType   string
-		mode     string
-		vol      *api.Volume
-		ctxValue = context.WithValue(ctx, util.VolumeInfoKey, volumeInfo)
+		fsType       string
+		mode         string
+		vol          *api.Volume
+		storageClass = util.ConvertStorageClass(req.Parameters[base.StorageTypeKey])
+		wipePolicy   = strings.ToLower(req.Parameters[WipePolicyKey])
+		ctxValue     = context.WithValue(ctx, util.VolumeInfoKey, volumeInfo)
 	)
 
+	if !util.IsWipePolicySupported(wipePolicy) {
+		return nil, status.Errorf(codes.InvalidArgument, "Wipe policy %s isn't supported", wipePolicy)
+	}
+	if util.IsWipePolicyForDrive(wipePolicy) && util.IsStorageClassLVG(storageClass) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Wipe policy %s isn't applicable to storage class %s", wipePolicy, storageClass)
+	}
+
 	if len(req.GetVolumeCapabilities()) == 0 {
 		err = fmt.Errorf("volume capabilities are empty: %+v", req.GetVolumeCapabilities())
 		ll.Errorf("Failed to create volume: %v", err)
@@ -218,10 +260,14 @@ func (c *CSIControllerService) CreateVolume(ctx context.Context, req *csi.Create
 		mode = apiV1.ModeFS
 
 		// check mountFlags
-		if !mountoptions.IsOptionsSupported(accessType.Mount.GetMountFlags()) {
-			err = fmt.Errorf("mountOptions are not supported: %+v", accessType.Mount.GetMountFlags())
+		if err = mountoptions.ValidateOptions(fsType, accessType.Mount.GetMountFlags()); err != nil {
+			ll.Errorf("Failed to create volume: %v", err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		// check mkfs options
+		if _, err = fs.ParseMkfsOptions(fs.FileSystem(fsType), req.Parameters[MkfsOptionsKey]); err != nil {
 			ll.Errorf("Failed to create volume: %v", err)
-			return nil, err
+			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
 	}
 
@@ -230,18 +276,57 @@ func (c *CSIControllerService) CreateVolume(ctx context.Context, req *csi.Create
 		mode = apiV1.ModeRAWPART
 	}
 
+	ioLimits, err := cgroup.NewIOLimits(req.Parameters[ReadBPSKey], req.Parameters[WriteBPSKey],
+		req.Parameters[ReadIOPSKey], req.Parameters[WriteIOPSKey])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	stripes, stripeSize, err := getStripes(storageClass, req.GetParameters())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cacheSize, cacheMode, err := getCache(storageClass, req.GetParameters())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// volume could be cloned from another volume or restored from snapshot
+	var sourceVolumeID, sourceSnapshotID string
+	if source := req.GetVolumeContentSource(); source != nil {
+		switch {
+		case source.GetVolume() != nil:
+			sourceVolumeID = source.GetVolume().GetVolumeId()
+		case source.GetSnapshot() != nil:
+			sourceSnapshotID = source.GetSnapshot().GetSnapshotId()
+		}
+		if sourceVolumeID == "" && sourceSnapshotID == "" {
+			return nil, status.Error(codes.InvalidArgument, "Volume content source is empty")
+		}
+		ll.Infof("Volume content source was provided: volume - %s, snapshot - %s", sourceVolumeID, sourceSnapshotID)
+	}
+
 	// try to acquire lock until context is valid. otherwise provisioner will send new request for the same volume
 	if ok := c.reqLock.TryLockWithContext(ctxValue); !ok {
 		ll.Warningf("Context canceled or timed out")
 		return nil, status.Error(codes.DeadlineExceeded, "Context canceled or timed out")
 	}
 	vol, err = c.svc.CreateVolume(ctxValue, api.Volume{
-		Id:           req.Name,
-		StorageClass: util.ConvertStorageClass(req.Parameters[base.StorageTypeKey]),
-		NodeId:       preferredNode,
-		Size:         req.GetCapacityRange().GetRequiredBytes(),
-		Mode:         mode,
-		Type:         fsType,
+		Id:               req.Name,
+		StorageClass:     storageClass,
+		NodeId:           preferredNode,
+		Size:             req.GetCapacityRange().GetRequiredBytes(),
+		Mode:             mode,
+		Type:             fsType,
+		SourceVolumeId:   sourceVolumeID,
+		SourceSnapshotId: sourceSnapshotID,
+		EncryptionSecret: getEncryptionSecret(req.GetParameters(), volumeInfo),
+		WipePolicy:       wipePolicy,
+		MkfsOptions:      mkfsOptions(mode, req.GetParameters()),
+		IOLimits:         ioLimits.String(),
+		Stripes:          stripes,
+		StripeSize:       stripeSize,
+		CacheSize:        cacheSize,
+		CacheMode:        cacheMode,
 	})
 	c.reqLock.Unlock()
 
@@ -267,6 +352,7 @@ func (c *CSIControllerService) CreateVolume(ctx context.Context, req *csi.Create
 			VolumeId:           req.Name,
 			CapacityBytes:      vol.Size,
 			VolumeContext:      req.GetParameters(),
+			ContentSource:      req.GetVolumeContentSource(),
 			AccessibleTopology: topologyList,
 		},
 	}, nil
@@ -341,19 +427,248 @@ func (c *CSIControllerService) ControllerUnpublishVolume(_ context.Context,
 	return nil, status.Error(codes.Unimplemented, "not implemented yet")
 }
 
-// ValidateVolumeCapabilities is not implemented yet
-func (c *CSIControllerService) ValidateVolumeCapabilities(context.Context, *csi.ValidateVolumeCapabilitiesRequest) (*csi.ValidateVolumeCapabilitiesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// ValidateVolumeCapabilities is the implementation of CSI Spec ValidateVolumeCapabilities.
+// Compares requested capabilities with mode, file system type and node of the Volume CR.
+// Capabilities are confirmed only if all of them are supported, otherwise mismatch is reported in message.
+// Receives golang context and CSI Spec ValidateVolumeCapabilitiesRequest
+// Returns CSI Spec ValidateVolumeCapabilitiesResponse or error if something went wrong
+func (c *CSIControllerService) ValidateVolumeCapabilities(_ context.Context,
+	req *csi.ValidateVolumeCapabilitiesRequest) (*csi.ValidateVolumeCapabilitiesResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":   "ValidateVolumeCapabilities",
+		"volumeID": req.GetVolumeId(),
+	})
+	ll.Debugf("Processing request: %v", req)
+
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID must be provided")
+	}
+	if len(req.GetVolumeCapabilities()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume capabilities missing in request")
+	}
+
+	volume, err := c.crHelper.GetVolumeByID(req.GetVolumeId())
+	if err != nil || volume.Spec.CSIStatus == apiV1.Removed {
+		return nil, status.Errorf(codes.NotFound, "volume %s doesn't exist", req.GetVolumeId())
+	}
+
+	for _, capability := range req.GetVolumeCapabilities() {
+		if err = validateVolumeCapability(&volume.Spec, capability); err != nil {
+			ll.Infof("Volume capability %v isn't supported: %v", capability, err)
+			return &csi.ValidateVolumeCapabilitiesResponse{Message: err.Error()}, nil
+		}
+	}
+
+	return &csi.ValidateVolumeCapabilitiesResponse{
+		Confirmed: &csi.ValidateVolumeCapabilitiesResponse_Confirmed{
+			VolumeContext:      req.GetVolumeContext(),
+			VolumeCapabilities: req.GetVolumeCapabilities(),
+			Parameters:         req.GetParameters(),
+		},
+	}, nil
 }
 
-// ListVolumes is not implemented yet
-func (c *CSIControllerService) ListVolumes(context.Context, *csi.ListVolumesRequest) (*csi.ListVolumesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// validateVolumeCapability checks that volume could be used with the capability
+// Returns error with the mismatch description if it couldn't
+func validateVolumeCapability(volume *api.Volume, capability *csi.VolumeCapability) error {
+	// volume is located on the drive of the single node
+	switch mode := capability.GetAccessMode().GetMode(); mode {
+	case csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY,
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_SINGLE_WRITER,
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_MULTI_WRITER:
+	default:
+		return fmt.Errorf("access mode %s is not supported", mode)
+	}
+
+	switch accessType := capability.GetAccessType().(type) {
+	case *csi.VolumeCapability_Block:
+		if volume.Mode != apiV1.ModeRAW && volume.Mode != apiV1.ModeRAWPART {
+			return fmt.Errorf("block access requested for volume in %s mode", volume.Mode)
+		}
+	case *csi.VolumeCapability_Mount:
+		if volume.Mode != apiV1.ModeFS {
+			return fmt.Errorf("mount access requested for volume in %s mode", volume.Mode)
+		}
+		fsType := strings.ToLower(accessType.Mount.GetFsType())
+		if fsType != "" && fsType != strings.ToLower(volume.Type) {
+			return fmt.Errorf("file system %s requested for volume with %s file system", fsType, volume.Type)
+		}
+		if err := mountoptions.ValidateOptions(volume.Type, accessType.Mount.GetMountFlags()); err != nil {
+			return fmt.Errorf("mount flags %v are not supported: %w", accessType.Mount.GetMountFlags(), err)
+		}
+	default:
+		return fmt.Errorf("access type is not specified")
+	}
+	return nil
 }
 
-// GetCapacity is not implemented yet
-func (c *CSIControllerService) GetCapacity(context.Context, *csi.GetCapacityRequest) (*csi.GetCapacityResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// ListVolumes is the implementation of CSI Spec ListVolumes. Returns page of the volumes sorted by ID
+// with nodes where they are published and their condition. Removed volumes are skipped.
+// Receives golang context and CSI Spec ListVolumesRequest
+// Returns CSI Spec ListVolumesResponse or error if something went wrong
+func (c *CSIControllerService) ListVolumes(_ context.Context, req *csi.ListVolumesRequest) (*csi.ListVolumesResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method": "ListVolumes",
+	})
+	ll.Debugf("Processing request: %v", req)
+
+	volumes, err := c.crHelper.GetVolumeCRs()
+	if err != nil {
+		ll.Errorf("Unable to read volumes: %v", err)
+		return nil, status.Error(codes.Internal, "Unable to read volumes")
+	}
+
+	entries := make([]*csi.ListVolumesResponse_Entry, 0, len(volumes))
+	for i := range volumes {
+		if volumes[i].Spec.CSIStatus == apiV1.Removed {
+			continue
+		}
+		entries = append(entries, &csi.ListVolumesResponse_Entry{
+			Volume: convertVolumeToCSI(&volumes[i].Spec),
+			Status: &csi.ListVolumesResponse_VolumeStatus{
+				PublishedNodeIds: getPublishedNodeIDs(&volumes[i].Spec),
+				VolumeCondition:  common.GetVolumeCondition(&volumes[i].Spec),
+			},
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Volume.VolumeId < entries[j].Volume.VolumeId
+	})
+
+	start, end, nextToken, err := paginate(len(entries), req.GetStartingToken(), req.GetMaxEntries())
+	if err != nil {
+		return nil, err
+	}
+
+	return &csi.ListVolumesResponse{
+		Entries:   entries[start:end],
+		NextToken: nextToken,
+	}, nil
+}
+
+// GetCapacity is the implementation of CSI Spec GetCapacity. Sums up AvailableCapacity of the requested topology segment
+// (all nodes if topology isn't set) which is suitable for the requested storage class and isn't reserved by
+// AvailableCapacityReservation. Free space of LVG based ACs is limited by LVGFreeSpaceAnnotation of the LVG CR.
+// Receives golang context and CSI Spec GetCapacityRequest
+// Returns CSI Spec GetCapacityResponse or error if something went wrong
+func (c *CSIControllerService) GetCapacity(ctx context.Context, req *csi.GetCapacityRequest) (*csi.GetCapacityResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method": "GetCapacity",
+	})
+	ll.Debugf("Processing request: %v", req)
+
+	var (
+		sc     = util.ConvertStorageClass(req.GetParameters()[base.StorageTypeKey])
+		nodeID = req.GetAccessibleTopology().GetSegments()[csibmnodeconst.NodeIDTopologyLabelKey]
+	)
+
+	acReader := capacityplanner.NewUnreservedACReader(ll,
+		capacityplanner.NewACReader(c.k8sclient, ll, false),
+		capacityplanner.NewACRReader(c.k8sclient, ll, false))
+	acs, err := acReader.ReadCapacity(ctx)
+	if err != nil {
+		ll.Errorf("Unable to read unreserved available capacity: %v", err)
+		return nil, status.Error(codes.Internal, "unable to read available capacity")
+	}
+
+	lvgFreeSpace, err := c.getLVGFreeSpace(ctx)
+	if err != nil {
+		ll.Errorf("Unable to read LVG CRs: %v", err)
+		return nil, status.Error(codes.Internal, "unable to read logical volume groups")
+	}
+
+	var total, maxSize int64
+	for i := range acs {
+		ac := &acs[i]
+		if nodeID != "" && ac.Spec.NodeId != nodeID {
+			continue
+		}
+		if !isACSuitableForStorageClass(ac, sc) {
+			continue
+		}
+		size := ac.Spec.Size
+		if freeSpace, ok := lvgFreeSpace[ac.Spec.Location]; ok && freeSpace < size {
+			size = freeSpace
+		}
+		if util.IsStorageClassLVG(sc) && !util.IsStorageClassLVG(ac.Spec.StorageClass) {
+			// drive will be added to the new LVG
+			size = capacityplanner.SubtractLVMMetadataSize(size)
+			switch {
+			case util.IsStorageClassLVGThin(sc):
+				// overcommit isn't taken into account until thin pool is created
+				size = capacityplanner.GetThinPoolDataSize(size)
+			case util.IsStorageClassLVGRaid(sc):
+				// each drive holds one image of RAID LV, so only its share of the data is counted
+				layout, _ := util.GetRaidLayout(util.GetRaidType(sc))
+				size = size * int64(layout.Stripes) / int64(layout.Drives)
+			}
+		}
+		if size <= 0 {
+			continue
+		}
+		total += size
+		if size > maxSize {
+			maxSize = size
+		}
+	}
+
+	// MinimumVolumeSize isn't set: drive based volume of any requested size gets the whole drive,
+	// so smaller requests are still satisfied
+	resp := &csi.GetCapacityResponse{
+		AvailableCapacity: total,
+		MaximumVolumeSize: &wrappers.Int64Value{Value: maxSize},
+	}
+
+	ll.Debugf("GetCapacity returns response: %v", resp)
+	return resp, nil
+}
+
+// getLVGFreeSpace returns free space of LVGs taken from LVGFreeSpaceAnnotation, key is the LVG name
+func (c *CSIControllerService) getLVGFreeSpace(ctx context.Context) (map[string]int64, error) {
+	lvgs := &lvgcrd.LogicalVolumeGroupList{}
+	if err := c.k8sclient.ReadList(ctx, lvgs); err != nil {
+		return nil, err
+	}
+
+	freeSpace := make(map[string]int64, len(lvgs.Items))
+	for _, lvg := range lvgs.Items {
+		sizeString, ok := lvg.Annotations[apiV1.LVGFreeSpaceAnnotation]
+		if !ok {
+			continue
+		}
+		size, err := strconv.ParseInt(sizeString, 10, 64)
+		if err != nil {
+			c.log.Warnf("Unable to parse %s annotation of LVG %s: %v", apiV1.LVGFreeSpaceAnnotation, lvg.Name, err)
+			continue
+		}
+		freeSpace[lvg.Name] = size
+	}
+	return freeSpace, nil
+}
+
+// isACSuitableForStorageClass checks whether volume of the storage class sc might be created on AC,
+// follows rules of the capacityplanner: tainted ACs and ACs of the storage groups are skipped,
+// ANY uses all drive based ACs, LVG storage classes use drive based ACs of the same media type as well
+func isACSuitableForStorageClass(ac *accrd.AvailableCapacity, sc string) bool {
+	if effect, ok := ac.Labels[apiV1.DriveTaintKey]; ok && effect == apiV1.DriveTaintValue {
+		return false
+	}
+	if ac.Labels[apiV1.StorageGroupLabelKey] != "" {
+		return false
+	}
+
+	acSC := ac.Spec.StorageClass
+	switch sc {
+	case acSC:
+		return true
+	case apiV1.StorageClassAny:
+		return acSC == apiV1.StorageClassHDD || acSC == apiV1.StorageClassSSD || acSC == apiV1.StorageClassNVMe
+	default:
+		// drive might be added to the new LVG of the LVG based SC
+		subSC := util.GetSubStorageClass(sc)
+		return subSC != "" && acSC == subSC
+	}
 }
 
 // ControllerGetCapabilities is the implementation of CSI Spec ControllerGetCapabilities.
@@ -379,6 +694,14 @@ func (c *CSIControllerService) ControllerGetCapabilities(context.Context, *csi.C
 	for _, c := range []csi.ControllerServiceCapability_RPC_Type{
 		csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
 		csi.ControllerServiceCapability_RPC_EXPAND_VOLUME,
+		csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT,
+		csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
+		csi.ControllerServiceCapability_RPC_CLONE_VOLUME,
+		csi.ControllerServiceCapability_RPC_GET_CAPACITY,
+		csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
+		csi.ControllerServiceCapability_RPC_GET_VOLUME,
+		csi.ControllerServiceCapability_RPC_LIST_VOLUMES_PUBLISHED_NODES,
+		csi.ControllerServiceCapability_RPC_VOLUME_CONDITION,
 	} {
 		caps = append(caps, newCap(c))
 	}
@@ -392,24 +715,186 @@ func (c *CSIControllerService) ControllerGetCapabilities(context.Context, *csi.C
 	return resp, nil
 }
 
-// CreateSnapshot is not implemented yet
-func (c *CSIControllerService) CreateSnapshot(context.Context, *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// CreateSnapshot is the implementation of CSI Spec CreateSnapshot. This method creates Snapshot CR for LVM volume
+// and waits for snapshot to be created by Reconcile loop of appropriate Node.
+// Receives golang context and CSI Spec CreateSnapshotRequest
+// Returns CSI Spec CreateSnapshotResponse or error if something went wrong
+func (c *CSIControllerService) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":     "CreateSnapshot",
+		"snapshotID": req.GetName(),
+	})
+	ll.Infof("Processing request: %v", req)
+
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Snapshot name missing in request")
+	}
+	if req.GetSourceVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Source volume ID missing in request")
+	}
+	ctxWithID := context.WithValue(ctx, base.RequestUUID, req.GetName())
+
+	// try to acquire lock until context is valid. otherwise snapshotter will send new request for the same snapshot
+	if ok := c.reqLock.TryLockWithContext(ctxWithID); !ok {
+		ll.Warningf("Context canceled or timed out")
+		return nil, status.Error(codes.DeadlineExceeded, "Context canceled or timed out")
+	}
+	snapshot, err := c.snapshotSvc.CreateSnapshot(ctxWithID, req.GetName(), req.GetSourceVolumeId())
+	c.reqLock.Unlock()
+
+	if err != nil {
+		ll.Errorf("Failed to create snapshot: %v", err)
+		return nil, err
+	}
+
+	if snapshot.CSIStatus == apiV1.Creating {
+		ll.Infof("Waiting until snapshot will reach Created status. Current status - %s", snapshot.CSIStatus)
+		if err = c.snapshotSvc.WaitSnapshotStatus(ctxWithID, snapshot.Id, apiV1.Failed, apiV1.Created); err != nil {
+			if errors.Is(err, common.ErrSnapshotFailed) {
+				// failed snapshot has no handle, so DeleteSnapshot is never called for it
+				if cleanupErr := c.snapshotSvc.UpdateCRsAfterSnapshotDeletion(ctxWithID, snapshot.Id); cleanupErr != nil {
+					ll.Errorf("Unable to remove failed snapshot: %v", cleanupErr)
+				}
+			}
+			return nil, status.Error(codes.Internal, "Unable to create snapshot")
+		}
+		snapshot.CSIStatus = apiV1.Created
+	}
+
+	return &csi.CreateSnapshotResponse{Snapshot: convertSnapshotToCSI(snapshot)}, nil
 }
 
-// DeleteSnapshot is not implemented yet
-func (c *CSIControllerService) DeleteSnapshot(context.Context, *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// DeleteSnapshot is the implementation of CSI Spec DeleteSnapshot. This method sets Snapshot CR's Spec.CSIStatus
+// to Removing and waits for snapshot to be removed by Reconcile loop of appropriate Node.
+// Receives golang context and CSI Spec DeleteSnapshotRequest
+// Returns CSI Spec DeleteSnapshotResponse or error if something went wrong
+func (c *CSIControllerService) DeleteSnapshot(ctx context.Context, req *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":     "DeleteSnapshot",
+		"snapshotID": req.GetSnapshotId(),
+	})
+	ll.Infof("Processing request: %v", req)
+
+	if req.GetSnapshotId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Snapshot ID must be provided")
+	}
+	ctxWithID := context.WithValue(ctx, base.RequestUUID, req.GetSnapshotId())
+
+	// try to acquire lock until context is valid. otherwise snapshotter will send new request for the same snapshot
+	if ok := c.reqLock.TryLockWithContext(ctxWithID); !ok {
+		ll.Warningf("Context canceled or timed out")
+		return nil, status.Error(codes.DeadlineExceeded, "Context canceled or timed out")
+	}
+	err := c.snapshotSvc.DeleteSnapshot(ctxWithID, req.GetSnapshotId())
+	c.reqLock.Unlock()
+
+	if err != nil {
+		if k8sError.IsNotFound(err) {
+			ll.Infof("Snapshot doesn't exist")
+			return &csi.DeleteSnapshotResponse{}, nil
+		}
+		ll.Errorf("Unable to delete snapshot: %v", err)
+		return nil, err
+	}
+
+	if err = c.snapshotSvc.WaitSnapshotStatus(ctxWithID, req.GetSnapshotId(), apiV1.Failed, apiV1.Removed); err != nil {
+		return nil, status.Error(codes.Internal, "Unable to delete snapshot")
+	}
+
+	// try to acquire lock until context is valid. otherwise snapshotter will send new request for the same snapshot
+	if ok := c.reqLock.TryLockWithContext(ctxWithID); !ok {
+		ll.Warningf("Context canceled or timed out")
+		return nil, status.Error(codes.DeadlineExceeded, "Context canceled or timed out")
+	}
+	err = c.snapshotSvc.UpdateCRsAfterSnapshotDeletion(ctxWithID, req.GetSnapshotId())
+	c.reqLock.Unlock()
+
+	if err != nil {
+		ll.Errorf("Unable to update CRs after snapshot deletion: %s", err)
+		return nil, status.Error(codes.Internal, "Unable to update CRs after snapshot deletion")
+	}
+
+	ll.Debug("Snapshot was successfully deleted")
+
+	return &csi.DeleteSnapshotResponse{}, nil
 }
 
-// ListSnapshots is not implemented yet
-func (c *CSIControllerService) ListSnapshots(context.Context, *csi.ListSnapshotsRequest) (*csi.ListSnapshotsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// ListSnapshots is the implementation of CSI Spec ListSnapshots. Snapshots could be filtered by snapshot ID
+// or by source volume ID, result is paginated with starting_token and max_entries.
+// Receives golang context and CSI Spec ListSnapshotsRequest
+// Returns CSI Spec ListSnapshotsResponse or error if something went wrong
+func (c *CSIControllerService) ListSnapshots(_ context.Context, req *csi.ListSnapshotsRequest) (*csi.ListSnapshotsResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method": "ListSnapshots",
+	})
+	ll.Debugf("Processing request: %v", req)
+
+	var (
+		snapshots []snapshotcrd.Snapshot
+		err       error
+	)
+	if req.GetSourceVolumeId() != "" {
+		snapshots, err = c.crHelper.GetSnapshotCRs(req.GetSourceVolumeId())
+	} else {
+		snapshots, err = c.crHelper.GetSnapshotCRs()
+	}
+	if err != nil {
+		ll.Errorf("Unable to read snapshots: %v", err)
+		return nil, status.Error(codes.Internal, "Unable to read snapshots")
+	}
+
+	entries := make([]*csi.ListSnapshotsResponse_Entry, 0, len(snapshots))
+	for i := range snapshots {
+		if req.GetSnapshotId() != "" && snapshots[i].Spec.Id != req.GetSnapshotId() {
+			continue
+		}
+		if snapshots[i].Spec.CSIStatus == apiV1.Removed {
+			continue
+		}
+		entries = append(entries, &csi.ListSnapshotsResponse_Entry{Snapshot: convertSnapshotToCSI(&snapshots[i].Spec)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Snapshot.SnapshotId < entries[j].Snapshot.SnapshotId
+	})
+
+	start, end, nextToken, err := paginate(len(entries), req.GetStartingToken(), req.GetMaxEntries())
+	if err != nil {
+		return nil, err
+	}
+
+	return &csi.ListSnapshotsResponse{
+		Entries:   entries[start:end],
+		NextToken: nextToken,
+	}, nil
 }
 
-// ControllerGetVolume is not implemented yet
-func (c *CSIControllerService) ControllerGetVolume(_ context.Context, _ *csi.ControllerGetVolumeRequest) (*csi.ControllerGetVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// ControllerGetVolume is the implementation of CSI Spec ControllerGetVolume.
+// Returns the volume with nodes where it is published and its condition
+// Receives golang context and CSI Spec ControllerGetVolumeRequest
+// Returns CSI Spec ControllerGetVolumeResponse or error if something went wrong
+func (c *CSIControllerService) ControllerGetVolume(_ context.Context, req *csi.ControllerGetVolumeRequest) (*csi.ControllerGetVolumeResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":   "ControllerGetVolume",
+		"volumeID": req.GetVolumeId(),
+	})
+	ll.Debugf("Processing request: %v", req)
+
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID must be provided")
+	}
+
+	volume, err := c.crHelper.GetVolumeByID(req.GetVolumeId())
+	if err != nil || volume.Spec.CSIStatus == apiV1.Removed {
+		return nil, status.Errorf(codes.NotFound, "volume %s doesn't exist", req.GetVolumeId())
+	}
+
+	return &csi.ControllerGetVolumeResponse{
+		Volume: convertVolumeToCSI(&volume.Spec),
+		Status: &csi.ControllerGetVolumeResponse_VolumeStatus{
+			PublishedNodeIds: getPublishedNodeIDs(&volume.Spec),
+			VolumeCondition:  common.GetVolumeCondition(&volume.Spec),
+		},
+	}, nil
 }
 
 // ControllerExpandVolume is the implementation of CSI Spec ControllerExpandVolume.
@@ -428,7 +913,7 @@ func (c *CSIControllerService) ControllerExpandVolume(ctx context.Context, req *
 	var (
 		volID         = req.GetVolumeId()
 		ctxWithID     = context.WithValue(ctx, base.RequestUUID, volID)
-		requiredBytes = capacityplanner.AlignSizeByPE(req.GetCapacityRange().GetRequiredBytes())
+		requiredBytes = req.GetCapacityRange().GetRequiredBytes()
 	)
 
 	if volID == "" {
@@ -440,12 +925,21 @@ func (c *CSIControllerService) ControllerExpandVolume(ctx context.Context, req *
 	if err != nil {
 		return nil, status.Error(codes.NotFound, "Volume doesn't exist")
 	}
+	// drive based volume could be expanded up to the drive size which isn't aligned by PE
+	if util.IsStorageClassLVG(volume.Spec.StorageClass) {
+		requiredBytes = capacityplanner.AlignSizeByPE(requiredBytes)
+	}
 	if volume.Spec.Size == requiredBytes || volume.Spec.Size > requiredBytes {
 		return &csi.ControllerExpandVolumeResponse{
 			CapacityBytes:         0,
 			NodeExpansionRequired: false,
 		}, nil
 	}
+	// resize.f2fs works only with unmounted file system, but NodeExpandVolume grows mounted one
+	if fs.FileSystem(volume.Spec.Type) == fs.F2FS &&
+		volume.Spec.Mode != apiV1.ModeRAW && volume.Spec.Mode != apiV1.ModeRAWPART {
+		return nil, status.Errorf(codes.InvalidArgument, "volume with file system %s can't be expanded", volume.Spec.Type)
+	}
 
 	// try to acquire lock until context is valid. otherwise provisioner will send new request for the same volume
 	if ok := c.reqLock.TryLockWithContext(ctxWithID); !ok {
@@ -474,8 +968,9 @@ func (c *CSIControllerService) ControllerExpandVolume(ctx context.Context, req *
 	}
 
 	return &csi.ControllerExpandVolumeResponse{
-		CapacityBytes:         requiredBytes,
-		NodeExpansionRequired: false,
+		CapacityBytes: requiredBytes,
+		// file system must be expanded on the node by NodeExpandVolume
+		NodeExpansionRequired: volume.Spec.Mode != apiV1.ModeRAW && volume.Spec.Mode != apiV1.ModeRAWPART,
 	}, nil
 }
 
@@ -485,3 +980,138 @@ func isNeedForRawPart(params map[string]string) bool {
 	}
 	return false
 }
+
+// mkfsOptions returns additional mkfs options of the volume, file system isn't created for block volumes
+func mkfsOptions(mode string, params map[string]string) string {
+	if mode != apiV1.ModeFS {
+		return ""
+	}
+	return strings.Join(strings.Fields(params[MkfsOptionsKey]), " ")
+}
+
+// getStripes returns number of drives and stripe size of the striped volume from the storage class parameters,
+// number of drives is 0 if it isn't set, in that case all free drives of the storage group are used
+func getStripes(sc string, params map[string]string) (int32, string, error) {
+	if !util.IsStorageClassLVGStriped(sc) {
+		return 0, "", nil
+	}
+	var stripes int64
+	if value, ok := params[base.StripesKey]; ok {
+		var err error
+		if stripes, err = strconv.ParseInt(value, 10, 32); err != nil || stripes < 2 {
+			return 0, "", fmt.Errorf("%s parameter must be a number of drives greater than 1, got %s",
+				base.StripesKey, value)
+		}
+	}
+	value, ok := params[base.StripeSizeKey]
+	if !ok {
+		return int32(stripes), "", nil
+	}
+	// stripe size must be a power of 2 and can't exceed LVM PE size
+	size, err := util.StrToBytes(value)
+	if err != nil || size < int64(4*util.KBYTE) || size > capacityplanner.DefaultPESize || size&(size-1) != 0 {
+		return 0, "", fmt.Errorf("%s parameter must be a power of 2 from 4k to %dk, got %s",
+			base.StripeSizeKey, capacityplanner.DefaultPESize/int64(util.KBYTE), value)
+	}
+	return int32(stripes), fmt.Sprintf("%dk", size/int64(util.KBYTE)), nil
+}
+
+// getCache returns size and mode of the volume cache from the storage class parameters,
+// size is 0 if volume isn't cached. Only thick LVs on HDD can be cached on the SSD or NVMe drives
+func getCache(sc string, params map[string]string) (int64, string, error) {
+	cacheType, ok := params[base.CacheKey]
+	if !ok {
+		return 0, "", nil
+	}
+	if sc != apiV1.StorageClassHDDLVG {
+		return 0, "", fmt.Errorf("volume cache is supported for %s storage class only, got %s",
+			apiV1.StorageClassHDDLVG, sc)
+	}
+	if util.GetCacheStorageClass(cacheType) == "" {
+		return 0, "", fmt.Errorf("%s parameter must be %s or %s, got %s",
+			base.CacheKey, apiV1.DriveTypeSSD, apiV1.DriveTypeNVMe, cacheType)
+	}
+	size, err := util.StrToBytes(params[base.CacheSizeKey])
+	if err != nil || size <= 0 {
+		return 0, "", fmt.Errorf("%s parameter must be a positive size, got %s",
+			base.CacheSizeKey, params[base.CacheSizeKey])
+	}
+	mode := strings.ToLower(params[base.CacheModeKey])
+	switch mode {
+	case "":
+		mode = apiV1.CacheModeWritethrough
+	case apiV1.CacheModeWritethrough, apiV1.CacheModeWriteback:
+	default:
+		return 0, "", fmt.Errorf("%s parameter must be %s or %s, got %s",
+			base.CacheModeKey, apiV1.CacheModeWritethrough, apiV1.CacheModeWriteback, mode)
+	}
+	return capacityplanner.AlignSizeByPE(size), mode, nil
+}
+
+// getEncryptionSecret returns namespace/name of the Secret with the volume encryption key
+// or empty string if volume shouldn't be encrypted
+func getEncryptionSecret(params map[string]string, volumeInfo *util.VolumeInfo) string {
+	secret := params[EncryptionSecretKey]
+	if secret == "" || strings.Contains(secret, "/") {
+		return secret
+	}
+	return volumeInfo.Namespace + "/" + secret
+}
+
+// convertSnapshotToCSI converts api.Snapshot to CSI Spec Snapshot, snapshot is ready to use once it's created
+func convertSnapshotToCSI(snapshot *api.Snapshot) *csi.Snapshot {
+	return &csi.Snapshot{
+		SnapshotId:     snapshot.Id,
+		SourceVolumeId: snapshot.VolumeId,
+		SizeBytes:      snapshot.Size,
+		CreationTime:   timestamppb.New(time.Unix(0, snapshot.CreationTime)),
+		ReadyToUse:     snapshot.CSIStatus == apiV1.Created,
+	}
+}
+
+// convertVolumeToCSI converts api.Volume to CSI Spec Volume, volume is accessible only from its node
+func convertVolumeToCSI(volume *api.Volume) *csi.Volume {
+	return &csi.Volume{
+		VolumeId:      volume.Id,
+		CapacityBytes: volume.Size,
+		AccessibleTopology: []*csi.Topology{
+			{Segments: map[string]string{csibmnodeconst.NodeIDTopologyLabelKey: volume.NodeId}},
+		},
+	}
+}
+
+// getPublishedNodeIDs returns node of the volume if volume is staged or published on it
+func getPublishedNodeIDs(volume *api.Volume) []string {
+	switch volume.CSIStatus {
+	case apiV1.VolumeReady, apiV1.Published:
+		return []string{volume.NodeId}
+	}
+	return nil
+}
+
+// paginate calculates bounds of the page for list requests based on CSI starting token and max entries
+// starting token is an index of the first entry, next token is empty when the last page is returned
+func paginate(total int, startingToken string, maxEntries int32) (int, int, string, error) {
+	if maxEntries < 0 {
+		return 0, 0, "", status.Error(codes.InvalidArgument, "max_entries must not be negative")
+	}
+
+	start := 0
+	if startingToken != "" {
+		var err error
+		if start, err = strconv.Atoi(startingToken); err != nil || start < 0 || start > total {
+			return 0, 0, "", status.Errorf(codes.Aborted, "invalid starting token %s", startingToken)
+		}
+	}
+
+	end := total
+	if maxEntries > 0 && start+int(maxEntries) < total {
+		end = start + int(maxEntries)
+	}
+
+	nextToken := ""
+	if end < total {
+		nextToken = strconv.Itoa(end)
+	}
+	return start, end, nextToken, nil
+}
@@ -19,6 +19,7 @@ package controller
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -35,6 +36,7 @@ import (
 	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
@@ -43,6 +45,7 @@ import (
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/cache"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
@@ -197,6 +200,64 @@ var _ = Describe("CSIControllerService CreateVolume", func() {
 			Expect(err).ToNot(BeNil())
 			Expect(err.Error()).To(ContainSubstring("Volume capabilities missing in request"))
 		})
+		It("Empty volume content source", func() {
+			req := getCreateVolumeRequest("req1", 1024*1024*1024*1024, "", "testClaim", false, false)
+			req.VolumeContentSource = &csi.VolumeContentSource{}
+
+			resp, err := controller.CreateVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Unsupported wipe policy", func() {
+			req := getCreateVolumeRequest("req1", 1024*1024*1024*1024, "", "testClaim", false, false)
+			req.Parameters[WipePolicyKey] = "shred"
+
+			resp, err := controller.CreateVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Invalid IO limit", func() {
+			req := getCreateVolumeRequest("req1", 1024*1024*1024*1024, "", "testClaim", false, false)
+			req.Parameters[WriteIOPSKey] = "-100"
+
+			resp, err := controller.CreateVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Mkfs option isn't allowed", func() {
+			req := getCreateVolumeRequest("req1", 1024*1024*1024*1024, "", "testClaim", false, false)
+			req.Parameters[MkfsOptionsKey] = "-m reflink=1 -f"
+
+			resp, err := controller.CreateVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Drive wipe policy for LVG storage class", func() {
+			req := getCreateVolumeRequest("req1", 1024*1024*1024*1024, "", "testClaim", false, false)
+			req.Parameters[base.StorageTypeKey] = apiV1.StorageClassHDDLVG
+			req.Parameters[WipePolicyKey] = apiV1.WipePolicyNVMeFormat
+
+			resp, err := controller.CreateVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Source volume not found", func() {
+			err := controller.k8sclient.Create(testCtx, testPVC1.DeepCopy())
+			Expect(err).To(BeNil())
+			err = controller.k8sclient.CreateCR(testCtx, testACR1.Name, testACR1.DeepCopy())
+			Expect(err).To(BeNil())
+
+			req := getCreateVolumeRequest("req1", 1024*1024*1024*1024, "", testPVC1Name, false, false)
+			req.VolumeContentSource = &csi.VolumeContentSource{
+				Type: &csi.VolumeContentSource_Volume{
+					Volume: &csi.VolumeContentSource_VolumeSource{VolumeId: "source-volume"},
+				},
+			}
+
+			resp, err := controller.CreateVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
 		It("Reservation not found", func() {
 			req := getCreateVolumeRequest("req1", 1024*1024*1024*1024, "", "testClaim", false, false)
 
@@ -731,6 +792,14 @@ var _ = Describe("CSIControllerService ControllerGetCapabilities", func() {
 			expectedCapabilitiesTypes = []csi.ControllerServiceCapability_RPC_Type{
 				csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
 				csi.ControllerServiceCapability_RPC_EXPAND_VOLUME,
+				csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT,
+				csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
+				csi.ControllerServiceCapability_RPC_CLONE_VOLUME,
+				csi.ControllerServiceCapability_RPC_GET_CAPACITY,
+				csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
+				csi.ControllerServiceCapability_RPC_GET_VOLUME,
+				csi.ControllerServiceCapability_RPC_LIST_VOLUMES_PUBLISHED_NODES,
+				csi.ControllerServiceCapability_RPC_VOLUME_CONDITION,
 			}
 		)
 
@@ -901,6 +970,22 @@ var _ = Describe("CSIControllerService ControllerExpandVolume", func() {
 			Expect(err).To(BeNil())
 			Expect(volumeCrd.Spec.CSIStatus).To(Equal(apiV1.Failed))
 		})
+		It("Volume with f2fs can't be expanded", func() {
+			volumeCrd := &vcrd.Volume{}
+			Expect(controller.k8sclient.ReadCR(testCtx, uuid, testNs, volumeCrd)).To(BeNil())
+			volumeCrd.Spec.Type = string(fs.F2FS)
+			volumeCrd.Spec.Mode = apiV1.ModeFS
+			Expect(controller.k8sclient.UpdateCR(testCtx, volumeCrd)).To(BeNil())
+			resp, err := controller.ControllerExpandVolume(context.Background(),
+				&csi.ControllerExpandVolumeRequest{
+					VolumeId:         uuid,
+					VolumeCapability: &csi.VolumeCapability{},
+					CapacityRange:    &csi.CapacityRange{RequiredBytes: volumeCrd.Spec.Size + int64(util.GBYTE)},
+				})
+
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
 		It("Expand failed", func() {
 			var (
 				err      error
@@ -1028,49 +1113,457 @@ func TestController_UnimplementedMethods(t *testing.T) {
 	controller := newSvc()
 	expected := codes.Unimplemented.String()
 
-	t.Run("ValidateVolumeCapabilities", func(t *testing.T) {
-		_, err := controller.ValidateVolumeCapabilities(testCtx, nil)
+	t.Run("ControllerPublishVolume", func(t *testing.T) {
+		_, err := controller.ControllerPublishVolume(testCtx, nil)
 		assert.True(t, strings.Contains(err.Error(), expected))
 	})
 
-	t.Run("ListVolumes", func(t *testing.T) {
-		_, err := controller.ListVolumes(testCtx, nil)
+	t.Run("ControllerUnpublishVolume", func(t *testing.T) {
+		_, err := controller.ControllerUnpublishVolume(testCtx, nil)
 		assert.True(t, strings.Contains(err.Error(), expected))
 	})
+}
 
-	t.Run("CreateSnapshot", func(t *testing.T) {
-		_, err := controller.CreateSnapshot(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+func TestController_CreateSnapshot(t *testing.T) {
+	controller := newSvc()
+
+	t.Run("Missing name", func(t *testing.T) {
+		_, err := controller.CreateSnapshot(testCtx, &csi.CreateSnapshotRequest{SourceVolumeId: "volume-id"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 	})
 
-	t.Run("DeleteSnapshot", func(t *testing.T) {
-		_, err := controller.DeleteSnapshot(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+	t.Run("Missing source volume", func(t *testing.T) {
+		_, err := controller.CreateSnapshot(testCtx, &csi.CreateSnapshotRequest{Name: "snapshot-id"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 	})
 
-	t.Run("ListSnapshots", func(t *testing.T) {
-		_, err := controller.ListSnapshots(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+	t.Run("Source volume doesn't exist", func(t *testing.T) {
+		_, err := controller.CreateSnapshot(testCtx,
+			&csi.CreateSnapshotRequest{Name: "snapshot-id", SourceVolumeId: "volume-id"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
 	})
 
-	t.Run("ControllerGetVolume", func(t *testing.T) {
-		_, err := controller.ControllerGetVolume(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+	t.Run("Snapshot exists", func(t *testing.T) {
+		snapshot := controller.k8sclient.ConstructSnapshotCR("snapshot-exists", api.Snapshot{
+			Id: "snapshot-exists", VolumeId: "volume-id", Size: 1024, CSIStatus: apiV1.Created,
+		})
+		assert.Nil(t, controller.k8sclient.CreateCR(testCtx, snapshot.Name, snapshot))
+
+		resp, err := controller.CreateSnapshot(testCtx,
+			&csi.CreateSnapshotRequest{Name: snapshot.Name, SourceVolumeId: "volume-id"})
+		assert.Nil(t, err)
+		assert.Equal(t, snapshot.Name, resp.Snapshot.SnapshotId)
+		assert.Equal(t, "volume-id", resp.Snapshot.SourceVolumeId)
+		assert.True(t, resp.Snapshot.ReadyToUse)
 	})
+}
 
-	t.Run("GetCapacity", func(t *testing.T) {
-		_, err := controller.GetCapacity(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+func TestController_DeleteSnapshot(t *testing.T) {
+	controller := newSvc()
+
+	t.Run("Missing snapshot ID", func(t *testing.T) {
+		_, err := controller.DeleteSnapshot(testCtx, &csi.DeleteSnapshotRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 	})
 
-	t.Run("ControllerPublishVolume", func(t *testing.T) {
-		_, err := controller.ControllerPublishVolume(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+	t.Run("Snapshot doesn't exist", func(t *testing.T) {
+		resp, err := controller.DeleteSnapshot(testCtx, &csi.DeleteSnapshotRequest{SnapshotId: "snapshot-id"})
+		assert.Nil(t, err)
+		assert.NotNil(t, resp)
 	})
+}
 
-	t.Run("ControllerUnpublishVolume", func(t *testing.T) {
-		_, err := controller.ControllerUnpublishVolume(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+func TestController_GetCapacity(t *testing.T) {
+	controller := newSvc()
+	lvg := &lvgcrd.LogicalVolumeGroup{
+		TypeMeta: k8smetav1.TypeMeta{Kind: "LogicalVolumeGroup", APIVersion: apiV1.APIV1Version},
+		ObjectMeta: k8smetav1.ObjectMeta{
+			Name:        testDriveLocation4,
+			Annotations: map[string]string{apiV1.LVGFreeSpaceAnnotation: strconv.FormatInt(50*int64(util.GBYTE), 10)},
+		},
+		Spec: api.LogicalVolumeGroup{Name: testDriveLocation4, Node: testNode2Name},
+	}
+	taintedAC := testAC2.DeepCopy()
+	taintedAC.Name = "tainted-ac"
+	taintedAC.Labels = map[string]string{apiV1.DriveTaintKey: apiV1.DriveTaintValue}
+	for _, obj := range []k8sClient.Object{testAC1.DeepCopy(), testACR1.DeepCopy(), testAC2.DeepCopy(),
+		testAC3.DeepCopy(), taintedAC, lvg} {
+		obj.SetResourceVersion("")
+		assert.Nil(t, controller.k8sclient.CreateCR(testCtx, obj.GetName(), obj))
+	}
+	getRequest := func(sc, node string) *csi.GetCapacityRequest {
+		req := &csi.GetCapacityRequest{Parameters: map[string]string{base.StorageTypeKey: sc}}
+		if node != "" {
+			req.AccessibleTopology = &csi.Topology{
+				Segments: map[string]string{csibmnodeconst.NodeIDTopologyLabelKey: node},
+			}
+		}
+		return req
+	}
+
+	t.Run("Reserved AC is skipped", func(t *testing.T) {
+		resp, err := controller.GetCapacity(testCtx, getRequest(apiV1.StorageClassHDD, testNode1Name))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), resp.AvailableCapacity)
+	})
+
+	t.Run("Drive based storage class", func(t *testing.T) {
+		resp, err := controller.GetCapacity(testCtx, getRequest(apiV1.StorageClassHDD, testNode2Name))
+		assert.Nil(t, err)
+		assert.Equal(t, testAC2.Spec.Size, resp.AvailableCapacity)
+		assert.Equal(t, testAC2.Spec.Size, resp.MaximumVolumeSize.GetValue())
+		assert.Nil(t, resp.MinimumVolumeSize)
+	})
+
+	t.Run("LVG storage class", func(t *testing.T) {
+		resp, err := controller.GetCapacity(testCtx, getRequest(apiV1.StorageClassHDDLVG, testNode2Name))
+		assert.Nil(t, err)
+		lvgFreeSpace := 50 * int64(util.GBYTE)
+		driveSpace := capacityplanner.SubtractLVMMetadataSize(testAC2.Spec.Size)
+		assert.Equal(t, lvgFreeSpace+driveSpace, resp.AvailableCapacity)
+		assert.Equal(t, driveSpace, resp.MaximumVolumeSize.GetValue())
+		assert.Nil(t, resp.MinimumVolumeSize)
+	})
+
+	t.Run("LVG thin storage class", func(t *testing.T) {
+		resp, err := controller.GetCapacity(testCtx, getRequest(apiV1.StorageClassHDDLVGThin, testNode2Name))
+		assert.Nil(t, err)
+		driveSpace := capacityplanner.GetThinPoolDataSize(capacityplanner.SubtractLVMMetadataSize(testAC2.Spec.Size))
+		assert.Equal(t, driveSpace, resp.AvailableCapacity)
+		assert.Nil(t, resp.MinimumVolumeSize)
+	})
+
+	t.Run("LVG RAID storage class", func(t *testing.T) {
+		resp, err := controller.GetCapacity(testCtx, getRequest(apiV1.StorageClassHDDLVGRaid1, testNode2Name))
+		assert.Nil(t, err)
+		// there is no RAID LVG, mirrored drive provides half of its space
+		driveSpace := capacityplanner.SubtractLVMMetadataSize(testAC2.Spec.Size) / 2
+		assert.Equal(t, driveSpace, resp.AvailableCapacity)
+	})
+
+	t.Run("All nodes", func(t *testing.T) {
+		resp, err := controller.GetCapacity(testCtx, getRequest(apiV1.StorageClassAny, ""))
+		assert.Nil(t, err)
+		assert.Equal(t, testAC2.Spec.Size, resp.AvailableCapacity)
+	})
+
+	t.Run("Unknown node", func(t *testing.T) {
+		resp, err := controller.GetCapacity(testCtx, getRequest(apiV1.StorageClassAny, "unknown"))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), resp.AvailableCapacity)
+		assert.Equal(t, int64(0), resp.MaximumVolumeSize.GetValue())
+	})
+}
+
+func TestController_ListVolumes(t *testing.T) {
+	controller := newSvc()
+	for _, v := range []api.Volume{
+		{Id: "volume-3", NodeId: testNode1Name, Size: 100, CSIStatus: apiV1.Created,
+			Health: apiV1.HealthBad, OperationalStatus: apiV1.OperationalStatusOperative},
+		{Id: "volume-1", NodeId: testNode1Name, Size: 200, CSIStatus: apiV1.Published,
+			Health: apiV1.HealthGood, OperationalStatus: apiV1.OperationalStatusOperative},
+		{Id: "volume-2", NodeId: testNode2Name, Size: 300, CSIStatus: apiV1.VolumeReady,
+			Health: apiV1.HealthGood, OperationalStatus: apiV1.OperationalStatusOperative},
+		{Id: "volume-4", NodeId: testNode2Name, Size: 400, CSIStatus: apiV1.Removed},
+	} {
+		volumeCR := controller.k8sclient.ConstructVolumeCR(v.Id, testNs, testAppLabels, v)
+		assert.Nil(t, controller.k8sclient.CreateCR(testCtx, v.Id, volumeCR))
+	}
+
+	t.Run("All volumes", func(t *testing.T) {
+		resp, err := controller.ListVolumes(testCtx, &csi.ListVolumesRequest{})
+		assert.Nil(t, err)
+		assert.Len(t, resp.Entries, 3)
+		assert.Empty(t, resp.NextToken)
+
+		assert.Equal(t, "volume-1", resp.Entries[0].Volume.VolumeId)
+		assert.Equal(t, int64(200), resp.Entries[0].Volume.CapacityBytes)
+		assert.Equal(t, testNode1Name,
+			resp.Entries[0].Volume.AccessibleTopology[0].Segments[csibmnodeconst.NodeIDTopologyLabelKey])
+		assert.Equal(t, []string{testNode1Name}, resp.Entries[0].Status.PublishedNodeIds)
+		assert.False(t, resp.Entries[0].Status.VolumeCondition.Abnormal)
+
+		assert.Equal(t, []string{testNode2Name}, resp.Entries[1].Status.PublishedNodeIds)
+
+		assert.Empty(t, resp.Entries[2].Status.PublishedNodeIds)
+		assert.True(t, resp.Entries[2].Status.VolumeCondition.Abnormal)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		resp, err := controller.ListVolumes(testCtx, &csi.ListVolumesRequest{MaxEntries: 2})
+		assert.Nil(t, err)
+		assert.Len(t, resp.Entries, 2)
+		assert.Equal(t, "2", resp.NextToken)
+
+		resp, err = controller.ListVolumes(testCtx, &csi.ListVolumesRequest{MaxEntries: 2, StartingToken: resp.NextToken})
+		assert.Nil(t, err)
+		assert.Len(t, resp.Entries, 1)
+		assert.Equal(t, "volume-3", resp.Entries[0].Volume.VolumeId)
+		assert.Empty(t, resp.NextToken)
+	})
+
+	t.Run("Invalid starting token", func(t *testing.T) {
+		_, err := controller.ListVolumes(testCtx, &csi.ListVolumesRequest{StartingToken: "abc"})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("Invalid max entries", func(t *testing.T) {
+		_, err := controller.ListVolumes(testCtx, &csi.ListVolumesRequest{MaxEntries: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestController_ControllerGetVolume(t *testing.T) {
+	controller := newSvc()
+	for _, v := range []api.Volume{
+		{Id: "volume-1", NodeId: testNode1Name, Size: 200, CSIStatus: apiV1.Published,
+			Health: apiV1.HealthSuspect, OperationalStatus: apiV1.OperationalStatusOperative},
+		{Id: "volume-2", NodeId: testNode2Name, Size: 400, CSIStatus: apiV1.Removed},
+	} {
+		volumeCR := controller.k8sclient.ConstructVolumeCR(v.Id, testNs, testAppLabels, v)
+		assert.Nil(t, controller.k8sclient.CreateCR(testCtx, v.Id, volumeCR))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		resp, err := controller.ControllerGetVolume(testCtx, &csi.ControllerGetVolumeRequest{VolumeId: "volume-1"})
+		assert.Nil(t, err)
+		assert.Equal(t, "volume-1", resp.Volume.VolumeId)
+		assert.Equal(t, int64(200), resp.Volume.CapacityBytes)
+		assert.Equal(t, []string{testNode1Name}, resp.Status.PublishedNodeIds)
+		assert.True(t, resp.Status.VolumeCondition.Abnormal)
+	})
+
+	t.Run("Empty volume ID", func(t *testing.T) {
+		_, err := controller.ControllerGetVolume(testCtx, &csi.ControllerGetVolumeRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Volume not found", func(t *testing.T) {
+		_, err := controller.ControllerGetVolume(testCtx, &csi.ControllerGetVolumeRequest{VolumeId: "unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = controller.ControllerGetVolume(testCtx, &csi.ControllerGetVolumeRequest{VolumeId: "volume-2"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestController_ValidateVolumeCapabilities(t *testing.T) {
+	controller := newSvc()
+	for _, v := range []api.Volume{
+		{Id: "fs-volume", NodeId: testNode1Name, Mode: apiV1.ModeFS, Type: "xfs", CSIStatus: apiV1.Created},
+		{Id: "raw-volume", NodeId: testNode1Name, Mode: apiV1.ModeRAWPART, CSIStatus: apiV1.Created},
+	} {
+		volumeCR := controller.k8sclient.ConstructVolumeCR(v.Id, testNs, testAppLabels, v)
+		assert.Nil(t, controller.k8sclient.CreateCR(testCtx, v.Id, volumeCR))
+	}
+	var (
+		singleWriter = &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER}
+		getMountCap  = func(fsType string, mountFlags ...string) *csi.VolumeCapability {
+			return &csi.VolumeCapability{
+				AccessMode: singleWriter,
+				AccessType: &csi.VolumeCapability_Mount{
+					Mount: &csi.VolumeCapability_MountVolume{FsType: fsType, MountFlags: mountFlags},
+				},
+			}
+		}
+		blockCap = &csi.VolumeCapability{
+			AccessMode: singleWriter,
+			AccessType: &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}},
+		}
+		validate = func(volumeID string, caps ...*csi.VolumeCapability) *csi.ValidateVolumeCapabilitiesResponse {
+			resp, err := controller.ValidateVolumeCapabilities(testCtx, &csi.ValidateVolumeCapabilitiesRequest{
+				VolumeId:           volumeID,
+				VolumeCapabilities: caps,
+			})
+			assert.Nil(t, err)
+			return resp
+		}
+	)
+
+	t.Run("Confirmed", func(t *testing.T) {
+		resp := validate("fs-volume", getMountCap("XFS", "noatime"), getMountCap(""))
+		assert.NotNil(t, resp.Confirmed)
+		assert.Len(t, resp.Confirmed.VolumeCapabilities, 2)
+
+		resp = validate("raw-volume", blockCap)
+		assert.NotNil(t, resp.Confirmed)
+	})
+
+	t.Run("Access type mismatch", func(t *testing.T) {
+		resp := validate("fs-volume", blockCap)
+		assert.Nil(t, resp.Confirmed)
+		assert.Contains(t, resp.Message, apiV1.ModeFS)
+
+		resp = validate("raw-volume", getMountCap("xfs"))
+		assert.Nil(t, resp.Confirmed)
+		assert.Contains(t, resp.Message, apiV1.ModeRAWPART)
+	})
+
+	t.Run("File system mismatch", func(t *testing.T) {
+		resp := validate("fs-volume", getMountCap("ext4"))
+		assert.Nil(t, resp.Confirmed)
+		assert.Contains(t, resp.Message, "ext4")
+	})
+
+	t.Run("Unsupported mount flags", func(t *testing.T) {
+		resp := validate("fs-volume", getMountCap("xfs", "unknown"))
+		assert.Nil(t, resp.Confirmed)
+		assert.Contains(t, resp.Message, "unknown")
+	})
+
+	t.Run("Unsupported access mode", func(t *testing.T) {
+		capability := getMountCap("xfs")
+		capability.AccessMode = &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER}
+		resp := validate("fs-volume", getMountCap("xfs"), capability)
+		assert.Nil(t, resp.Confirmed)
+		assert.Contains(t, resp.Message, csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER.String())
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		_, err := controller.ValidateVolumeCapabilities(testCtx, &csi.ValidateVolumeCapabilitiesRequest{
+			VolumeCapabilities: []*csi.VolumeCapability{blockCap}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = controller.ValidateVolumeCapabilities(testCtx, &csi.ValidateVolumeCapabilitiesRequest{VolumeId: "fs-volume"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Volume not found", func(t *testing.T) {
+		_, err := controller.ValidateVolumeCapabilities(testCtx, &csi.ValidateVolumeCapabilitiesRequest{
+			VolumeId: "unknown", VolumeCapabilities: []*csi.VolumeCapability{blockCap}})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestController_mkfsOptions(t *testing.T) {
+	params := map[string]string{MkfsOptionsKey: " -m reflink=1   -K "}
+	assert.Equal(t, "-m reflink=1 -K", mkfsOptions(apiV1.ModeFS, params))
+	assert.Equal(t, "", mkfsOptions(apiV1.ModeRAW, params))
+	assert.Equal(t, "", mkfsOptions(apiV1.ModeFS, map[string]string{}))
+}
+
+func TestController_getStripes(t *testing.T) {
+	stripes, stripeSize, err := getStripes(apiV1.StorageClassHDDLVGStriped,
+		map[string]string{base.StripesKey: "3", base.StripeSizeKey: "64Ki"})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), stripes)
+	assert.Equal(t, "64k", stripeSize)
+
+	// all drives of the storage group
+	stripes, stripeSize, err = getStripes(apiV1.StorageClassSSDLVGStriped, map[string]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), stripes)
+	assert.Empty(t, stripeSize)
+
+	// parameters are ignored for the other storage classes
+	stripes, _, err = getStripes(apiV1.StorageClassHDDLVG, map[string]string{base.StripesKey: "3"})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), stripes)
+
+	_, _, err = getStripes(apiV1.StorageClassHDDLVGStriped, map[string]string{base.StripesKey: "1"})
+	assert.NotNil(t, err)
+	_, _, err = getStripes(apiV1.StorageClassHDDLVGStriped, map[string]string{base.StripeSizeKey: "48k"})
+	assert.NotNil(t, err)
+	_, _, err = getStripes(apiV1.StorageClassHDDLVGStriped, map[string]string{base.StripeSizeKey: "8m"})
+	assert.NotNil(t, err)
+}
+
+func TestController_getCache(t *testing.T) {
+	size, mode, err := getCache(apiV1.StorageClassHDDLVG,
+		map[string]string{base.CacheKey: "ssd", base.CacheSizeKey: "10Gi", base.CacheModeKey: "WriteBack"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10*util.GBYTE), size)
+	assert.Equal(t, apiV1.CacheModeWriteback, mode)
+
+	// volume isn't cached
+	size, mode, err = getCache(apiV1.StorageClassHDDLVG, map[string]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), size)
+	assert.Empty(t, mode)
+
+	// writethrough by default, size is aligned by PE
+	size, mode, err = getCache(apiV1.StorageClassHDDLVG, map[string]string{base.CacheKey: "nvme", base.CacheSizeKey: "1M"})
+	assert.Nil(t, err)
+	assert.Equal(t, capacityplanner.DefaultPESize, size)
+	assert.Equal(t, apiV1.CacheModeWritethrough, mode)
+
+	_, _, err = getCache(apiV1.StorageClassHDDLVGThin, map[string]string{base.CacheKey: "ssd", base.CacheSizeKey: "1Gi"})
+	assert.NotNil(t, err)
+	_, _, err = getCache(apiV1.StorageClassHDDLVG, map[string]string{base.CacheKey: "hdd", base.CacheSizeKey: "1Gi"})
+	assert.NotNil(t, err)
+	_, _, err = getCache(apiV1.StorageClassHDDLVG, map[string]string{base.CacheKey: "ssd"})
+	assert.NotNil(t, err)
+	_, _, err = getCache(apiV1.StorageClassHDDLVG,
+		map[string]string{base.CacheKey: "ssd", base.CacheSizeKey: "1Gi", base.CacheModeKey: "writearound"})
+	assert.NotNil(t, err)
+}
+
+func TestController_getEncryptionSecret(t *testing.T) {
+	volumeInfo := &util.VolumeInfo{Namespace: "pvc-ns", Name: "pvc"}
+
+	assert.Equal(t, "", getEncryptionSecret(map[string]string{}, volumeInfo))
+	assert.Equal(t, "pvc-ns/volume-key",
+		getEncryptionSecret(map[string]string{EncryptionSecretKey: "volume-key"}, volumeInfo))
+	assert.Equal(t, "keys/volume-key",
+		getEncryptionSecret(map[string]string{EncryptionSecretKey: "keys/volume-key"}, volumeInfo))
+}
+
+func TestController_ListSnapshots(t *testing.T) {
+	controller := newSvc()
+	for _, s := range []api.Snapshot{
+		{Id: "snapshot-3", VolumeId: "volume-2", CSIStatus: apiV1.Creating},
+		{Id: "snapshot-1", VolumeId: "volume-1", CSIStatus: apiV1.Created},
+		{Id: "snapshot-2", VolumeId: "volume-1", CSIStatus: apiV1.Created},
+		{Id: "snapshot-4", VolumeId: "volume-2", CSIStatus: apiV1.Removed},
+	} {
+		assert.Nil(t, controller.k8sclient.CreateCR(testCtx, s.Id, controller.k8sclient.ConstructSnapshotCR(s.Id, s)))
+	}
+
+	t.Run("All snapshots", func(t *testing.T) {
+		resp, err := controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{})
+		assert.Nil(t, err)
+		assert.Len(t, resp.Entries, 3)
+		assert.Equal(t, "snapshot-1", resp.Entries[0].Snapshot.SnapshotId)
+		assert.Empty(t, resp.NextToken)
+	})
+
+	t.Run("Filter by snapshot ID", func(t *testing.T) {
+		resp, err := controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{SnapshotId: "snapshot-2"})
+		assert.Nil(t, err)
+		assert.Len(t, resp.Entries, 1)
+		assert.Equal(t, "snapshot-2", resp.Entries[0].Snapshot.SnapshotId)
+	})
+
+	t.Run("Filter by source volume", func(t *testing.T) {
+		resp, err := controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{SourceVolumeId: "volume-2"})
+		assert.Nil(t, err)
+		assert.Len(t, resp.Entries, 1)
+		assert.Equal(t, "snapshot-3", resp.Entries[0].Snapshot.SnapshotId)
+		assert.False(t, resp.Entries[0].Snapshot.ReadyToUse)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		resp, err := controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{MaxEntries: 2})
+		assert.Nil(t, err)
+		assert.Len(t, resp.Entries, 2)
+		assert.Equal(t, "2", resp.NextToken)
+
+		resp, err = controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{MaxEntries: 2, StartingToken: resp.NextToken})
+		assert.Nil(t, err)
+		assert.Len(t, resp.Entries, 1)
+		assert.Equal(t, "snapshot-3", resp.Entries[0].Snapshot.SnapshotId)
+		assert.Empty(t, resp.NextToken)
+	})
+
+	t.Run("Invalid starting token", func(t *testing.T) {
+		_, err := controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{StartingToken: "abc"})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		_, err = controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{StartingToken: "10"})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("Invalid max entries", func(t *testing.T) {
+		_, err := controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{MaxEntries: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 	})
 }
 
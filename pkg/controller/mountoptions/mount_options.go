@@ -9,66 +9,134 @@ package mountoptions
 		  name: sc1
 		mountOptions:
 		  - noatime
+		  - logbsize=256k
 */
 
-// MountOptionType type to clarify option purpose
-type MountOptionType string
+import (
+	"fmt"
+	"regexp"
+	"strings"
 
-const (
-	// PublishCmdOpt are options for mount func on NodePublishRequest
-	// Example: mount -o <PublishCmdOpt> /src /dst
-	PublishCmdOpt = MountOptionType("cmdOpt")
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 )
 
 // MountOption describes mount option
 type MountOption struct {
-	arg       string
-	mountType MountOptionType
+	// valuePattern is a pattern of the option value, option without value if nil
+	valuePattern *regexp.Regexp
+	// fileSystems which support option, option is supported by all file systems if empty
+	fileSystems []fs.FileSystem
 }
 
-const (
-	noatimeOpt = "noatime"
+// optionValueSeparator separates name and value of the option, e.g. logbsize=256k
+const optionValueSeparator = "="
+
+var (
+	extFileSystems = []fs.FileSystem{fs.EXT3, fs.EXT4}
+	xfsFileSystems = []fs.FileSystem{fs.XFS}
+
+	numberValue = regexp.MustCompile(`^[0-9]+$`)
+	sizeValue   = regexp.MustCompile(`^[0-9]+[kKmMgG]?$`)
 )
 
 var (
 	// supportedMountOption contains all supported options
-	// map[optName]{optArg, optType}
-	// optName - passed from SC
-	// optArg - cmd string
+	// map[optName]{optValuePattern, optFileSystems}
+	// optName - passed from SC, option is passed to cmd as is
+	// All options are applied on NodePublishVolume: NodeStageVolume only bind mounts the block device
+	// to the staging file (mount --bind /dev/sdb1 /staging/file), file system is mounted from the staging file
+	// on publish (mount -o <options> /staging/file /target), so there are no stage phase options
 	supportedMountOption = map[string]MountOption{
-		noatimeOpt: {
-			arg:       noatimeOpt,
-			mountType: PublishCmdOpt,
-		},
+		// generic options
+		"noatime":    {},
+		"nodiratime": {},
+		"relatime":   {},
+		"lazytime":   {},
+		"nosuid":     {},
+		"nodev":      {},
+		"noexec":     {},
+		"discard":    {fileSystems: []fs.FileSystem{fs.XFS, fs.EXT4, fs.BTRFS, fs.F2FS}},
+		// xfs options
+		"inode64":   {fileSystems: xfsFileSystems},
+		"largeio":   {fileSystems: xfsFileSystems},
+		"swalloc":   {fileSystems: xfsFileSystems},
+		"logbsize":  {valuePattern: sizeValue, fileSystems: xfsFileSystems},
+		"logbufs":   {valuePattern: regexp.MustCompile(`^[2-8]$`), fileSystems: xfsFileSystems},
+		"allocsize": {valuePattern: sizeValue, fileSystems: xfsFileSystems},
+		// ext options
+		"nobarrier":      {fileSystems: extFileSystems},
+		"data":           {valuePattern: regexp.MustCompile(`^(journal|ordered|writeback)$`), fileSystems: extFileSystems},
+		"commit":         {valuePattern: numberValue, fileSystems: extFileSystems},
+		"stripe":         {valuePattern: numberValue, fileSystems: []fs.FileSystem{fs.EXT4}},
+		"journal_ioprio": {valuePattern: regexp.MustCompile(`^[0-7]$`), fileSystems: []fs.FileSystem{fs.EXT4}},
+		// btrfs options
+		"compress":    {valuePattern: regexp.MustCompile(`^(zlib|lzo|zstd)(:[0-9]+)?$`), fileSystems: []fs.FileSystem{fs.BTRFS}},
+		"ssd":         {fileSystems: []fs.FileSystem{fs.BTRFS}},
+		"space_cache": {valuePattern: regexp.MustCompile(`^v[12]$`), fileSystems: []fs.FileSystem{fs.BTRFS}},
+		// f2fs options
+		"background_gc":      {valuePattern: regexp.MustCompile(`^(on|off|sync)$`), fileSystems: []fs.FileSystem{fs.F2FS}},
+		"compress_algorithm": {valuePattern: regexp.MustCompile(`^(lzo|lz4|zstd|lzo-rle)(:[0-9]+)?$`), fileSystems: []fs.FileSystem{fs.F2FS}},
 	}
 )
 
-// IsOptionSupported returns true if option in supportedMountOption
-func IsOptionSupported(option string) bool {
-	_, ok := supportedMountOption[option]
-	return ok
+// parseOption splits option to name and value, value is empty if option has no value
+func parseOption(option string) (name, value string, hasValue bool) {
+	name, value, hasValue = strings.Cut(option, optionValueSeparator)
+	return
 }
 
-// IsOptionsSupported returns true if all options in supportedMountOption
-func IsOptionsSupported(options []string) bool {
-	for _, option := range options {
-		if !IsOptionSupported(option) {
-			return false
+// isFileSystemSupported returns true if option is applicable to the file system,
+// empty file system means that file system isn't known yet and any registered file system fits
+func (o MountOption) isFileSystemSupported(fsType fs.FileSystem) bool {
+	if len(o.fileSystems) == 0 || fsType == "" {
+		return true
+	}
+	for _, fileSystem := range o.fileSystems {
+		if fileSystem == fsType {
+			return true
 		}
 	}
+	return false
+}
 
-	return true
+// ValidateOption checks that option is registered, its value matches the option and the option is applicable
+// to the file system. File system is case-insensitive, empty file system isn't checked
+// Returns error which describes why option isn't supported
+func ValidateOption(fsType, option string) error {
+	name, value, hasValue := parseOption(option)
+	opt, ok := supportedMountOption[name]
+	if !ok {
+		return fmt.Errorf("mount option %s isn't supported", name)
+	}
+	switch {
+	case opt.valuePattern == nil && hasValue:
+		return fmt.Errorf("mount option %s doesn't accept value", name)
+	case opt.valuePattern != nil && !opt.valuePattern.MatchString(value):
+		return fmt.Errorf("value %q of mount option %s isn't valid", value, name)
+	}
+	if !opt.isFileSystemSupported(fs.FileSystem(strings.ToLower(fsType))) {
+		return fmt.Errorf("mount option %s isn't supported by file system %s", name, fsType)
+	}
+	return nil
 }
 
-// FilterWithType returns all option from list with passed type
-func FilterWithType(mountType MountOptionType, options []string) (filteredOptions []string) {
+// ValidateOptions checks all options with ValidateOption
+// Returns error for the first not supported option
+func ValidateOptions(fsType string, options []string) error {
 	for _, option := range options {
-		if val, ok := supportedMountOption[option]; ok {
-			if mountType == val.mountType {
-				filteredOptions = append(filteredOptions, val.arg)
-			}
+		if err := ValidateOption(fsType, option); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	return
+// IsOptionSupported returns true if option is supported by the file system
+func IsOptionSupported(fsType, option string) bool {
+	return ValidateOption(fsType, option) == nil
+}
+
+// IsOptionsSupported returns true if all options are supported by the file system
+func IsOptionsSupported(fsType string, options []string) bool {
+	return ValidateOptions(fsType, options) == nil
 }
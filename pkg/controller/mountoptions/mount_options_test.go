@@ -0,0 +1,59 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mountoptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOption(t *testing.T) {
+	for _, option := range []string{"noatime", "discard", "inode64", "logbsize=256k", "logbufs=8", "allocsize=64m"} {
+		assert.Nil(t, ValidateOption("xfs", option), option)
+	}
+	for _, option := range []string{"noatime", "discard", "nobarrier", "data=ordered", "commit=30", "stripe=16"} {
+		assert.Nil(t, ValidateOption("EXT4", option), option)
+	}
+	for _, option := range []string{"discard", "compress=zstd:3", "ssd", "space_cache=v2"} {
+		assert.Nil(t, ValidateOption("btrfs", option), option)
+	}
+	assert.Nil(t, ValidateOption("f2fs", "background_gc=on"))
+	// file system isn't known
+	assert.Nil(t, ValidateOption("", "inode64"))
+
+	// not registered
+	assert.NotNil(t, ValidateOption("xfs", "someOpt"))
+	// unexpected value
+	assert.NotNil(t, ValidateOption("xfs", "noatime=1"))
+	// missed or invalid value
+	assert.NotNil(t, ValidateOption("xfs", "logbsize"))
+	assert.NotNil(t, ValidateOption("xfs", "logbufs=9"))
+	assert.NotNil(t, ValidateOption("ext4", "data=unordered"))
+	// not applicable to the file system
+	assert.NotNil(t, ValidateOption("ext4", "inode64"))
+	assert.NotNil(t, ValidateOption("xfs", "data=ordered"))
+	assert.NotNil(t, ValidateOption("ext3", "discard"))
+}
+
+func TestValidateOptions(t *testing.T) {
+	assert.Nil(t, ValidateOptions("xfs", nil))
+	assert.Nil(t, ValidateOptions("xfs", []string{"noatime", "logbsize=256k"}))
+	assert.NotNil(t, ValidateOptions("xfs", []string{"noatime", "nobarrier"}))
+	assert.True(t, IsOptionsSupported("ext4", []string{"noatime", "nobarrier"}))
+	assert.False(t, IsOptionsSupported("ext4", []string{"noatime", "someOpt"}))
+}
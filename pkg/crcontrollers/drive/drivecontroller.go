@@ -159,10 +159,16 @@ func (c *Controller) handleDriveUpdate(ctx context.Context, log *logrus.Entry, d
 		return ignore, err
 	}
 
+	// drive based volumes should be copied to the other drive before release
+	if _, ok := drive.Annotations[apiV1.DriveAnnotationVolumeCopy]; ok {
+		if reserved, err := c.handleVolumesCopy(ctx, log, drive); err != nil || !reserved {
+			return wait, err
+		}
+	}
+
 	// get drive fields
 	usage := drive.Spec.GetUsage()
 	health := drive.Spec.GetHealth()
-	id := drive.Spec.GetUUID()
 
 	// check whether update is required
 	toUpdate := false
@@ -195,7 +201,7 @@ func (c *Controller) handleDriveUpdate(ctx context.Context, log *logrus.Entry, d
 		drive.Spec.Usage = apiV1.DriveUsageRemoving
 
 		// check volumes annotations and update if required
-		volumes, err := c.crHelper.GetVolumesByLocation(ctx, id)
+		volumes, err := c.getDriveVolumes(ctx, drive)
 		if err != nil {
 			return ignore, err
 		}
@@ -277,7 +283,7 @@ func (c *Controller) changeVolumeUsageAfterActionAnnotation(ctx context.Context,
 }
 
 func (c *Controller) handleDriveStatus(ctx context.Context, drive *drivecrd.Drive) error {
-	volumes, err := c.crHelper.GetVolumesByLocation(ctx, drive.Spec.UUID)
+	volumes, err := c.getDriveVolumes(ctx, drive)
 	if err != nil {
 		return err
 	}
@@ -302,6 +308,19 @@ func (c *Controller) handleDriveStatus(ctx context.Context, drive *drivecrd.Driv
 	return nil
 }
 
+// getDriveVolumes returns volumes located on the drive which release/removal depends on.
+// Volumes of RAID LVG are kept on the remaining members, so RAID member drive doesn't wait for them
+func (c *Controller) getDriveVolumes(ctx context.Context, drive *drivecrd.Drive) ([]*volumecrd.Volume, error) {
+	lvg, err := c.crHelper.GetLVGByDrive(ctx, drive.Spec.UUID)
+	if err != nil && err != errTypes.ErrorNotFound {
+		return nil, err
+	}
+	if lvg != nil && lvg.Spec.RaidType != "" {
+		return nil, nil
+	}
+	return c.crHelper.GetVolumesByLocation(ctx, drive.Spec.UUID)
+}
+
 func (c *Controller) getVolsStatuses(volumes []*volumecrd.Volume) map[string]string {
 	statuses := map[string]string{}
 
@@ -381,7 +400,7 @@ func (c *Controller) checkAndPlaceStatusRemoved(drive *drivecrd.Drive) bool {
 
 func (c *Controller) handleDriveUsageReleasing(ctx context.Context, log *logrus.Entry, drive *drivecrd.Drive) (uint8, error) {
 	log.Debugf("releasing drive: %s", drive.Name)
-	volumes, err := c.crHelper.GetVolumesByLocation(ctx, drive.Spec.GetUUID())
+	volumes, err := c.getDriveVolumes(ctx, drive)
 	if err != nil {
 		return ignore, err
 	}
@@ -405,9 +424,101 @@ func (c *Controller) handleDriveUsageReleasing(ctx context.Context, log *logrus.
 	return update, nil
 }
 
+// handleVolumesCopy reserves target drive for each drive based volume of the drive with copy annotation.
+// Target is the drive from annotation or a clean drive of the same type for auto value, data of the volume
+// is copied to it by VolumeManager. Volumes of the BAD drive aren't copied
+// Returns false if there is no target drive for some volume yet
+func (c *Controller) handleVolumesCopy(ctx context.Context, log *logrus.Entry, drive *drivecrd.Drive) (bool, error) {
+	volumes, err := c.crHelper.GetVolumesByLocation(ctx, drive.Spec.UUID)
+	if err != nil {
+		return false, err
+	}
+	for _, vol := range volumes {
+		// data of LVG volumes is moved by LVG migration
+		if vol.Spec.LocationType != apiV1.LocationTypeDrive {
+			continue
+		}
+		if _, ok := vol.Annotations[apiV1.VolumeAnnotationCopyStatus]; ok {
+			continue
+		}
+		if vol.Spec.CSIStatus == apiV1.Removing || vol.Spec.CSIStatus == apiV1.Removed || vol.Spec.CSIStatus == apiV1.Failed {
+			continue
+		}
+		if vol.Annotations == nil {
+			vol.Annotations = make(map[string]string)
+		}
+
+		if drive.Spec.Health == apiV1.HealthBad {
+			vol.Annotations[apiV1.VolumeAnnotationCopyStatus] = apiV1.VolumeCopyFailed
+			c.eventRecorder.Eventf(vol, eventing.VolumeCopyFailed, "Data of volume %s can't be copied from BAD drive, %s",
+				vol.Name, drive.GetDriveDescription())
+		} else {
+			target, err := c.findCopyTarget(drive)
+			if err != nil {
+				return false, err
+			}
+			if target == nil {
+				log.Infof("There is no target drive to copy volume %s, waiting", vol.Name)
+				return false, nil
+			}
+			if err = c.reserveCopyTarget(ctx, target); err != nil {
+				return false, err
+			}
+			log.Infof("Volume %s is going to be copied to drive %s", vol.Name, target.Spec.UUID)
+			vol.Annotations[apiV1.VolumeAnnotationCopyTarget] = target.Spec.UUID
+			vol.Annotations[apiV1.VolumeAnnotationCopyStatus] = apiV1.VolumeCopyPending
+		}
+		if err = c.client.UpdateCR(ctx, vol); err != nil {
+			log.Errorf("Failed to update volume %s annotations, error: %v", vol.Name, err)
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// findCopyTarget returns clean drive with available capacity which is suitable to hold copy of the drive based volume,
+// returns nil if there is no such drive
+func (c *Controller) findCopyTarget(drive *drivecrd.Drive) (*drivecrd.Drive, error) {
+	drives, err := c.crHelper.GetDriveCRs(c.nodeID)
+	if err != nil {
+		return nil, err
+	}
+	value := drive.Annotations[apiV1.DriveAnnotationVolumeCopy]
+	for i := range drives {
+		target := &drives[i]
+		if value != apiV1.DriveAnnotationVolumeCopyAuto && target.Spec.UUID != value {
+			continue
+		}
+		if target.Spec.UUID == drive.Spec.UUID || !target.Spec.IsClean || target.Spec.Health != apiV1.HealthGood ||
+			target.Spec.Status != apiV1.DriveStatusOnline || target.Spec.Usage != apiV1.DriveUsageInUse ||
+			target.Spec.Type != drive.Spec.Type || target.Spec.Size < drive.Spec.Size || target.Spec.IsSystem {
+			continue
+		}
+		if ac, err := c.crHelper.GetACByLocation(target.Spec.UUID); err != nil || ac.Spec.Size == 0 {
+			continue
+		}
+		return target, nil
+	}
+	return nil, nil
+}
+
+// reserveCopyTarget marks target drive as used, so it isn't chosen for the new volumes
+func (c *Controller) reserveCopyTarget(ctx context.Context, target *drivecrd.Drive) error {
+	ac, err := c.crHelper.GetACByLocation(target.Spec.UUID)
+	if err != nil {
+		return err
+	}
+	ac.Spec.Size = 0
+	if err = c.client.UpdateCR(ctx, ac); err != nil {
+		return err
+	}
+	target.Spec.IsClean = false
+	return c.client.UpdateCR(ctx, target)
+}
+
 func (c *Controller) handleDriveUsageRemoving(ctx context.Context, log *logrus.Entry, drive *drivecrd.Drive) (uint8, error) {
 	// wait all volumes without fake-attach have REMOVED status
-	volumes, err := c.crHelper.GetVolumesByLocation(ctx, drive.Spec.UUID)
+	volumes, err := c.getDriveVolumes(ctx, drive)
 	if err != nil {
 		return ignore, err
 	}
@@ -755,6 +755,32 @@ func TestDriveController_handleDriveStatus(t *testing.T) {
 		assert.Nil(t, dc.client.DeleteCR(testCtx, expectedD))
 		assert.Nil(t, dc.client.DeleteCR(testCtx, expectedV))
 	})
+	t.Run("RAID LVG member offline, volumes are not MISSING", func(t *testing.T) {
+		expectedD := testCRDrive2.DeepCopy()
+		expectedD.Spec.Status = apiV1.DriveStatusOffline
+		assert.Nil(t, dc.client.CreateCR(testCtx, expectedD.Name, expectedD))
+
+		expectedLVG := lvgCR.DeepCopy()
+		expectedLVG.Spec.RaidType = apiV1.RaidType1
+		assert.Nil(t, dc.client.CreateCR(testCtx, expectedLVG.Name, expectedLVG))
+
+		expectedV := failedVolCR.DeepCopy()
+		expectedV.Spec.LocationType = apiV1.LocationTypeLVM
+		expectedV.Spec.StorageClass = apiV1.StorageClassHDDLVGRaid1
+		expectedV.Spec.Location = lvgCR.Name
+		expectedV.Spec.OperationalStatus = apiV1.OperationalStatusOperative
+		assert.Nil(t, dc.client.CreateCR(testCtx, expectedV.Name, expectedV))
+
+		assert.Nil(t, dc.handleDriveStatus(testCtx, expectedD))
+
+		resultVolume := &vcrd.Volume{}
+		assert.Nil(t, dc.client.ReadCR(testCtx, expectedV.Name, expectedV.Namespace, resultVolume))
+		assert.Equal(t, apiV1.OperationalStatusOperative, resultVolume.Spec.OperationalStatus)
+
+		assert.Nil(t, dc.client.DeleteCR(testCtx, expectedD))
+		assert.Nil(t, dc.client.DeleteCR(testCtx, expectedV))
+		assert.Nil(t, dc.client.DeleteCR(testCtx, expectedLVG))
+	})
 }
 
 func TestDriveController_checkAndPlaceStatusInUse(t *testing.T) {
@@ -919,6 +945,85 @@ func TestDriveController_handleDriveUsageRemovingWithLED(t *testing.T) {
 	}
 }
 
+func TestDriveController_handleVolumesCopy(t *testing.T) {
+	k8SClientset := fake.NewSimpleClientset()
+	scheme, err := k8s.PrepareScheme()
+	assert.Nil(t, err)
+	eventRecorder, err := events.New("baremetal-csi-node", "434aa7b1-8b8a-4ae8-92f9-1cc7e09a9030",
+		k8SClientset.CoreV1().Events(testNs), scheme, logrus.New())
+	assert.Nil(t, err)
+	defer eventRecorder.Wait()
+
+	prepare := func(t *testing.T) (*Controller, *dcrd.Drive, *vcrd.Volume) {
+		dc := NewController(setup(), nodeID, nil, eventRecorder, testLogger)
+		drive := testBadCRDrive.DeepCopy()
+		drive.Spec.Health = apiV1.HealthSuspect
+		drive.Spec.Usage = apiV1.DriveUsageInUse
+		drive.Annotations = map[string]string{apiV1.DriveAnnotationVolumeCopy: apiV1.DriveAnnotationVolumeCopyAuto}
+		assert.Nil(t, dc.client.CreateCR(testCtx, drive.Name, drive))
+		vol := failedVolCR.DeepCopy()
+		vol.Spec.CSIStatus = apiV1.Created
+		vol.Spec.LocationType = apiV1.LocationTypeDrive
+		vol.Spec.Usage = apiV1.VolumeUsageInUse
+		assert.Nil(t, dc.client.CreateCR(testCtx, vol.Name, vol))
+		return dc, drive, vol
+	}
+
+	t.Run("There is no target drive", func(t *testing.T) {
+		dc, drive, _ := prepare(t)
+
+		reserved, err := dc.handleVolumesCopy(testCtx, dc.log, drive)
+		assert.Nil(t, err)
+		assert.False(t, reserved)
+		status, err := dc.handleDriveUpdate(testCtx, dc.log, drive)
+		assert.Nil(t, err)
+		assert.Equal(t, wait, status)
+	})
+
+	t.Run("Target drive is reserved", func(t *testing.T) {
+		dc, drive, vol := prepare(t)
+		target := testCRDrive2.DeepCopy()
+		target.Spec.IsClean = true
+		target.Spec.IsSystem = false
+		target.Spec.Usage = apiV1.DriveUsageInUse
+		assert.Nil(t, dc.client.CreateCR(testCtx, target.Name, target))
+		ac := acCR2.DeepCopy()
+		assert.Nil(t, dc.client.CreateCR(testCtx, ac.Name, ac))
+
+		reserved, err := dc.handleVolumesCopy(testCtx, dc.log, drive)
+		assert.Nil(t, err)
+		assert.True(t, reserved)
+
+		resVol := &vcrd.Volume{}
+		assert.Nil(t, dc.client.ReadCR(testCtx, vol.Name, vol.Namespace, resVol))
+		assert.Equal(t, driveUUID2, resVol.Annotations[apiV1.VolumeAnnotationCopyTarget])
+		assert.Equal(t, apiV1.VolumeCopyPending, resVol.Annotations[apiV1.VolumeAnnotationCopyStatus])
+		resAC := &accrd.AvailableCapacity{}
+		assert.Nil(t, dc.client.ReadCR(testCtx, ac.Name, "", resAC))
+		assert.Equal(t, int64(0), resAC.Spec.Size)
+		resTarget := &dcrd.Drive{}
+		assert.Nil(t, dc.client.ReadCR(testCtx, target.Name, "", resTarget))
+		assert.False(t, resTarget.Spec.IsClean)
+
+		// volume is reserved once
+		reserved, err = dc.handleVolumesCopy(testCtx, dc.log, drive)
+		assert.Nil(t, err)
+		assert.True(t, reserved)
+	})
+
+	t.Run("Drive is BAD", func(t *testing.T) {
+		dc, drive, vol := prepare(t)
+		drive.Spec.Health = apiV1.HealthBad
+
+		reserved, err := dc.handleVolumesCopy(testCtx, dc.log, drive)
+		assert.Nil(t, err)
+		assert.True(t, reserved)
+		resVol := &vcrd.Volume{}
+		assert.Nil(t, dc.client.ReadCR(testCtx, vol.Name, vol.Namespace, resVol))
+		assert.Equal(t, apiV1.VolumeCopyFailed, resVol.Annotations[apiV1.VolumeAnnotationCopyStatus])
+	})
+}
+
 func TestDriveController_handleDriveUsageRemoving(t *testing.T) {
 	kubeClient := setup()
 	dc := NewController(kubeClient, nodeID, nil, new(events.Recorder), testLogger)
@@ -31,23 +31,34 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	vccrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
 	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/eventing"
 	metricsC "github.com/dell/csi-baremetal/pkg/metrics/common"
 )
 
 const (
 	lvgFinalizer            = "dell.emc.csi/lvg-cleanup"
 	lvgDeletionRetryTimeout = 1 * time.Second
+	lvgRepairRetryTimeout   = 30 * time.Second
+	lvgMigrateRetryTimeout  = 30 * time.Second
+	lvgMigratePollTimeout   = 10 * time.Second
 )
 
+// eventRecorder interface for sending events
+type eventRecorder interface {
+	Eventf(object runtime.Object, event *eventing.EventDescription, messageFmt string, args ...interface{})
+}
+
 // Controller is the LogicalVolumeGroup custom resource Controller for serving VG operations on Node side in Reconcile loop
 type Controller struct {
 	k8sClient *k8s.KubeClient
@@ -57,23 +68,31 @@ type Controller struct {
 	lvmOps  lvm.WrapLVM
 	e       command.CmdExecutor
 
+	recorder eventRecorder
+
+	// names of LogicalVolumeGroups which data is being moved by pvmove started by this controller
+	migratedLVGs map[string]struct{}
+
 	node string
 	log  *logrus.Entry
 }
 
 // NewController is the constructor for Controller struct
-// Receives an instance of base.KubeClient, ID of a node where it works and logrus logger
+// Receives an instance of base.KubeClient, ID of a node where it works, events recorder and logrus logger
 // Returns an instance of Controller
-func NewController(k8sClient *k8s.KubeClient, nodeID string, log *logrus.Logger) *Controller {
+func NewController(k8sClient *k8s.KubeClient, nodeID string, recorder eventRecorder, log *logrus.Logger) *Controller {
 	e := command.NewExecutor(log)
 	return &Controller{
 		k8sClient: k8sClient,
 		crHelper:  k8s.NewCRHelperImpl(k8sClient, log),
 		node:      nodeID,
+		recorder:  recorder,
 		log:       log.WithField("component", "Controller"),
 		e:         e,
 		lvmOps:    lvm.NewLVM(e, log),
 		listBlk:   lsblk.NewLSBLK(log),
+
+		migratedLVGs: map[string]struct{}{},
 	}
 }
 
@@ -115,6 +134,18 @@ func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return c.handlerLVGCreation(lvg)
 	}
 
+	// data of LogicalVolumeGroup drive should be moved to the spare drive while volumes stay online
+	if isMigrationRequested(lvg) {
+		ll.Info("Migrating LogicalVolumeGroup drive")
+		return c.handleLVGMigration(lvg)
+	}
+
+	// RAID LogicalVolumeGroup lost a member drive and should be repaired on a replacement drive
+	if lvg.Spec.RaidType != "" && lvg.Spec.Health == apiV1.HealthDegraded {
+		ll.Info("Repairing RAID LogicalVolumeGroup")
+		return c.handleLVGRepair(lvg)
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -197,6 +228,13 @@ func (c *Controller) handleLVGRemoving(lvg *lvgcrd.LogicalVolumeGroup) (ctrl.Res
 
 	drivesUUIDs := c.k8sClient.GetSystemDriveUUIDs()
 	if !util.ContainsString(drivesUUIDs, lvg.Spec.Locations[0]) {
+		// thin pool is LV, it should be removed before LogicalVolumeGroup
+		if lvg.Spec.ThinPool != "" {
+			if err := c.removeThinPool(lvg); err != nil {
+				ll.Errorf("Unable to remove thin pool: %v", err)
+				return ctrl.Result{Requeue: true}, err
+			}
+		}
 		// cleanup LVM artifacts
 		if err := c.removeLVGArtifacts(lvg.Name); err != nil {
 			ll.Errorf("Unable to cleanup LVM artifacts: %v", err)
@@ -207,6 +245,471 @@ func (c *Controller) handleLVGRemoving(lvg *lvgcrd.LogicalVolumeGroup) (ctrl.Res
 	return c.removeFinalizer(lvg)
 }
 
+// handleLVGRepair handles RAID LogicalVolumeGroup in DEGRADED health. Missing member drive is replaced by a clean drive
+// of the same type on the node: drive is added to the VG, RAID LVs are rebuilt with lvconvert --repair and missing PV
+// is removed from the VG. LogicalVolumeGroup is moved back to GOOD health if there are no missing members
+func (c *Controller) handleLVGRepair(lvg *lvgcrd.LogicalVolumeGroup) (ctrl.Result, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":  "handleLVGRepair",
+		"lvgName": lvg.Name,
+	})
+	// TODO - Remove context.Background() usage - https://github.com/dell/csi-baremetal/issues/703
+	ctx := context.WithValue(context.Background(), base.RequestUUID, lvg.Name)
+
+	drives, err := c.crHelper.GetDriveCRs(c.node)
+	if err != nil {
+		ll.Errorf("Unable to read drive list: %v", err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	drivesByUUID := make(map[string]*drivecrd.Drive, len(drives))
+	for i := range drives {
+		drivesByUUID[drives[i].Spec.UUID] = &drives[i]
+	}
+
+	var (
+		missingIdx = -1
+		members    []*drivecrd.Drive
+		allGood    = true
+	)
+	for i, driveUUID := range lvg.Spec.Locations {
+		drive, ok := drivesByUUID[driveUUID]
+		if !ok || drive.Spec.Status == apiV1.DriveStatusOffline {
+			if missingIdx == -1 {
+				missingIdx = i
+			}
+			continue
+		}
+		if drive.Spec.Health != apiV1.HealthGood {
+			allGood = false
+		}
+		members = append(members, drive)
+	}
+
+	if missingIdx == -1 {
+		if !allGood {
+			// member drive is BAD but still in place, wait until it's replaced
+			return ctrl.Result{RequeueAfter: lvgRepairRetryTimeout}, nil
+		}
+		ll.Info("All member drives are in place, LogicalVolumeGroup is healthy")
+		lvg.Spec.Health = apiV1.HealthGood
+		if err := c.k8sClient.UpdateCR(ctx, lvg); err != nil {
+			ll.Errorf("Unable to update LogicalVolumeGroup health: %v", err)
+			return ctrl.Result{Requeue: true}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	spare, err := c.findSpareDrive(ctx, members, drives)
+	if err != nil {
+		ll.Errorf("Unable to find replacement for member drive %s: %v", lvg.Spec.Locations[missingIdx], err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	if spare == nil {
+		ll.Infof("There is no replacement for member drive %s, waiting", lvg.Spec.Locations[missingIdx])
+		return ctrl.Result{RequeueAfter: lvgRepairRetryTimeout}, nil
+	}
+
+	if err := c.repairRaidLVG(lvg, spare); err != nil {
+		ll.Errorf("Unable to repair LogicalVolumeGroup on drive %s: %v", spare.Spec.UUID, err)
+		c.recorder.Eventf(lvg, eventing.VolumeGroupRepairFailed,
+			"Unable to repair LogicalVolumeGroup on drive %s: %v", spare.Spec.UUID, err)
+		return ctrl.Result{RequeueAfter: lvgRepairRetryTimeout}, nil
+	}
+	ll.Infof("Member drive %s was replaced by drive %s", lvg.Spec.Locations[missingIdx], spare.Spec.UUID)
+	c.recorder.Eventf(lvg, eventing.VolumeGroupRepaired, "Member drive %s was replaced by drive %s, %s",
+		lvg.Spec.Locations[missingIdx], spare.Spec.UUID, spare.GetDriveDescription())
+
+	// capacity of the replacement drive is consumed by the LogicalVolumeGroup
+	if ac, err := c.crHelper.GetACByLocation(spare.Spec.UUID); err == nil {
+		ac.Spec.Size = 0
+		if err := c.k8sClient.UpdateCR(ctx, ac); err != nil {
+			ll.Errorf("Unable to update AC %s: %v", ac.Name, err)
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+	spare.Spec.IsClean = false
+	if err := c.k8sClient.UpdateCR(ctx, spare); err != nil {
+		ll.Errorf("Unable to update drive %s: %v", spare.Name, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	lvg.Spec.Locations[missingIdx] = spare.Spec.UUID
+	if len(members)+1 == len(lvg.Spec.Locations) {
+		lvg.Spec.Health = apiV1.HealthGood
+	}
+	freeSpace, err := c.getRaidLVGFreeSpace(lvg)
+	if err != nil {
+		ll.Errorf("Unable to calculate free space: %v", err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	if err := c.setNewVGSize(lvg, freeSpace); err != nil {
+		ll.Errorf("Unable to update LogicalVolumeGroup: %v", err)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if lvg.Spec.Health != apiV1.HealthGood {
+		return ctrl.Result{Requeue: true}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// isMigrationRequested returns true if LogicalVolumeGroup has migration annotation and previous migration didn't fail
+func isMigrationRequested(lvg *lvgcrd.LogicalVolumeGroup) bool {
+	if _, ok := lvg.Annotations[apiV1.LVGMigrateDriveAnnotation]; !ok {
+		return false
+	}
+	_, failed := lvg.Annotations[apiV1.LVGMigrationFailedAnnotation]
+	return !failed
+}
+
+// handleLVGMigration handles LogicalVolumeGroup with migration annotation. Data of the drive from annotation
+// (or of the SUSPECT drive for auto value) is moved with pvmove to a clean drive of the same type on the node,
+// after that the drive is removed from the VG. Volumes of the LogicalVolumeGroup stay mounted during migration.
+// pvmove runs in background, its progress is checked on the next reconciliations. Source and spare drives are kept
+// in the annotations of LogicalVolumeGroup, so migration interrupted by restart of the node service is resumed
+func (c *Controller) handleLVGMigration(lvg *lvgcrd.LogicalVolumeGroup) (ctrl.Result, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":  "handleLVGMigration",
+		"lvgName": lvg.Name,
+	})
+	// TODO - Remove context.Background() usage - https://github.com/dell/csi-baremetal/issues/703
+	ctx := context.WithValue(context.Background(), base.RequestUUID, lvg.Name)
+
+	if lvg.Spec.RaidType != "" {
+		// RAID LogicalVolumeGroup is rebuilt on the replacement drive by repair
+		return c.failLVGMigration(ctx, lvg, "migration isn't supported for %s LogicalVolumeGroup", lvg.Spec.RaidType)
+	}
+
+	drives, err := c.crHelper.GetDriveCRs(c.node)
+	if err != nil {
+		ll.Errorf("Unable to read drive list: %v", err)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	source, spare := findDrive(drives, lvg.Annotations[apiV1.LVGMigrationSourceAnnotation]),
+		findDrive(drives, lvg.Annotations[apiV1.LVGMigrationTargetAnnotation])
+	if source == nil || spare == nil {
+		if _, ok := lvg.Annotations[apiV1.LVGMigrationSourceAnnotation]; ok {
+			// drives are selected again on retry
+			delete(lvg.Annotations, apiV1.LVGMigrationSourceAnnotation)
+			delete(lvg.Annotations, apiV1.LVGMigrationTargetAnnotation)
+			return c.failLVGMigration(ctx, lvg, "drives of the started migration aren't found")
+		}
+		return c.startLVGMigration(ctx, lvg, drives)
+	}
+
+	if _, ok := c.migratedLVGs[lvg.Name]; !ok {
+		// move wasn't started by this instance of the node service,
+		// move interrupted by restart is resumed instead of starting the new one
+		_, interrupted, err := c.lvmOps.GetPVMoveProgress(lvg.Name)
+		if err != nil {
+			ll.Errorf("Unable to check progress of data move: %v", err)
+			return ctrl.Result{RequeueAfter: lvgMigratePollTimeout}, nil
+		}
+		if interrupted {
+			ll.Infof("Resuming move of data of drive %s to drive %s", source.Spec.UUID, spare.Spec.UUID)
+			err = c.lvmOps.PVMoveResume()
+		} else {
+			ll.Infof("Moving data of drive %s to drive %s", source.Spec.UUID, spare.Spec.UUID)
+			err = c.migrateLVG(lvg, source, spare)
+		}
+		if err != nil {
+			return c.failLVGMigration(ctx, lvg, "unable to move data of drive %s to drive %s: %v",
+				source.Spec.UUID, spare.Spec.UUID, err)
+		}
+		c.migratedLVGs[lvg.Name] = struct{}{}
+	}
+
+	percent, inProgress, err := c.lvmOps.GetPVMoveProgress(lvg.Name)
+	if err != nil {
+		ll.Errorf("Unable to check progress of data move: %v", err)
+		return ctrl.Result{RequeueAfter: lvgMigratePollTimeout}, nil
+	}
+	if inProgress {
+		ll.Infof("Moving data of drive %s to drive %s, %.2f%% done", source.Spec.UUID, spare.Spec.UUID, percent)
+		return ctrl.Result{RequeueAfter: lvgMigratePollTimeout}, nil
+	}
+	delete(c.migratedLVGs, lvg.Name)
+
+	if err := c.removeMigratedDrive(lvg, source); err != nil {
+		return c.failLVGMigration(ctx, lvg, "unable to remove drive %s from VG: %v", source.Spec.UUID, err)
+	}
+	c.recorder.Eventf(lvg, eventing.VolumeGroupMigrated, "Drive %s was replaced by drive %s, %s",
+		source.Spec.UUID, spare.Spec.UUID, spare.GetDriveDescription())
+
+	for i, driveUUID := range lvg.Spec.Locations {
+		if driveUUID == source.Spec.UUID {
+			lvg.Spec.Locations[i] = spare.Spec.UUID
+		}
+	}
+	delete(lvg.Annotations, apiV1.LVGMigrationSourceAnnotation)
+	delete(lvg.Annotations, apiV1.LVGMigrationTargetAnnotation)
+	if lvg.Annotations[apiV1.LVGMigrateDriveAnnotation] != apiV1.LVGMigrateDriveAuto {
+		delete(lvg.Annotations, apiV1.LVGMigrateDriveAnnotation)
+	}
+	if c.isLVGDrivesHealthy(lvg, drives, spare) {
+		lvg.Spec.Health = apiV1.HealthGood
+		if err := c.resetVolumesHealth(ctx, lvg); err != nil {
+			ll.Errorf("Unable to update volumes health: %v", err)
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+	if err := c.k8sClient.UpdateCR(ctx, lvg); err != nil {
+		ll.Errorf("Unable to update LogicalVolumeGroup: %v", err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// startLVGMigration selects the drive of LogicalVolumeGroup which data should be moved and the spare drive for it,
+// consumes capacity of the spare drive and saves both drives in the annotations of LogicalVolumeGroup.
+// Data is moved on the next reconciliation
+func (c *Controller) startLVGMigration(ctx context.Context, lvg *lvgcrd.LogicalVolumeGroup,
+	drives []drivecrd.Drive) (ctrl.Result, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":  "startLVGMigration",
+		"lvgName": lvg.Name,
+	})
+
+	value := lvg.Annotations[apiV1.LVGMigrateDriveAnnotation]
+	var source *drivecrd.Drive
+	for _, driveUUID := range lvg.Spec.Locations {
+		for i := range drives {
+			if drives[i].Spec.UUID != driveUUID {
+				continue
+			}
+			if driveUUID == value || (value == apiV1.LVGMigrateDriveAuto && drives[i].Spec.Health == apiV1.HealthSuspect) {
+				source = &drives[i]
+			}
+		}
+		if source != nil {
+			break
+		}
+	}
+	if source == nil {
+		if value == apiV1.LVGMigrateDriveAuto {
+			// there is no SUSPECT drive in LogicalVolumeGroup, nothing to migrate
+			return ctrl.Result{}, nil
+		}
+		return c.failLVGMigration(ctx, lvg, "drive %s isn't found in LogicalVolumeGroup", value)
+	}
+	if source.Spec.Status != apiV1.DriveStatusOnline {
+		return c.failLVGMigration(ctx, lvg, "drive %s is %s, its data can't be moved", source.Spec.UUID,
+			source.Spec.Status)
+	}
+
+	spare, err := c.findSpareDrive(ctx, []*drivecrd.Drive{source}, drives)
+	if err != nil {
+		ll.Errorf("Unable to find spare drive to migrate drive %s: %v", source.Spec.UUID, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	if spare == nil {
+		ll.Infof("There is no spare drive to migrate drive %s, waiting", source.Spec.UUID)
+		return ctrl.Result{RequeueAfter: lvgMigrateRetryTimeout}, nil
+	}
+
+	// capacity of the spare drive is consumed by the LogicalVolumeGroup
+	if ac, err := c.crHelper.GetACByLocation(spare.Spec.UUID); err == nil {
+		ac.Spec.Size = 0
+		if err := c.k8sClient.UpdateCR(ctx, ac); err != nil {
+			ll.Errorf("Unable to update AC %s: %v", ac.Name, err)
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+	spare.Spec.IsClean = false
+	if err := c.k8sClient.UpdateCR(ctx, spare); err != nil {
+		ll.Errorf("Unable to update drive %s: %v", spare.Name, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	ll.Infof("Drive %s is selected to migrate drive %s", spare.Spec.UUID, source.Spec.UUID)
+	lvg.Annotations[apiV1.LVGMigrationSourceAnnotation] = source.Spec.UUID
+	lvg.Annotations[apiV1.LVGMigrationTargetAnnotation] = spare.Spec.UUID
+	if err := c.k8sClient.UpdateCR(ctx, lvg); err != nil {
+		ll.Errorf("Unable to update LogicalVolumeGroup: %v", err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// migrateLVG adds spare drive to the VG and starts moving data of the source drive to it in background
+func (c *Controller) migrateLVG(lvg *lvgcrd.LogicalVolumeGroup, source, spare *drivecrd.Drive) error {
+	srcDev, err := c.listBlk.SearchDrivePath(&source.Spec)
+	if err != nil {
+		return err
+	}
+	dstDev, err := c.listBlk.SearchDrivePath(&spare.Spec)
+	if err != nil {
+		return err
+	}
+	if vgName, err := c.lvmOps.GetVGNameByPVName(dstDev); err != nil || vgName != lvg.Name {
+		if err := c.lvmOps.PVCreate(dstDev); err != nil {
+			return fmt.Errorf("unable to create PV for device %s: %v", dstDev, err)
+		}
+		if err := c.lvmOps.VGExtend(lvg.Name, dstDev); err != nil {
+			return fmt.Errorf("unable to extend VG with device %s: %v", dstDev, err)
+		}
+	}
+	if err := c.lvmOps.PVMove(srcDev, dstDev); err != nil {
+		return fmt.Errorf("unable to move extents from device %s: %v", srcDev, err)
+	}
+	return nil
+}
+
+// removeMigratedDrive removes the source drive from the VG after its data was moved,
+// it fails if the data wasn't moved completely
+func (c *Controller) removeMigratedDrive(lvg *lvgcrd.LogicalVolumeGroup, source *drivecrd.Drive) error {
+	srcDev, err := c.listBlk.SearchDrivePath(&source.Spec)
+	if err != nil {
+		return err
+	}
+	if err := c.lvmOps.VGReduce(lvg.Name, srcDev); err != nil {
+		return err
+	}
+	return c.lvmOps.PVRemove(srcDev)
+}
+
+// findDrive returns drive with the provided UUID from the list or nil if there is no such drive
+func findDrive(drives []drivecrd.Drive, driveUUID string) *drivecrd.Drive {
+	for i := range drives {
+		if driveUUID != "" && drives[i].Spec.UUID == driveUUID {
+			return &drives[i]
+		}
+	}
+	return nil
+}
+
+// failLVGMigration marks LogicalVolumeGroup with migration failed annotation and sends event
+func (c *Controller) failLVGMigration(ctx context.Context, lvg *lvgcrd.LogicalVolumeGroup,
+	messageFmt string, args ...interface{}) (ctrl.Result, error) {
+	msg := fmt.Sprintf(messageFmt, args...)
+	c.log.WithField("lvgName", lvg.Name).Errorf("LogicalVolumeGroup migration failed: %s", msg)
+	c.recorder.Eventf(lvg, eventing.VolumeGroupMigrationFailed, msg)
+	delete(c.migratedLVGs, lvg.Name)
+
+	lvg.Annotations[apiV1.LVGMigrationFailedAnnotation] = msg
+	if err := c.k8sClient.UpdateCR(ctx, lvg); err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// isLVGDrivesHealthy returns true if all drives of the LogicalVolumeGroup are in GOOD health
+func (c *Controller) isLVGDrivesHealthy(lvg *lvgcrd.LogicalVolumeGroup, drives []drivecrd.Drive,
+	spare *drivecrd.Drive) bool {
+	for _, driveUUID := range lvg.Spec.Locations {
+		if driveUUID == spare.Spec.UUID {
+			continue
+		}
+		for i := range drives {
+			if drives[i].Spec.UUID == driveUUID && drives[i].Spec.Health != apiV1.HealthGood {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// resetVolumesHealth moves volumes of the LogicalVolumeGroup back to GOOD health after its drives were replaced
+func (c *Controller) resetVolumesHealth(ctx context.Context, lvg *lvgcrd.LogicalVolumeGroup) error {
+	volumes, err := c.crHelper.GetVolumeCRs(c.node)
+	if err != nil {
+		return err
+	}
+	for i := range volumes {
+		volume := &volumes[i]
+		if volume.Spec.Location != lvg.Name || volume.Spec.Health == apiV1.HealthGood {
+			continue
+		}
+		volume.Spec.Health = apiV1.HealthGood
+		if err := c.k8sClient.UpdateCR(ctx, volume); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findSpareDrive returns clean drive with available capacity which is suitable to replace missing member of the
+// RAID LogicalVolumeGroup or migrated drive, returns nil if there is no such drive. Drives which capacity is reserved
+// by AvailableCapacityReservation are skipped, they are going to be consumed by the volumes
+func (c *Controller) findSpareDrive(ctx context.Context, members []*drivecrd.Drive,
+	drives []drivecrd.Drive) (*drivecrd.Drive, error) {
+	if len(members) == 0 {
+		return nil, nil
+	}
+	acrList := &acrcrd.AvailableCapacityReservationList{}
+	if err := c.k8sClient.ReadList(ctx, acrList); err != nil {
+		return nil, fmt.Errorf("unable to read available capacity reservations: %v", err)
+	}
+	reserved := map[string]bool{}
+	for _, acr := range acrList.Items {
+		for _, request := range acr.Spec.ReservationRequests {
+			for _, name := range request.Reservations {
+				reserved[name] = true
+			}
+		}
+	}
+
+	var minSize = members[0].Spec.Size
+	for _, member := range members {
+		if member.Spec.Size < minSize {
+			minSize = member.Spec.Size
+		}
+	}
+	for i := range drives {
+		drive := &drives[i]
+		if !drive.Spec.IsClean || drive.Spec.Health != apiV1.HealthGood ||
+			drive.Spec.Status != apiV1.DriveStatusOnline || drive.Spec.Usage != apiV1.DriveUsageInUse ||
+			drive.Spec.Type != members[0].Spec.Type || drive.Spec.Size < minSize || drive.Spec.IsSystem {
+			continue
+		}
+		if ac, err := c.crHelper.GetACByLocation(drive.Spec.UUID); err != nil || ac.Spec.Size == 0 || reserved[ac.Name] {
+			continue
+		}
+		return drive, nil
+	}
+	return nil, nil
+}
+
+// repairRaidLVG adds replacement drive to the VG, rebuilds all RAID LVs on it and removes missing PVs from the VG
+func (c *Controller) repairRaidLVG(lvg *lvgcrd.LogicalVolumeGroup, spare *drivecrd.Drive) error {
+	dev, err := c.listBlk.SearchDrivePath(&spare.Spec)
+	if err != nil {
+		return err
+	}
+	if err := c.lvmOps.PVCreate(dev); err != nil {
+		return fmt.Errorf("unable to create PV for device %s: %v", dev, err)
+	}
+	if err := c.lvmOps.VGExtend(lvg.Name, dev); err != nil {
+		return fmt.Errorf("unable to extend VG with device %s: %v", dev, err)
+	}
+	lvs, err := c.lvmOps.GetLVsInVG(lvg.Name)
+	if err != nil {
+		return fmt.Errorf("unable to list LVs: %v", err)
+	}
+	for _, lv := range lvs {
+		if err := c.lvmOps.RaidLVRepair(fmt.Sprintf("%s/%s", lvg.Name, lv), dev); err != nil {
+			return fmt.Errorf("unable to repair LV %s: %v", lv, err)
+		}
+	}
+	return c.lvmOps.VGReduceMissing(lvg.Name)
+}
+
+// getRaidLVGFreeSpace returns free space of the RAID LogicalVolumeGroup based on the volumes allocated in it
+func (c *Controller) getRaidLVGFreeSpace(lvg *lvgcrd.LogicalVolumeGroup) (int64, error) {
+	volumes, err := c.crHelper.GetVolumeCRs(c.node)
+	if err != nil {
+		return 0, err
+	}
+	freeSpace := lvg.Spec.Size
+	for _, volume := range volumes {
+		if volume.Spec.Location == lvg.Name && volume.Spec.CSIStatus != apiV1.Removed {
+			freeSpace -= capacityplanner.GetRaidLVSize(lvg.Spec.RaidType, volume.Spec.Size)
+		}
+	}
+	return freeSpace, nil
+}
+
 // SetupWithManager registers Controller to ControllerManager
 func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -276,14 +779,45 @@ func (c *Controller) createSystemLVG(lvg *lvgcrd.LogicalVolumeGroup) (locations
 	if len(deviceFiles) == 0 {
 		return locations, errors.New("no one PVs were created")
 	}
+	// LVs of striped and RAID LogicalVolumeGroup are spread across all its drives
+	if (lvg.Spec.Stripes > 0 || lvg.Spec.RaidType != "") && len(deviceFiles) != len(lvg.Spec.Locations) {
+		return locations, fmt.Errorf("only %d of %d PVs were created", len(deviceFiles), len(lvg.Spec.Locations))
+	}
 	// create vg
 	if err = c.lvmOps.VGCreate(lvg.Name, deviceFiles...); err != nil {
 		ll.Errorf("Unable to create VG: %v", err)
 		return locations, err
 	}
+	// create thin pool, which takes all space of the VG
+	if lvg.Spec.ThinPool != "" {
+		metadataSize := capacityplanner.GetThinPoolMetadataSize(lvg.Spec.Size)
+		metadataSizeStr := fmt.Sprintf("%dm", metadataSize/int64(util.MBYTE))
+		if err = c.lvmOps.ThinPoolCreate(lvg.Spec.ThinPool, metadataSizeStr, lvg.Name); err != nil {
+			ll.Errorf("Unable to create thin pool %s: %v", lvg.Spec.ThinPool, err)
+			return locations, err
+		}
+		ll.Infof("Thin pool %s with metadata size %s was created.", lvg.Spec.ThinPool, metadataSizeStr)
+	}
 	return locations, nil
 }
 
+// removeThinPool removes thin pool of the LogicalVolumeGroup if there are no other LVs in it
+func (c *Controller) removeThinPool(lvg *lvgcrd.LogicalVolumeGroup) error {
+	lvs, err := c.lvmOps.GetLVsInVG(lvg.Name)
+	if err != nil {
+		return fmt.Errorf("unable to list LVs in LogicalVolumeGroup %s: %v", lvg.Name, err)
+	}
+	for _, lv := range lvs {
+		if lv != lvg.Spec.ThinPool {
+			return fmt.Errorf("there are LVs in thin pool of LogicalVolumeGroup %s", lvg.Name)
+		}
+	}
+	if len(lvs) == 0 {
+		return nil
+	}
+	return c.lvmOps.LVRemove(fmt.Sprintf("%s/%s", lvg.Name, lvg.Spec.ThinPool))
+}
+
 // removeLVGArtifacts removes LogicalVolumeGroup and PVs that doesn't correspond to particular LogicalVolumeGroup
 // when LogicalVolumeGroup is removed all PVs that were in that LogicalVolumeGroup becomes orphans
 func (c *Controller) removeLVGArtifacts(lvgName string) error {
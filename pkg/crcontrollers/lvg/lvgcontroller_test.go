@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
@@ -32,14 +33,17 @@ import (
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	vccrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
 	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/eventing"
 	"github.com/dell/csi-baremetal/pkg/mocks"
 	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
 )
@@ -153,7 +157,7 @@ var (
 )
 
 func Test_NewLVGController(t *testing.T) {
-	c := NewController(nil, "node", testLogger)
+	c := NewController(nil, "node", &mocks.NoOpRecorder{}, testLogger)
 	assert.NotNil(t, c)
 }
 
@@ -202,6 +206,68 @@ func TestReconcile_SuccessCreatingLVG(t *testing.T) {
 	assert.Contains(t, currLVG.ObjectMeta.Finalizers, lvgFinalizer)
 }
 
+func TestReconcile_SuccessCreatingThinLVG(t *testing.T) {
+	var (
+		lvmOps  = &mocklu.MockWrapLVM{}
+		listBlk = &mocklu.MockWrapLsblk{}
+		fLVG    = lvgCR1.DeepCopy()
+		lvg     = &lvgcrd.LogicalVolumeGroup{}
+	)
+
+	fLVG.Finalizers = []string{lvgFinalizer}
+	fLVG.Spec.ThinPool = apiV1.ThinPoolName
+	c := setup(t, node1ID, fLVG)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: fLVG.Name}}
+
+	c.lvmOps = lvmOps
+	c.listBlk = listBlk
+
+	listBlk.On("SearchDrivePath", mock.Anything).Return("", nil)
+	lvmOps.On("PVCreate", mock.Anything).Return(nil)
+	lvmOps.On("VGCreate", mock.Anything, mock.Anything).Return(nil)
+	// metadata size is limited by maximum size of thin pool metadata
+	lvmOps.On("ThinPoolCreate", apiV1.ThinPoolName, "16384m", fLVG.Name).Return(nil).Times(1)
+
+	res, err := c.Reconcile(tCtx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, res, ctrl.Result{})
+	err = c.k8sClient.ReadCR(tCtx, req.Name, "", lvg)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.Created, lvg.Spec.Status)
+	lvmOps.AssertExpectations(t)
+}
+
+func TestReconcile_FailedThinPoolCreate(t *testing.T) {
+	var (
+		lvmOps  = &mocklu.MockWrapLVM{}
+		listBlk = &mocklu.MockWrapLsblk{}
+		fLVG    = lvgCR1.DeepCopy()
+		lvg     = &lvgcrd.LogicalVolumeGroup{}
+	)
+
+	fLVG.Finalizers = []string{lvgFinalizer}
+	fLVG.Spec.ThinPool = apiV1.ThinPoolName
+	c := setup(t, node1ID, fLVG)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: fLVG.Name}}
+
+	c.lvmOps = lvmOps
+	c.listBlk = listBlk
+
+	listBlk.On("SearchDrivePath", mock.Anything).Return("", nil)
+	lvmOps.On("PVCreate", mock.Anything).Return(nil)
+	lvmOps.On("VGCreate", mock.Anything, mock.Anything).Return(nil)
+	lvmOps.On("ThinPoolCreate", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error"))
+
+	res, err := c.Reconcile(tCtx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, res, ctrl.Result{})
+	err = c.k8sClient.ReadCR(tCtx, req.Name, "", lvg)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.Failed, lvg.Spec.Status)
+}
+
 func TestReconcile_LVGHealthBad(t *testing.T) {
 	var fLVG = lvgCR1.DeepCopy()
 	fLVG.Spec.Status = apiV1.Created
@@ -219,6 +285,431 @@ func TestReconcile_LVGHealthBad(t *testing.T) {
 	assert.Equal(t, res, ctrl.Result{})
 }
 
+func TestReconcile_RaidLVGRepair(t *testing.T) {
+	var (
+		drive3UUID = "uuid-drive3"
+		spareDrive = drivecrd.Drive{
+			TypeMeta:   v1.TypeMeta{Kind: "Drive", APIVersion: apiV1.APIV1Version},
+			ObjectMeta: v1.ObjectMeta{Name: drive3UUID},
+			Spec: api.Drive{
+				UUID:         drive3UUID,
+				SerialNumber: "hdd3",
+				Health:       apiV1.HealthGood,
+				Type:         apiV1.DriveTypeHDD,
+				Size:         apiDrive1.Size,
+				Status:       apiV1.DriveStatusOnline,
+				Usage:        apiV1.DriveUsageInUse,
+				NodeId:       node1ID,
+				IsClean:      true,
+			},
+		}
+		spareAC = accrd.AvailableCapacity{
+			TypeMeta:   v1.TypeMeta{Kind: "AvailableCapacity", APIVersion: apiV1.APIV1Version},
+			ObjectMeta: v1.ObjectMeta{Name: "ac3"},
+			Spec: api.AvailableCapacity{
+				Location:     drive3UUID,
+				NodeId:       node1ID,
+				StorageClass: apiV1.StorageClassHDD,
+				Size:         spareDrive.Spec.Size,
+			},
+		}
+	)
+	prepare := func(t *testing.T) (*Controller, *lvgcrd.LogicalVolumeGroup, ctrl.Request) {
+		fLVG := lvgCR1.DeepCopy()
+		fLVG.Finalizers = []string{lvgFinalizer}
+		fLVG.Spec.Status = apiV1.Created
+		fLVG.Spec.Health = apiV1.HealthDegraded
+		fLVG.Spec.RaidType = apiV1.RaidType1
+		fLVG.Spec.Size = int64(300 * util.GBYTE)
+		c := setup(t, node1ID, fLVG)
+		return c, fLVG, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: fLVG.Name}}
+	}
+
+	t.Run("All members are in place", func(t *testing.T) {
+		c, fLVG, req := prepare(t)
+
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		lvg := &lvgcrd.LogicalVolumeGroup{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, fLVG.Name, "", lvg))
+		assert.Equal(t, apiV1.HealthGood, lvg.Spec.Health)
+	})
+
+	t.Run("No replacement drive", func(t *testing.T) {
+		c, _, req := prepare(t)
+		assert.Nil(t, c.k8sClient.DeleteCR(tCtx, drive2CR.DeepCopy()))
+
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: lvgRepairRetryTimeout}, res)
+	})
+
+	t.Run("Replacement drive is reserved", func(t *testing.T) {
+		lvmOps := &mocklu.MockWrapLVM{}
+		c, _, req := prepare(t)
+		c.lvmOps = lvmOps
+		assert.Nil(t, c.k8sClient.DeleteCR(tCtx, drive2CR.DeepCopy()))
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareDrive.Name, spareDrive.DeepCopy()))
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareAC.Name, spareAC.DeepCopy()))
+		acr := &acrcrd.AvailableCapacityReservation{
+			TypeMeta:   v1.TypeMeta{Kind: "AvailableCapacityReservation", APIVersion: apiV1.APIV1Version},
+			ObjectMeta: v1.ObjectMeta{Name: "acr1"},
+			Spec: api.AvailableCapacityReservation{
+				Status:              apiV1.ReservationConfirmed,
+				ReservationRequests: []*api.ReservationRequest{{Reservations: []string{spareAC.Name}}},
+			},
+		}
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, acr.Name, acr))
+
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: lvgRepairRetryTimeout}, res)
+		lvmOps.AssertNotCalled(t, "PVCreate", mock.Anything)
+	})
+
+	t.Run("Repair failed", func(t *testing.T) {
+		var (
+			lvmOps   = &mocklu.MockWrapLVM{}
+			listBlk  = &mocklu.MockWrapLsblk{}
+			recorder = &mocks.NoOpRecorder{}
+		)
+		c, _, req := prepare(t)
+		c.lvmOps, c.listBlk, c.recorder = lvmOps, listBlk, recorder
+		assert.Nil(t, c.k8sClient.DeleteCR(tCtx, drive2CR.DeepCopy()))
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareDrive.Name, spareDrive.DeepCopy()))
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareAC.Name, spareAC.DeepCopy()))
+
+		listBlk.On("SearchDrivePath", mock.Anything).Return("/dev/sdc", nil)
+		lvmOps.On("PVCreate", "/dev/sdc").Return(errors.New("error"))
+
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: lvgRepairRetryTimeout}, res)
+		assert.Equal(t, 1, len(recorder.Calls))
+		assert.Equal(t, eventing.VolumeGroupRepairFailed, recorder.Calls[0].Event)
+	})
+
+	t.Run("Member drive is replaced", func(t *testing.T) {
+		var (
+			lvmOps   = &mocklu.MockWrapLVM{}
+			listBlk  = &mocklu.MockWrapLsblk{}
+			recorder = &mocks.NoOpRecorder{}
+		)
+		c, fLVG, req := prepare(t)
+		c.lvmOps, c.listBlk, c.recorder = lvmOps, listBlk, recorder
+		assert.Nil(t, c.k8sClient.DeleteCR(tCtx, drive2CR.DeepCopy()))
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareDrive.Name, spareDrive.DeepCopy()))
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareAC.Name, spareAC.DeepCopy()))
+		volume := testVolumeCR1.DeepCopy()
+		volume.Spec.StorageClass = apiV1.StorageClassHDDLVGRaid1
+		volume.Spec.Size = int64(100 * util.GBYTE)
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, volume.Name, volume))
+
+		listBlk.On("SearchDrivePath", mock.Anything).Return("/dev/sdc", nil)
+		lvmOps.On("PVCreate", "/dev/sdc").Return(nil).Times(1)
+		lvmOps.On("VGExtend", fLVG.Name, "/dev/sdc").Return(nil).Times(1)
+		lvmOps.On("GetLVsInVG", fLVG.Name).Return([]string{"lv1", "lv2"}, nil).Times(1)
+		lvmOps.On("RaidLVRepair", fLVG.Name+"/lv1", "/dev/sdc").Return(nil).Times(1)
+		lvmOps.On("RaidLVRepair", fLVG.Name+"/lv2", "/dev/sdc").Return(nil).Times(1)
+		lvmOps.On("VGReduceMissing", fLVG.Name).Return(nil).Times(1)
+
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		lvmOps.AssertExpectations(t)
+		assert.Equal(t, 1, len(recorder.Calls))
+		assert.Equal(t, eventing.VolumeGroupRepaired, recorder.Calls[0].Event)
+
+		lvg := &lvgcrd.LogicalVolumeGroup{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, fLVG.Name, "", lvg))
+		assert.Equal(t, apiV1.HealthGood, lvg.Spec.Health)
+		assert.Equal(t, []string{drive1UUID, drive3UUID}, lvg.Spec.Locations)
+		expectedFree := fLVG.Spec.Size - capacityplanner.GetRaidLVSize(apiV1.RaidType1, volume.Spec.Size)
+		assert.Equal(t, strconv.FormatInt(expectedFree, 10), lvg.Annotations[apiV1.LVGFreeSpaceAnnotation])
+
+		ac := &accrd.AvailableCapacity{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, spareAC.Name, "", ac))
+		assert.Equal(t, int64(0), ac.Spec.Size)
+		drive := &drivecrd.Drive{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, drive3UUID, "", drive))
+		assert.False(t, drive.Spec.IsClean)
+	})
+}
+
+func TestReconcile_LVGMigration(t *testing.T) {
+	var (
+		drive3UUID = "uuid-drive3"
+		spareDrive = drivecrd.Drive{
+			TypeMeta:   v1.TypeMeta{Kind: "Drive", APIVersion: apiV1.APIV1Version},
+			ObjectMeta: v1.ObjectMeta{Name: drive3UUID},
+			Spec: api.Drive{
+				UUID:         drive3UUID,
+				SerialNumber: "hdd3",
+				Health:       apiV1.HealthGood,
+				Type:         apiV1.DriveTypeHDD,
+				Size:         apiDrive1.Size,
+				Status:       apiV1.DriveStatusOnline,
+				Usage:        apiV1.DriveUsageInUse,
+				NodeId:       node1ID,
+				IsClean:      true,
+			},
+		}
+		spareAC = accrd.AvailableCapacity{
+			TypeMeta:   v1.TypeMeta{Kind: "AvailableCapacity", APIVersion: apiV1.APIV1Version},
+			ObjectMeta: v1.ObjectMeta{Name: "ac3"},
+			Spec: api.AvailableCapacity{
+				Location:     drive3UUID,
+				NodeId:       node1ID,
+				StorageClass: apiV1.StorageClassHDD,
+				Size:         spareDrive.Spec.Size,
+			},
+		}
+	)
+	prepare := func(t *testing.T, value string) (*Controller, *lvgcrd.LogicalVolumeGroup, ctrl.Request) {
+		fLVG := lvgCR1.DeepCopy()
+		fLVG.Finalizers = []string{lvgFinalizer}
+		fLVG.Annotations = map[string]string{apiV1.LVGMigrateDriveAnnotation: value}
+		fLVG.Spec.Status = apiV1.Created
+		fLVG.Spec.Health = apiV1.HealthSuspect
+		c := setup(t, node1ID, fLVG)
+		drive := &drivecrd.Drive{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, drive2UUID, "", drive))
+		drive.Spec.Health = apiV1.HealthSuspect
+		assert.Nil(t, c.k8sClient.UpdateCR(tCtx, drive))
+		return c, fLVG, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: fLVG.Name}}
+	}
+	assertFailed := func(t *testing.T, c *Controller, name string, recorder *mocks.NoOpRecorder) {
+		lvg := &lvgcrd.LogicalVolumeGroup{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, name, "", lvg))
+		assert.NotEmpty(t, lvg.Annotations[apiV1.LVGMigrationFailedAnnotation])
+		assert.Equal(t, 1, len(recorder.Calls))
+		assert.Equal(t, eventing.VolumeGroupMigrationFailed, recorder.Calls[0].Event)
+	}
+
+	t.Run("No SUSPECT drive", func(t *testing.T) {
+		c, _, req := prepare(t, apiV1.LVGMigrateDriveAuto)
+		drive := &drivecrd.Drive{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, drive2UUID, "", drive))
+		drive.Spec.Health = apiV1.HealthGood
+		assert.Nil(t, c.k8sClient.UpdateCR(tCtx, drive))
+
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+	})
+
+	t.Run("Drive isn't in LVG", func(t *testing.T) {
+		recorder := &mocks.NoOpRecorder{}
+		c, fLVG, req := prepare(t, "unknown-drive")
+		c.recorder = recorder
+
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		assertFailed(t, c, fLVG.Name, recorder)
+
+		// failed migration isn't retried
+		res, err = c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		assert.Equal(t, 1, len(recorder.Calls))
+	})
+
+	t.Run("RAID LVG", func(t *testing.T) {
+		recorder := &mocks.NoOpRecorder{}
+		fLVG := lvgCR1.DeepCopy()
+		fLVG.Finalizers = []string{lvgFinalizer}
+		fLVG.Annotations = map[string]string{apiV1.LVGMigrateDriveAnnotation: drive2UUID}
+		fLVG.Spec.Status = apiV1.Created
+		fLVG.Spec.RaidType = apiV1.RaidType1
+		c := setup(t, node1ID, fLVG)
+		c.recorder = recorder
+
+		res, err := c.Reconcile(tCtx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: fLVG.Name}})
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		assertFailed(t, c, fLVG.Name, recorder)
+	})
+
+	t.Run("No spare drive", func(t *testing.T) {
+		c, _, req := prepare(t, apiV1.LVGMigrateDriveAuto)
+
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: lvgMigrateRetryTimeout}, res)
+	})
+
+	t.Run("Migration failed", func(t *testing.T) {
+		var (
+			lvmOps   = &mocklu.MockWrapLVM{}
+			listBlk  = &mocklu.MockWrapLsblk{}
+			recorder = &mocks.NoOpRecorder{}
+		)
+		c, fLVG, req := prepare(t, drive2UUID)
+		c.lvmOps, c.listBlk, c.recorder = lvmOps, listBlk, recorder
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareDrive.Name, spareDrive.DeepCopy()))
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareAC.Name, spareAC.DeepCopy()))
+
+		listBlk.On("SearchDrivePath", mock.Anything).Return("/dev/sdb", nil).Once()
+		listBlk.On("SearchDrivePath", mock.Anything).Return("/dev/sdc", nil).Once()
+		lvmOps.On("GetVGNameByPVName", "/dev/sdc").Return("", errors.New("error"))
+		lvmOps.On("PVCreate", "/dev/sdc").Return(nil)
+		lvmOps.On("VGExtend", fLVG.Name, "/dev/sdc").Return(nil)
+		lvmOps.On("PVMove", "/dev/sdb", "/dev/sdc").Return(errors.New("error"))
+		lvmOps.On("GetPVMoveProgress", fLVG.Name).Return(float64(0), false, nil)
+
+		// drives are selected
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{Requeue: true}, res)
+
+		res, err = c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		assertFailed(t, c, fLVG.Name, recorder)
+	})
+
+	t.Run("Drive is migrated", func(t *testing.T) {
+		var (
+			lvmOps   = &mocklu.MockWrapLVM{}
+			listBlk  = &mocklu.MockWrapLsblk{}
+			recorder = &mocks.NoOpRecorder{}
+		)
+		c, fLVG, req := prepare(t, apiV1.LVGMigrateDriveAuto)
+		c.lvmOps, c.listBlk, c.recorder = lvmOps, listBlk, recorder
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareDrive.Name, spareDrive.DeepCopy()))
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareAC.Name, spareAC.DeepCopy()))
+		volume := testVolumeCR1.DeepCopy()
+		volume.Spec.StorageClass = apiV1.StorageClassHDDLVG
+		volume.Spec.Health = apiV1.HealthSuspect
+		volume.Spec.Usage = apiV1.VolumeUsageInUse
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, volume.Name, volume))
+
+		// drives are selected, capacity of the spare drive is consumed
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{Requeue: true}, res)
+
+		lvg := &lvgcrd.LogicalVolumeGroup{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, fLVG.Name, "", lvg))
+		assert.Equal(t, drive2UUID, lvg.Annotations[apiV1.LVGMigrationSourceAnnotation])
+		assert.Equal(t, drive3UUID, lvg.Annotations[apiV1.LVGMigrationTargetAnnotation])
+		ac := &accrd.AvailableCapacity{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, spareAC.Name, "", ac))
+		assert.Equal(t, int64(0), ac.Spec.Size)
+		drive := &drivecrd.Drive{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, drive3UUID, "", drive))
+		assert.False(t, drive.Spec.IsClean)
+
+		// data is being moved
+		listBlk.On("SearchDrivePath", mock.Anything).Return("/dev/sdb", nil).Once()
+		listBlk.On("SearchDrivePath", mock.Anything).Return("/dev/sdc", nil).Once()
+		lvmOps.On("GetVGNameByPVName", "/dev/sdc").Return("", errors.New("error")).Times(1)
+		lvmOps.On("PVCreate", "/dev/sdc").Return(nil).Times(1)
+		lvmOps.On("VGExtend", fLVG.Name, "/dev/sdc").Return(nil).Times(1)
+		lvmOps.On("GetPVMoveProgress", fLVG.Name).Return(float64(0), false, nil).Times(1)
+		lvmOps.On("PVMove", "/dev/sdb", "/dev/sdc").Return(nil).Times(1)
+		lvmOps.On("GetPVMoveProgress", fLVG.Name).Return(float64(50), true, nil).Times(1)
+
+		res, err = c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: lvgMigratePollTimeout}, res)
+		assert.Equal(t, 0, len(recorder.Calls))
+
+		// data is moved, pvmove isn't started again
+		listBlk.On("SearchDrivePath", mock.Anything).Return("/dev/sdb", nil).Once()
+		lvmOps.On("GetPVMoveProgress", fLVG.Name).Return(float64(0), false, nil).Times(1)
+		lvmOps.On("VGReduce", fLVG.Name, "/dev/sdb").Return(nil).Times(1)
+		lvmOps.On("PVRemove", "/dev/sdb").Return(nil).Times(1)
+
+		res, err = c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		lvmOps.AssertExpectations(t)
+		assert.Equal(t, 1, len(recorder.Calls))
+		assert.Equal(t, eventing.VolumeGroupMigrated, recorder.Calls[0].Event)
+
+		lvg = &lvgcrd.LogicalVolumeGroup{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, fLVG.Name, "", lvg))
+		assert.Equal(t, apiV1.HealthGood, lvg.Spec.Health)
+		assert.Equal(t, []string{drive1UUID, drive3UUID}, lvg.Spec.Locations)
+		assert.Equal(t, apiV1.LVGMigrateDriveAuto, lvg.Annotations[apiV1.LVGMigrateDriveAnnotation])
+		assert.Empty(t, lvg.Annotations[apiV1.LVGMigrationSourceAnnotation])
+		assert.Empty(t, lvg.Annotations[apiV1.LVGMigrationTargetAnnotation])
+
+		vol := &vccrd.Volume{}
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, volume.Name, volume.Namespace, vol))
+		assert.Equal(t, apiV1.HealthGood, vol.Spec.Health)
+		assert.Equal(t, apiV1.VolumeUsageInUse, vol.Spec.Usage)
+	})
+
+	t.Run("Migration is resumed", func(t *testing.T) {
+		var (
+			lvmOps  = &mocklu.MockWrapLVM{}
+			listBlk = &mocklu.MockWrapLsblk{}
+		)
+		fLVG := lvgCR1.DeepCopy()
+		fLVG.Finalizers = []string{lvgFinalizer}
+		fLVG.Annotations = map[string]string{apiV1.LVGMigrateDriveAnnotation: drive2UUID,
+			apiV1.LVGMigrationSourceAnnotation: drive2UUID, apiV1.LVGMigrationTargetAnnotation: drive3UUID}
+		fLVG.Spec.Status = apiV1.Created
+		c := setup(t, node1ID, fLVG)
+		c.lvmOps, c.listBlk = lvmOps, listBlk
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareDrive.Name, spareDrive.DeepCopy()))
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: fLVG.Name}}
+
+		// move was interrupted by restart, it's resumed with bare pvmove
+		lvmOps.On("GetPVMoveProgress", fLVG.Name).Return(float64(80), true, nil).Times(2)
+		lvmOps.On("PVMoveResume").Return(nil).Times(1)
+
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: lvgMigratePollTimeout}, res)
+		lvmOps.AssertExpectations(t)
+		lvmOps.AssertNotCalled(t, "PVMove", mock.Anything, mock.Anything)
+		lvmOps.AssertNotCalled(t, "PVCreate", mock.Anything)
+
+		// move isn't resumed again by the same instance
+		lvmOps.On("GetPVMoveProgress", fLVG.Name).Return(float64(90), true, nil).Times(1)
+		res, err = c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: lvgMigratePollTimeout}, res)
+		lvmOps.AssertNumberOfCalls(t, "PVMoveResume", 1)
+	})
+
+	t.Run("Migration of the spare drive in VG is restarted", func(t *testing.T) {
+		var (
+			lvmOps  = &mocklu.MockWrapLVM{}
+			listBlk = &mocklu.MockWrapLsblk{}
+		)
+		fLVG := lvgCR1.DeepCopy()
+		fLVG.Finalizers = []string{lvgFinalizer}
+		fLVG.Annotations = map[string]string{apiV1.LVGMigrateDriveAnnotation: drive2UUID,
+			apiV1.LVGMigrationSourceAnnotation: drive2UUID, apiV1.LVGMigrationTargetAnnotation: drive3UUID}
+		fLVG.Spec.Status = apiV1.Created
+		c := setup(t, node1ID, fLVG)
+		c.lvmOps, c.listBlk = lvmOps, listBlk
+		assert.Nil(t, c.k8sClient.CreateCR(tCtx, spareDrive.Name, spareDrive.DeepCopy()))
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: fLVG.Name}}
+
+		// there is no interrupted move, spare drive is already in VG
+		lvmOps.On("GetPVMoveProgress", fLVG.Name).Return(float64(0), false, nil).Times(1)
+		listBlk.On("SearchDrivePath", mock.Anything).Return("/dev/sdb", nil).Once()
+		listBlk.On("SearchDrivePath", mock.Anything).Return("/dev/sdc", nil).Once()
+		lvmOps.On("GetVGNameByPVName", "/dev/sdc").Return(fLVG.Name, nil).Times(1)
+		lvmOps.On("PVMove", "/dev/sdb", "/dev/sdc").Return(nil).Times(1)
+		lvmOps.On("GetPVMoveProgress", fLVG.Name).Return(float64(10), true, nil).Times(1)
+
+		res, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: lvgMigratePollTimeout}, res)
+		lvmOps.AssertExpectations(t)
+		lvmOps.AssertNotCalled(t, "PVCreate", mock.Anything)
+		lvmOps.AssertNotCalled(t, "PVMoveResume")
+	})
+}
+
 func TestReconcile_SuccessDeletion(t *testing.T) {
 	var (
 		c   = setup(t, node1ID)
@@ -325,6 +816,41 @@ func TestReconcile_FailedNoPVs(t *testing.T) {
 	assert.Equal(t, apiV1.Failed, lvgCR.Spec.Status)
 }
 
+func TestReconcile_FailedStripedNotAllPVs(t *testing.T) {
+	// expect that striped LVG is not created on a part of its drives
+	var (
+		fLVG = lvgCR1.DeepCopy()
+		e    = &mocks.GoMockExecutor{}
+		// SearchDrivePath failed for /dev/sdb
+		lsblkResp = `{
+			  "blockdevices":[{
+				"name": "/dev/sda",
+				"type": "disk",
+				"serial": "hdd1"
+				}]
+			}`
+	)
+
+	fLVG.Finalizers = []string{lvgFinalizer}
+	fLVG.Spec.Stripes = 2
+	c := setup(t, node1ID, fLVG)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: fLVG.Name}}
+
+	c.lvmOps = lvm.NewLVM(e, testLogger)
+	e.OnCommand(lsblkAllDevicesCmd).Return(lsblkResp, "", nil)
+	e.OnCommand("/sbin/lvm pvcreate --yes /dev/sda").Return("", "", nil)
+
+	res, err := c.Reconcile(tCtx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, res, ctrl.Result{})
+
+	lvgCR := &lvgcrd.LogicalVolumeGroup{}
+	err = c.k8sClient.ReadCR(tCtx, lvgCR1.Name, "", lvgCR)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.Failed, lvgCR.Spec.Status)
+}
+
 func TestReconcile_FailedVGCreate(t *testing.T) {
 	var (
 		fLVG        = lvgCR1.DeepCopy()
@@ -398,6 +924,37 @@ func Test_removeLVGArtifacts_Fail(t *testing.T) {
 	assert.Contains(t, err.Error(), "unable to remove LogicalVolumeGroup")
 }
 
+func Test_removeThinPool(t *testing.T) {
+	var (
+		c      = setup(t, node1ID)
+		lvmOps = &mocklu.MockWrapLVM{}
+		lvg    = lvgCR1.DeepCopy()
+		pool   = fmt.Sprintf("%s/%s", lvg.Name, apiV1.ThinPoolName)
+	)
+
+	lvg.Spec.ThinPool = apiV1.ThinPoolName
+	c.lvmOps = lvmOps
+
+	// only thin pool in VG
+	lvmOps.On("GetLVsInVG", lvg.Name).Return([]string{apiV1.ThinPoolName}, nil).Times(1)
+	lvmOps.On("LVRemove", pool).Return(nil).Times(1)
+	assert.Nil(t, c.removeThinPool(lvg))
+
+	// thin pool was already removed
+	lvmOps.On("GetLVsInVG", lvg.Name).Return([]string{}, nil).Times(1)
+	assert.Nil(t, c.removeThinPool(lvg))
+
+	// thin pool contains volumes
+	lvmOps.On("GetLVsInVG", lvg.Name).Return([]string{apiV1.ThinPoolName, "some-lv"}, nil).Times(1)
+	assert.NotNil(t, c.removeThinPool(lvg))
+
+	// unable to list LVs
+	lvmOps.On("GetLVsInVG", lvg.Name).Return(nil, errors.New("error")).Times(1)
+	assert.NotNil(t, c.removeThinPool(lvg))
+
+	lvmOps.AssertNumberOfCalls(t, "LVRemove", 1)
+}
+
 func Test_increaseACSize(t *testing.T) {
 	c := setup(t, node1ID)
 
@@ -431,7 +988,7 @@ func setup(t *testing.T, node string, lvgs ...*lvgcrd.LogicalVolumeGroup) *Contr
 		assert.Nil(t, k8sClient.CreateCR(tCtx, lvg.Name, lvg))
 	}
 
-	return NewController(k8sClient, node, testLogger)
+	return NewController(k8sClient, node, &mocks.NoOpRecorder{}, testLogger)
 }
 
 func TestController_appendFinalizer(t *testing.T) {
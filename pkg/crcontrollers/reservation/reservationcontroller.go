@@ -112,7 +112,8 @@ func (c *Controller) handleReservationUpdate(ctx context.Context, log *logrus.En
 		volumes := make([]*v1api.Volume, len(reservationSpec.ReservationRequests))
 		for i, request := range reservationSpec.ReservationRequests {
 			capacity := request.CapacityRequest
-			volumes[i] = &v1api.Volume{Id: capacity.Name, Size: capacity.Size, StorageClass: capacity.StorageClass, StorageGroup: capacity.StorageGroup}
+			volumes[i] = &v1api.Volume{Id: capacity.Name, Size: capacity.Size, StorageClass: capacity.StorageClass,
+				StorageGroup: capacity.StorageGroup, NodeId: capacity.NodeId, Stripes: capacity.Stripes}
 		}
 
 		acReader := capacityplanner.NewACReader(c.client, log, true)
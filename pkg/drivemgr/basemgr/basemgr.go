@@ -17,7 +17,11 @@ limitations under the License.
 package basemgr
 
 import (
+	"context"
+	"encoding/json"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
@@ -26,18 +30,132 @@ import (
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/ipmi"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/ledctl"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsscsi"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/nvmecli"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/sgses"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/smartctl"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/uevent"
+)
+
+const (
+	// SmartInfoCacheTTL is a period during which SMART info of the drive is served from cache
+	SmartInfoCacheTTL = 30 * time.Second
+
+	// ueventSettleDelay is a delay after last disk uevent before drives are reported as changed,
+	// udev needs time to process uevents burst and update its database which is used by lsblk
+	ueventSettleDelay = 2 * time.Second
+	// DrivesResyncInterval is an interval of WatchDrives notifications without uevents,
+	// some drives changes (e.g. health) aren't reported by kernel
+	DrivesResyncInterval = time.Minute
 )
 
 // BaseManager is a drive manager based on Linux system utils
 type BaseManager struct {
-	exec     command.CmdExecutor
-	log      *logrus.Entry
-	lsscsi   lsscsi.WrapLsscsi
-	smartctl smartctl.WrapSmartctl
-	nvme     nvmecli.WrapNvmecli
+	exec       command.CmdExecutor
+	log        *logrus.Entry
+	lsscsi     lsscsi.WrapLsscsi
+	smartctl   smartctl.WrapSmartctl
+	nvme       nvmecli.WrapNvmecli
+	ledctl     ledctl.WrapLedctl
+	sgses      sgses.WrapSgSes
+	ipmi       ipmi.WrapIpmi
+	smartCache *smartInfoCache
+	// resyncInterval is an interval of WatchDrives notifications without uevents
+	resyncInterval time.Duration
+}
+
+// ueventReader reads kernel uevents
+type ueventReader interface {
+	Read() (*uevent.Event, error)
+	Close() error
+}
+
+// driveLED provides access to locate LED of the drive slot
+type driveLED struct {
+	get func() (bool, error)
+	set func(on bool) error
+}
+
+// smartInfoCache holds SMART info of the drives by their serial numbers and GetAllDrivesSmartInfo result
+// for ttl period and drives of the last GetDrivesList call to resolve device path by serial number.
+// Drives with empty serial number aren't cached
+type smartInfoCache struct {
+	sync.Mutex
+	ttl     time.Duration
+	entries map[string]smartInfoCacheEntry
+	all     smartInfoCacheEntry
+	drives  map[string]*api.Drive
+}
+
+// smartInfoCacheEntry is SMART info of the drive with the time it was received
+type smartInfoCacheEntry struct {
+	info      string
+	timestamp time.Time
+}
+
+// isExpired returns true if the entry is empty or was received more than ttl ago
+func (e smartInfoCacheEntry) isExpired(ttl time.Duration) bool {
+	return e.timestamp.IsZero() || time.Since(e.timestamp) > ttl
+}
+
+// get returns SMART info of the drive with serialNumber if it isn't expired
+func (c *smartInfoCache) get(serialNumber string) (string, bool) {
+	c.Lock()
+	defer c.Unlock()
+	entry, ok := c.entries[serialNumber]
+	if !ok || entry.isExpired(c.ttl) {
+		return "", false
+	}
+	return entry.info, true
+}
+
+// set stores SMART info of the drive with serialNumber
+func (c *smartInfoCache) set(serialNumber, info string) {
+	if serialNumber == "" {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.entries[serialNumber] = smartInfoCacheEntry{info: info, timestamp: time.Now()}
+}
+
+// getAll returns GetAllDrivesSmartInfo result if it isn't expired
+func (c *smartInfoCache) getAll() (string, bool) {
+	c.Lock()
+	defer c.Unlock()
+	if c.all.isExpired(c.ttl) {
+		return "", false
+	}
+	return c.all.info, true
+}
+
+// setAll stores GetAllDrivesSmartInfo result
+func (c *smartInfoCache) setAll(info string) {
+	c.Lock()
+	defer c.Unlock()
+	c.all = smartInfoCacheEntry{info: info, timestamp: time.Now()}
+}
+
+// getDrive returns drive with serialNumber from the last GetDrivesList call
+func (c *smartInfoCache) getDrive(serialNumber string) (*api.Drive, bool) {
+	c.Lock()
+	defer c.Unlock()
+	drive, ok := c.drives[serialNumber]
+	return drive, ok
+}
+
+// setDrives replaces stored drives with the result of GetDrivesList call
+func (c *smartInfoCache) setDrives(drives []*api.Drive) {
+	c.Lock()
+	defer c.Unlock()
+	c.drives = make(map[string]*api.Drive, len(drives))
+	for _, drive := range drives {
+		if drive.SerialNumber != "" {
+			c.drives[drive.SerialNumber] = drive
+		}
+	}
 }
 
 // GetDrivesList gets api.Drive slice using Linux system utils
@@ -55,29 +173,206 @@ func (mgr BaseManager) GetDrivesList() ([]*api.Drive, error) {
 		ll.Errorf("Failed to initialize devices, Error: %v", err)
 	}
 	devices = append(devices, nvmDevices...)
+	mgr.smartCache.setDrives(devices)
 	return devices, nil
 }
 
 // Locate implements Locate method of DriveManager interface
+// Locate LED is managed by sg_ses for drives in SES enclosure (SAS expander backplane) or ledctl for VMD/NPEM slots,
+// LocateStatusNotAvailable is returned if drive slot has no manageable LED
 func (mgr *BaseManager) Locate(serialNumber string, action int32) (int32, error) {
-	return -1, status.Error(codes.Unimplemented, "method Locate not implemented in BaseManager")
+	ll := mgr.log.WithField("method", "Locate")
+	drives, _ := mgr.GetDrivesList()
+	var drive *api.Drive
+	for _, d := range drives {
+		if d.SerialNumber == serialNumber {
+			drive = d
+			break
+		}
+	}
+	if drive == nil {
+		return -1, status.Errorf(codes.NotFound, "drive with serial number %s isn't found", serialNumber)
+	}
+
+	led := mgr.findDriveLED(drive)
+	if led == nil {
+		ll.Infof("Locate LED isn't available for drive %s", serialNumber)
+		return apiV1.LocateStatusNotAvailable, nil
+	}
+
+	switch action {
+	case apiV1.LocateStart, apiV1.LocateStop:
+		if err := led.set(action == apiV1.LocateStart); err != nil {
+			return -1, err
+		}
+	case apiV1.LocateStatus:
+	default:
+		return -1, status.Errorf(codes.InvalidArgument, "unsupported locate action %d", action)
+	}
+
+	on, err := led.get()
+	if err != nil {
+		return -1, err
+	}
+	if on {
+		return apiV1.LocateStatusOn, nil
+	}
+	return apiV1.LocateStatusOff, nil
+}
+
+// findDriveLED detects how locate LED of the drive slot can be managed
+// SES enclosures are checked for drives with SAS address, ledctl is checked for the rest drives
+// Returns nil if drive slot has no manageable LED
+func (mgr *BaseManager) findDriveLED(drive *api.Drive) *driveLED {
+	ll := mgr.log.WithField("method", "findDriveLED")
+	if drive.Type != apiV1.DriveTypeNVMe {
+		if led := mgr.findEnclosureLED(drive); led != nil {
+			return led
+		}
+	}
+	if _, err := mgr.ledctl.GetLocate(drive.Path); err != nil {
+		if err != ledctl.ErrNotSupported {
+			ll.Errorf("Failed to get locate LED of drive %s with ledctl: %v", drive.SerialNumber, err)
+		}
+		return nil
+	}
+	return &driveLED{
+		get: func() (bool, error) { return mgr.ledctl.GetLocate(drive.Path) },
+		set: func(on bool) error { return mgr.ledctl.SetLocate(drive.Path, on) },
+	}
+}
+
+// findEnclosureLED finds SES enclosure which contains element with SAS address of the drive
+// Returns nil if drive has no SAS address or isn't found in enclosures
+func (mgr *BaseManager) findEnclosureLED(drive *api.Drive) *driveLED {
+	ll := mgr.log.WithField("method", "findEnclosureLED")
+	sasAddress, err := mgr.lsscsi.GetSASAddress(drive.Path)
+	if err != nil {
+		ll.Errorf("Failed to get SAS address of drive %s: %v", drive.SerialNumber, err)
+		return nil
+	}
+	if sasAddress == "" {
+		return nil
+	}
+	enclosures, err := mgr.lsscsi.GetEnclosures()
+	if err != nil {
+		ll.Errorf("Failed to get SES enclosures: %v", err)
+		return nil
+	}
+	for _, enclosure := range enclosures {
+		enclosure := enclosure
+		if _, err := mgr.sgses.GetLocate(enclosure, sasAddress); err != nil {
+			ll.Debugf("Drive %s isn't found in enclosure %s: %v", drive.SerialNumber, enclosure, err)
+			continue
+		}
+		return &driveLED{
+			get: func() (bool, error) { return mgr.sgses.GetLocate(enclosure, sasAddress) },
+			set: func(on bool) error { return mgr.sgses.SetLocate(enclosure, sasAddress, on) },
+		}
+	}
+	return nil
 }
 
 // LocateNode implements LocateNode method of DriveManager interface
+// Node LED is managed by chassis identify command of ipmitool through in-band IPMI device,
+// action is skipped if the device isn't available on the node the same way as for drives without manageable LED
 func (mgr *BaseManager) LocateNode(action int32) error {
-	// not implemented
-	return status.Error(codes.Unimplemented, "method Locate not implemented in BaseManager")
+	ll := mgr.log.WithField("method", "LocateNode")
+	if action != apiV1.LocateStart && action != apiV1.LocateStop {
+		return status.Errorf(codes.InvalidArgument, "unsupported locate action %d", action)
+	}
+	err := mgr.ipmi.ChassisIdentify(action == apiV1.LocateStart)
+	if err == ipmi.ErrNotAvailable {
+		ll.Infof("Node locate LED isn't available: %v", err)
+		return nil
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to set node locate LED: %v", err)
+	}
+	return nil
 }
 
 // GetDriveSmartInfo implements GetDriveSmartInfo method of DriveManager interface
+// Returns smartctl JSON output for SCSI drive or nvme_cli smart-log JSON output for NVMe drive
 func (mgr *BaseManager) GetDriveSmartInfo(serialNumber string) (string, error) {
-	return "", status.Error(codes.Unimplemented, "method GetDriveSmartInfo not implemented in BaseManager")
+	if serialNumber == "" {
+		return "", status.Error(codes.InvalidArgument, "failed to get smart info: serial number is empty")
+	}
+	if info, ok := mgr.smartCache.get(serialNumber); ok {
+		return info, nil
+	}
+	// drive path is resolved from the last drives list which node refreshes on each discovery,
+	// drives are listed again only if drive is unknown or its SMART info can't be received by the stored path
+	if drive, ok := mgr.smartCache.getDrive(serialNumber); ok {
+		if info, err := mgr.getDriveSmartInfo(drive); err == nil {
+			return info, nil
+		}
+	}
+	drives, _ := mgr.GetDrivesList()
+	for _, drive := range drives {
+		if drive.SerialNumber == serialNumber {
+			info, err := mgr.getDriveSmartInfo(drive)
+			if err != nil {
+				return "", status.Errorf(codes.Internal, "failed to get smart info of drive %s: %v", serialNumber, err)
+			}
+			return info, nil
+		}
+	}
+	return "", status.Errorf(codes.NotFound, "failed to get smart info of drive %s: drive doesn't exist", serialNumber)
 }
 
 // GetAllDrivesSmartInfo implements GetAllDrivesSmartInfo method of DriveManager interface
+// Returns JSON object with drive serial number as a key and drive SMART info as a value,
+// drives without serial number and drives which SMART info can't be received are skipped
 func (mgr *BaseManager) GetAllDrivesSmartInfo() (string, error) {
-	// not implemented
-	return "", status.Error(codes.Unimplemented, "method GetAllDrivesSmartInfo not implemented in BaseManager")
+	ll := mgr.log.WithField("method", "GetAllDrivesSmartInfo")
+	if info, ok := mgr.smartCache.getAll(); ok {
+		return info, nil
+	}
+	drives, _ := mgr.GetDrivesList()
+	if len(drives) == 0 {
+		return "", status.Error(codes.NotFound, "failed to get smart info: no drives found")
+	}
+	smartInfo := make(map[string]json.RawMessage, len(drives))
+	for _, drive := range drives {
+		if drive.SerialNumber == "" {
+			ll.Warnf("Drive %s doesn't have serial number, its smart info is skipped", drive.Path)
+			continue
+		}
+		info, ok := mgr.smartCache.get(drive.SerialNumber)
+		if !ok {
+			var err error
+			if info, err = mgr.getDriveSmartInfo(drive); err != nil {
+				ll.Errorf("Failed to get smart info of drive %s, Error: %v", drive.SerialNumber, err)
+				continue
+			}
+		}
+		smartInfo[drive.SerialNumber] = json.RawMessage(info)
+	}
+	res, err := json.Marshal(smartInfo)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to marshal smart info: %v", err)
+	}
+	mgr.smartCache.setAll(string(res))
+	return string(res), nil
+}
+
+// getDriveSmartInfo gets SMART info of the drive using smartctl or nvme_cli for NVMe drive and caches it
+func (mgr *BaseManager) getDriveSmartInfo(drive *api.Drive) (string, error) {
+	var (
+		info string
+		err  error
+	)
+	if drive.Type == apiV1.DriveTypeNVMe {
+		info, err = mgr.nvme.GetSMARTLogJSON(drive.Path)
+	} else {
+		info, err = mgr.smartctl.GetSMARTInfoJSON(drive.Path)
+	}
+	if err != nil {
+		return "", err
+	}
+	mgr.smartCache.set(drive.SerialNumber, info)
+	return info, nil
 }
 
 // New is a constructor BaseManager
@@ -88,9 +383,91 @@ func New(exec command.CmdExecutor, logger *logrus.Logger) *BaseManager {
 		lsscsi:   lsscsi.NewLSSCSI(exec, logger),
 		smartctl: smartctl.NewSMARTCTL(exec),
 		nvme:     nvmecli.NewNVMECLI(exec, logger),
+		ledctl:   ledctl.NewLEDCTL(exec, logger),
+		sgses:    sgses.NewSGSES(exec, logger),
+		ipmi:     ipmi.NewIPMI(exec),
+		smartCache: &smartInfoCache{
+			ttl:     SmartInfoCacheTTL,
+			entries: make(map[string]smartInfoCacheEntry),
+		},
+		resyncInterval: DrivesResyncInterval,
 	}
 }
 
+// WatchDrives implements DriveWatcher interface, drives changes are detected by add, remove and change uevents
+// of disks received from netlink socket
+func (mgr *BaseManager) WatchDrives(ctx context.Context) (<-chan struct{}, error) {
+	listener, err := uevent.NewListener()
+	if err != nil {
+		return nil, err
+	}
+	return mgr.watchUevents(ctx, listener), nil
+}
+
+// watchUevents reads uevents with reader until ctx is done or reading is failed
+// Returns channel which receives notification after disk uevents are settled and each resyncInterval
+func (mgr *BaseManager) watchUevents(ctx context.Context, reader ueventReader) <-chan struct{} {
+	ll := mgr.log.WithField("method", "watchUevents")
+	events := make(chan struct{}, 1)
+	changes := make(chan struct{}, 1)
+
+	go func() {
+		<-ctx.Done()
+		if err := reader.Close(); err != nil {
+			ll.Errorf("Failed to close uevent reader: %v", err)
+		}
+	}()
+
+	go func() {
+		defer close(events)
+		for {
+			event, err := reader.Read()
+			if err != nil {
+				if ctx.Err() == nil {
+					ll.Errorf("Failed to read uevent: %v", err)
+				}
+				return
+			}
+			if !event.IsDisk() {
+				continue
+			}
+			ll.Debugf("Disk uevent %s %s", event.Action, event.DevPath)
+			select {
+			case events <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	go func() {
+		defer close(changes)
+		resync := time.NewTicker(mgr.resyncInterval)
+		defer resync.Stop()
+		var settled <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-events:
+				if !ok {
+					return
+				}
+				settled = time.After(ueventSettleDelay)
+				continue
+			case <-settled:
+				settled = nil
+			case <-resync.C:
+			}
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return changes
+}
+
 // GetSCSIDevices get []*api.Drive using lsscsi system util
 func (mgr *BaseManager) GetSCSIDevices() ([]*api.Drive, error) {
 	ll := mgr.log.WithField("method", "GetSCSIDevices")
@@ -17,17 +17,26 @@ limitations under the License.
 package basemgr
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/ipmi"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/ledctl"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsscsi"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/nvmecli"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/smartctl"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/uevent"
 	"github.com/dell/csi-baremetal/pkg/mocks"
 	"github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
 )
@@ -307,22 +316,311 @@ func TestLoopBackManager_GetDrivesListSuccess(t *testing.T) {
 	assert.Nil(t, err)
 }
 
-func TestLoopBackManager_GetDriveSmartInfo(t *testing.T) {
-	var mockexec = &mocks.GoMockExecutor{}
-	var manager  = New(mockexec, logger)
+func TestBaseManager_GetDriveSmartInfo(t *testing.T) {
+	var (
+		mockexec     = &mocks.GoMockExecutor{}
+		manager      = New(mockexec, logger)
+		mockLsscsi   = &linuxutils.MockWrapLsscsi{}
+		mockSmartctl = &linuxutils.MockWrapSmartctl{}
+		mockNvme     = &linuxutils.MockWrapNvmecli{}
+		smartInfo    = `{"serial_number": "testSN"}`
+		smartLog     = `{"critical_warning": 0}`
+	)
+	mockLsscsi.On("GetSCSIDevices", mock.Anything).
+		Return([]*lsscsi.SCSIDevice{{Path: "testPath", Vendor: "testVendor", Model: "testModel"}}, nil).Once()
+	mockSmartctl.On("GetDriveInfoByPath", "testPath").
+		Return(&smartctl.DeviceSMARTInfo{SerialNumber: "testSN", SmartStatus: map[string]bool{"passed": true}}, nil)
+	mockNvme.On("GetNVMDevices").
+		Return([]nvmecli.NVMDevice{{DevicePath: "nvmePath", ModelNumber: "testModel", SerialNumber: "nvmeSN", Vendor: 2311}}, nil).Once()
+	mockSmartctl.On("GetSMARTInfoJSON", "testPath").Return(smartInfo, nil).Once()
+	mockNvme.On("GetSMARTLogJSON", "nvmePath").Return(smartLog, nil).Once()
+	manager.lsscsi = mockLsscsi
+	manager.smartctl = mockSmartctl
+	manager.nvme = mockNvme
 
-	resp, err := manager.GetDriveSmartInfo("")
+	_, err := manager.GetDriveSmartInfo("")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 
-	assert.Equal(t, "", resp)
-	assert.NotNil(t, err)
+	info, err := manager.GetDriveSmartInfo("testSN")
+	assert.Nil(t, err)
+	assert.Equal(t, smartInfo, info)
+
+	// drive path is resolved from the stored drives list, drives aren't listed again
+	info, err = manager.GetDriveSmartInfo("nvmeSN")
+	assert.Nil(t, err)
+	assert.Equal(t, smartLog, info)
+	mockLsscsi.AssertNumberOfCalls(t, "GetSCSIDevices", 1)
+
+	// served from cache, smartctl and nvme_cli aren't called again
+	info, err = manager.GetDriveSmartInfo("testSN")
+	assert.Nil(t, err)
+	assert.Equal(t, smartInfo, info)
+
+	// unknown drive, drives are listed again
+	mockLsscsi.On("GetSCSIDevices", mock.Anything).
+		Return([]*lsscsi.SCSIDevice{{Path: "testPath", Vendor: "testVendor", Model: "testModel"}}, nil).Once()
+	mockNvme.On("GetNVMDevices").Return([]nvmecli.NVMDevice{}, nil).Once()
+	_, err = manager.GetDriveSmartInfo("unknownSN")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// cache is expired, SMART info can't be received by the stored path and after drives are listed again
+	manager.smartCache.ttl = 0
+	mockLsscsi.On("GetSCSIDevices", mock.Anything).
+		Return([]*lsscsi.SCSIDevice{{Path: "testPath", Vendor: "testVendor", Model: "testModel"}}, nil).Once()
+	mockNvme.On("GetNVMDevices").Return([]nvmecli.NVMDevice{}, nil).Once()
+	mockSmartctl.On("GetSMARTInfoJSON", "testPath").Return("", fmt.Errorf("error")).Twice()
+	_, err = manager.GetDriveSmartInfo("testSN")
+	assert.Equal(t, codes.Internal, status.Code(err))
+	mockLsscsi.AssertNumberOfCalls(t, "GetSCSIDevices", 3)
 }
 
-func TestLoopBackManager_GetAllDrivesSmartInfo(t *testing.T) {
-	var mockexec = &mocks.GoMockExecutor{}
-	var manager  = New(mockexec, logger)
+func TestBaseManager_GetAllDrivesSmartInfo(t *testing.T) {
+	var (
+		mockexec     = &mocks.GoMockExecutor{}
+		manager      = New(mockexec, logger)
+		mockLsscsi   = &linuxutils.MockWrapLsscsi{}
+		mockSmartctl = &linuxutils.MockWrapSmartctl{}
+		mockNvme     = &linuxutils.MockWrapNvmecli{}
+	)
+	mockLsscsi.On("GetSCSIDevices", mock.Anything).
+		Return([]*lsscsi.SCSIDevice{
+			{Path: "testPath", Vendor: "testVendor", Model: "testModel"},
+			{Path: "failPath", Vendor: "testVendor", Model: "testModel"},
+		}, nil).Once()
+	mockSmartctl.On("GetDriveInfoByPath", "testPath").
+		Return(&smartctl.DeviceSMARTInfo{SerialNumber: "testSN", SmartStatus: map[string]bool{"passed": true}}, nil)
+	mockSmartctl.On("GetDriveInfoByPath", "failPath").
+		Return(&smartctl.DeviceSMARTInfo{SerialNumber: "failSN", SmartStatus: map[string]bool{"passed": true}}, nil)
+	mockNvme.On("GetNVMDevices").
+		Return([]nvmecli.NVMDevice{{DevicePath: "nvmePath", ModelNumber: "testModel", SerialNumber: "nvmeSN", Vendor: 2311}}, nil).Once()
+	mockSmartctl.On("GetSMARTInfoJSON", "testPath").Return(`{"serial_number": "testSN"}`, nil).Once()
+	mockSmartctl.On("GetSMARTInfoJSON", "failPath").Return("", fmt.Errorf("error")).Once()
+	mockNvme.On("GetSMARTLogJSON", "nvmePath").Return(`{"critical_warning": 0}`, nil).Once()
+	manager.lsscsi = mockLsscsi
+	manager.smartctl = mockSmartctl
+	manager.nvme = mockNvme
+
+	info, err := manager.GetAllDrivesSmartInfo()
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"testSN": {"serial_number": "testSN"}, "nvmeSN": {"critical_warning": 0}}`, info)
 
-	resp, err := manager.GetAllDrivesSmartInfo()
+	// served from cache, drives aren't listed again
+	cached, err := manager.GetAllDrivesSmartInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, info, cached)
 
-	assert.Equal(t, "", resp)
-	assert.NotNil(t, err)
+	manager.smartCache.ttl = 0
+	mockLsscsi.On("GetSCSIDevices", mock.Anything).Return([]*lsscsi.SCSIDevice{}, nil).Once()
+	mockNvme.On("GetNVMDevices").Return([]nvmecli.NVMDevice{}, nil).Once()
+	_, err = manager.GetAllDrivesSmartInfo()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSmartInfoCache(t *testing.T) {
+	cache := &smartInfoCache{ttl: SmartInfoCacheTTL, entries: make(map[string]smartInfoCacheEntry)}
+
+	_, ok := cache.getAll()
+	assert.False(t, ok)
+
+	// drive without serial number isn't cached and doesn't collide with all drives result
+	cache.set("", `{"serial_number": ""}`)
+	_, ok = cache.get("")
+	assert.False(t, ok)
+	_, ok = cache.getAll()
+	assert.False(t, ok)
+
+	cache.setAll(`{"testSN": {}}`)
+	info, ok := cache.getAll()
+	assert.True(t, ok)
+	assert.Equal(t, `{"testSN": {}}`, info)
+	_, ok = cache.get("")
+	assert.False(t, ok)
+
+	cache.setDrives([]*api.Drive{{SerialNumber: "", Path: "emptyPath"}, {SerialNumber: "testSN", Path: "testPath"}})
+	_, ok = cache.getDrive("")
+	assert.False(t, ok)
+	drive, ok := cache.getDrive("testSN")
+	assert.True(t, ok)
+	assert.Equal(t, "testPath", drive.Path)
+
+	cache.ttl = 0
+	_, ok = cache.getAll()
+	assert.False(t, ok)
+}
+
+func TestBaseManager_Locate(t *testing.T) {
+	var (
+		mockexec     = &mocks.GoMockExecutor{}
+		manager      = New(mockexec, logger)
+		mockLsscsi   = &linuxutils.MockWrapLsscsi{}
+		mockSmartctl = &linuxutils.MockWrapSmartctl{}
+		mockNvme     = &linuxutils.MockWrapNvmecli{}
+		mockLedctl   = &linuxutils.MockWrapLedctl{}
+		mockSgSes    = &linuxutils.MockWrapSgSes{}
+		sasAddr      = "0x5000c5008e2bb1b5"
+	)
+	mockLsscsi.On("GetSCSIDevices", mock.Anything).
+		Return([]*lsscsi.SCSIDevice{
+			{Path: "/dev/sda", Vendor: "testVendor", Model: "testModel"},
+			{Path: "/dev/sdb", Vendor: "testVendor", Model: "testModel"},
+		}, nil)
+	mockSmartctl.On("GetDriveInfoByPath", "/dev/sda").
+		Return(&smartctl.DeviceSMARTInfo{SerialNumber: "sasSN", SmartStatus: map[string]bool{"passed": true}}, nil)
+	mockSmartctl.On("GetDriveInfoByPath", "/dev/sdb").
+		Return(&smartctl.DeviceSMARTInfo{SerialNumber: "sataSN", SmartStatus: map[string]bool{"passed": true}}, nil)
+	mockNvme.On("GetNVMDevices").
+		Return([]nvmecli.NVMDevice{{DevicePath: "/dev/nvme0n1", ModelNumber: "testModel", SerialNumber: "nvmeSN", Vendor: 2311}}, nil)
+	mockLsscsi.On("GetSASAddress", "/dev/sda").Return(sasAddr, nil)
+	mockLsscsi.On("GetSASAddress", "/dev/sdb").Return("", nil)
+	mockLsscsi.On("GetEnclosures").Return([]string{"/dev/sg2", "/dev/sg3"}, nil)
+	manager.lsscsi = mockLsscsi
+	manager.smartctl = mockSmartctl
+	manager.nvme = mockNvme
+	manager.ledctl = mockLedctl
+	manager.sgses = mockSgSes
+
+	t.Run("SES enclosure", func(t *testing.T) {
+		mockSgSes.On("GetLocate", "/dev/sg2", sasAddr).Return(false, fmt.Errorf("not found"))
+		mockSgSes.On("GetLocate", "/dev/sg3", sasAddr).Return(false, nil).Times(3)
+		mockSgSes.On("SetLocate", "/dev/sg3", sasAddr, true).Return(nil).Once()
+		mockSgSes.On("GetLocate", "/dev/sg3", sasAddr).Return(true, nil).Once()
+
+		status, err := manager.Locate("sasSN", apiV1.LocateStatus)
+		assert.Nil(t, err)
+		assert.Equal(t, apiV1.LocateStatusOff, status)
+
+		status, err = manager.Locate("sasSN", apiV1.LocateStart)
+		assert.Nil(t, err)
+		assert.Equal(t, apiV1.LocateStatusOn, status)
+	})
+
+	t.Run("ledctl", func(t *testing.T) {
+		mockLedctl.On("GetLocate", "/dev/nvme0n1").Return(true, nil).Once()
+		mockLedctl.On("SetLocate", "/dev/nvme0n1", false).Return(nil).Once()
+		mockLedctl.On("GetLocate", "/dev/nvme0n1").Return(false, nil).Once()
+
+		status, err := manager.Locate("nvmeSN", apiV1.LocateStop)
+		assert.Nil(t, err)
+		assert.Equal(t, apiV1.LocateStatusOff, status)
+	})
+
+	t.Run("Not available", func(t *testing.T) {
+		mockLedctl.On("GetLocate", "/dev/sdb").Return(false, ledctl.ErrNotSupported).Once()
+
+		status, err := manager.Locate("sataSN", apiV1.LocateStart)
+		assert.Nil(t, err)
+		assert.Equal(t, apiV1.LocateStatusNotAvailable, status)
+	})
+
+	t.Run("Set failed", func(t *testing.T) {
+		mockLedctl.On("GetLocate", "/dev/nvme0n1").Return(false, nil).Once()
+		mockLedctl.On("SetLocate", "/dev/nvme0n1", true).Return(fmt.Errorf("error")).Once()
+
+		_, err := manager.Locate("nvmeSN", apiV1.LocateStart)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Drive not found", func(t *testing.T) {
+		_, err := manager.Locate("unknownSN", apiV1.LocateStart)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestBaseManager_LocateNode(t *testing.T) {
+	var (
+		manager  = New(&mocks.GoMockExecutor{}, logger)
+		mockIpmi = &linuxutils.MockWrapIpmi{}
+	)
+	manager.ipmi = mockIpmi
+
+	mockIpmi.On("ChassisIdentify", true).Return(nil).Once()
+	assert.Nil(t, manager.LocateNode(apiV1.LocateStart))
+
+	mockIpmi.On("ChassisIdentify", false).Return(nil).Once()
+	assert.Nil(t, manager.LocateNode(apiV1.LocateStop))
+
+	// IPMI device isn't available on the node, action is skipped
+	mockIpmi.On("ChassisIdentify", true).Return(ipmi.ErrNotAvailable).Once()
+	assert.Nil(t, manager.LocateNode(apiV1.LocateStart))
+
+	mockIpmi.On("ChassisIdentify", false).Return(fmt.Errorf("error")).Once()
+	assert.Equal(t, codes.Internal, status.Code(manager.LocateNode(apiV1.LocateStop)))
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(manager.LocateNode(apiV1.LocateStatus)))
+	mockIpmi.AssertExpectations(t)
+}
+
+// fakeUevents is ueventReader which returns events from channel
+type fakeUevents struct {
+	events chan *uevent.Event
+	closed chan struct{}
+}
+
+func newFakeUevents() *fakeUevents {
+	return &fakeUevents{events: make(chan *uevent.Event, 10), closed: make(chan struct{})}
+}
+
+func (f *fakeUevents) Read() (*uevent.Event, error) {
+	select {
+	case event, ok := <-f.events:
+		if !ok {
+			return nil, errors.New("socket error")
+		}
+		return event, nil
+	case <-f.closed:
+		return nil, errors.New("closed")
+	}
+}
+
+func (f *fakeUevents) Close() error {
+	close(f.closed)
+	return nil
+}
+
+func TestBaseManager_watchUevents(t *testing.T) {
+	manager := New(&mocks.GoMockExecutor{}, logger)
+
+	t.Run("Disk uevents", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		reader := newFakeUevents()
+		changes := manager.watchUevents(ctx, reader)
+
+		reader.events <- &uevent.Event{Action: "add", Env: map[string]string{"SUBSYSTEM": "block", "DEVTYPE": "disk"}}
+		reader.events <- &uevent.Event{Action: "add", Env: map[string]string{"SUBSYSTEM": "block", "DEVTYPE": "partition"}}
+		select {
+		case <-changes:
+		case <-time.After(2 * ueventSettleDelay):
+			t.Fatal("drives changes aren't reported")
+		}
+
+		cancel()
+		for range changes {
+		}
+		<-reader.closed
+	})
+
+	t.Run("Resync", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		manager.resyncInterval = 10 * time.Millisecond
+		defer func() { manager.resyncInterval = DrivesResyncInterval }()
+
+		changes := manager.watchUevents(ctx, newFakeUevents())
+		select {
+		case <-changes:
+		case <-time.After(ueventSettleDelay):
+			t.Fatal("drives aren't resynced")
+		}
+	})
+
+	t.Run("Read failed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		reader := newFakeUevents()
+		changes := manager.watchUevents(ctx, reader)
+
+		close(reader.events)
+		for range changes {
+		}
+	})
 }
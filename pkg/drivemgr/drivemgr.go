@@ -17,7 +17,12 @@ limitations under the License.
 // Package drivemgr contains a code for managers of storage hardware such as drives
 package drivemgr
 
-import api "github.com/dell/csi-baremetal/api/generated/v1"
+import (
+	"context"
+	"time"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+)
 
 // DriveManager is the interface for managers that provide information about drives on a node
 type DriveManager interface {
@@ -33,3 +38,35 @@ type DriveManager interface {
 	// GetAllDrivesSmartInfo gets smart info for all drives on given node
 	GetAllDrivesSmartInfo() (string, error)
 }
+
+// DriveWatcher is an optional interface of DriveManager which is used by WatchDrives stream
+// DriveManagers which don't implement it respond to WatchDrives with Unimplemented code and node polls drives
+type DriveWatcher interface {
+	// WatchDrives returns channel which receives notification when drives might be changed
+	// Channel is closed when ctx is done
+	WatchDrives(ctx context.Context) (<-chan struct{}, error)
+}
+
+// PollDrives is a DriveWatcher helper for DriveManagers without change notifications
+// Returns channel which receives notification each interval until ctx is done
+func PollDrives(ctx context.Context, interval time.Duration) <-chan struct{} {
+	changes := make(chan struct{})
+	go func() {
+		defer close(changes)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				select {
+				case changes <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return changes
+}
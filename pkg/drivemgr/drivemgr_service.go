@@ -19,6 +19,7 @@ package drivemgr
 import (
 	"context"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -49,21 +50,109 @@ func NewDriveServer(logger *logrus.Logger, manager DriveManager) DriveServiceSer
 // Receives go context and DrivesRequest which contains node id
 // Returns DrivesResponse with slice of api.Drives structs
 func (svc *DriveServiceServerImpl) GetDrivesList(ctx context.Context, req *api.DrivesRequest) (*api.DrivesResponse, error) {
-	drives, err := svc.mgr.GetDrivesList()
+	drives, err := svc.getDrives(req.NodeId)
 	if err != nil {
 		svc.log.Errorf("DriveManager failed with error: %s", err.Error())
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	return &api.DrivesResponse{
+		Disks: drives,
+	}, nil
+}
+
+// WatchDrives sends snapshot of drives as ADDED events and then ADDED/MODIFIED/REMOVED events on drives changes
+// which are reported by DriveManager. Returns Unimplemented if DriveManager doesn't implement DriveWatcher
+func (svc *DriveServiceServerImpl) WatchDrives(req *api.WatchDrivesRequest, stream api.DriveService_WatchDrivesServer) error {
+	ll := svc.log.WithField("method", "WatchDrives")
+	watcher, ok := svc.mgr.(DriveWatcher)
+	if !ok {
+		return status.Error(codes.Unimplemented, "drive manager doesn't support drives watching")
+	}
+
+	ctx := stream.Context()
+	changes, err := watcher.WatchDrives(ctx)
+	if err != nil {
+		ll.Errorf("Unable to watch drives: %v", err)
+		return status.Error(codes.Internal, err.Error())
+	}
+	drives, err := svc.getDrives(req.NodeId)
+	if err != nil {
+		ll.Errorf("DriveManager failed with error: %v", err)
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err = stream.Send(&api.WatchDrivesResponse{Snapshot: true, Events: DiffDrives(nil, drives)}); err != nil {
+		return err
+	}
+	ll.Infof("Snapshot of %d drives is sent", len(drives))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-changes:
+			if !ok {
+				return nil
+			}
+		}
+		current, err := svc.getDrives(req.NodeId)
+		if err != nil {
+			// drives are kept as is, node will receive changes after DriveManager recovery
+			ll.Errorf("DriveManager failed with error: %v", err)
+			continue
+		}
+		events := DiffDrives(drives, current)
+		if len(events) == 0 {
+			continue
+		}
+		if err = stream.Send(&api.WatchDrivesResponse{Events: events}); err != nil {
+			return err
+		}
+		ll.Infof("%d drive events are sent", len(events))
+		drives = current
+	}
+}
+
+// getDrives invokes DriveManager's GetDrivesList() and fills node id and default status of drives
+func (svc *DriveServiceServerImpl) getDrives(nodeID string) ([]*api.Drive, error) {
+	drives, err := svc.mgr.GetDrivesList()
+	if err != nil {
+		return nil, err
+	}
 	// All drives are ONLINE by default
 	for _, drive := range drives {
-		drive.NodeId = req.NodeId
+		drive.NodeId = nodeID
 		if drive.Status == "" {
 			drive.Status = apiV1.DriveStatusOnline
 		}
 	}
-	return &api.DrivesResponse{
-		Disks: drives,
-	}, nil
+	return drives, nil
+}
+
+// DiffDrives returns ADDED, MODIFIED and REMOVED events which turn previous drives into current ones
+// Drives are matched by serial number
+func DiffDrives(previous, current []*api.Drive) []*api.DriveEvent {
+	previousBySN := make(map[string]*api.Drive, len(previous))
+	for _, drive := range previous {
+		previousBySN[drive.SerialNumber] = drive
+	}
+
+	events := make([]*api.DriveEvent, 0)
+	for _, drive := range current {
+		old, ok := previousBySN[drive.SerialNumber]
+		switch {
+		case !ok:
+			events = append(events, &api.DriveEvent{Type: apiV1.DriveEventAdded, Drive: drive})
+		case !proto.Equal(old, drive):
+			events = append(events, &api.DriveEvent{Type: apiV1.DriveEventModified, Drive: drive})
+		}
+		delete(previousBySN, drive.SerialNumber)
+	}
+	for _, drive := range previous {
+		if _, ok := previousBySN[drive.SerialNumber]; ok {
+			events = append(events, &api.DriveEvent{Type: apiV1.DriveEventRemoved, Drive: drive})
+		}
+	}
+	return events
 }
 
 // Locate invokes DriveManager's Locate method for manipulation drive's LED state
@@ -0,0 +1,196 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drivemgr
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+var testLogger = logrus.New()
+
+// fakeManager is DriveManager which returns drives set by test and notifies about changes on changes channel
+type fakeManager struct {
+	sync.Mutex
+	drives  []*api.Drive
+	err     error
+	changes chan struct{}
+}
+
+func (m *fakeManager) setDrives(drives []*api.Drive, err error) {
+	m.Lock()
+	defer m.Unlock()
+	m.drives, m.err = drives, err
+}
+
+func (m *fakeManager) GetDrivesList() ([]*api.Drive, error) {
+	m.Lock()
+	defer m.Unlock()
+	drives := make([]*api.Drive, 0, len(m.drives))
+	for _, drive := range m.drives {
+		copied := *drive
+		drives = append(drives, &copied)
+	}
+	return drives, m.err
+}
+
+func (m *fakeManager) Locate(string, int32) (int32, error) {
+	return 0, nil
+}
+
+func (m *fakeManager) LocateNode(int32) error {
+	return nil
+}
+
+func (m *fakeManager) GetDriveSmartInfo(string) (string, error) {
+	return "", nil
+}
+
+func (m *fakeManager) GetAllDrivesSmartInfo() (string, error) {
+	return "", nil
+}
+
+// watchingManager implements DriveWatcher
+type watchingManager struct {
+	*fakeManager
+}
+
+func (m *watchingManager) WatchDrives(ctx context.Context) (<-chan struct{}, error) {
+	return m.changes, nil
+}
+
+// fakeWatchStream collects responses of WatchDrives
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *api.WatchDrivesResponse
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(response *api.WatchDrivesResponse) error {
+	s.responses <- response
+	return nil
+}
+
+func (s *fakeWatchStream) receive(t *testing.T) *api.WatchDrivesResponse {
+	select {
+	case response := <-s.responses:
+		return response
+	case <-time.After(time.Second):
+		t.Fatal("response isn't sent")
+	}
+	return nil
+}
+
+func TestDiffDrives(t *testing.T) {
+	drive1 := &api.Drive{SerialNumber: "SN1", Health: apiV1.HealthGood}
+	drive2 := &api.Drive{SerialNumber: "SN2", Health: apiV1.HealthGood}
+	drive2Bad := &api.Drive{SerialNumber: "SN2", Health: apiV1.HealthBad}
+	drive3 := &api.Drive{SerialNumber: "SN3", Health: apiV1.HealthGood}
+
+	assert.Empty(t, DiffDrives(nil, nil))
+	assert.Empty(t, DiffDrives([]*api.Drive{drive1, drive2}, []*api.Drive{drive2, drive1}))
+	assert.Equal(t, []*api.DriveEvent{
+		{Type: apiV1.DriveEventModified, Drive: drive2Bad},
+		{Type: apiV1.DriveEventAdded, Drive: drive3},
+		{Type: apiV1.DriveEventRemoved, Drive: drive1},
+	}, DiffDrives([]*api.Drive{drive1, drive2}, []*api.Drive{drive2Bad, drive3}))
+}
+
+func TestDriveServiceServerImpl_WatchDrives(t *testing.T) {
+	drive1 := &api.Drive{SerialNumber: "SN1", Health: apiV1.HealthGood}
+	drive2 := &api.Drive{SerialNumber: "SN2", Health: apiV1.HealthGood}
+	req := &api.WatchDrivesRequest{NodeId: "node"}
+
+	t.Run("Unimplemented", func(t *testing.T) {
+		svc := NewDriveServer(testLogger, &fakeManager{})
+		err := svc.WatchDrives(req, &fakeWatchStream{ctx: context.Background()})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("Snapshot and changes", func(t *testing.T) {
+		mgr := &watchingManager{&fakeManager{drives: []*api.Drive{drive1}, changes: make(chan struct{})}}
+		svc := NewDriveServer(testLogger, mgr)
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &fakeWatchStream{ctx: ctx, responses: make(chan *api.WatchDrivesResponse, 10)}
+		done := make(chan error)
+		go func() {
+			done <- svc.WatchDrives(req, stream)
+		}()
+
+		response := stream.receive(t)
+		assert.True(t, response.Snapshot)
+		assert.Equal(t, 1, len(response.Events))
+		assert.Equal(t, apiV1.DriveEventAdded, response.Events[0].Type)
+		assert.Equal(t, "node", response.Events[0].Drive.NodeId)
+		assert.Equal(t, apiV1.DriveStatusOnline, response.Events[0].Drive.Status)
+
+		// failed GetDrivesList and unchanged drives are skipped
+		mgr.setDrives(nil, errors.New("error"))
+		mgr.changes <- struct{}{}
+		mgr.setDrives([]*api.Drive{drive1}, nil)
+		mgr.changes <- struct{}{}
+
+		mgr.setDrives([]*api.Drive{drive2}, nil)
+		mgr.changes <- struct{}{}
+		response = stream.receive(t)
+		assert.False(t, response.Snapshot)
+		assert.Equal(t, 2, len(response.Events))
+		assert.Equal(t, apiV1.DriveEventAdded, response.Events[0].Type)
+		assert.Equal(t, drive2.SerialNumber, response.Events[0].Drive.SerialNumber)
+		assert.Equal(t, apiV1.DriveEventRemoved, response.Events[1].Type)
+		assert.Equal(t, drive1.SerialNumber, response.Events[1].Drive.SerialNumber)
+
+		cancel()
+		assert.Nil(t, <-done)
+	})
+
+	t.Run("GetDrivesList failed", func(t *testing.T) {
+		mgr := &watchingManager{&fakeManager{err: errors.New("error"), changes: make(chan struct{})}}
+		svc := NewDriveServer(testLogger, mgr)
+		err := svc.WatchDrives(req, &fakeWatchStream{ctx: context.Background()})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestPollDrives(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := PollDrives(ctx, 10*time.Millisecond)
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		t.Fatal("drives aren't polled")
+	}
+	cancel()
+	for range changes {
+	}
+}
@@ -15,10 +15,14 @@ limitations under the License.
 */
 
 // Package idracmgr provides the iDRAC based implementation of DriveManager interface
+//
+// Deprecated: use redfishmgr which verifies BMC certificate, authenticates with Redfish session
+// and retries failed requests, iDRAC is supported by redfishmgr as a standard Redfish service
 package idracmgr
 
 import "C"
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -32,38 +36,51 @@ import (
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/drivemgr"
 )
 
 const (
 	storageURL = "/redfish/v1/Systems/System.Embedded.1/Storage/"
 	keyURL     = "@odata.id"
+
+	// DrivesPollInterval is an interval of iDRAC polling for WatchDrives stream
+	DrivesPollInterval = 15 * time.Second
 )
 
 // IDRACManager is the struct that implements DriveManager interface using iDRAC inside
 type IDRACManager struct {
-	log      *logrus.Entry
-	client   *http.Client
-	ip       string
-	user     string
-	password string
+	log          *logrus.Entry
+	client       *http.Client
+	ip           string
+	user         string
+	password     string
+	pollInterval time.Duration
 }
 
 // NewIDRACManager is the constructor of IDRACManager struct
 // Receives logrus logger, timeout for HTTP client, user's credentials for iDRAC and iDRAC IP
 // Returns an instance of IDRACManager
+//
+// Deprecated: use redfishmgr.NewRedfishManager
 func NewIDRACManager(log *logrus.Logger, timeout time.Duration, user string, password string, ip string) *IDRACManager {
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 	}
 	return &IDRACManager{
-		client:   &http.Client{Timeout: timeout, Transport: tr},
-		ip:       ip,
-		user:     user,
-		password: password,
-		log:      log.WithField("component", "IDRACManager"),
+		client:       &http.Client{Timeout: timeout, Transport: tr},
+		ip:           ip,
+		user:         user,
+		password:     password,
+		pollInterval: DrivesPollInterval,
+		log:          log.WithField("component", "IDRACManager"),
 	}
 }
 
+// WatchDrives implements DriveWatcher interface, iDRAC doesn't notify about drives changes so it is polled
+func (mgr *IDRACManager) WatchDrives(ctx context.Context) (<-chan struct{}, error) {
+	return drivemgr.PollDrives(ctx, mgr.pollInterval), nil
+}
+
 // Storage contains urls of controller, enclosure etc, example @odata.id:/redfish/v1/Systems/System.Embedded.1/Storage/NonRAID.Integrated.1-1
 /*
 ...
@@ -17,6 +17,7 @@ limitations under the License.
 package idracmgr
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -65,6 +66,24 @@ func TestNewIDRACManager(t *testing.T) {
 	assert.Equal(t, time.Second*10, idracManager.client.Timeout)
 }
 
+func TestIDRACManager_WatchDrives(t *testing.T) {
+	idracManager := NewIDRACManager(logger, time.Second, "user", "password", "10.10.10.10")
+	assert.Equal(t, DrivesPollInterval, idracManager.pollInterval)
+	idracManager.pollInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := idracManager.WatchDrives(ctx)
+	assert.Nil(t, err)
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		t.Fatal("drives aren't polled")
+	}
+	cancel()
+	for range changes {
+	}
+}
+
 func Test_doRequest(t *testing.T) {
 	idracManager := NewIDRACManager(logger, time.Second, "user", "password", "10.10.10.10")
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
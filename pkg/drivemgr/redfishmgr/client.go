@@ -0,0 +1,367 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redfishmgr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	sessionsURL     = "/redfish/v1/SessionService/Sessions"
+	authTokenHeader = "X-Auth-Token"
+
+	// failureThreshold is a number of consecutive failed requests after which circuit is opened
+	failureThreshold = 5
+	// openTimeout is a period during which requests fail fast when circuit is open
+	openTimeout = 30 * time.Second
+)
+
+// defaultBackoff is used to retry requests failed because of connection errors, BMC throttling (429) or 5xx responses
+var defaultBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Steps:    4,
+	Cap:      5 * time.Second,
+}
+
+// ErrCircuitOpen is returned without request to BMC after several consecutive failures
+var ErrCircuitOpen = errors.New("redfish service is unavailable, circuit is open")
+
+// Config contains settings of Redfish service connection
+type Config struct {
+	// Endpoint is a Redfish service URL, for example https://10.10.10.10
+	Endpoint string
+	User     string
+	Password string
+	Timeout  time.Duration
+	// CABundle is a path to PEM file with CA certificates which are used to verify BMC certificate
+	CABundle string
+	// PinnedCertificate is a SHA-256 fingerprint (hex, colons are allowed) of BMC certificate which is trusted without CA
+	PinnedCertificate string
+	// InsecureSkipVerify disables BMC certificate verification
+	InsecureSkipVerify bool
+}
+
+// StatusError is returned when Redfish service responds with unexpected HTTP status
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+}
+
+// Error implements error interface
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s failed with status %d", e.Method, e.URL, e.StatusCode)
+}
+
+// retryable returns true for errors of the requests which might succeed later
+func retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	// connection errors
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// client performs requests to Redfish service using session token authentication with fallback to Basic auth
+// for services without SessionService. Failed requests are retried with exponential backoff and circuit breaker
+// stops requests to unavailable service
+type client struct {
+	httpClient *http.Client
+	endpoint   string
+	user       string
+	password   string
+	backoff    wait.Backoff
+	breaker    *circuitBreaker
+	log        *logrus.Entry
+
+	// sessionMu protects session fields
+	sessionMu  sync.Mutex
+	token      string
+	sessionURL string
+	basicAuth  bool
+}
+
+// newClient is the constructor of client struct
+// Returns error if TLS configuration is invalid
+func newClient(cfg Config, logger *logrus.Entry) (*client, error) {
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &client{
+		httpClient: &http.Client{Timeout: cfg.Timeout, Transport: &http.Transport{TLSClientConfig: tlsConfig}},
+		endpoint:   strings.TrimSuffix(cfg.Endpoint, "/"),
+		user:       cfg.User,
+		password:   cfg.Password,
+		backoff:    defaultBackoff,
+		breaker:    &circuitBreaker{threshold: failureThreshold, timeout: openTimeout},
+		log:        logger.WithField("component", "RedfishClient"),
+	}, nil
+}
+
+// newTLSConfig creates TLS config which verifies BMC certificate with CA bundle, pinned fingerprint or system CAs
+func newTLSConfig(cfg Config) (*tls.Config, error) {
+	switch {
+	case cfg.InsecureSkipVerify:
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	case cfg.PinnedCertificate != "":
+		pinned, err := hex.DecodeString(strings.ReplaceAll(cfg.PinnedCertificate, ":", ""))
+		if err != nil || len(pinned) != sha256.Size {
+			return nil, fmt.Errorf("invalid SHA-256 fingerprint of pinned certificate %s", cfg.PinnedCertificate)
+		}
+		return &tls.Config{
+			// certificate chain isn't verified, BMC certificate is trusted by fingerprint only
+			InsecureSkipVerify: true,
+			VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+				if len(rawCerts) == 0 {
+					return errors.New("BMC didn't provide certificate")
+				}
+				fingerprint := sha256.Sum256(rawCerts[0])
+				if !bytes.Equal(fingerprint[:], pinned) {
+					return fmt.Errorf("BMC certificate fingerprint %x doesn't match pinned one", fingerprint)
+				}
+				return nil
+			},
+		}, nil
+	case cfg.CABundle != "":
+		pem, err := os.ReadFile(cfg.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA bundle %s: %v", cfg.CABundle, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", cfg.CABundle)
+		}
+		return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}, nil
+	default:
+		return &tls.Config{MinVersion: tls.VersionTLS12}, nil
+	}
+}
+
+// get performs GET request on Redfish resource with path and decodes response to v
+func (c *client) get(path string, v interface{}) error {
+	return c.do(http.MethodGet, path, nil, func(response *http.Response) error {
+		if response.StatusCode != http.StatusOK {
+			return &StatusError{Method: http.MethodGet, URL: path, StatusCode: response.StatusCode}
+		}
+		if err := json.NewDecoder(response.Body).Decode(v); err != nil {
+			return fmt.Errorf("fail to decode %s response: %v", path, err)
+		}
+		return nil
+	})
+}
+
+// patch performs PATCH request on Redfish resource with path and JSON body
+func (c *client) patch(path string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return c.do(http.MethodPatch, path, data, func(response *http.Response) error {
+		switch response.StatusCode {
+		case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+			return nil
+		default:
+			return &StatusError{Method: http.MethodPatch, URL: path, StatusCode: response.StatusCode}
+		}
+	})
+}
+
+// do performs request with retries and passes successful response to handle
+func (c *client) do(method, path string, body []byte, handle func(response *http.Response) error) error {
+	if !c.breaker.allow() {
+		return ErrCircuitOpen
+	}
+	err := retry.OnError(c.backoff, retryable, func() error {
+		response, err := c.doAuthorized(method, path, body)
+		if err != nil {
+			c.log.Warnf("%s %s failed: %v", method, path, err)
+			return err
+		}
+		defer func() {
+			if err := response.Body.Close(); err != nil {
+				c.log.Errorf("Fail to close connection url: %s, err: %v", path, err)
+			}
+		}()
+		return handle(response)
+	})
+	if err != nil && retryable(err) {
+		c.breaker.failure()
+	} else {
+		c.breaker.success()
+	}
+	return err
+}
+
+// doAuthorized sends request with session token, session is recreated once if token is expired (401)
+func (c *client) doAuthorized(method, path string, body []byte) (*http.Response, error) {
+	token, err := c.getToken("")
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.send(method, path, body, token)
+	if err != nil || response.StatusCode != http.StatusUnauthorized || token == "" {
+		return response, err
+	}
+	_ = response.Body.Close()
+	c.log.Infof("Session token is rejected, log in again")
+	if token, err = c.getToken(token); err != nil {
+		return nil, err
+	}
+	return c.send(method, path, body, token)
+}
+
+// send sends request authorized with session token or Basic auth if token is empty
+func (c *client) send(method, path string, body []byte, token string) (*http.Response, error) {
+	c.log.Debugf("%s %s", method, path)
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequest(method, c.endpoint+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		request.Header.Set(authTokenHeader, token)
+	} else {
+		request.SetBasicAuth(c.user, c.password)
+	}
+	request.Header.Add("Accept", "application/json")
+	if body != nil {
+		request.Header.Add("Content-Type", "application/json")
+	}
+	return c.httpClient.Do(request)
+}
+
+// getToken returns session token, session is created if there is no token or current token is the rejected one.
+// Token renewed by concurrent request is returned as is, so only one session is created for several rejected requests,
+// rejected session isn't deleted because it is already invalid on Redfish service side
+// Returns empty token if Redfish service doesn't support sessions and Basic auth should be used
+func (c *client) getToken(rejected string) (string, error) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	if c.basicAuth || (c.token != "" && c.token != rejected) {
+		return c.token, nil
+	}
+
+	data, err := json.Marshal(map[string]string{"UserName": c.user, "Password": c.password})
+	if err != nil {
+		return "", err
+	}
+	response, err := c.send(http.MethodPost, sessionsURL, data, "")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	token := response.Header.Get(authTokenHeader)
+	switch {
+	case (response.StatusCode == http.StatusCreated || response.StatusCode == http.StatusOK) && token != "":
+		c.token, c.sessionURL = token, response.Header.Get("Location")
+		c.log.Infof("Redfish session %s is created", c.sessionURL)
+	case response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusMethodNotAllowed ||
+		response.StatusCode == http.StatusNotImplemented:
+		c.log.Warnf("Redfish service doesn't support sessions, Basic auth is used")
+		c.basicAuth = true
+		c.token = ""
+	default:
+		return "", &StatusError{Method: http.MethodPost, URL: sessionsURL, StatusCode: response.StatusCode}
+	}
+	return c.token, nil
+}
+
+// logout deletes Redfish session
+func (c *client) logout() {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	if c.token == "" || c.sessionURL == "" {
+		return
+	}
+	path := strings.TrimPrefix(c.sessionURL, c.endpoint)
+	response, err := c.send(http.MethodDelete, path, nil, c.token)
+	if err != nil {
+		c.log.Errorf("Failed to delete Redfish session %s: %v", c.sessionURL, err)
+		return
+	}
+	_ = response.Body.Close()
+	c.token, c.sessionURL = "", ""
+}
+
+// circuitBreaker stops requests to Redfish service for timeout after threshold consecutive failures,
+// one trial request is allowed after timeout to check whether service is available again
+type circuitBreaker struct {
+	sync.Mutex
+	threshold int
+	timeout   time.Duration
+	failures  int
+	openedAt  time.Time
+}
+
+// allow returns false if circuit is open
+func (b *circuitBreaker) allow() bool {
+	b.Lock()
+	defer b.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if time.Since(b.openedAt) < b.timeout {
+		return false
+	}
+	// half-open, next failure opens circuit again
+	b.failures = b.threshold - 1
+	return true
+}
+
+// success closes circuit
+func (b *circuitBreaker) success() {
+	b.Lock()
+	defer b.Unlock()
+	b.failures = 0
+}
+
+// failure counts failed request and opens circuit if threshold is reached
+func (b *circuitBreaker) failure() {
+	b.Lock()
+	defer b.Unlock()
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = time.Now()
+	}
+}
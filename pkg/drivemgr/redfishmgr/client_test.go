@@ -0,0 +1,187 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redfishmgr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Session(t *testing.T) {
+	m := newRedfishMock(testResources())
+	m.sessions = true
+	m.start()
+	defer m.server.Close()
+	mgr := m.newManager()
+
+	_, err := mgr.GetDrivesList()
+	assert.Nil(t, err)
+	_, err = mgr.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, m.logins)
+	assert.Equal(t, "token-1", mgr.client.token)
+
+	// expired token is renewed
+	delete(m.tokens, "token-1")
+	_, err = mgr.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, m.logins)
+	assert.Equal(t, "token-2", mgr.client.token)
+
+	// token rejected for concurrent requests is renewed once
+	m.Lock()
+	delete(m.tokens, "token-2")
+	m.Unlock()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v := map[string]interface{}{}
+			assert.Nil(t, mgr.client.get(systemsURL, &v))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 3, m.logins)
+	assert.Equal(t, "token-3", mgr.client.token)
+
+	mgr.Close()
+	assert.Equal(t, "", mgr.client.token)
+}
+
+func TestClient_SessionFail(t *testing.T) {
+	m := newRedfishMock(testResources())
+	m.sessions = true
+	m.start()
+	defer m.server.Close()
+	mgr := m.newManager()
+	mgr.client.password = "wrong"
+
+	_, err := mgr.GetDrivesList()
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, m.logins)
+}
+
+func TestClient_BasicAuthFallback(t *testing.T) {
+	m := newRedfishMock(testResources()).start()
+	defer m.server.Close()
+	mgr := m.newManager()
+
+	_, err := mgr.GetDrivesList()
+	assert.Nil(t, err)
+	assert.True(t, mgr.client.basicAuth)
+	assert.Equal(t, "", mgr.client.token)
+}
+
+func TestClient_Retry(t *testing.T) {
+	m := newRedfishMock(testResources()).start()
+	defer m.server.Close()
+	mgr := m.newManager()
+
+	m.unavailable = 2
+	v := map[string]interface{}{}
+	assert.Nil(t, mgr.client.get(systemsURL, &v))
+	assert.Equal(t, 0, m.unavailable)
+
+	// not retryable
+	assert.NotNil(t, mgr.client.get("/redfish/v1/unknown", &v))
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	m := newRedfishMock(testResources()).start()
+	defer m.server.Close()
+	mgr := m.newManager()
+
+	m.unavailable = 100
+	v := map[string]interface{}{}
+	for i := 0; i < failureThreshold; i++ {
+		err := mgr.client.get(systemsURL, &v)
+		assert.NotNil(t, err)
+		assert.NotEqual(t, ErrCircuitOpen, err)
+	}
+	requests := m.requests
+	assert.Equal(t, ErrCircuitOpen, mgr.client.get(systemsURL, &v))
+	assert.Equal(t, requests, m.requests)
+
+	// half-open circuit is closed by successful request
+	m.unavailable = 0
+	mgr.client.breaker.openedAt = time.Now().Add(-openTimeout)
+	assert.Nil(t, mgr.client.get(systemsURL, &v))
+	assert.Nil(t, mgr.client.get(systemsURL, &v))
+}
+
+func TestClient_TLS(t *testing.T) {
+	m := newRedfishMock(testResources())
+	m.server.StartTLS()
+	defer m.server.Close()
+	fingerprint := sha256.Sum256(m.server.Certificate().Raw)
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, os.WriteFile(caBundle,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: m.server.Certificate().Raw}), 0600))
+
+	testCases := []struct {
+		name  string
+		cfg   Config
+		valid bool
+	}{
+		{"system CAs", Config{}, false},
+		{"insecure", Config{InsecureSkipVerify: true}, true},
+		{"pinned", Config{PinnedCertificate: hex.EncodeToString(fingerprint[:])}, true},
+		{"pinned mismatch", Config{PinnedCertificate: hex.EncodeToString(make([]byte, sha256.Size))}, false},
+		{"CA bundle", Config{CABundle: caBundle}, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.Endpoint, tc.cfg.User, tc.cfg.Password = m.server.URL, "user", "password"
+			mgr, err := NewRedfishManager(logger, tc.cfg)
+			assert.Nil(t, err)
+			mgr.client.backoff.Steps = 1
+			err = mgr.client.get(systemsURL, &map[string]interface{}{})
+			assert.Equal(t, tc.valid, err == nil, err)
+		})
+	}
+}
+
+func TestNewTLSConfigFail(t *testing.T) {
+	_, err := newTLSConfig(Config{PinnedCertificate: "AB:CD"})
+	assert.NotNil(t, err)
+	_, err = newTLSConfig(Config{CABundle: "/not/exist"})
+	assert.NotNil(t, err)
+
+	invalid := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, os.WriteFile(invalid, []byte("invalid"), 0600))
+	_, err = newTLSConfig(Config{CABundle: invalid})
+	assert.NotNil(t, err)
+}
+
+func TestRetryable(t *testing.T) {
+	assert.True(t, retryable(&StatusError{StatusCode: http.StatusServiceUnavailable}))
+	assert.True(t, retryable(&StatusError{StatusCode: http.StatusTooManyRequests}))
+	assert.False(t, retryable(&StatusError{StatusCode: http.StatusNotFound}))
+	assert.False(t, retryable(ErrCircuitOpen))
+}
@@ -0,0 +1,392 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package redfishmgr provides the DMTF Redfish based implementation of DriveManager interface
+package redfishmgr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/drivemgr"
+)
+
+const (
+	serviceRootURL = "/redfish/v1/"
+	systemsURL     = "/redfish/v1/Systems"
+
+	// $expand query parameters, "." expands subordinate resources (such as Drives of Storage), "*" expands all links
+	expandNoLinksQuery = "?$expand=."
+	expandAllQuery     = "?$expand=*"
+
+	healthOK       = "OK"
+	healthWarning  = "Warning"
+	healthCritical = "Critical"
+	stateAbsent    = "Absent"
+	protocolNVMe   = "NVMe"
+	mediaTypeSSD   = "SSD"
+
+	indicatorLEDBlinking = "Blinking"
+	indicatorLEDLit      = "Lit"
+	indicatorLEDOff      = "Off"
+
+	// staleDrivesTimeout is a period during which last discovered drives are returned while Redfish service is unavailable
+	staleDrivesTimeout = 10 * time.Minute
+
+	// DrivesPollInterval is an interval of Redfish service polling for WatchDrives stream
+	DrivesPollInterval = 15 * time.Second
+)
+
+// RedfishManager is the struct that implements DriveManager interface using BMC Redfish API
+type RedfishManager struct {
+	log          *logrus.Entry
+	client       *client
+	pollInterval time.Duration
+
+	// lastDrives are returned instead of error during BMC outage to prevent drives flapping to OFFLINE
+	lastDrivesMu   sync.Mutex
+	lastDrives     []*api.Drive
+	lastDrivesTime time.Time
+}
+
+// NewRedfishManager is the constructor of RedfishManager struct
+// Receives logrus logger and Redfish service connection settings
+// Returns an instance of RedfishManager or error if TLS settings are invalid
+func NewRedfishManager(log *logrus.Logger, cfg Config) (*RedfishManager, error) {
+	ll := log.WithField("component", "RedfishManager")
+	c, err := newClient(cfg, ll)
+	if err != nil {
+		return nil, err
+	}
+	return &RedfishManager{
+		client:       c,
+		pollInterval: DrivesPollInterval,
+		log:          ll,
+	}, nil
+}
+
+// Close deletes Redfish session
+func (mgr *RedfishManager) Close() {
+	mgr.client.logout()
+}
+
+// WatchDrives implements DriveWatcher interface, Redfish service doesn't notify about drives changes so it is polled
+func (mgr *RedfishManager) WatchDrives(ctx context.Context) (<-chan struct{}, error) {
+	return drivemgr.PollDrives(ctx, mgr.pollInterval), nil
+}
+
+// odataLink is a reference to Redfish resource
+type odataLink struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// collection is a Redfish resource collection, for example /redfish/v1/Systems
+type collection struct {
+	Members []odataLink `json:"Members"`
+}
+
+// serviceRoot contains protocol features of Redfish service used to detect $expand support
+type serviceRoot struct {
+	ProtocolFeaturesSupported struct {
+		ExpandQuery *struct {
+			ExpandAll bool `json:"ExpandAll"`
+			NoLinks   bool `json:"NoLinks"`
+		} `json:"ExpandQuery"`
+	} `json:"ProtocolFeaturesSupported"`
+}
+
+// system is a Redfish ComputerSystem resource
+type system struct {
+	ODataID                 string     `json:"@odata.id"`
+	Storage                 *odataLink `json:"Storage"`
+	LocationIndicatorActive *bool      `json:"LocationIndicatorActive"`
+	IndicatorLED            string     `json:"IndicatorLED"`
+}
+
+// storage is a Redfish Storage resource, Drives are links or expanded Drive resources
+type storage struct {
+	Drives []*redfishDrive `json:"Drives"`
+}
+
+// redfishDrive is a Redfish Drive resource
+type redfishDrive struct {
+	ODataID       string `json:"@odata.id"`
+	ID            string `json:"Id"`
+	SerialNumber  string `json:"SerialNumber"`
+	Manufacturer  string `json:"Manufacturer"`
+	Model         string `json:"Model"`
+	Revision      string `json:"Revision"`
+	MediaType     string `json:"MediaType"`
+	Protocol      string `json:"Protocol"`
+	CapacityBytes int64  `json:"CapacityBytes"`
+	Status        struct {
+		Health string `json:"Health"`
+		State  string `json:"State"`
+	} `json:"Status"`
+	PredictedMediaLifeLeftPercent *float64 `json:"PredictedMediaLifeLeftPercent"`
+	// LocationIndicatorActive replaces deprecated IndicatorLED in Redfish 2020.3
+	LocationIndicatorActive *bool  `json:"LocationIndicatorActive"`
+	IndicatorLED            string `json:"IndicatorLED"`
+}
+
+// GetDrivesList returns slice of *api.Drive created from Redfish drives of all systems
+// Last discovered drives are returned if Redfish service is unavailable for less than staleDrivesTimeout
+// Returns slice of *api.Drives struct or error if something went wrong
+func (mgr *RedfishManager) GetDrivesList() ([]*api.Drive, error) {
+	mgr.lastDrivesMu.Lock()
+	defer mgr.lastDrivesMu.Unlock()
+
+	drives, err := mgr.getDrives()
+	if err != nil {
+		if mgr.lastDrives != nil && time.Since(mgr.lastDrivesTime) < staleDrivesTimeout {
+			mgr.log.Warnf("Redfish service is unavailable, return drives discovered at %s: %v", mgr.lastDrivesTime, err)
+			return mgr.lastDrives, nil
+		}
+		return nil, err
+	}
+	apiDrives := make([]*api.Drive, 0, len(drives))
+	for _, drive := range drives {
+		if drive.Status.State == stateAbsent {
+			continue
+		}
+		apiDrives = append(apiDrives, convertDrive(drive))
+	}
+	mgr.lastDrives, mgr.lastDrivesTime = apiDrives, time.Now()
+	return apiDrives, nil
+}
+
+// Locate implements Locate method of DriveManager interface
+// Uses LocationIndicatorActive property of the drive or IndicatorLED for older Redfish services
+func (mgr *RedfishManager) Locate(serialNumber string, action int32) (int32, error) {
+	drives, err := mgr.getDrives()
+	if err != nil {
+		return -1, err
+	}
+	for _, drive := range drives {
+		if drive.SerialNumber == serialNumber {
+			return mgr.locate(drive.ODataID, drive.LocationIndicatorActive, drive.IndicatorLED, action)
+		}
+	}
+	return -1, status.Errorf(codes.NotFound, "drive with serial number %s isn't found", serialNumber)
+}
+
+// LocateNode implements LocateNode method of DriveManager interface
+// Uses LocationIndicatorActive or IndicatorLED property of the systems
+func (mgr *RedfishManager) LocateNode(action int32) error {
+	systems, err := mgr.getSystems()
+	if err != nil {
+		return err
+	}
+	for _, sys := range systems {
+		ledStatus, err := mgr.locate(sys.ODataID, sys.LocationIndicatorActive, sys.IndicatorLED, action)
+		if err != nil {
+			return err
+		}
+		if ledStatus == apiV1.LocateStatusNotAvailable {
+			return status.Errorf(codes.Unimplemented, "locate LED isn't supported for system %s", sys.ODataID)
+		}
+	}
+	return nil
+}
+
+// GetDriveSmartInfo implements GetDriveSmartInfo method of DriveManager interface
+func (mgr *RedfishManager) GetDriveSmartInfo(serialNumber string) (string, error) {
+	return "", status.Error(codes.Unimplemented, "method GetDriveSmartInfo not implemented in RedfishManager")
+}
+
+// GetAllDrivesSmartInfo implements GetAllDrivesSmartInfo method of DriveManager interface
+func (mgr *RedfishManager) GetAllDrivesSmartInfo() (string, error) {
+	return "", status.Error(codes.Unimplemented, "method GetAllDrivesSmartInfo not implemented in RedfishManager")
+}
+
+// locate manipulates locate LED of Redfish resource with url
+// Receives current LocationIndicatorActive and IndicatorLED properties of the resource and locate action
+// Returns LED status after action or LocateStatusNotAvailable if resource has no LED properties
+func (mgr *RedfishManager) locate(url string, locationIndicatorActive *bool, indicatorLED string, action int32) (int32, error) {
+	if locationIndicatorActive == nil && indicatorLED == "" {
+		return apiV1.LocateStatusNotAvailable, nil
+	}
+	var on bool
+	switch action {
+	case apiV1.LocateStatus:
+		if locationIndicatorActive != nil {
+			on = *locationIndicatorActive
+		} else {
+			on = indicatorLED == indicatorLEDBlinking || indicatorLED == indicatorLEDLit
+		}
+		return convertLEDStatus(on), nil
+	case apiV1.LocateStart, apiV1.LocateStop:
+		on = action == apiV1.LocateStart
+	default:
+		return -1, status.Errorf(codes.InvalidArgument, "unsupported locate action %d", action)
+	}
+
+	body := map[string]interface{}{}
+	if locationIndicatorActive != nil {
+		body["LocationIndicatorActive"] = on
+	} else if on {
+		body["IndicatorLED"] = indicatorLEDBlinking
+	} else {
+		body["IndicatorLED"] = indicatorLEDOff
+	}
+	if err := mgr.client.patch(url, body); err != nil {
+		return -1, err
+	}
+	return convertLEDStatus(on), nil
+}
+
+// getSystems returns all ComputerSystem resources of Redfish service
+func (mgr *RedfishManager) getSystems() ([]*system, error) {
+	var systems collection
+	if err := mgr.client.get(systemsURL, &systems); err != nil {
+		return nil, err
+	}
+	res := make([]*system, 0, len(systems.Members))
+	for _, member := range systems.Members {
+		sys := &system{}
+		if err := mgr.client.get(member.ODataID, sys); err != nil {
+			return nil, err
+		}
+		res = append(res, sys)
+	}
+	return res, nil
+}
+
+// getDrives walks Systems -> Storage -> Drives and returns all Redfish drives
+// Drives are requested with Storage resource if Redfish service supports $expand query
+// Returns error if any storage or drive can't be requested to not report drives as removed
+func (mgr *RedfishManager) getDrives() ([]*redfishDrive, error) {
+	ll := mgr.log.WithField("method", "getDrives")
+	systems, err := mgr.getSystems()
+	if err != nil {
+		return nil, err
+	}
+	expand := mgr.getExpandQuery()
+
+	drives := make([]*redfishDrive, 0)
+	for _, sys := range systems {
+		if sys.Storage == nil {
+			ll.Infof("System %s has no storage", sys.ODataID)
+			continue
+		}
+		var storages collection
+		if err := mgr.client.get(sys.Storage.ODataID, &storages); err != nil {
+			return nil, err
+		}
+		for _, member := range storages.Members {
+			st := &storage{}
+			if err := mgr.client.get(member.ODataID+expand, st); err != nil {
+				return nil, err
+			}
+			for _, drive := range st.Drives {
+				// drive isn't expanded, only link is provided
+				if drive.ID == "" && drive.SerialNumber == "" {
+					if err := mgr.client.get(drive.ODataID, drive); err != nil {
+						var statusErr *StatusError
+						if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+							ll.Infof("Drive %s is removed", drive.ODataID)
+							continue
+						}
+						return nil, err
+					}
+				}
+				drives = append(drives, drive)
+			}
+		}
+	}
+	return drives, nil
+}
+
+// getExpandQuery returns $expand query parameter supported by Redfish service or empty string
+func (mgr *RedfishManager) getExpandQuery() string {
+	var root serviceRoot
+	if err := mgr.client.get(serviceRootURL, &root); err != nil {
+		mgr.log.Errorf("Failed to get service root, $expand isn't used: %v", err)
+		return ""
+	}
+	expandQuery := root.ProtocolFeaturesSupported.ExpandQuery
+	switch {
+	case expandQuery == nil:
+		return ""
+	case expandQuery.NoLinks:
+		return expandNoLinksQuery
+	case expandQuery.ExpandAll:
+		return expandAllQuery
+	default:
+		return ""
+	}
+}
+
+// convertDrive converts Redfish drive to api.Drive
+func convertDrive(drive *redfishDrive) *api.Drive {
+	apiDrive := &api.Drive{
+		VID:          drive.Manufacturer,
+		PID:          drive.Model,
+		SerialNumber: drive.SerialNumber,
+		Health:       convertDriveHealth(drive.Status.Health),
+		Type:         convertDriveType(drive.Protocol, drive.MediaType),
+		Size:         drive.CapacityBytes,
+		Status:       apiV1.DriveStatusOnline,
+		Firmware:     drive.Revision,
+	}
+	if drive.PredictedMediaLifeLeftPercent != nil {
+		apiDrive.Endurance = int64(*drive.PredictedMediaLifeLeftPercent)
+	}
+	return apiDrive
+}
+
+// convertDriveHealth converts Redfish status health to apiV1 Health string
+func convertDriveHealth(health string) string {
+	switch health {
+	case healthOK:
+		return apiV1.HealthGood
+	case healthWarning:
+		return apiV1.HealthSuspect
+	case healthCritical:
+		return apiV1.HealthBad
+	default:
+		return apiV1.HealthUnknown
+	}
+}
+
+// convertDriveType converts Redfish drive protocol and media type to drive type string
+func convertDriveType(protocol, mediaType string) string {
+	switch {
+	case protocol == protocolNVMe:
+		return apiV1.DriveTypeNVMe
+	case mediaType == mediaTypeSSD:
+		return apiV1.DriveTypeSSD
+	default:
+		return apiV1.DriveTypeHDD
+	}
+}
+
+// convertLEDStatus converts LED state to apiV1 locate status
+func convertLEDStatus(on bool) int32 {
+	if on {
+		return apiV1.LocateStatusOn
+	}
+	return apiV1.LocateStatusOff
+}
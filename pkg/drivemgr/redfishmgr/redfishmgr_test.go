@@ -0,0 +1,336 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redfishmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+var logger = logrus.New()
+
+const (
+	testSystem   = "/redfish/v1/Systems/1"
+	testStorages = "/redfish/v1/Systems/1/Storage"
+	testStorage  = "/redfish/v1/Systems/1/Storage/RAID.1"
+	testDrive1   = "/redfish/v1/Systems/1/Storage/RAID.1/Drives/Disk.0"
+	testDrive2   = "/redfish/v1/Systems/1/Storage/RAID.1/Drives/Disk.1"
+	testDrive3   = "/redfish/v1/Systems/1/Storage/RAID.1/Drives/Disk.2"
+)
+
+// redfishMock is a local Redfish service which serves resources by URL (with query) and records PATCH requests
+type redfishMock struct {
+	sync.Mutex
+	server    *httptest.Server
+	resources map[string]interface{}
+	patches   map[string]map[string]interface{}
+	// sessions enables SessionService, otherwise only Basic auth is supported
+	sessions bool
+	tokens   map[string]bool
+	logins   int
+	requests int
+	// unavailable is a number of next requests which are responded with 503
+	unavailable int
+}
+
+func newRedfishMock(resources map[string]interface{}) *redfishMock {
+	m := &redfishMock{resources: resources, patches: map[string]map[string]interface{}{}, tokens: map[string]bool{}}
+	m.server = httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		m.Lock()
+		defer m.Unlock()
+		m.requests++
+		if m.unavailable > 0 {
+			m.unavailable--
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if m.sessions && req.URL.Path == sessionsURL {
+			m.login(rw, req)
+			return
+		}
+		user, password, ok := req.BasicAuth()
+		if !m.tokens[req.Header.Get(authTokenHeader)] && (!ok || user != "user" || password != "password") {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		url := req.URL.Path
+		if req.URL.RawQuery != "" {
+			url += "?" + req.URL.RawQuery
+		}
+		resource, ok := m.resources[url]
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(rw).Encode(resource)
+		case http.MethodPatch:
+			body := map[string]interface{}{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			m.patches[url] = body
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			rw.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	return m
+}
+
+func (m *redfishMock) login(rw http.ResponseWriter, req *http.Request) {
+	creds := map[string]string{}
+	if err := json.NewDecoder(req.Body).Decode(&creds); err != nil || creds["UserName"] != "user" || creds["Password"] != "password" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	m.logins++
+	token := fmt.Sprintf("token-%d", m.logins)
+	m.tokens[token] = true
+	rw.Header().Set(authTokenHeader, token)
+	rw.Header().Set("Location", fmt.Sprintf("%s/%d", sessionsURL, m.logins))
+	rw.WriteHeader(http.StatusCreated)
+}
+
+func (m *redfishMock) start() *redfishMock {
+	m.server.Start()
+	return m
+}
+
+func (m *redfishMock) newManager() *RedfishManager {
+	mgr, err := NewRedfishManager(logger, Config{Endpoint: m.server.URL, User: "user", Password: "password", Timeout: time.Second})
+	if err != nil {
+		panic(err)
+	}
+	mgr.client.backoff = wait.Backoff{Duration: time.Millisecond, Factor: 2, Steps: 3}
+	return mgr
+}
+
+func link(url string) map[string]interface{} {
+	return map[string]interface{}{"@odata.id": url}
+}
+
+func testDrive(url, sn string) map[string]interface{} {
+	return map[string]interface{}{
+		"@odata.id": url, "Id": sn, "SerialNumber": sn, "Manufacturer": "VENDOR", "Model": "MODEL",
+		"Revision": "FW1", "MediaType": "HDD", "Protocol": "SAS", "CapacityBytes": 1024,
+		"Status": map[string]interface{}{"Health": "OK", "State": "Enabled"},
+	}
+}
+
+func testResources() map[string]interface{} {
+	nvme := testDrive(testDrive2, "SN2")
+	nvme["Protocol"] = "NVMe"
+	nvme["MediaType"] = "SSD"
+	nvme["PredictedMediaLifeLeftPercent"] = 87.5
+	nvme["Status"] = map[string]interface{}{"Health": "Warning", "State": "Enabled"}
+	nvme["IndicatorLED"] = "Off"
+
+	hdd := testDrive(testDrive1, "SN1")
+	hdd["LocationIndicatorActive"] = false
+
+	absent := testDrive(testDrive3, "SN3")
+	absent["Status"] = map[string]interface{}{"State": "Absent"}
+
+	return map[string]interface{}{
+		serviceRootURL: map[string]interface{}{},
+		systemsURL:     map[string]interface{}{"Members": []interface{}{link(testSystem)}},
+		testSystem: map[string]interface{}{
+			"@odata.id": testSystem, "Storage": link(testStorages), "LocationIndicatorActive": false,
+		},
+		testStorages: map[string]interface{}{"Members": []interface{}{link(testStorage)}},
+		testStorage:  map[string]interface{}{"Drives": []interface{}{link(testDrive1), link(testDrive2), link(testDrive3)}},
+		testDrive1:   hdd,
+		testDrive2:   nvme,
+		testDrive3:   absent,
+	}
+}
+
+func TestRedfishManager_GetDrivesList(t *testing.T) {
+	m := newRedfishMock(testResources()).start()
+	defer m.server.Close()
+
+	drives, err := m.newManager().GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(drives))
+
+	assert.Equal(t, "SN1", drives[0].SerialNumber)
+	assert.Equal(t, "VENDOR", drives[0].VID)
+	assert.Equal(t, "MODEL", drives[0].PID)
+	assert.Equal(t, "FW1", drives[0].Firmware)
+	assert.Equal(t, int64(1024), drives[0].Size)
+	assert.Equal(t, apiV1.DriveTypeHDD, drives[0].Type)
+	assert.Equal(t, apiV1.HealthGood, drives[0].Health)
+	assert.Equal(t, apiV1.DriveStatusOnline, drives[0].Status)
+	assert.Equal(t, int64(0), drives[0].Endurance)
+
+	assert.Equal(t, "SN2", drives[1].SerialNumber)
+	assert.Equal(t, apiV1.DriveTypeNVMe, drives[1].Type)
+	assert.Equal(t, apiV1.HealthSuspect, drives[1].Health)
+	assert.Equal(t, int64(87), drives[1].Endurance)
+}
+
+func TestRedfishManager_GetDrivesListExpand(t *testing.T) {
+	resources := testResources()
+	resources[serviceRootURL] = map[string]interface{}{
+		"ProtocolFeaturesSupported": map[string]interface{}{
+			"ExpandQuery": map[string]interface{}{"ExpandAll": true, "NoLinks": true},
+		},
+	}
+	// drives are served only within expanded storage
+	resources[testStorage+expandNoLinksQuery] = map[string]interface{}{
+		"Drives": []interface{}{resources[testDrive1], resources[testDrive2]},
+	}
+	delete(resources, testDrive1)
+	delete(resources, testDrive2)
+	m := newRedfishMock(resources).start()
+	defer m.server.Close()
+
+	drives, err := m.newManager().GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(drives))
+	assert.Equal(t, "SN1", drives[0].SerialNumber)
+	assert.Equal(t, "SN2", drives[1].SerialNumber)
+}
+
+func TestRedfishManager_GetDrivesListFail(t *testing.T) {
+	m := newRedfishMock(testResources()).start()
+	defer m.server.Close()
+
+	mgr := m.newManager()
+	mgr.client.password = "wrong"
+	_, err := mgr.GetDrivesList()
+	assert.NotNil(t, err)
+
+	delete(m.resources, testDrive1)
+	drives, err := m.newManager().GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(drives))
+}
+
+func TestRedfishManager_Locate(t *testing.T) {
+	m := newRedfishMock(testResources()).start()
+	defer m.server.Close()
+	mgr := m.newManager()
+
+	// LocationIndicatorActive
+	ledStatus, err := mgr.Locate("SN1", apiV1.LocateStatus)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOff, ledStatus)
+
+	ledStatus, err = mgr.Locate("SN1", apiV1.LocateStart)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, ledStatus)
+	assert.Equal(t, map[string]interface{}{"LocationIndicatorActive": true}, m.patches[testDrive1])
+
+	// IndicatorLED
+	ledStatus, err = mgr.Locate("SN2", apiV1.LocateStart)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, ledStatus)
+	assert.Equal(t, map[string]interface{}{"IndicatorLED": "Blinking"}, m.patches[testDrive2])
+
+	ledStatus, err = mgr.Locate("SN2", apiV1.LocateStop)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOff, ledStatus)
+	assert.Equal(t, map[string]interface{}{"IndicatorLED": "Off"}, m.patches[testDrive2])
+
+	// no LED properties
+	ledStatus, err = mgr.Locate("SN3", apiV1.LocateStart)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusNotAvailable, ledStatus)
+
+	_, err = mgr.Locate("SN1", 10)
+	assert.NotNil(t, err)
+
+	_, err = mgr.Locate("unknown", apiV1.LocateStart)
+	assert.NotNil(t, err)
+}
+
+func TestRedfishManager_LocateNode(t *testing.T) {
+	m := newRedfishMock(testResources()).start()
+	defer m.server.Close()
+	mgr := m.newManager()
+
+	assert.Nil(t, mgr.LocateNode(apiV1.LocateStart))
+	assert.Equal(t, map[string]interface{}{"LocationIndicatorActive": true}, m.patches[testSystem])
+
+	delete(m.resources[testSystem].(map[string]interface{}), "LocationIndicatorActive")
+	assert.NotNil(t, mgr.LocateNode(apiV1.LocateStop))
+}
+
+func TestRedfishManager_SmartInfo(t *testing.T) {
+	mgr, err := NewRedfishManager(logger, Config{Endpoint: "https://127.0.0.1"})
+	assert.Nil(t, err)
+
+	_, err = mgr.GetDriveSmartInfo("SN1")
+	assert.NotNil(t, err)
+	_, err = mgr.GetAllDrivesSmartInfo()
+	assert.NotNil(t, err)
+}
+
+func TestRedfishManager_WatchDrives(t *testing.T) {
+	mgr, err := NewRedfishManager(logger, Config{Endpoint: "https://127.0.0.1"})
+	assert.Nil(t, err)
+	assert.Equal(t, DrivesPollInterval, mgr.pollInterval)
+	mgr.pollInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := mgr.WatchDrives(ctx)
+	assert.Nil(t, err)
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		t.Fatal("drives aren't polled")
+	}
+	cancel()
+	for range changes {
+	}
+}
+
+func TestRedfishManager_GetDrivesListOutage(t *testing.T) {
+	m := newRedfishMock(testResources()).start()
+	defer m.server.Close()
+	mgr := m.newManager()
+
+	drives, err := mgr.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(drives))
+
+	// last discovered drives are returned during outage
+	m.unavailable = 100
+	stale, err := mgr.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, drives, stale)
+
+	mgr.lastDrivesTime = time.Now().Add(-staleDrivesTimeout)
+	_, err = mgr.GetDrivesList()
+	assert.NotNil(t, err)
+}
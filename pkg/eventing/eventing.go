@@ -213,6 +213,74 @@ var (
 		severity:    ErrorType,
 		symptomCode: NoneSymptomCode,
 	}
+	VolumeGroupDegraded = &EventDescription{
+		reason:      "VolumeGroupDegraded",
+		severity:    WarningType,
+		symptomCode: NoneSymptomCode,
+	}
+	VolumeGroupRepaired = &EventDescription{
+		reason:      "VolumeGroupRepaired",
+		severity:    NormalType,
+		symptomCode: NoneSymptomCode,
+	}
+	VolumeGroupRepairFailed = &EventDescription{
+		reason:      "VolumeGroupRepairFailed",
+		severity:    ErrorType,
+		symptomCode: NoneSymptomCode,
+	}
+	VolumeGroupMigrated = &EventDescription{
+		reason:      "VolumeGroupMigrated",
+		severity:    NormalType,
+		symptomCode: NoneSymptomCode,
+	}
+	VolumeGroupMigrationFailed = &EventDescription{
+		reason:      "VolumeGroupMigrationFailed",
+		severity:    ErrorType,
+		symptomCode: NoneSymptomCode,
+	}
+
+	VolumeWipeFailed = &EventDescription{
+		reason:      "VolumeWipeFailed",
+		severity:    ErrorType,
+		symptomCode: NoneSymptomCode,
+	}
+	VolumeWipePolicyChanged = &EventDescription{
+		reason:      "VolumeWipePolicyChanged",
+		severity:    WarningType,
+		symptomCode: NoneSymptomCode,
+	}
+
+	VolumeCopyStarted = &EventDescription{
+		reason:      "VolumeCopyStarted",
+		severity:    NormalType,
+		symptomCode: NoneSymptomCode,
+	}
+	VolumeCopyWaiting = &EventDescription{
+		reason:      "VolumeCopyWaiting",
+		severity:    WarningType,
+		symptomCode: NoneSymptomCode,
+	}
+	VolumeCopyProgress = &EventDescription{
+		reason:      "VolumeCopyProgress",
+		severity:    NormalType,
+		symptomCode: NoneSymptomCode,
+	}
+	VolumeCopyCompleted = &EventDescription{
+		reason:      "VolumeCopyCompleted",
+		severity:    NormalType,
+		symptomCode: NoneSymptomCode,
+	}
+	VolumeCopyFailed = &EventDescription{
+		reason:      "VolumeCopyFailed",
+		severity:    ErrorType,
+		symptomCode: NoneSymptomCode,
+	}
+
+	VolumeIOLimitsFailed = &EventDescription{
+		reason:      "VolumeIOLimitsFailed",
+		severity:    ErrorType,
+		symptomCode: NoneSymptomCode,
+	}
 
 	WBTValueSetFailed = &EventDescription{
 		reason:      "WBTValueSetFailed",
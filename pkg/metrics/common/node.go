@@ -34,8 +34,57 @@ var DbgNodePublishDuration = metrics.NewMetricsWithCustomLabels(prometheus.Gauge
 	Help: "duration of the NodePublishVolume",
 }, "source", "method", "volume_name")
 
+// SmartTemperature used to export current temperature of the drive
+var SmartTemperature = metrics.NewMetricsWithCustomLabels(prometheus.GaugeOpts{
+	Name: "drive_smart_temperature_celsius",
+	Help: "current temperature of the drive reported by SMART",
+}, "source", "drive_uuid", "serial_number", "node")
+
+// SmartPowerOnHours used to export power-on hours of the drive
+var SmartPowerOnHours = metrics.NewMetricsWithCustomLabels(prometheus.GaugeOpts{
+	Name: "drive_smart_power_on_hours",
+	Help: "power-on hours of the drive reported by SMART",
+}, "source", "drive_uuid", "serial_number", "node")
+
+// SmartReallocatedSectors used to export reallocated sectors count of the SATA drive
+var SmartReallocatedSectors = metrics.NewMetricsWithCustomLabels(prometheus.GaugeOpts{
+	Name: "drive_smart_reallocated_sectors",
+	Help: "reallocated sectors count of the drive reported by SMART",
+}, "source", "drive_uuid", "serial_number", "node")
+
+// SmartPendingSectors used to export current pending sectors count of the SATA drive
+var SmartPendingSectors = metrics.NewMetricsWithCustomLabels(prometheus.GaugeOpts{
+	Name: "drive_smart_pending_sectors",
+	Help: "current pending sectors count of the drive reported by SMART",
+}, "source", "drive_uuid", "serial_number", "node")
+
+// SmartMediaErrors used to export media errors count of the NVMe drive
+var SmartMediaErrors = metrics.NewMetricsWithCustomLabels(prometheus.GaugeOpts{
+	Name: "drive_smart_media_errors",
+	Help: "media errors count of the drive reported by SMART",
+}, "source", "drive_uuid", "serial_number", "node")
+
+// SmartPercentageUsed used to export used endurance percentage of the NVMe/SAS drive
+var SmartPercentageUsed = metrics.NewMetricsWithCustomLabels(prometheus.GaugeOpts{
+	Name: "drive_smart_percentage_used",
+	Help: "used endurance percentage of the drive reported by SMART",
+}, "source", "drive_uuid", "serial_number", "node")
+
+// SmartGrownDefects used to export grown defect list size of the SAS drive
+var SmartGrownDefects = metrics.NewMetricsWithCustomLabels(prometheus.GaugeOpts{
+	Name: "drive_smart_grown_defects",
+	Help: "grown defect list size of the drive reported by SMART",
+}, "source", "drive_uuid", "serial_number", "node")
+
 // nolint: gochecknoinits
 func init() {
 	prometheus.MustRegister(DbgNodeStageDuration.Collect())
 	prometheus.MustRegister(DbgNodePublishDuration.Collect())
+	prometheus.MustRegister(SmartTemperature.Collect())
+	prometheus.MustRegister(SmartPowerOnHours.Collect())
+	prometheus.MustRegister(SmartReallocatedSectors.Collect())
+	prometheus.MustRegister(SmartPendingSectors.Collect())
+	prometheus.MustRegister(SmartMediaErrors.Collect())
+	prometheus.MustRegister(SmartPercentageUsed.Collect())
+	prometheus.MustRegister(SmartGrownDefects.Collect())
 }
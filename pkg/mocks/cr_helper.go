@@ -16,6 +16,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	snapshotcrd "github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+
 	v1api "github.com/dell/csi-baremetal/api/generated/v1"
 
 	volumecrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
@@ -242,6 +244,35 @@ func (_m *CRHelper) GetLVGCRs(node ...string) ([]lvgcrd.LogicalVolumeGroup, erro
 	return r0, r1
 }
 
+// GetSnapshotCRs provides a mock function with given fields: volumeID
+func (_m *CRHelper) GetSnapshotCRs(volumeID ...string) ([]snapshotcrd.Snapshot, error) {
+	_va := make([]interface{}, len(volumeID))
+	for _i := range volumeID {
+		_va[_i] = volumeID[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []snapshotcrd.Snapshot
+	if rf, ok := ret.Get(0).(func(...string) []snapshotcrd.Snapshot); ok {
+		r0 = rf(volumeID...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]snapshotcrd.Snapshot)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(...string) error); ok {
+		r1 = rf(volumeID...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVGNameByLVGCRName provides a mock function with given fields: lvgCRName
 func (_m *CRHelper) GetVGNameByLVGCRName(lvgCRName string) (string, error) {
 	ret := _m.Called(lvgCRName)
@@ -263,6 +294,29 @@ func (_m *CRHelper) GetVGNameByLVGCRName(lvgCRName string) (string, error) {
 	return r0, r1
 }
 
+// GetSnapshotByID provides a mock function with given fields: snapshotID
+func (_m *CRHelper) GetSnapshotByID(snapshotID string) (*snapshotcrd.Snapshot, error) {
+	ret := _m.Called(snapshotID)
+
+	var r0 *snapshotcrd.Snapshot
+	if rf, ok := ret.Get(0).(func(string) *snapshotcrd.Snapshot); ok {
+		r0 = rf(snapshotID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*snapshotcrd.Snapshot)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(snapshotID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVolumeByID provides a mock function with given fields: volID
 func (_m *CRHelper) GetVolumeByID(volID string) (*volumecrd.Volume, error) {
 	ret := _m.Called(volID)
@@ -74,6 +74,11 @@ func (m *MockDriveMgrClientFail) GetAllDrivesSmartInfo(ctx context.Context, req
 	return nil, status.Errorf(m.Code, "method GetAllDrivesSmartInfo in MockDriveMgrClient returns: %d", m.Code)
 }
 
+// WatchDrives is a stub for WatchDrives DriveManager's method, node falls back to GetDrivesList polling
+func (m *MockDriveMgrClientFail) WatchDrives(ctx context.Context, in *api.WatchDrivesRequest, opts ...grpc.CallOption) (api.DriveService_WatchDrivesClient, error) {
+	return nil, status.Error(codes.Unimplemented, "method WatchDrives not implemented in MockDriveMgrClientFail")
+}
+
 // NewMockDriveMgrClient returns new instance of MockDriveMgrClient
 // Receives slice of api.Drive which would be used in imitation of GetDrivesList
 func NewMockDriveMgrClient(drives []*api.Drive, smartInfo SmartInfo) *MockDriveMgrClient {
@@ -135,6 +140,11 @@ func (m *MockDriveMgrClient) GetAllDrivesSmartInfo(ctx context.Context, req *api
 	return nil, status.Errorf(codes.NotFound, "failed to get smart info of all drives: NotFound")
 }
 
+// WatchDrives is a stub for WatchDrives DriveManager's method, node falls back to GetDrivesList polling
+func (m *MockDriveMgrClient) WatchDrives(ctx context.Context, in *api.WatchDrivesRequest, opts ...grpc.CallOption) (api.DriveService_WatchDrivesClient, error) {
+	return nil, status.Error(codes.Unimplemented, "method WatchDrives not implemented in MockDriveMgrClient")
+}
+
 // GetDrivesList is the simulation of failure during DriveManager's GetDrivesList
 // Returns nil DrivesResponse and non nil error
 func (m *MockDriveMgrClientFailJSON) GetDrivesList(ctx context.Context, in *api.DrivesRequest, opts ...grpc.CallOption) (*api.DrivesResponse, error) {
@@ -164,3 +174,8 @@ func (m *MockDriveMgrClientFailJSON) GetAllDrivesSmartInfo(ctx context.Context,
 		SmartInfo: m.MockJSON,
 	}, nil
 }
+
+// WatchDrives is a stub for WatchDrives DriveManager's method, node falls back to GetDrivesList polling
+func (m *MockDriveMgrClientFailJSON) WatchDrives(ctx context.Context, in *api.WatchDrivesRequest, opts ...grpc.CallOption) (api.DriveService_WatchDrivesClient, error) {
+	return nil, status.Error(codes.Unimplemented, "method WatchDrives not implemented in MockDriveMgrClientFailJSON")
+}
@@ -0,0 +1,35 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/blkcopy"
+)
+
+// MockWrapBlkCopy is a mock implementation of WrapBlkCopy interface from blkcopy package
+type MockWrapBlkCopy struct {
+	mock.Mock
+}
+
+// Copy is a mock implementations
+func (m *MockWrapBlkCopy) Copy(src, dst string, progress blkcopy.ProgressFunc) error {
+	args := m.Mock.Called(src, dst, progress)
+
+	return args.Error(0)
+}
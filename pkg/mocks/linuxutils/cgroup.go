@@ -0,0 +1,49 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cgroup"
+)
+
+// MockWrapCgroup is a mock implementation of WrapCgroup interface from cgroup package
+type MockWrapCgroup struct {
+	mock.Mock
+}
+
+// GetDeviceNumbers is a mock implementations
+func (m *MockWrapCgroup) GetDeviceNumbers(device string) (string, error) {
+	args := m.Mock.Called(device)
+
+	return args.String(0), args.Error(1)
+}
+
+// SetIOMax is a mock implementations
+func (m *MockWrapCgroup) SetIOMax(podUID, deviceNumbers string, limits cgroup.IOLimits) error {
+	args := m.Mock.Called(podUID, deviceNumbers, limits)
+
+	return args.Error(0)
+}
+
+// ResetIOMax is a mock implementations
+func (m *MockWrapCgroup) ResetIOMax(podUID, deviceNumbers string) error {
+	args := m.Mock.Called(podUID, deviceNumbers)
+
+	return args.Error(0)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWrapCryptsetup is a mock implementation of WrapCryptsetup interface from cryptsetup package
+type MockWrapCryptsetup struct {
+	mock.Mock
+}
+
+// IsLuks is a mock implementations
+func (m *MockWrapCryptsetup) IsLuks(device string) (bool, error) {
+	args := m.Mock.Called(device)
+
+	return args.Bool(0), args.Error(1)
+}
+
+// LuksFormat is a mock implementations
+func (m *MockWrapCryptsetup) LuksFormat(device string, key []byte) error {
+	args := m.Mock.Called(device, key)
+
+	return args.Error(0)
+}
+
+// Open is a mock implementations
+func (m *MockWrapCryptsetup) Open(device, name string, key []byte) error {
+	args := m.Mock.Called(device, name, key)
+
+	return args.Error(0)
+}
+
+// IsOpened is a mock implementations
+func (m *MockWrapCryptsetup) IsOpened(name string) bool {
+	args := m.Mock.Called(name)
+
+	return args.Bool(0)
+}
+
+// Close is a mock implementations
+func (m *MockWrapCryptsetup) Close(name string) error {
+	args := m.Mock.Called(name)
+
+	return args.Error(0)
+}
+
+// Resize is a mock implementations
+func (m *MockWrapCryptsetup) Resize(name string, key []byte) error {
+	args := m.Mock.Called(name, key)
+
+	return args.Error(0)
+}
+
+// Erase is a mock implementations
+func (m *MockWrapCryptsetup) Erase(device string) error {
+	args := m.Mock.Called(device)
+
+	return args.Error(0)
+}
@@ -70,7 +70,7 @@ func (m *MockWrapFS) RmDir(src string) error {
 }
 
 // CreateFS is a mock implementations
-func (m *MockWrapFS) CreateFS(fsType fs.FileSystem, device, uuid string) error {
+func (m *MockWrapFS) CreateFS(fsType fs.FileSystem, device, uuid string, _ ...string) error {
 	args := m.Mock.Called(fsType, device, uuid)
 
 	return args.Error(0)
@@ -138,3 +138,34 @@ func (m *MockWrapFS) RemoveLoopDevice(device string) error {
 
 	return args.Error(0)
 }
+
+// SetFSUUID is a mock implementation
+func (m *MockWrapFS) SetFSUUID(fsType fs.FileSystem, device, uuid string) error {
+	args := m.Mock.Called(fsType, device, uuid)
+
+	return args.Error(0)
+}
+
+// GetFSStats is a mock implementations
+func (m *MockWrapFS) GetFSStats(path string) (*fs.FSStats, error) {
+	args := m.Mock.Called(path)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fs.FSStats), args.Error(1)
+}
+
+// GetBlockDeviceSize is a mock implementations
+func (m *MockWrapFS) GetBlockDeviceSize(device string) (int64, error) {
+	args := m.Mock.Called(device)
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GrowFS is a mock implementations
+func (m *MockWrapFS) GrowFS(fsType fs.FileSystem, device, mountPoint string) error {
+	args := m.Mock.Called(fsType, device, mountPoint)
+
+	return args.Error(0)
+}
@@ -0,0 +1,40 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWrapIpmi is a mock implementation of WrapIpmi interface from ipmi package
+type MockWrapIpmi struct {
+	mock.Mock
+}
+
+// GetBmcIP is a mock implementations
+func (m *MockWrapIpmi) GetBmcIP() string {
+	args := m.Mock.Called()
+
+	return args.String(0)
+}
+
+// ChassisIdentify is a mock implementations
+func (m *MockWrapIpmi) ChassisIdentify(on bool) error {
+	args := m.Mock.Called(on)
+
+	return args.Error(0)
+}
@@ -0,0 +1,40 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWrapLedctl is a mock implementation of WrapLedctl interface from ledctl package
+type MockWrapLedctl struct {
+	mock.Mock
+}
+
+// GetLocate is a mock implementations
+func (m *MockWrapLedctl) GetLocate(path string) (bool, error) {
+	args := m.Mock.Called(path)
+
+	return args.Bool(0), args.Error(1)
+}
+
+// SetLocate is a mock implementations
+func (m *MockWrapLedctl) SetLocate(path string, on bool) error {
+	args := m.Mock.Called(path, on)
+
+	return args.Error(0)
+}
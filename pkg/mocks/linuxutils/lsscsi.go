@@ -33,3 +33,17 @@ func (m *MockWrapLsscsi) GetSCSIDevices() ([]*lsscsi.SCSIDevice, error) {
 
 	return args.Get(0).([]*lsscsi.SCSIDevice), args.Error(1)
 }
+
+// GetSASAddress is a mock implementations
+func (m *MockWrapLsscsi) GetSASAddress(path string) (string, error) {
+	args := m.Mock.Called(path)
+
+	return args.String(0), args.Error(1)
+}
+
+// GetEnclosures is a mock implementations
+func (m *MockWrapLsscsi) GetEnclosures() ([]string, error) {
+	args := m.Mock.Called()
+
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -18,6 +18,8 @@ package linuxutils
 
 import (
 	"github.com/stretchr/testify/mock"
+
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
 )
 
 // MockWrapLVM is a mock implementation of WrapLVM interface from lvm package
@@ -88,6 +90,13 @@ func (m *MockWrapLVM) LVRemove(fullLVName string) error {
 	return args.Error(0)
 }
 
+// LVSnapshot is a mock implementations
+func (m *MockWrapLVM) LVSnapshot(name, size, fullLVName string) error {
+	args := m.Mock.Called(name, size, fullLVName)
+
+	return args.Error(0)
+}
+
 // IsVGContainsLVs is a mock implementations
 func (m *MockWrapLVM) IsVGContainsLVs(vgName string) bool {
 	args := m.Mock.Called(vgName)
@@ -137,3 +146,132 @@ func (m *MockWrapLVM) GetVGNameByPVName(pvName string) (string, error) {
 
 	return args.String(0), args.Error(1)
 }
+
+// ThinPoolCreate is a mock implementations
+func (m *MockWrapLVM) ThinPoolCreate(name, metadataSize, vgName string) error {
+	args := m.Mock.Called(name, metadataSize, vgName)
+
+	return args.Error(0)
+}
+
+// ThinLVCreate is a mock implementations
+func (m *MockWrapLVM) ThinLVCreate(name, size, fullPoolName string) error {
+	args := m.Mock.Called(name, size, fullPoolName)
+
+	return args.Error(0)
+}
+
+// ThinSnapshot is a mock implementations
+func (m *MockWrapLVM) ThinSnapshot(name, fullLVName string) error {
+	args := m.Mock.Called(name, fullLVName)
+
+	return args.Error(0)
+}
+
+// GetThinPoolUsage is a mock implementations
+func (m *MockWrapLVM) GetThinPoolUsage(fullPoolName string) (*lvm.ThinPoolUsage, error) {
+	args := m.Mock.Called(fullPoolName)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*lvm.ThinPoolUsage), args.Error(1)
+}
+
+// RaidLVCreate is a mock implementation
+func (m *MockWrapLVM) RaidLVCreate(name, size, vgName, raidType string) error {
+	args := m.Mock.Called(name, size, vgName, raidType)
+
+	return args.Error(0)
+}
+
+// StripedLVCreate is a mock implementation
+func (m *MockWrapLVM) StripedLVCreate(name, size, vgName string, stripes int, stripeSize string) error {
+	args := m.Mock.Called(name, size, vgName, stripes, stripeSize)
+
+	return args.Error(0)
+}
+
+// RaidLVRepair is a mock implementation
+func (m *MockWrapLVM) RaidLVRepair(fullLVName, pvName string) error {
+	args := m.Mock.Called(fullLVName, pvName)
+
+	return args.Error(0)
+}
+
+// VGExtend is a mock implementation
+func (m *MockWrapLVM) VGExtend(name, pvName string) error {
+	args := m.Mock.Called(name, pvName)
+
+	return args.Error(0)
+}
+
+// VGReduceMissing is a mock implementation
+func (m *MockWrapLVM) VGReduceMissing(name string) error {
+	args := m.Mock.Called(name)
+
+	return args.Error(0)
+}
+
+// PVMove is a mock implementation
+func (m *MockWrapLVM) PVMove(srcPVName, dstPVName string) error {
+	args := m.Mock.Called(srcPVName, dstPVName)
+
+	return args.Error(0)
+}
+
+// PVMoveResume is a mock implementation
+func (m *MockWrapLVM) PVMoveResume() error {
+	args := m.Mock.Called()
+
+	return args.Error(0)
+}
+
+// GetPVMoveProgress is a mock implementation
+func (m *MockWrapLVM) GetPVMoveProgress(vgName string) (float64, bool, error) {
+	args := m.Mock.Called(vgName)
+
+	return args.Get(0).(float64), args.Bool(1), args.Error(2)
+}
+
+// VGReduce is a mock implementation
+func (m *MockWrapLVM) VGReduce(name, pvName string) error {
+	args := m.Mock.Called(name, pvName)
+
+	return args.Error(0)
+}
+
+// PVChangeAllocatable is a mock implementation
+func (m *MockWrapLVM) PVChangeAllocatable(pvName string, allocatable bool) error {
+	args := m.Mock.Called(pvName, allocatable)
+
+	return args.Error(0)
+}
+
+// CacheAttach is a mock implementation
+func (m *MockWrapLVM) CacheAttach(fullLVName, fullCacheLVName, cacheMode string) error {
+	args := m.Mock.Called(fullLVName, fullCacheLVName, cacheMode)
+
+	return args.Error(0)
+}
+
+// CacheDetach is a mock implementation
+func (m *MockWrapLVM) CacheDetach(fullLVName string) error {
+	args := m.Mock.Called(fullLVName)
+
+	return args.Error(0)
+}
+
+// LVCreateOnPV is a mock implementation
+func (m *MockWrapLVM) LVCreateOnPV(name, size, vgName, pvName string) error {
+	args := m.Mock.Called(name, size, vgName, pvName)
+
+	return args.Error(0)
+}
+
+// IsLVCached is a mock implementation
+func (m *MockWrapLVM) IsLVCached(fullLVName string) (bool, error) {
+	args := m.Mock.Called(fullLVName)
+
+	return args.Bool(0), args.Error(1)
+}
@@ -33,3 +33,10 @@ func (m *MockWrapNvmecli) GetNVMDevices() ([]nvmecli.NVMDevice, error) {
 
 	return args.Get(0).([]nvmecli.NVMDevice), args.Error(1)
 }
+
+// GetSMARTLogJSON is a mock implementations
+func (m *MockWrapNvmecli) GetSMARTLogJSON(path string) (string, error) {
+	args := m.Mock.Called(path)
+
+	return args.String(0), args.Error(1)
+}
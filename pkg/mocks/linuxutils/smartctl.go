@@ -33,3 +33,10 @@ func (m *MockWrapSmartctl) GetDriveInfoByPath(path string) (*smartctl.DeviceSMAR
 
 	return args.Get(0).(*smartctl.DeviceSMARTInfo), args.Error(1)
 }
+
+// GetSMARTInfoJSON is a mock implementations
+func (m *MockWrapSmartctl) GetSMARTInfoJSON(path string) (string, error) {
+	args := m.Mock.Called(path)
+
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWrapWipe is a mock implementation of WrapWipe interface from wipe package
+type MockWrapWipe struct {
+	mock.Mock
+}
+
+// Discard is a mock implementations
+func (m *MockWrapWipe) Discard(device string) error {
+	args := m.Mock.Called(device)
+
+	return args.Error(0)
+}
+
+// ZeroOut is a mock implementations
+func (m *MockWrapWipe) ZeroOut(device string) error {
+	args := m.Mock.Called(device)
+
+	return args.Error(0)
+}
+
+// NVMeFormat is a mock implementations
+func (m *MockWrapWipe) NVMeFormat(device string) error {
+	args := m.Mock.Called(device)
+
+	return args.Error(0)
+}
+
+// ATASecureErase is a mock implementations
+func (m *MockWrapWipe) ATASecureErase(device string) error {
+	args := m.Mock.Called(device)
+
+	return args.Error(0)
+}
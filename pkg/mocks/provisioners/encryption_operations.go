@@ -0,0 +1,40 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioners
+
+import (
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+)
+
+// MockEncryptionOpts is a mock implementation of EncryptionOperations interface from utilwrappers package
+type MockEncryptionOpts struct {
+	mocklu.MockWrapCryptsetup
+}
+
+// OpenEncryptedDevice is a mock implementation
+func (m *MockEncryptionOpts) OpenEncryptedDevice(device, name string, key []byte, format bool) (string, error) {
+	args := m.Mock.Called(device, name, key, format)
+
+	return args.String(0), args.Error(1)
+}
+
+// ReleaseEncryptedDevice is a mock implementation
+func (m *MockEncryptionOpts) ReleaseEncryptedDevice(device, name string) error {
+	args := m.Mock.Called(device, name)
+
+	return args.Error(0)
+}
@@ -48,7 +48,7 @@ func (m *MockFsOpts) UnmountWithCheck(path string) error {
 }
 
 // CreateFSIfNotExist is a mock implementation
-func (m *MockFsOpts) CreateFSIfNotExist(fsType fs.FileSystem, device, uuid string) error {
+func (m *MockFsOpts) CreateFSIfNotExist(fsType fs.FileSystem, device, uuid, _ string) error {
 	args := m.Mock.Called(fsType, device, uuid)
 
 	return args.Error(0)
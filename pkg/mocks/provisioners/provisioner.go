@@ -20,9 +20,10 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/blkcopy"
 )
 
-// MockProvisioner is a mock implementation of Provisioner interface
+// MockProvisioner is a mock implementation of Provisioner, ContentProvisioner and SnapshotProvisioner interfaces
 type MockProvisioner struct {
 	mock.Mock
 }
@@ -33,7 +34,12 @@ func GetMockProvisionerSuccess(everytimePath string) *MockProvisioner {
 	mp := MockProvisioner{}
 	mp.On("PrepareVolume", mock.Anything).Return(nil)
 	mp.On("ReleaseVolume", mock.Anything, mock.Anything).Return(nil)
+	mp.On("ExpandVolume", mock.Anything).Return(nil)
 	mp.On("GetVolumePath", mock.Anything).Return(everytimePath, nil)
+	mp.On("CopyVolumeContent", mock.Anything, mock.Anything).Return(nil)
+	mp.On("PrepareSnapshot", mock.Anything).Return(nil)
+	mp.On("ReleaseSnapshot", mock.Anything).Return(nil)
+	mp.On("GetSnapshotPath", mock.Anything).Return(everytimePath, nil)
 
 	return &mp
 }
@@ -52,9 +58,44 @@ func (m *MockProvisioner) ReleaseVolume(volume *api.Volume, drive *api.Drive) er
 	return args.Error(0)
 }
 
+// ExpandVolume is the mock implementation of ExpandVolume method from Provisioner interface
+func (m *MockProvisioner) ExpandVolume(volume *api.Volume) error {
+	args := m.Mock.Called(volume)
+
+	return args.Error(0)
+}
+
 // GetVolumePath is the mock implementation of GetVolumePath method from Provisioner interface
 func (m *MockProvisioner) GetVolumePath(volume *api.Volume) (string, error) {
 	args := m.Mock.Called(volume)
 
 	return args.String(0), args.Error(1)
 }
+
+// CopyVolumeContent is the mock implementation of CopyVolumeContent method from ContentProvisioner interface
+func (m *MockProvisioner) CopyVolumeContent(volume *api.Volume, progress blkcopy.ProgressFunc) error {
+	args := m.Mock.Called(volume, progress)
+
+	return args.Error(0)
+}
+
+// PrepareSnapshot is the mock implementation of PrepareSnapshot method from SnapshotProvisioner interface
+func (m *MockProvisioner) PrepareSnapshot(snapshot *api.Snapshot) error {
+	args := m.Mock.Called(snapshot)
+
+	return args.Error(0)
+}
+
+// ReleaseSnapshot is the mock implementation of ReleaseSnapshot method from SnapshotProvisioner interface
+func (m *MockProvisioner) ReleaseSnapshot(snapshot *api.Snapshot) error {
+	args := m.Mock.Called(snapshot)
+
+	return args.Error(0)
+}
+
+// GetSnapshotPath is the mock implementation of GetSnapshotPath method from SnapshotProvisioner interface
+func (m *MockProvisioner) GetSnapshotPath(snapshot *api.Snapshot) (string, error) {
+	args := m.Mock.Called(snapshot)
+
+	return args.String(0), args.Error(1)
+}
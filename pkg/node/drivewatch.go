@@ -0,0 +1,157 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+// watchDrivesBackoff is used between reconnects of WatchDrives stream, it is reset after snapshot is received
+var watchDrivesBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Steps:    7,
+	Cap:      time.Minute,
+}
+
+// driveSnapshot holds drives received from WatchDrives stream of DriveManager
+type driveSnapshot struct {
+	sync.Mutex
+	// synced is true if snapshot is received from current stream
+	synced bool
+	// drives by serial number
+	drives map[string]*api.Drive
+}
+
+// apply applies snapshot or drive events from WatchDrives response
+func (s *driveSnapshot) apply(response *api.WatchDrivesResponse) {
+	s.Lock()
+	defer s.Unlock()
+	if response.Snapshot {
+		s.drives = make(map[string]*api.Drive, len(response.Events))
+		s.synced = true
+	}
+	for _, event := range response.Events {
+		if event.Drive == nil {
+			continue
+		}
+		switch event.Type {
+		case apiV1.DriveEventAdded, apiV1.DriveEventModified:
+			s.drives[event.Drive.SerialNumber] = event.Drive
+		case apiV1.DriveEventRemoved:
+			delete(s.drives, event.Drive.SerialNumber)
+		}
+	}
+}
+
+// list returns copies of drives sorted by serial number and true if snapshot is synced with DriveManager
+func (s *driveSnapshot) list() ([]*api.Drive, bool) {
+	s.Lock()
+	defer s.Unlock()
+	if !s.synced {
+		return nil, false
+	}
+	drives := make([]*api.Drive, 0, len(s.drives))
+	for _, drive := range s.drives {
+		drives = append(drives, proto.Clone(drive).(*api.Drive))
+	}
+	sort.Slice(drives, func(i, j int) bool {
+		return drives[i].SerialNumber < drives[j].SerialNumber
+	})
+	return drives, true
+}
+
+// reset marks snapshot as not synced, Discover polls drives until next snapshot is received
+func (s *driveSnapshot) reset() {
+	s.Lock()
+	defer s.Unlock()
+	s.synced = false
+	s.drives = nil
+}
+
+// WatchDrives consumes WatchDrives stream of DriveManager and reconnects with backoff if stream is broken.
+// Discover uses drives from the stream instead of GetDrivesList while stream is alive, onChange is called
+// on each received response to run Discover without waiting for next polling.
+// Returns when ctx is done or DriveManager doesn't support the stream, Discover keeps polling GetDrivesList in that case
+func (m *VolumeManager) WatchDrives(ctx context.Context, onChange func()) {
+	ll := m.log.WithField("method", "WatchDrives")
+	backoff := watchDrivesBackoff
+	for {
+		synced, err := m.watchDrives(ctx, onChange)
+		m.drivesSnapshot.reset()
+		if ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			ll.Infof("DriveManager doesn't support drives watching, drives are polled")
+			return
+		}
+		if synced {
+			backoff = watchDrivesBackoff
+		}
+		delay := backoff.Step()
+		ll.Warnf("WatchDrives stream is broken: %v, reconnect in %s", err, delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// watchDrives receives responses of WatchDrives stream until it is broken
+// Returns true if snapshot was received and error which broke the stream
+func (m *VolumeManager) watchDrives(ctx context.Context, onChange func()) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := m.driveMgrClient.WatchDrives(ctx, &api.WatchDrivesRequest{NodeId: m.nodeID})
+	if err != nil {
+		return false, err
+	}
+	synced := false
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return synced, status.Error(codes.Unavailable, "stream is closed by DriveManager")
+		}
+		if err != nil {
+			return synced, err
+		}
+		if !synced && !response.Snapshot {
+			// drive events can't be applied without snapshot
+			continue
+		}
+		synced = true
+		m.drivesSnapshot.apply(response)
+		m.log.WithField("method", "watchDrives").
+			Infof("Received %d drive events, snapshot: %v", len(response.Events), response.Snapshot)
+		onChange()
+	}
+}
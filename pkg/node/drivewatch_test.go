@@ -0,0 +1,161 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	dataDiscover "github.com/dell/csi-baremetal/pkg/base/linuxutils/datadiscover/types"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+)
+
+// watchDrivesClient is DriveServiceClient which returns streams with responses
+type watchDrivesClient struct {
+	*mocks.MockDriveMgrClientFail
+	responses []*api.WatchDrivesResponse
+	calls     int
+}
+
+func (c *watchDrivesClient) WatchDrives(ctx context.Context, in *api.WatchDrivesRequest, opts ...grpc.CallOption) (api.DriveService_WatchDrivesClient, error) {
+	c.calls++
+	return &watchDrivesStream{responses: c.responses}, nil
+}
+
+// watchDrivesStream returns responses and then io.EOF
+type watchDrivesStream struct {
+	grpc.ClientStream
+	responses []*api.WatchDrivesResponse
+}
+
+func (s *watchDrivesStream) Recv() (*api.WatchDrivesResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+	return response, nil
+}
+
+func TestDriveSnapshot(t *testing.T) {
+	snapshot := &driveSnapshot{}
+	_, synced := snapshot.list()
+	assert.False(t, synced)
+
+	d1, d2 := &api.Drive{SerialNumber: "SN1"}, &api.Drive{SerialNumber: "SN2"}
+	snapshot.apply(&api.WatchDrivesResponse{Snapshot: true, Events: []*api.DriveEvent{
+		{Type: apiV1.DriveEventAdded, Drive: d2},
+		{Type: apiV1.DriveEventAdded, Drive: d1},
+	}})
+	drives, synced := snapshot.list()
+	assert.True(t, synced)
+	assert.Equal(t, []*api.Drive{d1, d2}, drives)
+
+	// returned drives are copies
+	drives[0].UUID = "uuid"
+	assert.Equal(t, "", d1.UUID)
+
+	d2Bad := &api.Drive{SerialNumber: "SN2", Health: apiV1.HealthBad}
+	snapshot.apply(&api.WatchDrivesResponse{Events: []*api.DriveEvent{
+		{Type: apiV1.DriveEventModified, Drive: d2Bad},
+		{Type: apiV1.DriveEventRemoved, Drive: d1},
+	}})
+	drives, _ = snapshot.list()
+	assert.Equal(t, []*api.Drive{d2Bad}, drives)
+
+	snapshot.reset()
+	_, synced = snapshot.list()
+	assert.False(t, synced)
+}
+
+func TestVolumeManager_WatchDrives(t *testing.T) {
+	backoff := watchDrivesBackoff
+	watchDrivesBackoff = wait.Backoff{Duration: time.Millisecond, Steps: 1}
+	defer func() { watchDrivesBackoff = backoff }()
+
+	t.Run("Unimplemented", func(t *testing.T) {
+		vm := prepareSuccessVolumeManager(t)
+		vm.WatchDrives(context.Background(), func() {
+			t.Fatal("unexpected change")
+		})
+	})
+
+	t.Run("Reconnect", func(t *testing.T) {
+		d1, d2 := &api.Drive{SerialNumber: "SN1"}, &api.Drive{SerialNumber: "SN2"}
+		client := &watchDrivesClient{
+			MockDriveMgrClientFail: &mocks.MockDriveMgrClientFail{Code: codes.Unavailable},
+			responses: []*api.WatchDrivesResponse{
+				{Events: []*api.DriveEvent{{Type: apiV1.DriveEventAdded, Drive: d2}}},
+				{Snapshot: true, Events: []*api.DriveEvent{{Type: apiV1.DriveEventAdded, Drive: d1}}},
+				{Events: []*api.DriveEvent{{Type: apiV1.DriveEventAdded, Drive: d2}}},
+			},
+		}
+		vm := prepareSuccessVolumeManager(t)
+		vm.driveMgrClient = client
+
+		ctx, cancel := context.WithCancel(context.Background())
+		changes := make([][]*api.Drive, 0)
+		vm.WatchDrives(ctx, func() {
+			drives, synced := vm.drivesSnapshot.list()
+			assert.True(t, synced)
+			changes = append(changes, drives)
+			if len(changes) == 4 {
+				cancel()
+			}
+		})
+
+		assert.Equal(t, 2, client.calls)
+		assert.Equal(t, [][]*api.Drive{{d1}, {d1, d2}, {d1}, {d1, d2}}, changes)
+		_, synced := vm.drivesSnapshot.list()
+		assert.False(t, synced)
+	})
+}
+
+func TestVolumeManager_DiscoverWatchedDrives(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	// GetDrivesList isn't called while WatchDrives stream is alive
+	vm.driveMgrClient = &mocks.MockDriveMgrClientFail{Code: codes.Unavailable}
+	discoverData := &mocklu.MockWrapDataDiscover{}
+	discoverData.On("DiscoverData", mock.Anything, mock.Anything).Return(&dataDiscover.DiscoverResult{}, nil)
+	vm.dataDiscover = discoverData
+
+	events := make([]*api.DriveEvent, 0)
+	for _, drive := range getDriveMgrRespBasedOnDrives(drive1, drive2) {
+		events = append(events, &api.DriveEvent{Type: apiV1.DriveEventAdded, Drive: drive})
+	}
+	vm.drivesSnapshot.apply(&api.WatchDrivesResponse{Snapshot: true, Events: events})
+	assert.Nil(t, vm.Discover())
+
+	drives := &drivecrd.DriveList{}
+	assert.Nil(t, vm.k8sClient.ReadList(testCtx, drives))
+	assert.Equal(t, 2, len(drives.Items))
+
+	vm.drivesSnapshot.reset()
+	assert.NotNil(t, vm.Discover())
+}
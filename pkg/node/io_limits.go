@@ -0,0 +1,100 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cgroup"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// applyIOLimits throttles IO of the pod to the device of the volume with io.max of the pod cgroup
+// and reports applied limits in the volume annotation, Volume CR isn't updated
+// Receives volume and UID of the pod which consumes the volume
+// Returns error if something went wrong
+func (m *VolumeManager) applyIOLimits(vol *volumecrd.Volume, podUID string) error {
+	limits, err := cgroup.ParseIOLimits(vol.Spec.IOLimits)
+	if err != nil {
+		return err
+	}
+	if limits.IsEmpty() {
+		return nil
+	}
+	if podUID == "" {
+		return fmt.Errorf("pod UID isn't provided, podInfoOnMount is required for IO limits")
+	}
+
+	device, err := m.getIOLimitsDevice(vol)
+	if err != nil {
+		return err
+	}
+	deviceNumbers, err := m.cgroupOps.GetDeviceNumbers(device)
+	if err != nil {
+		return err
+	}
+	if err = m.cgroupOps.SetIOMax(podUID, deviceNumbers, limits); err != nil {
+		return err
+	}
+
+	if vol.Annotations == nil {
+		vol.Annotations = make(map[string]string)
+	}
+	vol.Annotations[apiV1.VolumeAnnotationIOMaxPrefix+podUID] = fmt.Sprintf("%s %s", deviceNumbers, limits)
+	return nil
+}
+
+// removeIOLimits removes IO limits which were applied for the pod which unpublishes the volume,
+// pod UID is a part of the target path. Volume CR isn't updated
+// Receives volume and target path of NodeUnpublishVolume request
+// Returns error if something went wrong
+func (m *VolumeManager) removeIOLimits(vol *volumecrd.Volume, targetPath string) error {
+	pathParts := strings.Split(targetPath, "/")
+	for key, value := range vol.Annotations {
+		if !strings.HasPrefix(key, apiV1.VolumeAnnotationIOMaxPrefix) {
+			continue
+		}
+		podUID := strings.TrimPrefix(key, apiV1.VolumeAnnotationIOMaxPrefix)
+		if !util.ContainsString(pathParts, podUID) {
+			continue
+		}
+		deviceNumbers, _, _ := strings.Cut(value, " ")
+		// cgroup is removed with the pod, limits are removed too
+		if err := m.cgroupOps.ResetIOMax(podUID, deviceNumbers); err != nil && !errors.Is(err, cgroup.ErrPodCgroupNotFound) {
+			return err
+		}
+		delete(vol.Annotations, key)
+	}
+	return nil
+}
+
+// getIOLimitsDevice returns path of the device which IO is throttled, partitions can't be throttled,
+// so the whole drive is used for drive based volume
+func (m *VolumeManager) getIOLimitsDevice(vol *volumecrd.Volume) (string, error) {
+	if util.IsStorageClassLVG(vol.Spec.StorageClass) {
+		return m.getProvisionerForVolume(&vol.Spec).GetVolumePath(&vol.Spec)
+	}
+	drive, err := m.crHelper.GetDriveCRByVolume(vol)
+	if err != nil {
+		return "", err
+	}
+	return drive.Spec.Path, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cgroup"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+const (
+	testPodUID        = "8c7ba4e3-2f5f-4cc3-9a36-ae1c0f5e2b3f"
+	testIOLimitsPath  = "/var/lib/kubelet/pods/" + testPodUID + "/volumes/kubernetes.io~csi/pvc-1/mount"
+	testDeviceNumbers = "8:0"
+)
+
+func TestVolumeManager_applyIOLimits(t *testing.T) {
+	var (
+		limits = cgroup.IOLimits{ReadIOPS: 100, WriteBPS: 1024}
+		ioMax  = apiV1.VolumeAnnotationIOMaxPrefix + testPodUID
+	)
+
+	t.Run("drive based volume", func(t *testing.T) {
+		vm := prepareSuccessVolumeManager(t)
+		cgroupOps := &mocklu.MockWrapCgroup{}
+		vm.cgroupOps = cgroupOps
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testDriveCR.Name, testDriveCR.DeepCopy()))
+		vol := volCR.DeepCopy()
+		vol.Spec.IOLimits = limits.String()
+
+		cgroupOps.On("GetDeviceNumbers", drive1.Path).Return(testDeviceNumbers, nil).Once()
+		cgroupOps.On("SetIOMax", testPodUID, testDeviceNumbers, limits).Return(nil).Once()
+		assert.Nil(t, vm.applyIOLimits(vol, testPodUID))
+		assert.Equal(t, "8:0 wbps=1024 riops=100", vol.Annotations[ioMax])
+
+		// limits are removed for the pod of the target path only
+		vol.Annotations[apiV1.VolumeAnnotationIOMaxPrefix+"other-pod"] = "8:0 riops=100"
+		cgroupOps.On("ResetIOMax", testPodUID, testDeviceNumbers).Return(nil).Once()
+		assert.Nil(t, vm.removeIOLimits(vol, testIOLimitsPath))
+		assert.Empty(t, vol.Annotations[ioMax])
+		assert.NotEmpty(t, vol.Annotations[apiV1.VolumeAnnotationIOMaxPrefix+"other-pod"])
+		cgroupOps.AssertExpectations(t)
+	})
+
+	t.Run("LVM volume", func(t *testing.T) {
+		vm := prepareSuccessVolumeManager(t)
+		cgroupOps := &mocklu.MockWrapCgroup{}
+		vm.cgroupOps = cgroupOps
+		pMock := mockProv.GetMockProvisionerSuccess("/dev/vg/lv")
+		vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.LVMBasedVolumeType: pMock})
+		vol := volCR.DeepCopy()
+		vol.Spec.StorageClass = apiV1.StorageClassHDDLVG
+		vol.Spec.IOLimits = limits.String()
+
+		cgroupOps.On("GetDeviceNumbers", "/dev/vg/lv").Return("253:1", nil).Once()
+		cgroupOps.On("SetIOMax", testPodUID, "253:1", limits).Return(nil).Once()
+		assert.Nil(t, vm.applyIOLimits(vol, testPodUID))
+		assert.Equal(t, "253:1 wbps=1024 riops=100", vol.Annotations[ioMax])
+
+		// pod is already removed
+		cgroupOps.On("ResetIOMax", testPodUID, "253:1").Return(cgroup.ErrPodCgroupNotFound).Once()
+		assert.Nil(t, vm.removeIOLimits(vol, testIOLimitsPath))
+		assert.Empty(t, vol.Annotations[ioMax])
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		vm := prepareSuccessVolumeManager(t)
+		cgroupOps := &mocklu.MockWrapCgroup{}
+		vm.cgroupOps = cgroupOps
+		vol := volCR.DeepCopy()
+
+		assert.Nil(t, vm.applyIOLimits(vol, testPodUID))
+		assert.Nil(t, vm.removeIOLimits(vol, testIOLimitsPath))
+		cgroupOps.AssertNotCalled(t, "SetIOMax")
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		vm := prepareSuccessVolumeManager(t)
+		cgroupOps := &mocklu.MockWrapCgroup{}
+		vm.cgroupOps = cgroupOps
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testDriveCR.Name, testDriveCR.DeepCopy()))
+		vol := volCR.DeepCopy()
+		vol.Spec.IOLimits = limits.String()
+
+		// pod UID isn't provided
+		assert.NotNil(t, vm.applyIOLimits(vol, ""))
+
+		// pod cgroup isn't found
+		cgroupOps.On("GetDeviceNumbers", drive1.Path).Return(testDeviceNumbers, nil)
+		cgroupOps.On("SetIOMax", testPodUID, testDeviceNumbers, limits).Return(cgroup.ErrPodCgroupNotFound).Once()
+		assert.NotNil(t, vm.applyIOLimits(vol, testPodUID))
+		assert.Empty(t, vol.Annotations[ioMax])
+
+		// limits aren't removed
+		vol.Annotations = map[string]string{ioMax: "8:0 riops=100"}
+		cgroupOps.On("ResetIOMax", testPodUID, testDeviceNumbers).Return(errors.New("io.max error")).Once()
+		assert.NotNil(t, vm.removeIOLimits(vol, testIOLimitsPath))
+		assert.NotEmpty(t, vol.Annotations[ioMax])
+	})
+}
@@ -44,6 +44,8 @@ import (
 	baseerr "github.com/dell/csi-baremetal/pkg/base/error"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cryptsetup"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/common"
 	"github.com/dell/csi-baremetal/pkg/controller"
@@ -152,6 +154,20 @@ func getStagingPath(logger *logrus.Entry, stagingPath string) string {
 	return stagingPath
 }
 
+// getMountOptions returns mount flags of the mount volume capability, they are applied on publish
+// where file system is mounted, stage is a bind mount of the block device
+// Returns error if any mount flag isn't supported by the file system of the volume
+func getMountOptions(capability *csi.VolumeCapability, fsType string) ([]string, error) {
+	accessType, ok := capability.GetAccessType().(*csi.VolumeCapability_Mount)
+	if !ok {
+		return nil, nil
+	}
+	if err := mountoptions.ValidateOptions(fsType, accessType.Mount.GetMountFlags()); err != nil {
+		return nil, err
+	}
+	return accessType.Mount.GetMountFlags(), nil
+}
+
 func (s *CSINodeService) processFakeAttachInNodeStageVolume(
 	ll *logrus.Entry,
 	volumeCR *volumecrd.Volume,
@@ -280,6 +296,8 @@ func (s *CSINodeService) isFakeAttachNeed(volumeCR *volumecrd.Volume) (bool, boo
 
 // NodeStageVolume is the implementation of CSI Spec NodeStageVolume. Performs when the first pod consumes a volume.
 // This method mounts volume with appropriate VolumeID into the StagingTargetPath from request.
+// Encrypted volume is opened (and formatted on the first stage) with the key from request secrets
+// and its mapper device is mounted.
 // Receives golang context and CSI Spec NodeStageVolumeRequest
 // Returns CSI Spec NodeStageVolumeResponse or error if something went wrong
 func (s *CSINodeService) NodeStageVolume(ctx context.Context, req *csi.NodeStageVolumeRequest) (retresp *csi.NodeStageVolumeResponse, reterr error) {
@@ -340,6 +358,12 @@ func (s *CSINodeService) NodeStageVolume(ctx context.Context, req *csi.NodeStage
 		ll.Errorf("Unexpected volume status: %s", currStatus)
 		return nil, fmt.Errorf("corresponding volume is in unexpected state - %s", currStatus)
 	}
+	// data of the volume is being copied to another drive, volume stays unstaged until copy is finished
+	if copyStatus := volumeCR.Annotations[apiV1.VolumeAnnotationCopyStatus]; copyStatus == apiV1.VolumeCopyPending ||
+		copyStatus == apiV1.VolumeCopyWaiting || copyStatus == apiV1.VolumeCopyInProgress {
+		ll.Errorf("Volume is being copied to drive %s", volumeCR.Annotations[apiV1.VolumeAnnotationCopyTarget])
+		return nil, status.Errorf(codes.Unavailable, "volume %s is being copied to another drive", volumeID)
+	}
 
 	var (
 		resp        = &csi.NodeStageVolumeResponse{}
@@ -363,6 +387,9 @@ func (s *CSINodeService) NodeStageVolume(ctx context.Context, req *csi.NodeStage
 	}
 
 	partition, err := s.getProvisionerForVolume(&volumeCR.Spec).GetVolumePath(&volumeCR.Spec)
+	if err == nil && volumeCR.Spec.EncryptionSecret != "" {
+		partition, err = s.openEncryptedVolume(ctx, volumeCR, partition, req.GetSecrets())
+	}
 	if err != nil {
 		if err == baseerr.ErrorGetDriveFailed {
 			return nil, err
@@ -409,6 +436,42 @@ func (s *CSINodeService) NodeStageVolume(ctx context.Context, req *csi.NodeStage
 	return resp, errToReturn
 }
 
+// openEncryptedVolume opens LUKS device of the encrypted volume with the key from CSI secrets.
+// Node doesn't read Secrets, so device is formatted with LUKS and FS is created on the mapper device
+// during the first stage of the volume. Volume CR is marked with encryption/formatted annotation after format,
+// device of the marked volume is never formatted again
+// Returns path of the opened mapper device
+func (s *CSINodeService) openEncryptedVolume(ctx context.Context, volumeCR *volumecrd.Volume, device string,
+	secrets map[string]string) (string, error) {
+	vol := &volumeCR.Spec
+	key, ok := secrets[apiV1.EncryptionKeyName]
+	if !ok || key == "" {
+		return "", fmt.Errorf("encryption key %s is missing in node stage secrets", apiV1.EncryptionKeyName)
+	}
+	formatted := volumeCR.Annotations[apiV1.VolumeAnnotationEncryptionFormatted] == apiV1.VolumeEncryptionFormatted
+	mapperPath, err := s.encOps.OpenEncryptedDevice(device, vol.Id, []byte(key), !formatted)
+	if err != nil {
+		return "", err
+	}
+	if !formatted {
+		volumeCR.Annotations[apiV1.VolumeAnnotationEncryptionFormatted] = apiV1.VolumeEncryptionFormatted
+		if err = s.k8sClient.UpdateCR(ctx, volumeCR); err != nil {
+			return "", fmt.Errorf("unable to mark volume as formatted: %w", err)
+		}
+	}
+	if vol.Mode == apiV1.ModeRAW || vol.Mode == apiV1.ModeRAWPART {
+		return mapperPath, nil
+	}
+	fsUUID, err := util.GetVolumeUUID(vol.Id)
+	if err != nil {
+		return "", err
+	}
+	if err = s.fsOps.CreateFSIfNotExist(fs.FileSystem(vol.Type), mapperPath, fsUUID, vol.MkfsOptions); err != nil {
+		return "", fmt.Errorf("unable to create file system on encrypted device %s: %w", mapperPath, err)
+	}
+	return mapperPath, nil
+}
+
 // NodeUnstageVolume is the implementation of CSI Spec NodeUnstageVolume. Performs when the last pod stops consume
 // a volume. This method unmounts volume with appropriate VolumeID from the StagingTargetPath from request.
 // Receives golang context and CSI Spec NodeUnstageVolumeRequest
@@ -476,6 +539,9 @@ func (s *CSINodeService) NodeUnstageVolume(ctx context.Context, req *csi.NodeUns
 		if errToReturn == nil {
 			errToReturn = s.fsOps.RmDir(targetPath)
 		}
+		if errToReturn == nil && volumeCR.Spec.EncryptionSecret != "" {
+			errToReturn = s.encOps.Close(volumeCR.Spec.Id)
+		}
 
 		if errToReturn != nil {
 			volumeCR.Spec.CSIStatus = apiV1.Failed
@@ -544,15 +610,7 @@ func (s *CSINodeService) NodePublishVolume(ctx context.Context, req *csi.NodePub
 		return nil, status.Error(codes.InvalidArgument, "Target Path missing in request")
 	}
 	var (
-		err          error
-		mountOptions []string
-	)
-
-	if accessType, ok := req.GetVolumeCapability().AccessType.(*csi.VolumeCapability_Mount); ok {
-		mountOptions = mountoptions.FilterWithType(mountoptions.PublishCmdOpt, accessType.Mount.GetMountFlags())
-	}
-
-	var (
+		err      error
 		volumeID = req.GetVolumeId()
 		srcPath  = getStagingPath(ll, req.GetStagingTargetPath())
 		dstPath  = req.GetTargetPath()
@@ -576,6 +634,12 @@ func (s *CSINodeService) NodePublishVolume(ctx context.Context, req *csi.NodePub
 		return nil, status.Error(codes.FailedPrecondition, msg)
 	}
 
+	mountOptions, err := getMountOptions(req.GetVolumeCapability(), volumeCR.Spec.Type)
+	if err != nil {
+		ll.Errorf("Mount options are invalid: %v", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	var (
 		resp        = &csi.NodePublishVolumeResponse{}
 		newStatus   = apiV1.Published
@@ -593,6 +657,11 @@ func (s *CSINodeService) NodePublishVolume(ctx context.Context, req *csi.NodePub
 			ll.Errorf("Unable to mount volume: %v", err)
 			newStatus = apiV1.Failed
 			resp, errToReturn = nil, fmt.Errorf("failed to publish volume: mount error %s", err.Error())
+		} else if err := s.applyIOLimits(volumeCR, req.VolumeContext[util.PodUIDKey]); err != nil {
+			// volume is usable without throttling, so publishing isn't failed
+			ll.Errorf("Unable to apply IO limits %s: %v", volumeCR.Spec.IOLimits, err)
+			s.VolumeManager.recorder.Eventf(volumeCR, eventing.VolumeIOLimitsFailed,
+				"Unable to apply IO limits, error: %v", err)
 		}
 	}
 
@@ -678,6 +747,12 @@ func (s *CSINodeService) NodeUnpublishVolume(ctx context.Context, req *csi.NodeU
 		return nil, status.Error(codes.Internal, "unmount error")
 	}
 
+	if err := s.removeIOLimits(volumeCR, req.GetTargetPath()); err != nil {
+		ll.Errorf("Unable to remove IO limits: %v", err)
+		s.VolumeManager.recorder.Eventf(volumeCR, eventing.VolumeIOLimitsFailed,
+			"Unable to remove IO limits, error: %v", err)
+	}
+
 	// support volume sharing by multiple pods, here we need only remove the owner from volume owners list
 	// set volume state to VolumeReady only if Volume Owners is EMPTY
 	var pod corev1.Pod
@@ -718,18 +793,139 @@ func (s *CSINodeService) NodeUnpublishVolume(ctx context.Context, req *csi.NodeU
 	return &csi.NodeUnpublishVolumeResponse{}, nil
 }
 
-// NodeGetVolumeStats returns empty response
-func (s *CSINodeService) NodeGetVolumeStats(_ context.Context, _ *csi.NodeGetVolumeStatsRequest) (*csi.NodeGetVolumeStatsResponse, error) {
-	return &csi.NodeGetVolumeStatsResponse{}, nil
+// NodeGetVolumeStats is the implementation of CSI Spec NodeGetVolumeStats.
+// Reports capacity and inodes usage of FS volumes, device size of RAW volumes and volume condition
+// based on Health and OperationalStatus of the Volume CR.
+// Receives golang context and CSI Spec NodeGetVolumeStatsRequest
+// Returns CSI Spec NodeGetVolumeStatsResponse or error if something went wrong
+func (s *CSINodeService) NodeGetVolumeStats(_ context.Context, req *csi.NodeGetVolumeStatsRequest) (*csi.NodeGetVolumeStatsResponse, error) {
+	ll := s.log.WithFields(logrus.Fields{
+		"method":   "NodeGetVolumeStats",
+		"volumeID": req.GetVolumeId(),
+	})
+
+	if len(req.GetVolumeId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID missing in request")
+	}
+	if len(req.GetVolumePath()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume Path missing in request")
+	}
+
+	volumeCR, err := s.crHelper.GetVolumeByID(req.GetVolumeId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Unable to find volume")
+	}
+
+	resp := &csi.NodeGetVolumeStatsResponse{VolumeCondition: common.GetVolumeCondition(&volumeCR.Spec)}
+	switch volumeCR.Spec.Mode {
+	case apiV1.ModeRAW, apiV1.ModeRAWPART:
+		size, err := s.fsOps.GetBlockDeviceSize(req.GetVolumePath())
+		if err != nil {
+			ll.Errorf("Unable to get size of block device: %v", err)
+			return nil, status.Errorf(codes.NotFound, "unable to get stats for volume path %s", req.GetVolumePath())
+		}
+		resp.Usage = []*csi.VolumeUsage{{
+			Unit:  csi.VolumeUsage_BYTES,
+			Total: size,
+		}}
+	default:
+		stats, err := s.fsOps.GetFSStats(req.GetVolumePath())
+		if err != nil {
+			ll.Errorf("Unable to get file system stats: %v", err)
+			return nil, status.Errorf(codes.NotFound, "unable to get stats for volume path %s", req.GetVolumePath())
+		}
+		resp.Usage = []*csi.VolumeUsage{
+			{
+				Unit:      csi.VolumeUsage_BYTES,
+				Total:     stats.TotalBytes,
+				Available: stats.AvailableBytes,
+				Used:      stats.UsedBytes,
+			},
+			{
+				Unit:      csi.VolumeUsage_INODES,
+				Total:     stats.TotalInodes,
+				Available: stats.FreeInodes,
+				Used:      stats.UsedInodes,
+			},
+		}
+	}
+
+	return resp, nil
 }
 
-// NodeExpandVolume returns empty response
-func (s *CSINodeService) NodeExpandVolume(_ context.Context, _ *csi.NodeExpandVolumeRequest) (*csi.NodeExpandVolumeResponse, error) {
-	return &csi.NodeExpandVolumeResponse{}, nil
+// NodeExpandVolume is the implementation of CSI Spec NodeExpandVolume. Performs after ControllerExpandVolume
+// has expanded the underlying device. Resizes mapper device of the encrypted volume with the key from request secrets.
+// Grows file system of the FS mode volume up to the size of the device,
+// does nothing for RAW volumes. Calling it for already expanded volume does nothing.
+// Receives golang context and CSI Spec NodeExpandVolumeRequest
+// Returns CSI Spec NodeExpandVolumeResponse or error if something went wrong
+func (s *CSINodeService) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandVolumeRequest) (*csi.NodeExpandVolumeResponse, error) {
+	ll := s.log.WithFields(logrus.Fields{
+		"method":   "NodeExpandVolume",
+		"volumeID": req.GetVolumeId(),
+	})
+
+	ll.Infof("locking volume on request: %v", req)
+	s.volMu.LockKey(req.GetVolumeId())
+	defer func() {
+		err := s.volMu.UnlockKey(req.GetVolumeId())
+		if err != nil {
+			ll.Warnf("Unlocking volume with error %s", err)
+		}
+	}()
+	if err := s.checkRequestContext(ctx, ll); err != nil {
+		return nil, err
+	}
+
+	// Check arguments
+	if len(req.GetVolumeId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID missing in request")
+	}
+	if len(req.GetVolumePath()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume Path missing in request")
+	}
+
+	volumeCR, err := s.crHelper.GetVolumeByID(req.GetVolumeId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Unable to find volume")
+	}
+
+	if volumeCR.Spec.EncryptionSecret != "" {
+		// mapper device of the encrypted volume doesn't follow size of the underlying device
+		key := req.GetSecrets()[apiV1.EncryptionKeyName]
+		if err = s.encOps.Resize(volumeCR.Spec.Id, []byte(key)); err != nil {
+			ll.Errorf("Unable to resize encrypted device: %v", err)
+			return nil, status.Error(codes.Internal, "unable to expand encrypted device")
+		}
+	}
+
+	if volumeCR.Spec.Mode == apiV1.ModeRAW || volumeCR.Spec.Mode == apiV1.ModeRAWPART ||
+		req.GetVolumeCapability().GetBlock() != nil {
+		ll.Debugf("Volume in mode %s doesn't require file system expansion", volumeCR.Spec.Mode)
+		return &csi.NodeExpandVolumeResponse{CapacityBytes: volumeCR.Spec.Size}, nil
+	}
+
+	device, err := s.getProvisionerForVolume(&volumeCR.Spec).GetVolumePath(&volumeCR.Spec)
+	if err != nil {
+		ll.Errorf("Unable to get device for volume: %v", err)
+		return nil, status.Error(codes.Internal, "unable to find device of the volume")
+	}
+	if volumeCR.Spec.EncryptionSecret != "" {
+		device = cryptsetup.GetMapperPath(volumeCR.Spec.Id)
+	}
+
+	if err = s.fsOps.GrowFS(fs.FileSystem(volumeCR.Spec.Type), device, req.GetVolumePath()); err != nil {
+		ll.Errorf("Unable to grow file system: %v", err)
+		return nil, status.Error(codes.Internal, "unable to expand file system")
+	}
+
+	ll.Infof("File system on %s was expanded", device)
+	return &csi.NodeExpandVolumeResponse{CapacityBytes: volumeCR.Spec.Size}, nil
 }
 
 // NodeGetCapabilities is the implementation of CSI Spec NodeGetCapabilities.
-// Provides Node capabilities of CSI driver to k8s. STAGE/UNSTAGE Volume for now.
+// Provides Node capabilities of CSI driver to k8s: STAGE/UNSTAGE Volume, GET_VOLUME_STATS, VOLUME_CONDITION
+// and EXPAND_VOLUME.
 // Receives golang context and CSI Spec NodeGetCapabilitiesRequest
 // Returns CSI Spec NodeGetCapabilitiesResponse and nil error
 func (s *CSINodeService) NodeGetCapabilities(_ context.Context, _ *csi.NodeGetCapabilitiesRequest) (*csi.NodeGetCapabilitiesResponse, error) {
@@ -740,6 +936,27 @@ func (s *CSINodeService) NodeGetCapabilities(_ context.Context, _ *csi.NodeGetCa
 					Type: csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
 				},
 			},
+		},
+		{
+			Type: &csi.NodeServiceCapability_Rpc{
+				Rpc: &csi.NodeServiceCapability_RPC{
+					Type: csi.NodeServiceCapability_RPC_GET_VOLUME_STATS,
+				},
+			},
+		},
+		{
+			Type: &csi.NodeServiceCapability_Rpc{
+				Rpc: &csi.NodeServiceCapability_RPC{
+					Type: csi.NodeServiceCapability_RPC_VOLUME_CONDITION,
+				},
+			},
+		},
+		{
+			Type: &csi.NodeServiceCapability_Rpc{
+				Rpc: &csi.NodeServiceCapability_RPC{
+					Type: csi.NodeServiceCapability_RPC_EXPAND_VOLUME,
+				},
+			},
 		}},
 	}, nil
 }
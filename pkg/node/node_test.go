@@ -43,6 +43,7 @@ import (
 	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/node/common"
 	"github.com/dell/csi-baremetal/pkg/mocks"
@@ -55,6 +56,7 @@ var (
 	node   *CSINodeService
 	prov   *mockProv.MockProvisioner
 	fsOps  *mockProv.MockFsOpts
+	encOps *mockProv.MockEncryptionOpts
 	volOps *mocks.VolumeOperationsMock
 	wbtOps *mocklu.MockWrapWbt
 )
@@ -63,6 +65,7 @@ func setVariables() {
 	node = newNodeService()
 	prov = &mockProv.MockProvisioner{}
 	fsOps = &mockProv.MockFsOpts{}
+	encOps = &mockProv.MockEncryptionOpts{}
 	volOps = &mocks.VolumeOperationsMock{}
 	wbtOps = &mocklu.MockWrapWbt{}
 	node.provisioners = map[p.VolumeType]p.Provisioner{
@@ -70,6 +73,7 @@ func setVariables() {
 		p.LVMBasedVolumeType:   prov,
 	}
 	node.fsOps = fsOps
+	node.encOps = encOps
 	node.svc = volOps
 	node.wbtOps = wbtOps
 }
@@ -159,6 +163,20 @@ var _ = Describe("CSINodeService NodePublish()", func() {
 			Expect(err).NotTo(BeNil())
 			Expect(err.Error()).To(ContainSubstring("Staging Path missing in request"))
 		})
+		It("Should fail, because mount option isn't supported", func() {
+			volumeCap := &csi.VolumeCapability{
+				AccessType: &csi.VolumeCapability_Mount{
+					Mount: &csi.VolumeCapability_MountVolume{MountFlags: []string{"noatime", "someOpt"}},
+				},
+			}
+			req := getNodePublishRequest(testV1ID, targetPath, *volumeCap)
+
+			resp, err := node.NodePublishVolume(testCtx, req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			fsOps.AssertNotCalled(GinkgoT(), "PrepareAndPerformMount",
+				mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
 		It("Should fail, because Volume has failed status", func() {
 			req := getNodePublishRequest(testV1ID, targetPath, *testVolumeCap)
 			vol1 := &vcrd.Volume{}
@@ -238,9 +256,87 @@ var _ = Describe("CSINodeService NodeStage()", func() {
 			Expect(resp).NotTo(BeNil())
 			Expect(err).To(BeNil())
 		})
+		It("Should stage mapper device of the encrypted volume", func() {
+			vol1 := &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testVolume1.Id, "", vol1)).To(BeNil())
+			vol1.Spec.EncryptionSecret = testNs + "/volume-key"
+			Expect(node.k8sClient.UpdateCR(testCtx, vol1)).To(BeNil())
+
+			req := getNodeStageRequest(testVolume1.Id, *testVolumeCap)
+			req.Secrets = map[string]string{apiV1.EncryptionKeyName: "secret-key"}
+			partitionPath := "/partition/path/for/volume1"
+			mapperPath := "/dev/mapper/" + testVolume1.Id
+			prov.On("GetVolumePath", &vol1.Spec).Return(partitionPath, nil)
+			encOps.On("OpenEncryptedDevice", partitionPath, testVolume1.Id, []byte("secret-key"), true).
+				Return(mapperPath, nil).Once()
+			fsOps.On("CreateFSIfNotExist", fs.FileSystem(vol1.Spec.Type), mapperPath, testVolume1.Id).Return(nil).Once()
+			fsOps.On("PrepareAndPerformMount",
+				mapperPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false).
+				Return(nil).Once()
+
+			resp, err := node.NodeStageVolume(testCtx, req)
+			Expect(resp).NotTo(BeNil())
+			Expect(err).To(BeNil())
+			fsOps.AssertExpectations(GinkgoT())
+			Expect(node.k8sClient.ReadCR(testCtx, testVolume1.Id, "", vol1)).To(BeNil())
+			Expect(vol1.Annotations[apiV1.VolumeAnnotationEncryptionFormatted]).To(Equal(apiV1.VolumeEncryptionFormatted))
+		})
+		It("Should not format encrypted volume on the next stage", func() {
+			vol1 := &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testVolume1.Id, "", vol1)).To(BeNil())
+			vol1.Spec.EncryptionSecret = testNs + "/volume-key"
+			vol1.Annotations = map[string]string{
+				apiV1.VolumeAnnotationEncryptionFormatted: apiV1.VolumeEncryptionFormatted}
+			Expect(node.k8sClient.UpdateCR(testCtx, vol1)).To(BeNil())
+
+			req := getNodeStageRequest(testVolume1.Id, *testVolumeCap)
+			req.Secrets = map[string]string{apiV1.EncryptionKeyName: "secret-key"}
+			partitionPath := "/partition/path/for/volume1"
+			mapperPath := "/dev/mapper/" + testVolume1.Id
+			prov.On("GetVolumePath", &vol1.Spec).Return(partitionPath, nil)
+			encOps.On("OpenEncryptedDevice", partitionPath, testVolume1.Id, []byte("secret-key"), false).
+				Return(mapperPath, nil).Once()
+			fsOps.On("CreateFSIfNotExist", fs.FileSystem(vol1.Spec.Type), mapperPath, testVolume1.Id).Return(nil).Once()
+			fsOps.On("PrepareAndPerformMount",
+				mapperPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false).
+				Return(nil).Once()
+
+			resp, err := node.NodeStageVolume(testCtx, req)
+			Expect(resp).NotTo(BeNil())
+			Expect(err).To(BeNil())
+			encOps.AssertExpectations(GinkgoT())
+		})
 	})
 
 	Context("NodeStage() failure", func() {
+		It("Should fail, because encryption key is missing in secrets", func() {
+			vol1 := &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testVolume1.Id, "", vol1)).To(BeNil())
+			vol1.Spec.EncryptionSecret = testNs + "/volume-key"
+			Expect(node.k8sClient.UpdateCR(testCtx, vol1)).To(BeNil())
+
+			req := getNodeStageRequest(testVolume1.Id, *testVolumeCap)
+			prov.On("GetVolumePath", &vol1.Spec).Return("/partition/path/for/volume1", nil)
+
+			resp, err := node.NodeStageVolume(testCtx, req)
+			Expect(resp).To(BeNil())
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("encryption key"))
+			encOps.AssertNotCalled(GinkgoT(), "OpenEncryptedDevice", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+		It("Should fail, because volume is being copied", func() {
+			vol1 := &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testVolume1.Id, "", vol1)).To(BeNil())
+			vol1.Annotations = map[string]string{apiV1.VolumeAnnotationCopyStatus: apiV1.VolumeCopyInProgress}
+			Expect(node.k8sClient.UpdateCR(testCtx, vol1)).To(BeNil())
+
+			req := getNodeStageRequest(testVolume1.Id, *testVolumeCap)
+			resp, err := node.NodeStageVolume(testCtx, req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.Unavailable))
+			fsOps.AssertNotCalled(GinkgoT(), "PrepareAndPerformMount",
+				mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
 		It("Should fail with missing volume capabilities", func() {
 			req := &csi.NodeStageVolumeRequest{}
 
@@ -509,6 +605,23 @@ var _ = Describe("CSINodeService NodeUnStage()", func() {
 			Expect(err).To(BeNil())
 			Expect(volumeCR.Spec.CSIStatus).To(Equal(apiV1.Created))
 		})
+		It("Should unstage volume and close mapper device of the encrypted volume", func() {
+			vol1 := &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testVolume1.Id, "", vol1)).To(BeNil())
+			vol1.Spec.EncryptionSecret = testNs + "/volume-key"
+			Expect(node.k8sClient.UpdateCR(testCtx, vol1)).To(BeNil())
+
+			req := getNodeUnstageRequest(testV1ID, stagePath)
+			targetPath := path.Join(req.GetStagingTargetPath(), stagingFileName)
+			fsOps.On("UnmountWithCheck", targetPath).Return(nil)
+			fsOps.On("RmDir", targetPath).Return(nil)
+			encOps.On("Close", testV1ID).Return(nil).Once()
+
+			resp, err := node.NodeUnstageVolume(testCtx, req)
+			Expect(resp).NotTo(BeNil())
+			Expect(err).To(BeNil())
+			encOps.AssertExpectations(GinkgoT())
+		})
 	})
 
 	Context("NodeUnStage() failure", func() {
@@ -632,20 +745,183 @@ var _ = Describe("CSINodeService NodeGetInfo()", func() {
 })
 
 var _ = Describe("CSINodeService NodeGetCapabilities()", func() {
-	It("Should return STAGE_UNSTAGE_VOLUME capabilities", func() {
+	It("Should return STAGE_UNSTAGE_VOLUME, GET_VOLUME_STATS, VOLUME_CONDITION and EXPAND_VOLUME capabilities", func() {
 		node := newNodeService()
 
 		resp, err := node.NodeGetCapabilities(testCtx, &csi.NodeGetCapabilitiesRequest{})
 		Expect(err).To(BeNil())
 		Expect(resp).ToNot(BeNil())
 		capabilities := resp.GetCapabilities()
-		expectedCapability := &csi.NodeServiceCapability_Rpc{
-			Rpc: &csi.NodeServiceCapability_RPC{
-				Type: csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
-			},
+		Expect(len(capabilities)).To(Equal(4))
+		expectedTypes := []csi.NodeServiceCapability_RPC_Type{
+			csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
+			csi.NodeServiceCapability_RPC_GET_VOLUME_STATS,
+			csi.NodeServiceCapability_RPC_VOLUME_CONDITION,
+			csi.NodeServiceCapability_RPC_EXPAND_VOLUME,
 		}
-		Expect(len(capabilities)).To(Equal(1))
-		Expect(capabilities[0].Type).To(Equal(expectedCapability))
+		for i, t := range expectedTypes {
+			Expect(capabilities[i].GetRpc().GetType()).To(Equal(t))
+		}
+	})
+})
+
+var _ = Describe("CSINodeService NodeGetVolumeStats()", func() {
+	BeforeEach(func() {
+		setVariables()
+	})
+
+	Context("NodeGetVolumeStats() success", func() {
+		It("Should return bytes and inodes usage for FS volume", func() {
+			req := &csi.NodeGetVolumeStatsRequest{VolumeId: testV1ID, VolumePath: targetPath}
+			fsOps.On("GetFSStats", targetPath).Return(&fs.FSStats{
+				TotalBytes:     1000,
+				AvailableBytes: 400,
+				UsedBytes:      600,
+				TotalInodes:    100,
+				FreeInodes:     90,
+				UsedInodes:     10,
+			}, nil)
+
+			resp, err := node.NodeGetVolumeStats(testCtx, req)
+			Expect(err).To(BeNil())
+			Expect(resp.GetUsage()).To(HaveLen(2))
+			Expect(resp.GetUsage()[0]).To(Equal(&csi.VolumeUsage{
+				Unit: csi.VolumeUsage_BYTES, Total: 1000, Available: 400, Used: 600}))
+			Expect(resp.GetUsage()[1]).To(Equal(&csi.VolumeUsage{
+				Unit: csi.VolumeUsage_INODES, Total: 100, Available: 90, Used: 10}))
+			Expect(resp.GetVolumeCondition().GetAbnormal()).To(BeFalse())
+		})
+		It("Should return device size for RAW volume", func() {
+			volumeCR := &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", volumeCR)).To(BeNil())
+			volumeCR.Spec.Mode = apiV1.ModeRAW
+			volumeCR.Spec.Health = apiV1.HealthBad
+			Expect(node.k8sClient.UpdateCR(testCtx, volumeCR)).To(BeNil())
+
+			req := &csi.NodeGetVolumeStatsRequest{VolumeId: testV1ID, VolumePath: targetPath}
+			fsOps.On("GetBlockDeviceSize", targetPath).Return(int64(1024), nil)
+
+			resp, err := node.NodeGetVolumeStats(testCtx, req)
+			Expect(err).To(BeNil())
+			Expect(resp.GetUsage()).To(HaveLen(1))
+			Expect(resp.GetUsage()[0].GetTotal()).To(Equal(int64(1024)))
+			Expect(resp.GetUsage()[0].GetUnit()).To(Equal(csi.VolumeUsage_BYTES))
+			Expect(resp.GetVolumeCondition().GetAbnormal()).To(BeTrue())
+		})
+	})
+
+	Context("NodeGetVolumeStats() failure", func() {
+		It("Should fail, because of empty volume ID or path", func() {
+			_, err := node.NodeGetVolumeStats(testCtx, &csi.NodeGetVolumeStatsRequest{VolumePath: targetPath})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			_, err = node.NodeGetVolumeStats(testCtx, &csi.NodeGetVolumeStatsRequest{VolumeId: testV1ID})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Should fail, because volume CR doesn't exist", func() {
+			req := &csi.NodeGetVolumeStatsRequest{VolumeId: "unknown", VolumePath: targetPath}
+			_, err := node.NodeGetVolumeStats(testCtx, req)
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+		It("Should fail, because statfs failed", func() {
+			req := &csi.NodeGetVolumeStatsRequest{VolumeId: testV1ID, VolumePath: targetPath}
+			fsOps.On("GetFSStats", targetPath).Return(nil, errors.New("error"))
+
+			_, err := node.NodeGetVolumeStats(testCtx, req)
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+	})
+})
+
+var _ = Describe("CSINodeService NodeExpandVolume()", func() {
+	device := "/dev/sda1"
+
+	BeforeEach(func() {
+		setVariables()
+	})
+
+	Context("NodeExpandVolume() success", func() {
+		It("Should grow file system of FS volume", func() {
+			volumeCR := &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", volumeCR)).To(BeNil())
+			volumeCR.Spec.Type = string(fs.XFS)
+			Expect(node.k8sClient.UpdateCR(testCtx, volumeCR)).To(BeNil())
+
+			req := &csi.NodeExpandVolumeRequest{VolumeId: testV1ID, VolumePath: targetPath}
+			prov.On("GetVolumePath", &volumeCR.Spec).Return(device, nil)
+			fsOps.On("GrowFS", fs.XFS, device, targetPath).Return(nil)
+
+			resp, err := node.NodeExpandVolume(testCtx, req)
+			Expect(err).To(BeNil())
+			Expect(resp.GetCapacityBytes()).To(Equal(volumeCR.Spec.Size))
+			fsOps.AssertCalled(GinkgoT(), "GrowFS", fs.XFS, device, targetPath)
+		})
+		It("Should resize mapper device and grow file system of the encrypted volume", func() {
+			volumeCR := &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", volumeCR)).To(BeNil())
+			volumeCR.Spec.Type = string(fs.XFS)
+			volumeCR.Spec.EncryptionSecret = testNs + "/volume-key"
+			Expect(node.k8sClient.UpdateCR(testCtx, volumeCR)).To(BeNil())
+
+			mapperPath := "/dev/mapper/" + testV1ID
+			req := &csi.NodeExpandVolumeRequest{VolumeId: testV1ID, VolumePath: targetPath,
+				Secrets: map[string]string{apiV1.EncryptionKeyName: "secret-key"}}
+			encOps.On("Resize", testV1ID, []byte("secret-key")).Return(nil).Once()
+			prov.On("GetVolumePath", &volumeCR.Spec).Return(device, nil)
+			fsOps.On("GrowFS", fs.XFS, mapperPath, targetPath).Return(nil).Once()
+
+			_, err := node.NodeExpandVolume(testCtx, req)
+			Expect(err).To(BeNil())
+			encOps.AssertExpectations(GinkgoT())
+			fsOps.AssertExpectations(GinkgoT())
+		})
+		It("Should skip RAW volume", func() {
+			volumeCR := &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", volumeCR)).To(BeNil())
+			volumeCR.Spec.Mode = apiV1.ModeRAW
+			Expect(node.k8sClient.UpdateCR(testCtx, volumeCR)).To(BeNil())
+
+			req := &csi.NodeExpandVolumeRequest{VolumeId: testV1ID, VolumePath: targetPath}
+			_, err := node.NodeExpandVolume(testCtx, req)
+			Expect(err).To(BeNil())
+			fsOps.AssertNotCalled(GinkgoT(), "GrowFS", mock.Anything, mock.Anything, mock.Anything)
+		})
+	})
+
+	Context("NodeExpandVolume() failure", func() {
+		It("Should fail, because of empty volume ID or path", func() {
+			_, err := node.NodeExpandVolume(testCtx, &csi.NodeExpandVolumeRequest{VolumePath: targetPath})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			_, err = node.NodeExpandVolume(testCtx, &csi.NodeExpandVolumeRequest{VolumeId: testV1ID})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Should fail, because volume CR doesn't exist", func() {
+			req := &csi.NodeExpandVolumeRequest{VolumeId: "unknown", VolumePath: targetPath}
+			_, err := node.NodeExpandVolume(testCtx, req)
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+		It("Should fail, because resize of the encrypted device failed", func() {
+			volumeCR := &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", volumeCR)).To(BeNil())
+			volumeCR.Spec.EncryptionSecret = testNs + "/volume-key"
+			Expect(node.k8sClient.UpdateCR(testCtx, volumeCR)).To(BeNil())
+
+			req := &csi.NodeExpandVolumeRequest{VolumeId: testV1ID, VolumePath: targetPath}
+			encOps.On("Resize", testV1ID, mock.Anything).Return(errors.New("error"))
+
+			_, err := node.NodeExpandVolume(testCtx, req)
+			Expect(status.Code(err)).To(Equal(codes.Internal))
+			fsOps.AssertNotCalled(GinkgoT(), "GrowFS", mock.Anything, mock.Anything, mock.Anything)
+		})
+		It("Should fail, because file system growth failed", func() {
+			req := &csi.NodeExpandVolumeRequest{VolumeId: testV1ID, VolumePath: targetPath}
+			prov.On("GetVolumePath", mock.Anything).Return(device, nil)
+			fsOps.On("GrowFS", mock.Anything, device, targetPath).Return(errors.New("error"))
+
+			_, err := node.NodeExpandVolume(testCtx, req)
+			Expect(status.Code(err)).To(Equal(codes.Internal))
+		})
 	})
 })
 
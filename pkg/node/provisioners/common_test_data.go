@@ -92,6 +92,22 @@ var (
 		Spec:       testAPIDrive,
 	}
 
+	// Drive CR of the cache of volume
+	testCachePV      = "/dev/sdc"
+	testCacheDriveCR = drivecrd.Drive{
+		TypeMeta:   k8smetav1.TypeMeta{Kind: "Drive", APIVersion: apiV1.APIV1Version},
+		ObjectMeta: k8smetav1.ObjectMeta{Name: "ssd1-uuid"},
+		Spec: api.Drive{
+			UUID:         "ssd1-uuid",
+			SerialNumber: "ssd1-sn",
+			NodeId:       testNodeID,
+			Health:       apiV1.HealthGood,
+			Type:         apiV1.DriveTypeSSD,
+			Size:         1024 * 1024 * 1024 * 100,
+			Status:       apiV1.DriveStatusOnline,
+		},
+	}
+
 	testV2ID    = "volume-2-id"
 	testVolume2 = api.Volume{ // points on testDriveCR
 		Id:           testV2ID,
@@ -116,4 +132,13 @@ var (
 		StorageClass: apiV1.StorageClassHDD,
 		Mode:         apiV1.ModeRAWPART,
 	}
+
+	testSnapshot1 = api.Snapshot{ // points on testVolume1
+		Id:           "snapshot-1-id",
+		VolumeId:     testV1ID,
+		NodeId:       testNodeID,
+		Location:     testAPILVG.Name,
+		StorageClass: apiV1.StorageClassHDDLVG,
+		Size:         1024 * 1024 * 100,
+	}
 )
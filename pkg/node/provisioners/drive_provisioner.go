@@ -29,9 +29,11 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	baseerr "github.com/dell/csi-baremetal/pkg/base/error"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/blkcopy"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/partitionhelper"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/wipe"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	uw "github.com/dell/csi-baremetal/pkg/node/provisioners/utilwrappers"
 )
@@ -41,6 +43,9 @@ const (
 	DefaultPartitionLabel = "CSI"
 	// DefaultPartitionNumber partition number
 	DefaultPartitionNumber = "1"
+	// maxPartitionOverhead is the max space of the drive which isn't covered by the partition that spans the whole drive:
+	// alignment of the partition start and backup GPT at the end of the drive
+	maxPartitionOverhead = 2 * int64(util.MBYTE)
 )
 
 // DriveProvisioner is a implementation of Provisioner interface
@@ -51,6 +56,12 @@ type DriveProvisioner struct {
 	fsOps uw.FSOperations
 	// partOps uses for operations with partitions
 	partOps uw.PartitionOperations
+	// encOps uses for operations with LUKS encrypted devices
+	encOps uw.EncryptionOperations
+	// wipeOps uses for destroying data of the released volumes
+	wipeOps wipe.WrapWipe
+	// blkCopy uses for copying content of the clone source
+	blkCopy blkcopy.WrapBlkCopy
 
 	k8sClient *k8s.KubeClient
 	crHelper  k8s.CRHelper
@@ -67,14 +78,18 @@ func NewDriveProvisioner(
 		listBlk:   lsblk.NewLSBLK(log),
 		fsOps:     uw.NewFSOperationsImpl(e, log),
 		partOps:   uw.NewPartitionOperationsImpl(e, log),
+		encOps:    uw.NewEncryptionOperationsImpl(e, log),
+		wipeOps:   wipe.NewWipe(e, log),
+		blkCopy:   blkcopy.NewBlkCopy(log),
 		k8sClient: k,
 		crHelper:  k8s.NewCRHelperImpl(k, log),
 		log:       log.WithField("component", "DriveProvisioner"),
 	}
 }
 
-// PrepareVolume create partition and FS based on vol attributes.
-// After that partition is ready for mount operations
+// PrepareVolume create partition and FS based on vol attributes. Partition (or the whole drive in RAW mode)
+// of the encrypted volume is left empty, it is formatted with LUKS during NodeStageVolume with the key from CSI secrets.
+// Content of the clone source is copied to partition by CopyVolumeContent. After that partition is ready for mount operations
 func (d *DriveProvisioner) PrepareVolume(vol *api.Volume) error {
 	ll := d.log.WithFields(logrus.Fields{
 		"method":   "PrepareVolume",
@@ -124,14 +139,66 @@ func (d *DriveProvisioner) PrepareVolume(vol *api.Volume) error {
 	}
 	ll.Infof("Partition was created successfully %+v", partPtr)
 
+	if vol.EncryptionSecret != "" {
+		return nil
+	}
+
+	// file system is copied from the source by CopyVolumeContent
+	if vol.SourceVolumeId != "" {
+		return nil
+	}
+
 	if vol.Mode == apiV1.ModeRAWPART {
 		return nil
 	}
 
-	return d.fsOps.CreateFSIfNotExist(fs.FileSystem(vol.Type), partPtr.GetFullPath(), volUUID)
+	return d.fsOps.CreateFSIfNotExist(fs.FileSystem(vol.Type), partPtr.GetFullPath(), volUUID, vol.MkfsOptions)
+}
+
+// CopyVolumeContent performs block-level copy of the source volume content to the partition (or the whole drive
+// in RAW mode) prepared by PrepareVolume and sets own UUID of the copied file system, does nothing if volume isn't
+// a clone or is encrypted. Drive doesn't support snapshots, so source volume must not be staged to get consistent copy
+func (d *DriveProvisioner) CopyVolumeContent(vol *api.Volume, progress blkcopy.ProgressFunc) error {
+	if vol.SourceVolumeId == "" || vol.EncryptionSecret != "" {
+		return nil
+	}
+	deviceFile, err := d.GetVolumePath(vol)
+	if err != nil {
+		return fmt.Errorf("unable to determine full path of the volume: %w", err)
+	}
+	volUUID, err := util.GetVolumeUUID(vol.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get volume UUID %s: %w", vol.Id, err)
+	}
+
+	srcVolume, err := d.crHelper.GetVolumeByID(vol.SourceVolumeId)
+	if err != nil {
+		return fmt.Errorf("unable to read source volume %s: %w", vol.SourceVolumeId, err)
+	}
+	if srcVolume.Spec.CSIStatus != apiV1.Created {
+		return fmt.Errorf("source volume %s is in use, current status - %s", vol.SourceVolumeId, srcVolume.Spec.CSIStatus)
+	}
+	srcFile, err := d.GetVolumePath(&srcVolume.Spec)
+	if err != nil {
+		return fmt.Errorf("unable to determine full path of the source volume %s: %w", vol.SourceVolumeId, err)
+	}
+
+	d.log.WithFields(logrus.Fields{
+		"method":   "copyVolumeContent",
+		"volumeID": vol.Id,
+	}).Infof("Copying content of volume %s to %s", srcFile, deviceFile)
+	if err = d.blkCopy.Copy(srcFile, deviceFile, progress); err != nil {
+		return err
+	}
+	if vol.Mode == apiV1.ModeRAW || vol.Mode == apiV1.ModeRAWPART {
+		return nil
+	}
+	// copied FS has UUID of the source FS, clone must have own one to be mounted on the same node with the source
+	return d.fsOps.SetFSUUID(fs.FileSystem(vol.Type), deviceFile, volUUID)
 }
 
-// ReleaseVolume remove FS and partition based on vol attributes.
+// ReleaseVolume remove FS and partition based on vol attributes, mapper device of the encrypted volume is closed
+// and LUKS header is erased. Data of the drive is destroyed according to the volume wipe policy.
 // After that partition is completely removed
 func (d *DriveProvisioner) ReleaseVolume(vol *api.Volume, drive *api.Drive) error {
 	ll := d.log.WithFields(logrus.Fields{
@@ -157,14 +224,29 @@ func (d *DriveProvisioner) ReleaseVolume(vol *api.Volume, drive *api.Drive) erro
 	)
 
 	part.Name, err = d.partOps.SearchPartName(device, part.PartUUID)
+	if vol.EncryptionSecret != "" {
+		// volume in RAW mode is encrypted without partition
+		encDevice := device
+		if err == nil {
+			encDevice = part.GetFullPath()
+		}
+		if rErr := d.encOps.ReleaseEncryptedDevice(encDevice, vol.Id); rErr != nil {
+			return fmt.Errorf("unable to release encrypted device %s: %w", encDevice, rErr)
+		}
+	}
 	if err != nil {
-		return d.wipeDevice(device,
-			fmt.Errorf("unable to find partition name for volume %s: %w", vol.Id, err), ll)
+		if err = d.wipeDevice(device,
+			fmt.Errorf("unable to find partition name for volume %s: %w", vol.Id, err), ll); err != nil {
+			return err
+		}
+		return eraseData(d.wipeOps, vol.WipePolicy, device)
 	}
 
 	// wipe FS on partition
-	if err = d.fsOps.WipeFS(part.GetFullPath()); err != nil {
-		return err
+	if vol.WipePolicy != apiV1.WipePolicyNone {
+		if err = d.fsOps.WipeFS(part.GetFullPath()); err != nil {
+			return err
+		}
 	}
 
 	err = d.partOps.ReleasePartition(part)
@@ -173,7 +255,70 @@ func (d *DriveProvisioner) ReleaseVolume(vol *api.Volume, drive *api.Drive) erro
 	}
 
 	// wipe all superblocks (wipe partition table signature)
-	return d.fsOps.WipeFS(device)
+	if err = d.fsOps.WipeFS(device); err != nil {
+		return err
+	}
+
+	// volume consumes the whole drive, so the whole drive is wiped
+	if util.IsWipePolicyErasingData(vol.WipePolicy) {
+		ll.Infof("Wipe device %s with policy %s", device, vol.WipePolicy)
+	}
+	return eraseData(d.wipeOps, vol.WipePolicy, device)
+}
+
+// ExpandVolume checks that partition of the volume covers the whole drive. Partition is created up to the end of
+// the drive, so drive based volume is expanded only on the file system level.
+// Volume in RAW mode consumes the whole drive and doesn't require expansion
+func (d *DriveProvisioner) ExpandVolume(vol *api.Volume) error {
+	ll := d.log.WithFields(logrus.Fields{
+		"method":   "ExpandVolume",
+		"volumeID": vol.Id,
+	})
+
+	if vol.Mode == apiV1.ModeRAW {
+		ll.Infof("Volume in mode %s consumes the whole drive", vol.Mode)
+		return nil
+	}
+
+	var (
+		ctxWithID = context.WithValue(context.Background(), base.RequestUUID, vol.Id)
+		drive     = &drivecrd.Drive{}
+	)
+
+	if err := d.k8sClient.ReadCR(ctxWithID, vol.Location, "", drive); err != nil {
+		return fmt.Errorf("failed to read drive CR with name %s, error %w", vol.Location, err)
+	}
+	if vol.Size > drive.Spec.Size {
+		return fmt.Errorf("volume size %d exceeds size of the drive %d", vol.Size, drive.Spec.Size)
+	}
+
+	device, err := d.listBlk.SearchDrivePath(&drive.Spec)
+	if err != nil {
+		return err
+	}
+
+	partUUID, err := util.GetVolumeUUID(vol.Id)
+	if err != nil {
+		return fmt.Errorf("unable to get partition UUID for volume %s: %w", vol.Id, err)
+	}
+	partName, err := d.partOps.SearchPartName(device, partUUID)
+	if err != nil {
+		return fmt.Errorf("unable to find partition name for volume %s: %w", vol.Id, err)
+	}
+
+	deviceSize, err := d.fsOps.GetBlockDeviceSize(device)
+	if err != nil {
+		return err
+	}
+	partSize, err := d.fsOps.GetBlockDeviceSize(device + partName)
+	if err != nil {
+		return err
+	}
+	if deviceSize-partSize > maxPartitionOverhead {
+		return fmt.Errorf("size of partition %s%s is %d after expansion, size of device - %d",
+			device, partName, partSize, deviceSize)
+	}
+	return nil
 }
 
 // wipeDevice check is there any partition on device or not,
@@ -17,6 +17,7 @@ limitations under the License.
 package provisioners
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -24,11 +25,15 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/partitionhelper"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/mocks"
 	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
 	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
 	uw "github.com/dell/csi-baremetal/pkg/node/provisioners/utilwrappers"
@@ -113,6 +118,80 @@ func TestDriveProvisioner_PrepareVolume_Block_Success(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestDriveProvisioner_PrepareVolume_Encrypted(t *testing.T) {
+	var (
+		dp, mockLsblk, mockPH, mockFS = setupTestDriveProvisioner()
+		encOps                        = &mockProv.MockEncryptionOpts{}
+		device                        = "/some/device"
+		vol                           = testVolume2
+		expectedPart                  = uw.Partition{Device: device, Num: DefaultPartitionNumber, Name: "p1"}
+	)
+	dp.encOps = encOps
+	vol.EncryptionSecret = testNs + "/volume-key"
+	assert.Nil(t, dp.k8sClient.CreateCR(testCtx, testDriveCR.Name, testDriveCR.DeepCopy()))
+
+	mockLsblk.On("SearchDrivePath", &testDriveCR.Spec).Return(device, nil)
+	mockPH.On("PreparePartition", mock.Anything).Return(&expectedPart, nil)
+
+	// partition is formatted with LUKS and FS during NodeStageVolume
+	assert.Nil(t, dp.PrepareVolume(&vol))
+	mockPH.AssertNumberOfCalls(t, "PreparePartition", 1)
+
+	// volume in RAW mode is encrypted without partition
+	vol = testVolume2Raw
+	vol.EncryptionSecret = testNs + "/volume-key"
+	assert.Nil(t, dp.PrepareVolume(&vol))
+	mockPH.AssertNumberOfCalls(t, "PreparePartition", 1)
+
+	mockFS.AssertNotCalled(t, "CreateFSIfNotExist", mock.Anything, mock.Anything, mock.Anything)
+	encOps.AssertNotCalled(t, "OpenEncryptedDevice", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDriveProvisioner_PrepareVolume_Clone(t *testing.T) {
+	var (
+		dp, mockLsblk, _, _ = setupTestDriveProvisioner()
+		crHelper            = &mocks.CRHelper{}
+		device              = "/some/device"
+		vol                 = testVolume2Raw
+		err                 error
+	)
+	dp.crHelper = crHelper
+	vol.Id = "clone-volume-id"
+	vol.SourceVolumeId = testVolume2Raw.Id
+	srcVolume := testVolume2Raw
+	srcVolume.CSIStatus = apiV1.Created
+
+	err = dp.k8sClient.CreateCR(testCtx, testDriveCR.Name, testDriveCR.DeepCopy())
+	assert.Nil(t, err)
+
+	blkCopy := &mocklu.MockWrapBlkCopy{}
+	dp.blkCopy = blkCopy
+
+	mockLsblk.On("SearchDrivePath", &testDriveCR.Spec).Return(device, nil)
+	crHelper.On("GetVolumeByID", testVolume2Raw.Id).Return(&volumecrd.Volume{Spec: srcVolume}, nil).Times(1)
+	blkCopy.On("Copy", device, device, mock.Anything).Return(nil).Times(1)
+
+	// content isn't copied by PrepareVolume
+	err = dp.PrepareVolume(&vol)
+	assert.Nil(t, err)
+	blkCopy.AssertNotCalled(t, "Copy", mock.Anything, mock.Anything, mock.Anything)
+
+	err = dp.CopyVolumeContent(&vol, nil)
+	assert.Nil(t, err)
+
+	// source volume is in use
+	srcVolume.CSIStatus = apiV1.Published
+	crHelper.On("GetVolumeByID", testVolume2Raw.Id).Return(&volumecrd.Volume{Spec: srcVolume}, nil).Times(1)
+	err = dp.CopyVolumeContent(&vol, nil)
+	assert.NotNil(t, err)
+	blkCopy.AssertNumberOfCalls(t, "Copy", 1)
+
+	// source volume doesn't exist
+	crHelper.On("GetVolumeByID", testVolume2Raw.Id).Return(nil, errTest).Times(1)
+	err = dp.CopyVolumeContent(&vol, nil)
+	assert.NotNil(t, err)
+}
+
 func TestDriveProvisioner_PrepareVolume_Blockrawpart_Success(t *testing.T) {
 	var (
 		dp, mockLsblk, mockPH, _ = setupTestDriveProvisioner()
@@ -236,6 +315,76 @@ func TestDriveProvisioner_ReleaseVolume_Success(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestDriveProvisioner_ReleaseVolume_Encrypted(t *testing.T) {
+	var (
+		dp, mockLsblk, mockPH, mockFS = setupTestDriveProvisioner()
+		encOps                        = &mockProv.MockEncryptionOpts{}
+		deviceFile                    = "/dev/sda"
+		partName                      = "p1"
+		vol                           = testVolume2
+	)
+	dp.encOps = encOps
+	vol.EncryptionSecret = testNs + "/volume-key"
+
+	mockLsblk.On("SearchDrivePath", &testDriveCR.Spec).Return(deviceFile, nil)
+	mockPH.On("SearchPartName", deviceFile, vol.Id).Return(partName, nil).Once()
+	encOps.On("ReleaseEncryptedDevice", deviceFile+partName, vol.Id).Return(nil).Once()
+	mockFS.On("WipeFS", mock.Anything).Return(nil)
+	mockPH.On("ReleasePartition", mock.Anything).Return(nil)
+
+	assert.Nil(t, dp.ReleaseVolume(&vol, &testDriveCR.Spec))
+
+	// volume in RAW mode is encrypted without partition
+	mockPH.On("SearchPartName", deviceFile, vol.Id).Return("", errTest).Once()
+	encOps.On("ReleaseEncryptedDevice", deviceFile, vol.Id).Return(nil).Once()
+	mockLsblk.On("GetBlockDevices", deviceFile).Return(nil, nil).Once()
+
+	assert.Nil(t, dp.ReleaseVolume(&vol, &testDriveCR.Spec))
+	encOps.AssertExpectations(t)
+
+	// unable to close encrypted device
+	mockPH.On("SearchPartName", deviceFile, vol.Id).Return(partName, nil).Once()
+	encOps.On("ReleaseEncryptedDevice", deviceFile+partName, vol.Id).Return(errTest).Once()
+
+	err := dp.ReleaseVolume(&vol, &testDriveCR.Spec)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to release encrypted device")
+}
+
+func TestDriveProvisioner_ReleaseVolume_WipePolicy(t *testing.T) {
+	var (
+		dp, mockLsblk, mockPH, mockFS = setupTestDriveProvisioner()
+		wipeOps                       = &mocklu.MockWrapWipe{}
+		deviceFile                    = "/dev/sda"
+		partName                      = "p1"
+		vol                           = testVolume2
+	)
+	dp.wipeOps = wipeOps
+
+	mockLsblk.On("SearchDrivePath", &testDriveCR.Spec).Return(deviceFile, nil)
+	mockPH.On("SearchPartName", deviceFile, vol.Id).Return(partName, nil)
+	mockPH.On("ReleasePartition", mock.Anything).Return(nil)
+	mockFS.On("WipeFS", mock.Anything).Return(nil)
+
+	// the whole drive is erased after partition removal
+	vol.WipePolicy = apiV1.WipePolicyATASecureErase
+	wipeOps.On("ATASecureErase", deviceFile).Return(nil).Once()
+	assert.Nil(t, dp.ReleaseVolume(&vol, &testDriveCR.Spec))
+	wipeOps.AssertExpectations(t)
+
+	// FS signatures on partition aren't wiped
+	vol.WipePolicy = apiV1.WipePolicyNone
+	assert.Nil(t, dp.ReleaseVolume(&vol, &testDriveCR.Spec))
+	// partition and device in the first call, device only in the second one
+	mockFS.AssertNumberOfCalls(t, "WipeFS", 3)
+
+	// wipe failed
+	vol.WipePolicy = apiV1.WipePolicyZero
+	wipeOps.On("ZeroOut", deviceFile).Return(errTest).Once()
+	err := dp.ReleaseVolume(&vol, &testDriveCR.Spec)
+	assert.True(t, errors.Is(err, ErrWipeFailed))
+}
+
 func TestDriveProvisioner_ReleaseVolume_Fail(t *testing.T) {
 	var (
 		dp, mockLsblk, mockPH, mockFS = setupTestDriveProvisioner()
@@ -297,6 +446,44 @@ func TestDriveProvisioner_ReleaseVolume_Fail(t *testing.T) {
 	assert.Equal(t, errTest, err)
 }
 
+func TestDriveProvisioner_ExpandVolume(t *testing.T) {
+	var (
+		dp, mockLsblk, mockPH, mockFS = setupTestDriveProvisioner()
+		driveCR                       = testDriveCR.DeepCopy()
+		vol                           = testVolume2
+		deviceFile                    = "/dev/sda"
+		partName                      = "1"
+		driveSize                     = int64(util.GBYTE)
+	)
+
+	driveCR.Spec.Size = driveSize
+	assert.Nil(t, dp.k8sClient.CreateCR(testCtx, driveCR.Name, driveCR))
+	vol.Size = driveSize
+
+	mockLsblk.On("SearchDrivePath", &driveCR.Spec).Return(deviceFile, nil)
+	mockPH.On("SearchPartName", deviceFile, testVolume2.Id).Return(partName, nil)
+	mockFS.On("GetBlockDeviceSize", deviceFile).Return(driveSize, nil)
+	mockFS.On("GetBlockDeviceSize", deviceFile+partName).Return(driveSize-int64(util.MBYTE), nil).Once()
+	assert.Nil(t, dp.ExpandVolume(&vol))
+
+	// partition doesn't cover the whole drive
+	mockFS.On("GetBlockDeviceSize", deviceFile+partName).Return(driveSize/2, nil).Once()
+	assert.NotNil(t, dp.ExpandVolume(&vol))
+
+	// partition UUID can't be obtained from volume ID
+	vol.Id = ""
+	assert.NotNil(t, dp.ExpandVolume(&vol))
+	vol.Id = testVolume2.Id
+
+	// volume is bigger than drive
+	vol.Size = driveSize + 1
+	assert.NotNil(t, dp.ExpandVolume(&vol))
+
+	// volume in RAW mode isn't expanded
+	assert.Nil(t, dp.ExpandVolume(&testVolume2Raw))
+	mockPH.AssertNumberOfCalls(t, "SearchPartName", 2)
+}
+
 func TestDriveProvisioner_GetVolumePath_Success(t *testing.T) {
 	var (
 		dp, mockLsblk, mockPH, _ = setupTestDriveProvisioner()
@@ -17,42 +17,77 @@ limitations under the License.
 package provisioners
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/utils/keymutex"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/blkcopy"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/wipe"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	uw "github.com/dell/csi-baremetal/pkg/node/provisioners/utilwrappers"
 )
 
-// LVMProvisioner is a implementation of Provisioner interface
+const (
+	// lvm reserves LV names which start with "snapshot" prefix
+	reservedSnapshotPrefix = "snapshot"
+	snapshotLVPrefix       = "snap"
+	// prefix of the temporary snapshot of the clone source
+	cloneSnapshotPrefix = "clone-"
+	// suffix of the cache LV name of the cached volume
+	cacheLVSuffix = "-cache"
+)
+
+// LVMProvisioner is a implementation of Provisioner and SnapshotProvisioner interfaces
 // Work with volumes based on Volume Groups
 type LVMProvisioner struct {
-	lvmOps   lvm.WrapLVM
-	fsOps    uw.FSOperations
-	crHelper k8s.CRHelper
-	log      *logrus.Entry
+	lvmOps    lvm.WrapLVM
+	listBlk   lsblk.WrapLsblk
+	fsOps     uw.FSOperations
+	encOps    uw.EncryptionOperations
+	wipeOps   wipe.WrapWipe
+	blkCopy   blkcopy.WrapBlkCopy
+	k8sClient *k8s.KubeClient
+	crHelper  k8s.CRHelper
+	// vgMu serializes allocation of extents in VG, so LV isn't allocated on the cache drive
+	// before the drive is made not allocatable
+	vgMu keymutex.KeyMutex
+	log  *logrus.Entry
 }
 
 // NewLVMProvisioner is a constructor for LVMProvisioner
 func NewLVMProvisioner(e command.CmdExecutor, k *k8s.KubeClient, log *logrus.Logger) *LVMProvisioner {
 	return &LVMProvisioner{
-		lvmOps:   lvm.NewLVM(e, log),
-		fsOps:    uw.NewFSOperationsImpl(e, log),
-		crHelper: k8s.NewCRHelperImpl(k, log),
-		log:      log.WithField("component", "LVMProvisioner"),
+		lvmOps:    lvm.NewLVM(e, log),
+		listBlk:   lsblk.NewLSBLK(log),
+		fsOps:     uw.NewFSOperationsImpl(e, log),
+		encOps:    uw.NewEncryptionOperationsImpl(e, log),
+		wipeOps:   wipe.NewWipe(e, log),
+		blkCopy:   blkcopy.NewBlkCopy(log),
+		k8sClient: k,
+		crHelper:  k8s.NewCRHelperImpl(k, log),
+		vgMu:      keymutex.NewHashed(0),
+		log:       log.WithField("component", "LVMProvisioner"),
 	}
 }
 
 // PrepareVolume search volume group based on vol attributes, creates Logical Volume
-// and create file system on it. After that Logical Volume is ready for mount operations
+// and create file system on it. Logical Volume of the encrypted volume is left empty, it is formatted with LUKS
+// during NodeStageVolume with the key from CSI secrets. Content of the clone source is copied to Logical Volume
+// by CopyVolumeContent. After that Logical Volume is ready for mount operations
 func (l *LVMProvisioner) PrepareVolume(vol *api.Volume) error {
 	ll := l.log.WithFields(logrus.Fields{
 		"method":   "PrepareVolume",
@@ -65,11 +100,6 @@ func (l *LVMProvisioner) PrepareVolume(vol *api.Volume) error {
 		err    error
 	)
 
-	// prepare size in megabytes for the argument
-	size, _ := util.ToSizeUnit(vol.Size, util.BYTE, util.MBYTE)
-	sizeStr := strconv.FormatInt(size, 10)
-	sizeStr += "m"
-
 	vgName, err = l.getVGName(vol)
 	if err != nil {
 		return err
@@ -80,24 +110,279 @@ func (l *LVMProvisioner) PrepareVolume(vol *api.Volume) error {
 		return fmt.Errorf("failed to get volume UUID %s: %w", vol.Id, err)
 	}
 
-	// create lv with name /dev/VG_NAME/vol.Id
-	ll.Infof("Creating LV %s sizeof %s in VG %s", vol.Id, sizeStr, vgName)
-	if err = l.lvmOps.LVCreate(vol.Id, sizeStr, vgName); err != nil {
-		return fmt.Errorf("unable to create LV: %v", err)
+	if err = l.createLV(vol, vgName); err != nil {
+		return err
 	}
 
 	deviceFile := fmt.Sprintf("/dev/%s/%s", vgName, vol.Id)
+
+	if vol.EncryptionSecret != "" {
+		return nil
+	}
+
+	// file system is copied from the source by CopyVolumeContent
+	if vol.SourceVolumeId != "" || vol.SourceSnapshotId != "" {
+		return nil
+	}
+
 	ll.Debugf("Creating FS on %s", deviceFile)
 
 	if vol.Mode == apiV1.ModeRAW || vol.Mode == apiV1.ModeRAWPART {
 		return nil
 	}
 
-	return l.fsOps.CreateFSIfNotExist(fs.FileSystem(vol.Type), deviceFile, volUUID)
+	return l.fsOps.CreateFSIfNotExist(fs.FileSystem(vol.Type), deviceFile, volUUID, vol.MkfsOptions)
+}
+
+// createLV creates Logical Volume /dev/VG_NAME/vol.Id according to the storage class of the volume
+// and attaches cache to it
+func (l *LVMProvisioner) createLV(vol *api.Volume, vgName string) (err error) {
+	ll := l.log.WithFields(logrus.Fields{
+		"method":   "createLV",
+		"volumeID": vol.Id,
+	})
+
+	// prepare size in megabytes for the argument
+	size, _ := util.ToSizeUnit(vol.Size, util.BYTE, util.MBYTE)
+	sizeStr := strconv.FormatInt(size, 10)
+	sizeStr += "m"
+
+	defer l.lockVG(vgName)()
+	if util.IsStorageClassLVGThin(vol.StorageClass) {
+		pool := fmt.Sprintf("%s/%s", vgName, apiV1.ThinPoolName)
+		ll.Infof("Creating thin LV %s sizeof %s in thin pool %s", vol.Id, sizeStr, pool)
+		if err = l.lvmOps.ThinLVCreate(vol.Id, sizeStr, pool); err != nil {
+			return fmt.Errorf("unable to create thin LV: %v", err)
+		}
+	} else if raidType := util.GetRaidType(vol.StorageClass); raidType != "" {
+		ll.Infof("Creating %s LV %s sizeof %s in VG %s", raidType, vol.Id, sizeStr, vgName)
+		if err = l.lvmOps.RaidLVCreate(vol.Id, sizeStr, vgName, raidType); err != nil {
+			return fmt.Errorf("unable to create %s LV: %v", raidType, err)
+		}
+	} else if vol.Stripes > 1 {
+		ll.Infof("Creating LV %s sizeof %s striped across %d PVs in VG %s", vol.Id, sizeStr, vol.Stripes, vgName)
+		if err = l.lvmOps.StripedLVCreate(vol.Id, sizeStr, vgName, int(vol.Stripes), vol.StripeSize); err != nil {
+			return fmt.Errorf("unable to create striped LV: %v", err)
+		}
+	} else {
+		ll.Infof("Creating LV %s sizeof %s in VG %s", vol.Id, sizeStr, vgName)
+		if err = l.lvmOps.LVCreate(vol.Id, sizeStr, vgName); err != nil {
+			return fmt.Errorf("unable to create LV: %v", err)
+		}
+	}
+
+	if vol.CacheLocation != "" {
+		return l.attachCache(vol, vgName, fmt.Sprintf("/dev/%s/%s", vgName, vol.Id))
+	}
+	return nil
+}
+
+// lockVG locks allocation of extents in VG vgName
+// Returns function which unlocks VG
+func (l *LVMProvisioner) lockVG(vgName string) func() {
+	l.vgMu.LockKey(vgName)
+	return func() {
+		if err := l.vgMu.UnlockKey(vgName); err != nil {
+			l.log.Warnf("Unlocking VG %s with error %v", vgName, err)
+		}
+	}
+}
+
+// attachCache adds the cache drive of the volume to VG vgName, creates cache LV of the volume on it and attaches
+// cache LV to deviceFile, cache LV must be in the same VG as the cached LV. Cache drive is made not allocatable
+// after cache LV creation, so other LVs of VG are never allocated on it and the drive could be removed from VG
+// on release. Cache LV is removed if it can't be attached. VG must be locked by the caller
+func (l *LVMProvisioner) attachCache(vol *api.Volume, vgName, deviceFile string) error {
+	ll := l.log.WithFields(logrus.Fields{
+		"method":   "attachCache",
+		"volumeID": vol.Id,
+	})
+
+	cached, err := l.lvmOps.IsLVCached(deviceFile)
+	if err != nil {
+		return fmt.Errorf("unable to check cache of %s: %v", deviceFile, err)
+	}
+	if cached {
+		ll.Infof("Cache is already attached to %s", deviceFile)
+		return nil
+	}
+
+	cachePV, err := l.getCachePV(vol)
+	if err != nil {
+		return err
+	}
+	if pvVG, vgErr := l.lvmOps.GetVGNameByPVName(cachePV); vgErr != nil || pvVG != vgName {
+		ll.Infof("Adding cache drive %s to VG %s", cachePV, vgName)
+		if err = l.lvmOps.PVCreate(cachePV); err != nil {
+			return fmt.Errorf("unable to create PV for cache drive %s: %v", cachePV, err)
+		}
+		if err = l.lvmOps.VGExtend(vgName, cachePV); err != nil {
+			return fmt.Errorf("unable to extend VG %s with cache drive %s: %v", vgName, cachePV, err)
+		}
+	} else if err = l.lvmOps.PVChangeAllocatable(cachePV, true); err != nil {
+		// cache drive was made not allocatable by the previous attempt
+		return fmt.Errorf("unable to make cache drive %s allocatable: %v", cachePV, err)
+	}
+
+	size, _ := util.ToSizeUnit(vol.CacheSize, util.BYTE, util.MBYTE)
+	sizeStr := strconv.FormatInt(size, 10) + "m"
+	cacheLV := fmt.Sprintf("/dev/%s/%s", vgName, cacheLVName(vol.Id))
+
+	ll.Infof("Creating cache LV %s sizeof %s on %s", cacheLV, sizeStr, cachePV)
+	if err = l.lvmOps.LVCreateOnPV(cacheLVName(vol.Id), sizeStr, vgName, cachePV); err != nil {
+		return fmt.Errorf("unable to create cache LV: %v", err)
+	}
+	if err = l.lvmOps.PVChangeAllocatable(cachePV, false); err != nil {
+		return fmt.Errorf("unable to make cache drive %s not allocatable: %v", cachePV, err)
+	}
+	ll.Infof("Attaching cache LV %s to %s in %s mode", cacheLV, deviceFile, vol.CacheMode)
+	if err = l.lvmOps.CacheAttach(deviceFile, cacheLV, vol.CacheMode); err != nil {
+		if rErr := l.lvmOps.LVRemove(cacheLV); rErr != nil {
+			ll.Errorf("Unable to remove cache LV %s: %v", cacheLV, rErr)
+		}
+		return fmt.Errorf("unable to attach cache LV %s: %v", cacheLV, err)
+	}
+	return nil
+}
+
+// detachCache writes dirty blocks of the cache to deviceFile, detaches the cache, removes cache LV of the volume
+// and removes the cache drive from VG vgName. Steps which were already done are skipped, so it could be repeated
+func (l *LVMProvisioner) detachCache(vol *api.Volume, vgName, deviceFile string) error {
+	ll := l.log.WithFields(logrus.Fields{
+		"method":   "detachCache",
+		"volumeID": vol.Id,
+	})
+
+	cached, err := l.lvmOps.IsLVCached(deviceFile)
+	if err != nil {
+		return fmt.Errorf("unable to check cache of %s: %v", deviceFile, err)
+	}
+	if cached {
+		ll.Infof("Flushing and detaching cache from %s", deviceFile)
+		if err = l.lvmOps.CacheDetach(deviceFile); err != nil {
+			return fmt.Errorf("unable to detach cache from %s: %v", deviceFile, err)
+		}
+	}
+
+	cacheLV := fmt.Sprintf("/dev/%s/%s", vgName, cacheLVName(vol.Id))
+	if err = l.lvmOps.LVRemove(cacheLV); err != nil {
+		return fmt.Errorf("unable to remove cache LV %s: %v", cacheLV, err)
+	}
+
+	cachePV, err := l.getCachePV(vol)
+	if err != nil {
+		return err
+	}
+	ll.Infof("Removing cache drive %s from VG %s", cachePV, vgName)
+	if err = l.lvmOps.VGReduce(vgName, cachePV); err != nil {
+		return fmt.Errorf("unable to remove cache drive %s from VG %s: %v", cachePV, vgName, err)
+	}
+	return l.lvmOps.PVRemove(cachePV)
+}
+
+// getCachePV returns device of the cache drive of the volume, Volume.CacheLocation is a Drive CR name
+func (l *LVMProvisioner) getCachePV(vol *api.Volume) (string, error) {
+	drive := &drivecrd.Drive{}
+	ctx := context.WithValue(context.Background(), base.RequestUUID, vol.Id)
+	if err := l.k8sClient.ReadCR(ctx, vol.CacheLocation, "", drive); err != nil {
+		return "", fmt.Errorf("failed to read cache drive CR with name %s, error %w", vol.CacheLocation, err)
+	}
+	return l.listBlk.SearchDrivePath(&drive.Spec)
+}
+
+// CopyVolumeContent copies content of the snapshot or the source volume to Logical Volume prepared by PrepareVolume
+// and sets own UUID of the copied file system. Content of the encrypted volume isn't copied
+func (l *LVMProvisioner) CopyVolumeContent(vol *api.Volume, progress blkcopy.ProgressFunc) error {
+	if vol.EncryptionSecret != "" || (vol.SourceVolumeId == "" && vol.SourceSnapshotId == "") {
+		return nil
+	}
+	deviceFile, err := l.GetVolumePath(vol)
+	if err != nil {
+		return fmt.Errorf("unable to determine full path of the volume: %w", err)
+	}
+	volUUID, err := util.GetVolumeUUID(vol.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get volume UUID %s: %w", vol.Id, err)
+	}
+
+	if err = l.copyVolumeContent(vol, deviceFile, progress); err != nil {
+		return err
+	}
+	if vol.Mode == apiV1.ModeRAW || vol.Mode == apiV1.ModeRAWPART {
+		return nil
+	}
+	// copied FS has UUID of the source FS, clone must have own one to be mounted on the same node with the source
+	return l.fsOps.SetFSUUID(fs.FileSystem(vol.Type), deviceFile, volUUID)
+}
+
+// copyVolumeContent copies content of the snapshot or the source volume to the deviceFile,
+// source volume is copied through the temporary snapshot to get point-in-time copy of it
+func (l *LVMProvisioner) copyVolumeContent(vol *api.Volume, deviceFile string, progress blkcopy.ProgressFunc) error {
+	ll := l.log.WithFields(logrus.Fields{
+		"method":   "copyVolumeContent",
+		"volumeID": vol.Id,
+	})
+
+	if vol.SourceSnapshotId != "" {
+		snapshot, err := l.crHelper.GetSnapshotByID(vol.SourceSnapshotId)
+		if err != nil {
+			return fmt.Errorf("unable to read source snapshot %s: %w", vol.SourceSnapshotId, err)
+		}
+		srcFile, err := l.GetSnapshotPath(&snapshot.Spec)
+		if err != nil {
+			return err
+		}
+		ll.Infof("Copying content of snapshot %s to %s", srcFile, deviceFile)
+		return l.blkCopy.Copy(srcFile, deviceFile, progress)
+	}
+
+	srcVolume, err := l.crHelper.GetVolumeByID(vol.SourceVolumeId)
+	if err != nil {
+		return fmt.Errorf("unable to read source volume %s: %w", vol.SourceVolumeId, err)
+	}
+	tmpSnapshot := &api.Snapshot{
+		Id:           cloneSnapshotPrefix + vol.Id,
+		VolumeId:     srcVolume.Spec.Id,
+		Location:     srcVolume.Spec.Location,
+		StorageClass: srcVolume.Spec.StorageClass,
+		Size:         capacityplanner.GetCloneSnapshotSize(srcVolume.Spec.Size),
+	}
+	// COW area isn't reserved in AC, so it must fit into free space of VG to not take space of other LVs
+	if !util.IsStorageClassLVGThin(tmpSnapshot.StorageClass) {
+		vgName, err := l.getVGName(&srcVolume.Spec)
+		if err != nil {
+			return err
+		}
+		freeSpace, err := l.lvmOps.GetVgFreeSpace(vgName)
+		if err != nil {
+			return fmt.Errorf("unable to get free space of VG %s: %w", vgName, err)
+		}
+		if freeSpace < tmpSnapshot.Size {
+			return fmt.Errorf("not enough free space in VG %s for temporary snapshot of volume %s: "+
+				"required - %d, available - %d", vgName, srcVolume.Spec.Id, tmpSnapshot.Size, freeSpace)
+		}
+	}
+	if err = l.PrepareSnapshot(tmpSnapshot); err != nil {
+		return err
+	}
+	defer func() {
+		if err := l.ReleaseSnapshot(tmpSnapshot); err != nil {
+			ll.Errorf("Unable to remove temporary snapshot %s: %v", tmpSnapshot.Id, err)
+		}
+	}()
+
+	srcFile, err := l.GetSnapshotPath(tmpSnapshot)
+	if err != nil {
+		return err
+	}
+	ll.Infof("Copying content of volume %s to %s", srcFile, deviceFile)
+	return l.blkCopy.Copy(srcFile, deviceFile, progress)
 }
 
 // ReleaseVolume search volume group based on vol attributes, remove Logical Volume
-// and wipe file system on it. After that Logical Volume that had consumed by vol is completely removed
+// and wipe file system on it, mapper device of the encrypted volume is closed and LUKS header is erased.
+// Data of the Logical Volume is destroyed according to the volume wipe policy.
+// After that Logical Volume that had consumed by vol is completely removed
 func (l *LVMProvisioner) ReleaseVolume(vol *api.Volume, _ *api.Drive) error {
 	ll := logrus.WithFields(logrus.Fields{
 		"method":   "ReleaseVolume",
@@ -110,6 +395,31 @@ func (l *LVMProvisioner) ReleaseVolume(vol *api.Volume, _ *api.Drive) error {
 		return fmt.Errorf("unable to determine full path of the volume: %v", err)
 	}
 
+	if vol.EncryptionSecret != "" {
+		if err = l.encOps.ReleaseEncryptedDevice(deviceFile, vol.Id); err != nil {
+			return fmt.Errorf("unable to release encrypted device %s: %w", deviceFile, err)
+		}
+	}
+
+	if util.IsWipePolicyForDrive(vol.WipePolicy) {
+		return fmt.Errorf("%w: policy %s isn't applicable to LV %s", ErrWipeFailed, vol.WipePolicy, deviceFile)
+	}
+
+	// dirty blocks of the writeback cache are written to LV before the cache is removed
+	if vol.CacheLocation != "" {
+		vgName, err := l.getVGName(vol)
+		if err != nil {
+			return err
+		}
+		if err = l.detachCache(vol, vgName, deviceFile); err != nil {
+			return err
+		}
+	}
+
+	if vol.WipePolicy == apiV1.WipePolicyNone {
+		return l.lvmOps.LVRemove(deviceFile)
+	}
+
 	if err := l.fsOps.WipeFS(deviceFile); err != nil {
 		// check whether such LV (deviceFile) exist or not
 		vgName, sErr := l.getVGName(vol)
@@ -129,9 +439,52 @@ func (l *LVMProvisioner) ReleaseVolume(vol *api.Volume, _ *api.Drive) error {
 		return fmt.Errorf("failed to wipe FS on device %s: %v", deviceFile, err)
 	}
 
+	policy := GetEffectiveWipePolicy(vol)
+	if policy != vol.WipePolicy {
+		ll.Warnf("Wipe policy %s isn't applicable to thin LV %s, policy %s is used", vol.WipePolicy, deviceFile, policy)
+	}
+	if util.IsWipePolicyErasingData(policy) {
+		ll.Infof("Wipe LV %s with policy %s", deviceFile, policy)
+	}
+	if err = eraseData(l.wipeOps, policy, deviceFile); err != nil {
+		return err
+	}
+
 	return l.lvmOps.LVRemove(deviceFile)
 }
 
+// ExpandVolume expands Logical Volume up to vol.Size and checks that the new size has been reached.
+// File system on the Logical Volume isn't resized
+func (l *LVMProvisioner) ExpandVolume(vol *api.Volume) error {
+	ll := l.log.WithFields(logrus.Fields{
+		"method":   "ExpandVolume",
+		"volumeID": vol.Id,
+	})
+
+	vgName, err := l.getVGName(vol)
+	if err != nil {
+		return fmt.Errorf("unable to determine full path of the volume: %w", err)
+	}
+	deviceFile := fmt.Sprintf("/dev/%s/%s", vgName, vol.Id)
+
+	ll.Infof("Expand LV %s up to %d bytes", deviceFile, vol.Size)
+	unlock := l.lockVG(vgName)
+	err = l.lvmOps.ExpandLV(deviceFile, vol.Size)
+	unlock()
+	if err != nil {
+		return err
+	}
+
+	size, err := l.fsOps.GetBlockDeviceSize(deviceFile)
+	if err != nil {
+		return err
+	}
+	if size < vol.Size {
+		return fmt.Errorf("size of %s is %d after expansion, expected - %d", deviceFile, size, vol.Size)
+	}
+	return nil
+}
+
 // GetVolumePath search Volume Group name by vol attributes and construct
 // full path to the volume using template: /dev/VG_NAME/LV_NAME
 func (l *LVMProvisioner) GetVolumePath(vol *api.Volume) (string, error) {
@@ -149,6 +502,91 @@ func (l *LVMProvisioner) GetVolumePath(vol *api.Volume) (string, error) {
 	return fmt.Sprintf("/dev/%s/%s", vgName, vol.Id), nil // /dev/VG_NAME/LV_NAME
 }
 
+// PrepareSnapshot search volume group based on snapshot attributes and creates copy-on-write snapshot
+// of the source Logical Volume. Size of the COW area is equal to snapshot size.
+// Snapshot of the thin Logical Volume is created in the same thin pool and doesn't have COW area
+func (l *LVMProvisioner) PrepareSnapshot(snapshot *api.Snapshot) error {
+	ll := l.log.WithFields(logrus.Fields{
+		"method":     "PrepareSnapshot",
+		"snapshotID": snapshot.Id,
+	})
+	ll.Infof("Processing for snapshot %+v", *snapshot)
+
+	// prepare size in megabytes for the argument
+	size, _ := util.ToSizeUnit(snapshot.Size, util.BYTE, util.MBYTE)
+	sizeStr := strconv.FormatInt(size, 10) + "m"
+
+	vgName, err := l.getVGName(snapshotSourceVolume(snapshot))
+	if err != nil {
+		return err
+	}
+
+	origin := fmt.Sprintf("/dev/%s/%s", vgName, snapshot.VolumeId)
+	lvName := snapshotLVName(snapshot.Id)
+	if util.IsStorageClassLVGThin(snapshot.StorageClass) {
+		ll.Infof("Creating thin snapshot LV %s for LV %s", lvName, origin)
+		if err = l.lvmOps.ThinSnapshot(lvName, origin); err != nil {
+			return fmt.Errorf("unable to create thin snapshot of LV %s: %v", origin, err)
+		}
+		return nil
+	}
+	ll.Infof("Creating snapshot LV %s sizeof %s for LV %s", lvName, sizeStr, origin)
+	defer l.lockVG(vgName)()
+	if err = l.lvmOps.LVSnapshot(lvName, sizeStr, origin); err != nil {
+		return fmt.Errorf("unable to create snapshot of LV %s: %v", origin, err)
+	}
+	return nil
+}
+
+// ReleaseSnapshot removes snapshot Logical Volume, source Logical Volume stays untouched
+func (l *LVMProvisioner) ReleaseSnapshot(snapshot *api.Snapshot) error {
+	ll := l.log.WithFields(logrus.Fields{
+		"method":     "ReleaseSnapshot",
+		"snapshotID": snapshot.Id,
+	})
+	ll.Infof("Processing for snapshot %v", snapshot)
+
+	deviceFile, err := l.GetSnapshotPath(snapshot)
+	if err != nil {
+		return fmt.Errorf("unable to determine full path of the snapshot: %v", err)
+	}
+
+	return l.lvmOps.LVRemove(deviceFile)
+}
+
+// GetSnapshotPath search Volume Group name by snapshot attributes and construct
+// full path to the snapshot using template: /dev/VG_NAME/LV_NAME
+func (l *LVMProvisioner) GetSnapshotPath(snapshot *api.Snapshot) (string, error) {
+	vgName, err := l.getVGName(snapshotSourceVolume(snapshot))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("/dev/%s/%s", vgName, snapshotLVName(snapshot.Id)), nil // /dev/VG_NAME/LV_NAME
+}
+
+// snapshotSourceVolume returns volume with attributes required to find source LV of the snapshot
+func snapshotSourceVolume(snapshot *api.Snapshot) *api.Volume {
+	return &api.Volume{
+		Id:           snapshot.VolumeId,
+		Location:     snapshot.Location,
+		StorageClass: snapshot.StorageClass,
+	}
+}
+
+// cacheLVName returns name of the cache LV of the volume
+func cacheLVName(volumeID string) string {
+	return volumeID + cacheLVSuffix
+}
+
+// snapshotLVName returns name of LV for snapshot, names with "snapshot" prefix are reserved by lvm
+func snapshotLVName(snapshotID string) string {
+	if strings.HasPrefix(snapshotID, reservedSnapshotPrefix) {
+		return snapshotLVPrefix + strings.TrimPrefix(snapshotID, reservedSnapshotPrefix)
+	}
+	return snapshotID
+}
+
 func (l *LVMProvisioner) getVGName(vol *api.Volume) (string, error) {
 	var vgName = vol.Location
 
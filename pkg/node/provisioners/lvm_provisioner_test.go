@@ -17,6 +17,7 @@ limitations under the License.
 package provisioners
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -25,17 +26,22 @@ import (
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/mocks"
 	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
 	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
 )
 
 var (
-	lp     *LVMProvisioner
-	lvmOps *mocklu.MockWrapLVM
-	fsOps  *mockProv.MockFsOpts
+	lp      *LVMProvisioner
+	lvmOps  *mocklu.MockWrapLVM
+	fsOps   *mockProv.MockFsOpts
+	blkCopy *mocklu.MockWrapBlkCopy
 )
 
 func setupTestLVMProvisioner() {
@@ -47,9 +53,12 @@ func setupTestLVMProvisioner() {
 	lp = NewLVMProvisioner(&command.Executor{}, kubeClient, testLogger)
 	lvmOps = &mocklu.MockWrapLVM{}
 	fsOps = &mockProv.MockFsOpts{}
+	blkCopy = &mocklu.MockWrapBlkCopy{}
 
 	lp.lvmOps = lvmOps
 	lp.fsOps = fsOps
+	lp.blkCopy = blkCopy
+	lp.listBlk = mocklu.GetMockWrapLsblk(testCachePV)
 }
 
 func TestLVMProvisioner_PrepareVolume_Success(t *testing.T) {
@@ -86,6 +95,141 @@ func TestLVMProvisioner_PrepareVolume_Block_RawPart_Success(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestLVMProvisioner_PrepareVolume_Thin(t *testing.T) {
+	setupTestLVMProvisioner()
+
+	vol := testVolume1
+	vol.StorageClass = apiV1.StorageClassHDDLVGThin
+	pool := fmt.Sprintf("%s/%s", vol.Location, apiV1.ThinPoolName)
+
+	lvmOps.On("ThinLVCreate", vol.Id, mock.Anything, pool).Return(nil).Times(1)
+	devFile := fmt.Sprintf("/dev/%s/%s", vol.Location, vol.Id)
+	fsOps.On("CreateFSIfNotExist", fs.FileSystem(vol.Type), devFile, vol.Id).Return(nil).Times(1)
+
+	err := lp.PrepareVolume(&vol)
+	assert.Nil(t, err)
+	lvmOps.AssertNotCalled(t, "LVCreate", mock.Anything, mock.Anything, mock.Anything)
+
+	// ThinLVCreate failed
+	lvmOps.On("ThinLVCreate", vol.Id, mock.Anything, pool).Return(errTest).Times(1)
+	err = lp.PrepareVolume(&vol)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to create thin LV")
+}
+
+func TestLVMProvisioner_PrepareVolume_Raid(t *testing.T) {
+	setupTestLVMProvisioner()
+
+	vol := testVolume1
+	vol.StorageClass = apiV1.StorageClassHDDLVGRaid10
+
+	lvmOps.On("RaidLVCreate", vol.Id, mock.Anything, vol.Location, apiV1.RaidType10).Return(nil).Times(1)
+	devFile := fmt.Sprintf("/dev/%s/%s", vol.Location, vol.Id)
+	fsOps.On("CreateFSIfNotExist", fs.FileSystem(vol.Type), devFile, vol.Id).Return(nil).Times(1)
+
+	err := lp.PrepareVolume(&vol)
+	assert.Nil(t, err)
+	lvmOps.AssertNotCalled(t, "LVCreate", mock.Anything, mock.Anything, mock.Anything)
+
+	// RaidLVCreate failed
+	lvmOps.On("RaidLVCreate", vol.Id, mock.Anything, vol.Location, apiV1.RaidType10).Return(errTest).Times(1)
+	err = lp.PrepareVolume(&vol)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to create raid10 LV")
+}
+
+func TestLVMProvisioner_PrepareVolume_Striped(t *testing.T) {
+	setupTestLVMProvisioner()
+
+	vol := testVolume1
+	vol.StorageClass = apiV1.StorageClassHDDLVGStriped
+	vol.Stripes, vol.StripeSize = 3, "64k"
+
+	lvmOps.On("StripedLVCreate", vol.Id, mock.Anything, vol.Location, 3, "64k").Return(nil).Times(1)
+	devFile := fmt.Sprintf("/dev/%s/%s", vol.Location, vol.Id)
+	fsOps.On("CreateFSIfNotExist", fs.FileSystem(vol.Type), devFile, vol.Id).Return(nil).Times(1)
+
+	err := lp.PrepareVolume(&vol)
+	assert.Nil(t, err)
+	lvmOps.AssertNotCalled(t, "LVCreate", mock.Anything, mock.Anything, mock.Anything)
+
+	// StripedLVCreate failed
+	lvmOps.On("StripedLVCreate", vol.Id, mock.Anything, vol.Location, 3, "64k").Return(errTest).Times(1)
+	err = lp.PrepareVolume(&vol)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to create striped LV")
+}
+
+func TestLVMProvisioner_PrepareVolume_Cached(t *testing.T) {
+	setupTestLVMProvisioner()
+
+	var (
+		vol     = testVolume1
+		devFile = fmt.Sprintf("/dev/%s/%s", vol.Location, vol.Id)
+		cacheLV = fmt.Sprintf("/dev/%s/%s-cache", vol.Location, vol.Id)
+	)
+	vol.CacheLocation = testCacheDriveCR.Name
+	vol.CacheSize = int64(util.GBYTE)
+	vol.CacheMode = apiV1.CacheModeWriteback
+	assert.Nil(t, lp.k8sClient.CreateCR(testCtx, testCacheDriveCR.Name, testCacheDriveCR.DeepCopy()))
+
+	lvmOps.On("LVCreate", vol.Id, mock.Anything, vol.Location).Return(nil)
+	lvmOps.On("IsLVCached", devFile).Return(false, nil).Times(1)
+	lvmOps.On("GetVGNameByPVName", testCachePV).Return("", errTest).Times(1)
+	lvmOps.On("PVCreate", testCachePV).Return(nil).Times(1)
+	lvmOps.On("VGExtend", vol.Location, testCachePV).Return(nil).Times(1)
+	lvmOps.On("LVCreateOnPV", vol.Id+"-cache", "1024m", vol.Location, testCachePV).Return(nil)
+	lvmOps.On("PVChangeAllocatable", testCachePV, false).Return(nil)
+	lvmOps.On("CacheAttach", devFile, cacheLV, apiV1.CacheModeWriteback).Return(nil).Times(1)
+	fsOps.On("CreateFSIfNotExist", fs.FileSystem(vol.Type), devFile, vol.Id).Return(nil)
+
+	err := lp.PrepareVolume(&vol)
+	assert.Nil(t, err)
+	// other LVs of VG aren't allocated on the cache drive
+	lvmOps.AssertCalled(t, "PVChangeAllocatable", testCachePV, false)
+
+	// cache is already attached
+	lvmOps.On("IsLVCached", devFile).Return(true, nil).Times(1)
+	err = lp.PrepareVolume(&vol)
+	assert.Nil(t, err)
+	lvmOps.AssertNumberOfCalls(t, "CacheAttach", 1)
+
+	// CacheAttach failed, cache LV is removed, cache drive is already in VG
+	lvmOps.On("IsLVCached", devFile).Return(false, nil).Times(1)
+	lvmOps.On("GetVGNameByPVName", testCachePV).Return(vol.Location, nil).Times(1)
+	lvmOps.On("PVChangeAllocatable", testCachePV, true).Return(nil).Times(1)
+	lvmOps.On("CacheAttach", devFile, cacheLV, apiV1.CacheModeWriteback).Return(errTest).Times(1)
+	lvmOps.On("LVRemove", cacheLV).Return(nil).Times(1)
+	err = lp.PrepareVolume(&vol)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to attach cache LV")
+	lvmOps.AssertCalled(t, "LVRemove", cacheLV)
+	lvmOps.AssertNumberOfCalls(t, "VGExtend", 1)
+
+	// cache drive CR doesn't exist
+	vol.CacheLocation = "unknown-drive"
+	lvmOps.On("IsLVCached", devFile).Return(false, nil).Times(1)
+	err = lp.PrepareVolume(&vol)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to read cache drive CR")
+}
+
+func TestLVMProvisioner_PrepareVolume_Encrypted(t *testing.T) {
+	setupTestLVMProvisioner()
+	encOps := &mockProv.MockEncryptionOpts{}
+	lp.encOps = encOps
+
+	vol := testVolume1
+	vol.EncryptionSecret = testNs + "/volume-key"
+
+	// LV is formatted with LUKS and FS during NodeStageVolume
+	lvmOps.On("LVCreate", vol.Id, mock.Anything, vol.Location).Return(nil)
+	assert.Nil(t, lp.PrepareVolume(&vol))
+	lvmOps.AssertNumberOfCalls(t, "LVCreate", 1)
+	fsOps.AssertNotCalled(t, "CreateFSIfNotExist", mock.Anything, mock.Anything, mock.Anything)
+	encOps.AssertNotCalled(t, "OpenEncryptedDevice", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestLVMProvisioner_PrepareVolume_Fail(t *testing.T) {
 	setupTestLVMProvisioner()
 	var err error
@@ -120,6 +264,84 @@ func TestLVMProvisioner_PrepareVolume_Fail(t *testing.T) {
 	assert.Equal(t, errTest, err)
 }
 
+func TestLVMProvisioner_PrepareVolume_Clone(t *testing.T) {
+	setupTestLVMProvisioner()
+	crHelper := &mocks.CRHelper{}
+	lp.crHelper = crHelper
+
+	var (
+		vol       = testVolume1
+		srcVolume = &volumecrd.Volume{Spec: testVolume1}
+		devFile   = fmt.Sprintf("/dev/%s/%s", vol.Location, "clone-volume-id")
+		tmpFile   = fmt.Sprintf("/dev/%s/%s", vol.Location, "clone-clone-volume-id")
+		origin    = fmt.Sprintf("/dev/%s/%s", vol.Location, testVolume1.Id)
+	)
+	vol.Id = "clone-volume-id"
+	vol.SourceVolumeId = testVolume1.Id
+	srcVolume.Spec.Size = 1024 * 1024 * 1024
+
+	crHelper.On("GetVolumeByID", testVolume1.Id).Return(srcVolume, nil)
+	lvmOps.On("LVCreate", vol.Id, mock.Anything, vol.Location).Return(nil)
+
+	// content isn't copied and FS isn't created by PrepareVolume
+	err := lp.PrepareVolume(&vol)
+	assert.Nil(t, err)
+	blkCopy.AssertNotCalled(t, "Copy", mock.Anything, mock.Anything, mock.Anything)
+	fsOps.AssertNotCalled(t, "CreateFSIfNotExist", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// COW area of the temporary snapshot has the same size as the source
+	lvmOps.On("GetVgFreeSpace", vol.Location).Return(int64(2*1024*1024*1024), nil).Times(2)
+	lvmOps.On("LVSnapshot", "clone-clone-volume-id", "1024m", origin).Return(nil).Times(1)
+	lvmOps.On("LVRemove", tmpFile).Return(nil).Times(1)
+	blkCopy.On("Copy", tmpFile, devFile, mock.Anything).Return(nil).Times(1)
+	fsOps.On("SetFSUUID", fs.FileSystem(vol.Type), devFile, vol.Id).Return(nil).Times(1)
+
+	err = lp.CopyVolumeContent(&vol, nil)
+	assert.Nil(t, err)
+
+	// temporary snapshot is removed even if copy failed
+	lvmOps.On("LVSnapshot", "clone-clone-volume-id", "1024m", origin).Return(nil).Times(1)
+	lvmOps.On("LVRemove", tmpFile).Return(nil).Times(1)
+	blkCopy.On("Copy", tmpFile, devFile, mock.Anything).Return(errTest).Times(1)
+
+	err = lp.CopyVolumeContent(&vol, nil)
+	assert.Equal(t, errTest, err)
+	lvmOps.AssertNumberOfCalls(t, "LVRemove", 2)
+	fsOps.AssertNumberOfCalls(t, "SetFSUUID", 1)
+
+	// not enough free space in VG for temporary snapshot
+	lvmOps.On("GetVgFreeSpace", vol.Location).Return(int64(1024*1024), nil).Times(1)
+	err = lp.CopyVolumeContent(&vol, nil)
+	assert.NotNil(t, err)
+	lvmOps.AssertNumberOfCalls(t, "LVSnapshot", 2)
+}
+
+func TestLVMProvisioner_PrepareVolume_RestoreSnapshot(t *testing.T) {
+	setupTestLVMProvisioner()
+	crHelper := &mocks.CRHelper{}
+	lp.crHelper = crHelper
+
+	vol := testVolume1Raw
+	vol.Id = "restored-volume-id"
+	vol.SourceSnapshotId = testSnapshot1.Id
+
+	crHelper.On("GetSnapshotByID", testSnapshot1.Id).
+		Return(&snapshotcrd.Snapshot{Spec: testSnapshot1}, nil).Times(1)
+	lvmOps.On("LVCreate", vol.Id, mock.Anything, vol.Location).Return(nil)
+	blkCopy.On("Copy", fmt.Sprintf("/dev/%s/%s", vol.Location, "snap-1-id"),
+		fmt.Sprintf("/dev/%s/%s", vol.Location, vol.Id), mock.Anything).Return(nil).Times(1)
+
+	err := lp.PrepareVolume(&vol)
+	assert.Nil(t, err)
+	err = lp.CopyVolumeContent(&vol, nil)
+	assert.Nil(t, err)
+
+	// snapshot doesn't exist
+	crHelper.On("GetSnapshotByID", testSnapshot1.Id).Return(nil, errTest).Times(1)
+	err = lp.CopyVolumeContent(&vol, nil)
+	assert.NotNil(t, err)
+}
+
 func TestLVMProvisioner_ReleaseVolume_Success(t *testing.T) {
 	setupTestLVMProvisioner()
 
@@ -142,6 +364,107 @@ func TestLVMProvisioner_ReleaseVolume_Success(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestLVMProvisioner_ReleaseVolume_Cached(t *testing.T) {
+	setupTestLVMProvisioner()
+
+	var (
+		vol     = testVolume1
+		devFile = fmt.Sprintf("/dev/%s/%s", vol.Location, vol.Id)
+		cacheLV = fmt.Sprintf("/dev/%s/%s-cache", vol.Location, vol.Id)
+	)
+	vol.CacheLocation = testCacheDriveCR.Name
+	assert.Nil(t, lp.k8sClient.CreateCR(testCtx, testCacheDriveCR.Name, testCacheDriveCR.DeepCopy()))
+
+	lvmOps.On("IsLVCached", devFile).Return(true, nil).Times(1)
+	lvmOps.On("CacheDetach", devFile).Return(nil).Times(1)
+	lvmOps.On("LVRemove", cacheLV).Return(nil)
+	lvmOps.On("VGReduce", vol.Location, testCachePV).Return(nil)
+	lvmOps.On("PVRemove", testCachePV).Return(nil)
+	fsOps.On("WipeFS", devFile).Return(nil)
+	lvmOps.On("LVRemove", devFile).Return(nil)
+
+	err := lp.ReleaseVolume(&vol, &api.Drive{})
+	assert.Nil(t, err)
+
+	// cache was detached during the previous attempt
+	lvmOps.On("IsLVCached", devFile).Return(false, nil).Times(1)
+	err = lp.ReleaseVolume(&vol, &api.Drive{})
+	assert.Nil(t, err)
+	lvmOps.AssertNumberOfCalls(t, "CacheDetach", 1)
+	lvmOps.AssertNumberOfCalls(t, "VGReduce", 2)
+
+	// LV isn't removed if cache wasn't detached
+	lvmOps.On("IsLVCached", devFile).Return(true, nil).Times(1)
+	lvmOps.On("CacheDetach", devFile).Return(errTest).Times(1)
+	err = lp.ReleaseVolume(&vol, &api.Drive{})
+	assert.NotNil(t, err)
+	lvmOps.AssertNumberOfCalls(t, "LVRemove", 4)
+}
+
+func TestLVMProvisioner_ReleaseVolume_Encrypted(t *testing.T) {
+	setupTestLVMProvisioner()
+	encOps := &mockProv.MockEncryptionOpts{}
+	lp.encOps = encOps
+
+	var (
+		vol     = testVolume1
+		devFile = fmt.Sprintf("/dev/%s/%s", testVolume1.Location, testVolume1.Id)
+	)
+	vol.EncryptionSecret = testNs + "/volume-key"
+
+	encOps.On("ReleaseEncryptedDevice", devFile, vol.Id).Return(nil).Once()
+	fsOps.On("WipeFS", devFile).Return(nil).Once()
+	lvmOps.On("LVRemove", devFile).Return(nil).Once()
+	assert.Nil(t, lp.ReleaseVolume(&vol, &api.Drive{}))
+
+	// unable to close encrypted device
+	encOps.On("ReleaseEncryptedDevice", devFile, vol.Id).Return(errTest).Once()
+	err := lp.ReleaseVolume(&vol, &api.Drive{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to release encrypted device")
+}
+
+func TestLVMProvisioner_ReleaseVolume_WipePolicy(t *testing.T) {
+	setupTestLVMProvisioner()
+	wipeOps := &mocklu.MockWrapWipe{}
+	lp.wipeOps = wipeOps
+
+	var (
+		vol     = testVolume1
+		devFile = fmt.Sprintf("/dev/%s/%s", testVolume1.Location, testVolume1.Id)
+	)
+	fsOps.On("WipeFS", devFile).Return(nil)
+	lvmOps.On("LVRemove", devFile).Return(nil)
+
+	// LV is zeroed before removal
+	vol.WipePolicy = apiV1.WipePolicyZero
+	wipeOps.On("ZeroOut", devFile).Return(nil).Once()
+	assert.Nil(t, lp.ReleaseVolume(&vol, &api.Drive{}))
+
+	// thin LV is discarded instead of zeroing
+	vol.StorageClass = apiV1.StorageClassHDDLVGThin
+	wipeOps.On("Discard", devFile).Return(nil).Once()
+	assert.Nil(t, lp.ReleaseVolume(&vol, &api.Drive{}))
+	wipeOps.AssertExpectations(t)
+
+	// wipe is skipped
+	vol.WipePolicy = apiV1.WipePolicyNone
+	assert.Nil(t, lp.ReleaseVolume(&vol, &api.Drive{}))
+	fsOps.AssertNumberOfCalls(t, "WipeFS", 2)
+
+	// wipe failed, LV isn't removed
+	vol.WipePolicy = apiV1.WipePolicyDiscard
+	wipeOps.On("Discard", devFile).Return(errTest).Once()
+	err := lp.ReleaseVolume(&vol, &api.Drive{})
+	assert.True(t, errors.Is(err, ErrWipeFailed))
+	lvmOps.AssertNumberOfCalls(t, "LVRemove", 3)
+
+	// policy isn't applicable to LV
+	vol.WipePolicy = apiV1.WipePolicyNVMeFormat
+	err = lp.ReleaseVolume(&vol, &api.Drive{})
+	assert.True(t, errors.Is(err, ErrWipeFailed))
+}
+
 func TestLVMProvisioner_ReleaseVolume_Fail(t *testing.T) {
 	setupTestLVMProvisioner()
 
@@ -185,6 +508,29 @@ func TestLVMProvisioner_ReleaseVolume_Fail(t *testing.T) {
 	assert.Equal(t, errTest, err)
 }
 
+func TestLVMProvisioner_ExpandVolume(t *testing.T) {
+	setupTestLVMProvisioner()
+
+	var (
+		vol     = testVolume1
+		devFile = fmt.Sprintf("/dev/%s/%s", testVolume1.Location, testVolume1.Id)
+	)
+	vol.Size = int64(util.GBYTE)
+
+	lvmOps.On("ExpandLV", devFile, vol.Size).Return(nil).Times(1)
+	fsOps.On("GetBlockDeviceSize", devFile).Return(vol.Size, nil).Times(1)
+	assert.Nil(t, lp.ExpandVolume(&vol))
+
+	// LV wasn't expanded up to required size
+	lvmOps.On("ExpandLV", devFile, vol.Size).Return(nil).Times(1)
+	fsOps.On("GetBlockDeviceSize", devFile).Return(vol.Size/2, nil).Times(1)
+	assert.NotNil(t, lp.ExpandVolume(&vol))
+
+	// ExpandLV failed
+	lvmOps.On("ExpandLV", devFile, vol.Size).Return(errTest).Times(1)
+	assert.Equal(t, errTest, lp.ExpandVolume(&vol))
+}
+
 func TestLVMProvisioner_GetVolumePath_Success(t *testing.T) {
 	setupTestLVMProvisioner()
 
@@ -202,3 +548,51 @@ func TestLVMProvisioner_getVGName_Success(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, testVolume1.Location, vgName)
 }
+
+func TestLVMProvisioner_PrepareSnapshot(t *testing.T) {
+	setupTestLVMProvisioner()
+
+	origin := fmt.Sprintf("/dev/%s/%s", testSnapshot1.Location, testSnapshot1.VolumeId)
+	lvmOps.On("LVSnapshot", "snap-1-id", "100m", origin).Return(nil).Times(1)
+	err := lp.PrepareSnapshot(&testSnapshot1)
+	assert.Nil(t, err)
+
+	lvmOps.On("LVSnapshot", "snap-1-id", "100m", origin).Return(errTest).Times(1)
+	err = lp.PrepareSnapshot(&testSnapshot1)
+	assert.NotNil(t, err)
+}
+
+func TestLVMProvisioner_PrepareSnapshot_Thin(t *testing.T) {
+	setupTestLVMProvisioner()
+
+	snapshot := testSnapshot1
+	snapshot.StorageClass = apiV1.StorageClassHDDLVGThin
+	origin := fmt.Sprintf("/dev/%s/%s", snapshot.Location, snapshot.VolumeId)
+
+	lvmOps.On("ThinSnapshot", "snap-1-id", origin).Return(nil).Times(1)
+	err := lp.PrepareSnapshot(&snapshot)
+	assert.Nil(t, err)
+	lvmOps.AssertNotCalled(t, "LVSnapshot", mock.Anything, mock.Anything, mock.Anything)
+
+	lvmOps.On("ThinSnapshot", "snap-1-id", origin).Return(errTest).Times(1)
+	err = lp.PrepareSnapshot(&snapshot)
+	assert.NotNil(t, err)
+}
+
+func TestLVMProvisioner_ReleaseSnapshot(t *testing.T) {
+	setupTestLVMProvisioner()
+
+	snapshotPath := fmt.Sprintf("/dev/%s/%s", testSnapshot1.Location, "snap-1-id")
+	lvmOps.On("LVRemove", snapshotPath).Return(nil).Times(1)
+	err := lp.ReleaseSnapshot(&testSnapshot1)
+	assert.Nil(t, err)
+
+	lvmOps.On("LVRemove", snapshotPath).Return(errTest).Times(1)
+	err = lp.ReleaseSnapshot(&testSnapshot1)
+	assert.Equal(t, errTest, err)
+}
+
+func TestLVMProvisioner_snapshotLVName(t *testing.T) {
+	assert.Equal(t, "snap-1-id", snapshotLVName("snapshot-1-id"))
+	assert.Equal(t, "snap-1-id", snapshotLVName("snap-1-id"))
+}
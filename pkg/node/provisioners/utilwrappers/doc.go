@@ -20,5 +20,6 @@ Package utilwrappers consists of code that manipulates by os utils and use code
 Interfaces descriptions:
 1. FSOperations works with file system and holds compound methods for interacting with it
 2. PartitionOperations works with partition on the system and holds compound methods for interacting with it
+3. EncryptionOperations works with LUKS encrypted devices and holds compound methods for interacting with them
 */
 package utilwrappers
@@ -0,0 +1,104 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilwrappers
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cryptsetup"
+)
+
+// EncryptionOperations is a high-level interface
+// that encapsulates operations with LUKS encrypted devices on node
+type EncryptionOperations interface {
+	// OpenEncryptedDevice formats device with LUKS2 if it isn't formatted yet and format is allowed and opens it,
+	// returns path of the opened device
+	OpenEncryptedDevice(device, name string, key []byte, format bool) (string, error)
+	// ReleaseEncryptedDevice closes opened device and erases LUKS header of the device
+	ReleaseEncryptedDevice(device, name string) error
+	cryptsetup.WrapCryptsetup
+}
+
+// EncryptionOperationsImpl is a base implementation for EncryptionOperations interface
+type EncryptionOperationsImpl struct {
+	cryptsetup.WrapCryptsetup
+	log *logrus.Entry
+}
+
+// NewEncryptionOperationsImpl constructor for EncryptionOperationsImpl and returns pointer on it
+func NewEncryptionOperationsImpl(e command.CmdExecutor, log *logrus.Logger) *EncryptionOperationsImpl {
+	return &EncryptionOperationsImpl{
+		WrapCryptsetup: cryptsetup.NewCryptsetup(e, log),
+		log:            log.WithField("component", "EncryptionOperations"),
+	}
+}
+
+// OpenEncryptedDevice (idempotent) implementation of EncryptionOperations method
+// formats device with LUKS2 if there is no LUKS header on it and format is allowed (the first stage of the volume)
+// and opens it as /dev/mapper/<name>. Device is never formatted if LUKS header check failed
+func (eo *EncryptionOperationsImpl) OpenEncryptedDevice(device, name string, key []byte, format bool) (string, error) {
+	ll := eo.log.WithFields(logrus.Fields{
+		"method": "OpenEncryptedDevice",
+	})
+
+	isLuks, err := eo.IsLuks(device)
+	if err != nil {
+		return "", fmt.Errorf("unable to check LUKS header of device %s: %w", device, err)
+	}
+	if !isLuks {
+		if !format {
+			return "", fmt.Errorf("there is no LUKS header on device %s which has been already formatted", device)
+		}
+		ll.Infof("Formatting device %s with LUKS", device)
+		if err := eo.LuksFormat(device, key); err != nil {
+			return "", fmt.Errorf("unable to format device %s with LUKS: %w", device, err)
+		}
+	}
+
+	if err := eo.Open(device, name, key); err != nil {
+		return "", fmt.Errorf("unable to open encrypted device %s: %w", device, err)
+	}
+	return cryptsetup.GetMapperPath(name), nil
+}
+
+// ReleaseEncryptedDevice (idempotent) implementation of EncryptionOperations method
+// closes /dev/mapper/<name> and erases LUKS key slots of the device, so data of the device can't be decrypted anymore
+func (eo *EncryptionOperationsImpl) ReleaseEncryptedDevice(device, name string) error {
+	ll := eo.log.WithFields(logrus.Fields{
+		"method": "ReleaseEncryptedDevice",
+	})
+
+	if err := eo.Close(name); err != nil {
+		return fmt.Errorf("unable to close encrypted device %s: %w", name, err)
+	}
+
+	isLuks, err := eo.IsLuks(device)
+	if err != nil {
+		return fmt.Errorf("unable to check LUKS header of device %s: %w", device, err)
+	}
+	if !isLuks {
+		ll.Infof("There is no LUKS header on device %s", device)
+		return nil
+	}
+	if err := eo.Erase(device); err != nil {
+		return fmt.Errorf("unable to erase LUKS header of device %s: %w", device, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilwrappers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cryptsetup"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+)
+
+func TestEncryptionOperationsImpl_OpenEncryptedDevice(t *testing.T) {
+	var (
+		encOps   = NewEncryptionOperationsImpl(&command.Executor{}, logrus.New())
+		wrapCS   = &mocklu.MockWrapCryptsetup{}
+		device   = "/dev/sda1"
+		name     = "pvc-1"
+		key      = []byte("key")
+		errCrypt = errors.New("error")
+	)
+	encOps.WrapCryptsetup = wrapCS
+
+	// device isn't formatted
+	wrapCS.On("IsLuks", device).Return(false, nil).Once()
+	wrapCS.On("LuksFormat", device, key).Return(nil).Once()
+	wrapCS.On("Open", device, name, key).Return(nil).Once()
+	path, err := encOps.OpenEncryptedDevice(device, name, key, true)
+	assert.Nil(t, err)
+	assert.Equal(t, cryptsetup.GetMapperPath(name), path)
+
+	// device has been already formatted
+	wrapCS.On("IsLuks", device).Return(true, nil).Once()
+	wrapCS.On("Open", device, name, key).Return(nil).Once()
+	_, err = encOps.OpenEncryptedDevice(device, name, key, true)
+	assert.Nil(t, err)
+	wrapCS.AssertNumberOfCalls(t, "LuksFormat", 1)
+
+	// format failed
+	wrapCS.On("IsLuks", device).Return(false, nil).Once()
+	wrapCS.On("LuksFormat", device, key).Return(errCrypt).Once()
+	_, err = encOps.OpenEncryptedDevice(device, name, key, true)
+	assert.True(t, errors.Is(err, errCrypt))
+
+	// open failed
+	wrapCS.On("IsLuks", device).Return(true, nil).Once()
+	wrapCS.On("Open", device, name, key).Return(errCrypt).Once()
+	_, err = encOps.OpenEncryptedDevice(device, name, key, true)
+	assert.True(t, errors.Is(err, errCrypt))
+
+	// LUKS header check failed, device isn't formatted
+	wrapCS.On("IsLuks", device).Return(false, errCrypt).Once()
+	_, err = encOps.OpenEncryptedDevice(device, name, key, true)
+	assert.True(t, errors.Is(err, errCrypt))
+
+	// device has been already formatted on the first stage, but there is no LUKS header on it
+	wrapCS.On("IsLuks", device).Return(false, nil).Once()
+	_, err = encOps.OpenEncryptedDevice(device, name, key, false)
+	assert.NotNil(t, err)
+	wrapCS.AssertNumberOfCalls(t, "LuksFormat", 2)
+}
+
+func TestEncryptionOperationsImpl_ReleaseEncryptedDevice(t *testing.T) {
+	var (
+		encOps   = NewEncryptionOperationsImpl(&command.Executor{}, logrus.New())
+		wrapCS   = &mocklu.MockWrapCryptsetup{}
+		device   = "/dev/sda1"
+		name     = "pvc-1"
+		errCrypt = errors.New("error")
+	)
+	encOps.WrapCryptsetup = wrapCS
+
+	wrapCS.On("Close", name).Return(nil).Once()
+	wrapCS.On("IsLuks", device).Return(true, nil).Once()
+	wrapCS.On("Erase", device).Return(nil).Once()
+	assert.Nil(t, encOps.ReleaseEncryptedDevice(device, name))
+
+	// LUKS header has been already erased
+	wrapCS.On("Close", name).Return(nil).Once()
+	wrapCS.On("IsLuks", device).Return(false, nil).Once()
+	assert.Nil(t, encOps.ReleaseEncryptedDevice(device, name))
+	wrapCS.AssertNumberOfCalls(t, "Erase", 1)
+
+	// close failed
+	wrapCS.On("Close", name).Return(errCrypt).Once()
+	assert.True(t, errors.Is(encOps.ReleaseEncryptedDevice(device, name), errCrypt))
+
+	// erase failed
+	wrapCS.On("Close", name).Return(nil).Once()
+	wrapCS.On("IsLuks", device).Return(true, nil).Once()
+	wrapCS.On("Erase", device).Return(errCrypt).Once()
+	assert.True(t, errors.Is(encOps.ReleaseEncryptedDevice(device, name), errCrypt))
+	// LUKS header check failed
+	wrapCS.On("Close", name).Return(nil).Once()
+	wrapCS.On("IsLuks", device).Return(false, errCrypt).Once()
+	assert.True(t, errors.Is(encOps.ReleaseEncryptedDevice(device, name), errCrypt))
+}
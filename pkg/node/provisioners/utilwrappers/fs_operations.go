@@ -35,8 +35,8 @@ type FSOperations interface {
 	MountFakeTmpfs(volumeID, dst string) error
 	// UnmountWithCheck unmount operation
 	UnmountWithCheck(path string) error
-	// CreateFSIfNotExist checks FS and creates one if not exist
-	CreateFSIfNotExist(fsType fs.FileSystem, device, uuid string) error
+	// CreateFSIfNotExist checks FS and creates one with additional mkfs options if not exist
+	CreateFSIfNotExist(fsType fs.FileSystem, device, uuid, mkfsOptions string) error
 	// CreateFakeDevice creates of fake block device
 	CreateFakeDevice(src string) (string, error)
 	fs.WrapFS
@@ -181,19 +181,25 @@ func (fsOp *FSOperationsImpl) MountFakeTmpfs(volumeID, dst string) error {
 	return fsOp.Mount(volumeID, dst, "-t tmpfs -o size=1M,rw")
 }
 
-// CreateFSIfNotExist checks FS and creates one if not exist
+// CreateFSIfNotExist checks FS and creates one if not exist, mkfsOptions are validated against allowlist of the FS
 /*
 	CMD example:
 		lsblk <device> --output FSTYPE --noheadings
 		# Check output
 
-		mkfs.<fsType> <device>
+		mkfs.<fsType> <device> <mkfsOptions>
 */
-func (fsOp *FSOperationsImpl) CreateFSIfNotExist(fsType fs.FileSystem, device, uuid string) error {
+func (fsOp *FSOperationsImpl) CreateFSIfNotExist(fsType fs.FileSystem, device, uuid, mkfsOptions string) error {
 	ll := fsOp.log.WithFields(logrus.Fields{
 		"method": "CreateFSIfNotExist",
 	})
 
+	options, err := fs.ParseMkfsOptions(fsType, mkfsOptions)
+	if err != nil {
+		ll.Errorf("Unable to create FS on %s: %v", device, err)
+		return err
+	}
+
 	// check existing FS
 	existingType, err := fsOp.GetFSType(device)
 	if err != nil {
@@ -219,7 +225,7 @@ func (fsOp *FSOperationsImpl) CreateFSIfNotExist(fsType fs.FileSystem, device, u
 	}
 
 	// create FS
-	err = fsOp.CreateFS(fsType, device, uuid)
+	err = fsOp.CreateFS(fsType, device, uuid, options...)
 	if err != nil {
 		ll.Errorf("Unable to create FS type %s on %s: %v", fsType, device, err)
 		return err
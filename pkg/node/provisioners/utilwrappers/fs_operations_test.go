@@ -233,10 +233,34 @@ func TestFSOperationsImpl_CreateFSIfNotExist_NoFS(t *testing.T) {
 	wrapFS.On("GetFSUUID", path).Return("", nil).Once()
 	wrapFS.On("CreateFS", fs.FileSystem(fsType), path, uuid).Return(nil).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid, "")
 	assert.Nil(t, err)
 }
 
+func TestFSOperationsImpl_CreateFSIfNotExist_MkfsOptions(t *testing.T) {
+	var (
+		fsOps  = NewFSOperationsImpl(&command.Executor{}, logrus.New())
+		wrapFS = &mocklu.MockWrapFS{}
+		path   = "/some/path"
+		uuid   = "test-uuid"
+		fsType = "xfs"
+		err    error
+	)
+	fsOps.WrapFS = wrapFS
+
+	wrapFS.On("GetFSType", path).Return("", nil).Once()
+	wrapFS.On("GetFSUUID", path).Return("", nil).Once()
+	wrapFS.On("CreateFS", fs.FileSystem(fsType), path, uuid).Return(nil).Once()
+
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid, "-m reflink=1 -d agcount=8")
+	assert.Nil(t, err)
+
+	// option isn't allowed, device isn't touched
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid, "-f")
+	assert.NotNil(t, err)
+	wrapFS.AssertNumberOfCalls(t, "GetFSType", 1)
+}
+
 func TestFSOperationsImpl_CreateFSIfNotExist_FSExists(t *testing.T) {
 	var (
 		fsOps  = NewFSOperationsImpl(&command.Executor{}, logrus.New())
@@ -251,7 +275,7 @@ func TestFSOperationsImpl_CreateFSIfNotExist_FSExists(t *testing.T) {
 	wrapFS.On("GetFSType", path).Return(fsType, nil).Once()
 	wrapFS.On("GetFSUUID", path).Return(uuid, nil).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid, "")
 	assert.Nil(t, err)
 }
 
@@ -269,7 +293,7 @@ func TestFSOperationsImpl_CreateFSIfNotExist_OtherFS(t *testing.T) {
 	wrapFS.On("GetFSType", path).Return("other_FS", nil).Once()
 	wrapFS.On("GetFSUUID", path).Return(uuid, nil).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid, "")
 	assert.NotNil(t, err)
 }
 
@@ -287,7 +311,7 @@ func TestFSOperationsImpl_CreateFSIfNotExist_OtherUUID(t *testing.T) {
 	wrapFS.On("GetFSType", path).Return(fsType, nil).Once()
 	wrapFS.On("GetFSUUID", path).Return("other_UUID", nil).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid, "")
 	assert.NotNil(t, err)
 }
 
@@ -304,7 +328,7 @@ func TestFSOperationsImpl_CreateFSIfNotExist_CheckError(t *testing.T) {
 
 	wrapFS.On("GetFSType", path).Return("", errors.New("some_error")).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid, "")
 	assert.NotNil(t, err)
 }
 
@@ -322,7 +346,7 @@ func TestFSOperationsImpl_CreateFSIfNotExist_CheckUUIDError(t *testing.T) {
 	wrapFS.On("GetFSType", path).Return("", nil).Once()
 	wrapFS.On("GetFSUUID", path).Return("", errors.New("some_error")).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid, "")
 	assert.NotNil(t, err)
 }
 
@@ -341,7 +365,7 @@ func TestFSOperationsImpl_CreateFSIfNotExist_CreateError(t *testing.T) {
 	wrapFS.On("GetFSUUID", path).Return("", nil).Once()
 	wrapFS.On("CreateFS", fs.FileSystem(fsType), path, uuid).Return(errors.New("some_error")).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, uuid, "")
 	assert.NotNil(t, err)
 }
 
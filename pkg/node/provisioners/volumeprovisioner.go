@@ -20,7 +20,10 @@ limitations under the License.
 // and encapsulates all low-level work with these objects.
 package provisioners
 
-import api "github.com/dell/csi-baremetal/api/generated/v1"
+import (
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/blkcopy"
+)
 
 // VolumeType is used for describing class of volume depending on underlying structures
 // volume could be based on partitions, logical volume and so on
@@ -39,6 +42,26 @@ type Provisioner interface {
 	PrepareVolume(volume *api.Volume) error
 	// ReleaseVolume completely releases underlying resources that had consumed by volume
 	ReleaseVolume(volume *api.Volume, drive *api.Drive) error
+	// ExpandVolume grows underlying device of the volume up to the volume size
+	ExpandVolume(volume *api.Volume) error
 	// GetVolumePath returns full path of device file that represent volume on node
 	GetVolumePath(volume *api.Volume) (string, error)
 }
+
+// ContentProvisioner is a high-level interface that encapsulates copy of the volume content from the source volume
+// (clone) or from the snapshot (restore). Copy might take long time, so it isn't performed by PrepareVolume
+type ContentProvisioner interface {
+	// CopyVolumeContent copies content of the volume source to the volume prepared by PrepareVolume,
+	// progress is reported after each copied chunk
+	CopyVolumeContent(volume *api.Volume, progress blkcopy.ProgressFunc) error
+}
+
+// SnapshotProvisioner is a high-level interface that encapsulates all low-level work with volume snapshots on node
+type SnapshotProvisioner interface {
+	// PrepareSnapshot creates point-in-time copy of the source volume
+	PrepareSnapshot(snapshot *api.Snapshot) error
+	// ReleaseSnapshot completely releases underlying resources that had consumed by snapshot
+	ReleaseSnapshot(snapshot *api.Snapshot) error
+	// GetSnapshotPath returns full path of device file that represent snapshot on node
+	GetSnapshotPath(snapshot *api.Snapshot) (string, error)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioners
+
+import (
+	"errors"
+	"fmt"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/wipe"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// ErrWipeFailed is returned by ReleaseVolume when data of the volume wasn't destroyed according to its wipe policy
+var ErrWipeFailed = errors.New("wipe failed")
+
+// GetEffectiveWipePolicy returns wipe policy which is actually applied to the volume on release.
+// Zeroing of the thin LV allocates all its blocks in the thin pool, so it is replaced with discard:
+// discarded blocks return to the pool and are zeroed by the pool before the next use
+func GetEffectiveWipePolicy(vol *api.Volume) string {
+	if vol.WipePolicy == apiV1.WipePolicyZero && util.IsStorageClassLVGThin(vol.StorageClass) {
+		return apiV1.WipePolicyDiscard
+	}
+	return vol.WipePolicy
+}
+
+// eraseData destroys data on the device according to the wipe policy,
+// does nothing for policies which don't destroy data
+func eraseData(wipeOps wipe.WrapWipe, policy, device string) error {
+	var err error
+	switch policy {
+	case apiV1.WipePolicyDiscard:
+		err = wipeOps.Discard(device)
+	case apiV1.WipePolicyZero:
+		err = wipeOps.ZeroOut(device)
+	case apiV1.WipePolicyNVMeFormat:
+		err = wipeOps.NVMeFormat(device)
+	case apiV1.WipePolicyATASecureErase:
+		err = wipeOps.ATASecureErase(device)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: unable to wipe device %s with policy %s: %v", ErrWipeFailed, device, policy, err)
+	}
+	return nil
+}
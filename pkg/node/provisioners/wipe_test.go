@@ -0,0 +1,67 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioners
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+)
+
+func TestEraseData(t *testing.T) {
+	var (
+		wipeOps = &mocklu.MockWrapWipe{}
+		device  = "/dev/sda"
+	)
+
+	// policies which don't destroy data
+	for _, policy := range []string{"", apiV1.WipePolicyNone, apiV1.WipePolicySignatures} {
+		assert.Nil(t, eraseData(wipeOps, policy, device))
+	}
+	wipeOps.AssertExpectations(t)
+
+	wipeOps.On("Discard", device).Return(nil).Once()
+	assert.Nil(t, eraseData(wipeOps, apiV1.WipePolicyDiscard, device))
+	wipeOps.On("ZeroOut", device).Return(nil).Once()
+	assert.Nil(t, eraseData(wipeOps, apiV1.WipePolicyZero, device))
+	wipeOps.On("NVMeFormat", device).Return(nil).Once()
+	assert.Nil(t, eraseData(wipeOps, apiV1.WipePolicyNVMeFormat, device))
+	wipeOps.On("ATASecureErase", device).Return(nil).Once()
+	assert.Nil(t, eraseData(wipeOps, apiV1.WipePolicyATASecureErase, device))
+	wipeOps.AssertExpectations(t)
+
+	wipeOps.On("ZeroOut", device).Return(errTest).Once()
+	err := eraseData(wipeOps, apiV1.WipePolicyZero, device)
+	assert.True(t, errors.Is(err, ErrWipeFailed))
+}
+
+func TestGetEffectiveWipePolicy(t *testing.T) {
+	vol := &api.Volume{StorageClass: apiV1.StorageClassHDDLVG, WipePolicy: apiV1.WipePolicyZero}
+	assert.Equal(t, apiV1.WipePolicyZero, GetEffectiveWipePolicy(vol))
+
+	// thin LV isn't zeroed
+	vol.StorageClass = apiV1.StorageClassHDDLVGThin
+	assert.Equal(t, apiV1.WipePolicyDiscard, GetEffectiveWipePolicy(vol))
+
+	vol.WipePolicy = apiV1.WipePolicySignatures
+	assert.Equal(t, apiV1.WipePolicySignatures, GetEffectiveWipePolicy(vol))
+}
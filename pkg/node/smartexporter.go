@@ -0,0 +1,174 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/smartctl"
+	"github.com/dell/csi-baremetal/pkg/metrics"
+	metricscomm "github.com/dell/csi-baremetal/pkg/metrics/common"
+)
+
+// smartMetric binds exported SMART metric with the function which extracts its value from SMARTAttributes
+type smartMetric struct {
+	metric metrics.StatisticWithCustomLabels
+	value  func(attrs *smartctl.SMARTAttributes) *int64
+}
+
+// SmartExporter periodically parses SMART information of the node drives received from drivemgr
+// and exports it as prometheus metrics labelled by drive UUID, serial number and node
+type SmartExporter struct {
+	client   api.DriveServiceClient
+	crHelper k8s.CRHelper
+	nodeID   string
+	interval time.Duration
+	metrics  []smartMetric
+	// serial numbers of the drives with exported metrics
+	exported map[string]bool
+	log      *logrus.Entry
+}
+
+// NewSmartExporter is the constructor for SmartExporter struct
+// Receives drivemgr client, kube client to resolve drive UUIDs, node ID and interval between collections
+// Returns an instance of SmartExporter
+func NewSmartExporter(client api.DriveServiceClient, k8sClient *k8s.KubeClient, nodeID string,
+	interval time.Duration, logger *logrus.Logger) *SmartExporter {
+	return &SmartExporter{
+		client:   client,
+		crHelper: k8s.NewCRHelperImpl(k8sClient, logger),
+		nodeID:   nodeID,
+		interval: interval,
+		metrics: []smartMetric{
+			{metricscomm.SmartTemperature, func(a *smartctl.SMARTAttributes) *int64 { return a.Temperature }},
+			{metricscomm.SmartPowerOnHours, func(a *smartctl.SMARTAttributes) *int64 { return a.PowerOnHours }},
+			{metricscomm.SmartReallocatedSectors, func(a *smartctl.SMARTAttributes) *int64 { return a.ReallocatedSectors }},
+			{metricscomm.SmartPendingSectors, func(a *smartctl.SMARTAttributes) *int64 { return a.PendingSectors }},
+			{metricscomm.SmartMediaErrors, func(a *smartctl.SMARTAttributes) *int64 { return a.MediaErrors }},
+			{metricscomm.SmartPercentageUsed, func(a *smartctl.SMARTAttributes) *int64 { return a.PercentageUsed }},
+			{metricscomm.SmartGrownDefects, func(a *smartctl.SMARTAttributes) *int64 { return a.GrownDefects }},
+		},
+		exported: make(map[string]bool),
+		log:      logger.WithField("component", "SmartExporter"),
+	}
+}
+
+// Run collects SMART metrics every interval until ctx is done
+// Returns if drivemgr doesn't support SMART info, e.g. RedfishManager
+func (e *SmartExporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		if err := e.Collect(ctx); err != nil {
+			if status.Code(err) == codes.Unimplemented {
+				e.log.Infof("DriveManager doesn't support SMART info, SMART metrics aren't exported: %v", err)
+				return
+			}
+			e.log.Errorf("Unable to collect SMART metrics: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect requests SMART information of all drives from drivemgr and updates exported metrics
+// gRPC status error is returned as is if drivemgr doesn't implement GetAllDrivesSmartInfo
+// SMART information is expected as JSON object with drive serial number as a key and smartctl or nvme_cli JSON output as a value
+func (e *SmartExporter) Collect(ctx context.Context) error {
+	ll := e.log.WithField("method", "Collect")
+
+	resp, err := e.client.GetAllDrivesSmartInfo(ctx, &api.Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("drivemgr response failure: %v", err)
+	}
+	smartInfo := map[string]json.RawMessage{}
+	if err = json.Unmarshal([]byte(resp.GetSmartInfo()), &smartInfo); err != nil {
+		return fmt.Errorf("unable to unmarshal SMART info, error: %v", err)
+	}
+
+	drives, err := e.crHelper.GetDriveCRs(e.nodeID)
+	if err != nil {
+		return fmt.Errorf("unable to read drive CRs, error: %v", err)
+	}
+	driveUUIDs := make(map[string]string, len(drives))
+	for _, d := range drives {
+		driveUUIDs[d.Spec.SerialNumber] = d.Spec.UUID
+	}
+
+	exported := make(map[string]bool, len(smartInfo))
+	for sn, info := range smartInfo {
+		driveUUID, ok := driveUUIDs[sn]
+		if !ok {
+			ll.Debugf("Drive CR with serial number %s isn't found, skip SMART metrics", sn)
+			continue
+		}
+		attrs, err := smartctl.ParseSMARTAttributes(info)
+		if err != nil {
+			ll.Errorf("Unable to parse SMART info of drive %s: %v", sn, err)
+			continue
+		}
+		e.update(driveUUID, sn, attrs)
+		exported[sn] = true
+	}
+
+	// clear metrics of the drives which are removed or don't report SMART info anymore
+	for sn := range e.exported {
+		if !exported[sn] {
+			e.clear(sn)
+		}
+	}
+	e.exported = exported
+	return nil
+}
+
+// update sets metrics of the drive to the values of attrs, metrics of unreported attributes are cleared
+func (e *SmartExporter) update(driveUUID, sn string, attrs *smartctl.SMARTAttributes) {
+	driveLabels := prometheus.Labels{"serial_number": sn}
+	for _, m := range e.metrics {
+		value := m.value(attrs)
+		if value == nil {
+			m.metric.Clear(driveLabels)
+			continue
+		}
+		labels := prometheus.Labels{"drive_uuid": driveUUID, "serial_number": sn, "node": e.nodeID}
+		// clear metric in case of drive UUID was changed
+		m.metric.UpdateValue(float64(*value), labels, true, driveLabels)
+	}
+}
+
+// clear removes all metrics of the drive with serial number sn
+func (e *SmartExporter) clear(sn string) {
+	for _, m := range e.metrics {
+		m.metric.Clear(prometheus.Labels{"serial_number": sn})
+	}
+}
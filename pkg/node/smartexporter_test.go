@@ -0,0 +1,104 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	metricscomm "github.com/dell/csi-baremetal/pkg/metrics/common"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+func TestSmartExporter_Collect(t *testing.T) {
+	kubeClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	for _, d := range []api.Drive{disk1, disk2} {
+		assert.Nil(t, kubeClient.CreateCR(testCtx, d.UUID, kubeClient.ConstructDriveCR(d.UUID, d)))
+	}
+
+	smartInfo := `{
+		"hdd1": {"temperature": {"current": 35}, "power_on_time": {"hours": 100},
+			"ata_smart_attributes": {"table": [{"id": 5, "raw": {"value": 4}}, {"id": 197, "raw": {"value": 1}}]}},
+		"hdd2": {"nvme_smart_health_information_log": {"temperature": 50, "media_errors": 2, "percentage_used": 10}},
+		"unknown": {"temperature": {"current": 20}}
+	}`
+	client := &mocks.MockDriveMgrClientFailJSON{MockJSON: smartInfo}
+	e := NewSmartExporter(client, kubeClient, nodeID, 0, testLogger)
+
+	assert.Nil(t, e.Collect(testCtx))
+	value := func(m *prometheus.GaugeVec, uuid, sn string) float64 {
+		return testutil.ToFloat64(m.With(prometheus.Labels{"source": "MetricsWithCustomLabels",
+			"drive_uuid": uuid, "serial_number": sn, "node": nodeID}))
+	}
+	assert.Equal(t, float64(35), value(metricscomm.SmartTemperature.GaugeVec, disk1.UUID, "hdd1"))
+	assert.Equal(t, float64(100), value(metricscomm.SmartPowerOnHours.GaugeVec, disk1.UUID, "hdd1"))
+	assert.Equal(t, float64(4), value(metricscomm.SmartReallocatedSectors.GaugeVec, disk1.UUID, "hdd1"))
+	assert.Equal(t, float64(1), value(metricscomm.SmartPendingSectors.GaugeVec, disk1.UUID, "hdd1"))
+	assert.Equal(t, float64(50), value(metricscomm.SmartTemperature.GaugeVec, disk2.UUID, "hdd2"))
+	assert.Equal(t, float64(2), value(metricscomm.SmartMediaErrors.GaugeVec, disk2.UUID, "hdd2"))
+	assert.Equal(t, float64(10), value(metricscomm.SmartPercentageUsed.GaugeVec, disk2.UUID, "hdd2"))
+	assert.Equal(t, 2, testutil.CollectAndCount(metricscomm.SmartTemperature.GaugeVec))
+	assert.Equal(t, 1, testutil.CollectAndCount(metricscomm.SmartMediaErrors.GaugeVec))
+
+	// drive hdd2 doesn't report SMART info anymore
+	client.MockJSON = `{"hdd1": {"temperature": {"current": 36}}}`
+	assert.Nil(t, e.Collect(testCtx))
+	assert.Equal(t, 1, testutil.CollectAndCount(metricscomm.SmartTemperature.GaugeVec))
+	assert.Equal(t, 0, testutil.CollectAndCount(metricscomm.SmartMediaErrors.GaugeVec))
+	assert.Equal(t, 0, testutil.CollectAndCount(metricscomm.SmartPowerOnHours.GaugeVec))
+	assert.Equal(t, float64(36), value(metricscomm.SmartTemperature.GaugeVec, disk1.UUID, "hdd1"))
+
+	client.MockJSON = "not a json"
+	assert.NotNil(t, e.Collect(testCtx))
+
+	failClient := &mocks.MockDriveMgrClientFail{}
+	assert.NotNil(t, NewSmartExporter(failClient, kubeClient, nodeID, 0, testLogger).Collect(testCtx))
+}
+
+func TestSmartExporter_Run(t *testing.T) {
+	kubeClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+
+	// Run returns without waiting for ctx if drivemgr doesn't support SMART info
+	failClient := &mocks.MockDriveMgrClientFail{Code: codes.Unimplemented}
+	done := make(chan struct{})
+	go func() {
+		NewSmartExporter(failClient, kubeClient, nodeID, time.Millisecond, testLogger).Run(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SmartExporter.Run isn't stopped on Unimplemented error")
+	}
+
+	// Run keeps collecting on other errors until ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	NewSmartExporter(&mocks.MockDriveMgrClientFail{Code: codes.Internal}, kubeClient, nodeID,
+		time.Millisecond, testLogger).Run(ctx)
+	assert.NotNil(t, ctx.Err())
+}
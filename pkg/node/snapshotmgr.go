@@ -0,0 +1,142 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	metricsC "github.com/dell/csi-baremetal/pkg/metrics/common"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+// ReconcileSnapshot is the Reconcile loop of VolumeManager for Snapshot CRs. This loop creates snapshot of the source
+// volume if Snapshot.Spec.CSIStatus is Creating and removes it if Snapshot.Spec.CSIStatus is Removing.
+// Returns reconcile result as ctrl.Result or error if something went wrong
+func (m *VolumeManager) ReconcileSnapshot(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	defer metricsC.ReconcileDuration.EvaluateDurationForType("node_snapshot_controller")()
+	m.volMu.LockKey(req.Name)
+	ll := m.log.WithFields(logrus.Fields{
+		"method":     "ReconcileSnapshot",
+		"snapshotID": req.Name,
+	})
+	defer func() {
+		err := m.volMu.UnlockKey(req.Name)
+		if err != nil {
+			ll.Warnf("Unlocking snapshot with error %s", err)
+		}
+	}()
+	ctx, cancelFn := context.WithTimeout(
+		context.WithValue(ctx, base.RequestUUID, req.Name),
+		VolumeOperationsTimeout)
+	defer cancelFn()
+
+	snapshot := &snapshotcrd.Snapshot{}
+	if err := m.k8sClient.ReadCR(ctx, req.Name, "", snapshot); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	ll.Infof("Processing for status %s", snapshot.Spec.CSIStatus)
+	switch snapshot.Spec.CSIStatus {
+	case apiV1.Creating, apiV1.Removing:
+	default:
+		return ctrl.Result{}, nil
+	}
+
+	prov, err := m.getSnapshotProvisioner()
+	if err != nil {
+		ll.Errorf("Unable to process snapshot: %v", err)
+		if snapshot.Spec.CSIStatus == apiV1.Removing {
+			return ctrl.Result{}, err
+		}
+		return m.handleSnapshotStatus(ctx, snapshot, "", func(*api.Snapshot) error { return err })
+	}
+	switch snapshot.Spec.CSIStatus {
+	case apiV1.Creating:
+		return m.handleSnapshotStatus(ctx, snapshot, apiV1.Created, prov.PrepareSnapshot)
+	case apiV1.Removing:
+		return m.handleSnapshotStatus(ctx, snapshot, apiV1.Removed, prov.ReleaseSnapshot)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// handleSnapshotStatus performs snapshot operation on the node and update Snapshot CR's CSIStatus
+// to successStatus or to Failed if operation has failed.
+// Failed removal keeps Removing status and is retried, Failed snapshot CR is removed by controller together
+// with its space in AC, so it must never have LV on the node
+func (m *VolumeManager) handleSnapshotStatus(ctx context.Context, snapshot *snapshotcrd.Snapshot, successStatus string,
+	operation func(*api.Snapshot) error) (ctrl.Result, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":     "handleSnapshotStatus",
+		"snapshotID": snapshot.Name,
+	})
+
+	newStatus := successStatus
+	err := operation(&snapshot.Spec)
+	if err != nil && snapshot.Spec.CSIStatus == apiV1.Removing {
+		ll.Errorf("Unable to move snapshot to status %s: %v. Removal will be retried", successStatus, err)
+		return ctrl.Result{}, err
+	}
+	if err != nil {
+		ll.Errorf("Unable to move snapshot to status %s: %v. Set snapshot status to Failed", successStatus, err)
+		newStatus = apiV1.Failed
+	}
+
+	snapshot.Spec.CSIStatus = newStatus
+	if updateErr := m.k8sClient.UpdateCR(ctx, snapshot); updateErr != nil {
+		ll.Errorf("Unable to update snapshot status to %s: %v", newStatus, updateErr)
+		return ctrl.Result{Requeue: true}, updateErr
+	}
+
+	return ctrl.Result{}, err
+}
+
+// getSnapshotProvisioner returns SnapshotProvisioner implementation, only LVM based volumes support snapshots
+// Returns error if LVM provisioner doesn't support snapshots
+func (m *VolumeManager) getSnapshotProvisioner() (p.SnapshotProvisioner, error) {
+	prov, ok := m.provisioners[p.LVMBasedVolumeType].(p.SnapshotProvisioner)
+	if !ok {
+		return nil, fmt.Errorf("provisioner of %s volumes doesn't support snapshots", p.LVMBasedVolumeType)
+	}
+	return prov, nil
+}
+
+// SetupSnapshotControllerWithManager registers VolumeManager to ControllerManager as a controller for Snapshot CR
+func (m *VolumeManager) SetupSnapshotControllerWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&snapshotcrd.Snapshot{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+		}).
+		WithEventFilter(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			snapshot, ok := obj.(*snapshotcrd.Snapshot)
+			return ok && snapshot.Spec.NodeId == m.nodeID
+		})).
+		Complete(reconcile.Func(m.ReconcileSnapshot))
+}
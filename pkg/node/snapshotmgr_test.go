@@ -0,0 +1,103 @@
+/*
+Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+func TestVolumeManager_ReconcileSnapshot(t *testing.T) {
+	var (
+		vm       = prepareSuccessVolumeManager(t)
+		pMock    = &mockProv.MockProvisioner{}
+		snapshot = vm.k8sClient.ConstructSnapshotCR("snapshot-1", api.Snapshot{
+			Id:           "snapshot-1",
+			VolumeId:     testID,
+			NodeId:       nodeID,
+			Location:     testLVGName,
+			StorageClass: apiV1.StorageClassHDDLVG,
+			Size:         1024 * 1024,
+			CSIStatus:    apiV1.Creating,
+		})
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: snapshot.Name}}
+		res ctrl.Result
+		err error
+	)
+	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.LVMBasedVolumeType: pMock})
+
+	// snapshot CR doesn't exist
+	res, err = vm.ReconcileSnapshot(testCtx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{}, res)
+
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, snapshot.Name, snapshot))
+
+	// creating
+	pMock.On("PrepareSnapshot", mock.Anything).Return(nil).Once()
+	res, err = vm.ReconcileSnapshot(testCtx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{}, res)
+	current := &snapshotcrd.Snapshot{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, snapshot.Name, "", current))
+	assert.Equal(t, apiV1.Created, current.Spec.CSIStatus)
+
+	// removing failed, status is kept to retry removal
+	current.Spec.CSIStatus = apiV1.Removing
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, current))
+	pMock.On("ReleaseSnapshot", mock.Anything).Return(testErr).Once()
+	_, err = vm.ReconcileSnapshot(testCtx, req)
+	assert.NotNil(t, err)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, snapshot.Name, "", current))
+	assert.Equal(t, apiV1.Removing, current.Spec.CSIStatus)
+
+	// removing
+	pMock.On("ReleaseSnapshot", mock.Anything).Return(nil).Once()
+	_, err = vm.ReconcileSnapshot(testCtx, req)
+	assert.Nil(t, err)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, snapshot.Name, "", current))
+	assert.Equal(t, apiV1.Removed, current.Spec.CSIStatus)
+
+	// provisioner doesn't support snapshots
+	current.Spec.CSIStatus = apiV1.Creating
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, current))
+	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{
+		p.LVMBasedVolumeType: p.NewDriveProvisioner(&mocks.GoMockExecutor{}, vm.k8sClient, testLogger)})
+	_, err = vm.ReconcileSnapshot(testCtx, req)
+	assert.NotNil(t, err)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, snapshot.Name, "", current))
+	assert.Equal(t, apiV1.Failed, current.Spec.CSIStatus)
+
+	// removal isn't failed if provisioner doesn't support snapshots
+	current.Spec.CSIStatus = apiV1.Removing
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, current))
+	_, err = vm.ReconcileSnapshot(testCtx, req)
+	assert.NotNil(t, err)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, snapshot.Name, "", current))
+	assert.Equal(t, apiV1.Removing, current.Spec.CSIStatus)
+}
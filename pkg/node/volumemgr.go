@@ -18,11 +18,14 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -44,10 +47,14 @@ import (
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/blkcopy"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cgroup"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/datadiscover"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/datadiscover/types"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
@@ -78,6 +85,13 @@ const (
 
 	numberOfRetries  = 5
 	delayBeforeRetry = 2
+
+	// volumeCopyRetryTimeout is the interval of checks whether volume is unstaged and can be copied
+	volumeCopyRetryTimeout = 30 * time.Second
+	// volumeCopyPollTimeout is the interval of checks of the volume copy progress
+	volumeCopyPollTimeout = 10 * time.Second
+	// volumeCopyProgressStep is the step of copy progress in percents which is reported in volume CR and events
+	volumeCopyProgressStep = 10
 )
 
 // eventRecorder interface for sending events
@@ -98,6 +112,8 @@ type VolumeManager struct {
 
 	// uses for communicating with hardware manager
 	driveMgrClient api.DriveServiceClient
+	// drives received from WatchDrives stream of hardware manager
+	drivesSnapshot *driveSnapshot
 	// holds implementations of Provisioner interface
 	provisioners map[p.VolumeType]p.Provisioner
 
@@ -105,6 +121,8 @@ type VolumeManager struct {
 	partOps ph.WrapPartition
 	// uses for FS operations such as Mount/Unmount, MkFS and so on
 	fsOps utilwrappers.FSOperations
+	// uses for opening/closing mapper devices of the encrypted volumes
+	encOps utilwrappers.EncryptionOperations
 	// uses for LVM operations
 	lvmOps lvm.WrapLVM
 	// uses for running lsblk util
@@ -112,6 +130,12 @@ type VolumeManager struct {
 	// uses for disable/enable WBT
 	wbtOps    wbtops.WrapWbt
 	wbtConfig *wbtconf.WbtConfig
+	// uses for IO throttling of the pods
+	cgroupOps cgroup.WrapCgroup
+	// uses for copy of the volumes to another drive
+	blkCopy blkcopy.WrapBlkCopy
+	// copies of the volumes running in background, key is volume ID and value is *volumeCopy
+	volumeCopies sync.Map
 
 	// uses for searching suitable Available Capacity
 	acProvider common.AvailableCapacityOperations
@@ -143,6 +167,19 @@ type VolumeManager struct {
 	dataDiscover types.WrapDataDiscover
 }
 
+// volumeCopy holds state of the volume copy running in background
+type volumeCopy struct {
+	// target volume which content is copied to
+	target api.Volume
+	// percent of the copied data, updated atomically by copy
+	percent int64
+	// percent of the copied data reported in volume CR and events
+	reported int64
+	// closed when copy is finished, err is set before that
+	done chan struct{}
+	err  error
+}
+
 // driveStates internal struct, holds info about drive updates
 // not thread safe
 type driveUpdates struct {
@@ -219,16 +256,20 @@ func NewVolumeManager(
 		crHelper:       k8s.NewCRHelperImpl(k8sClient, logger),
 		cachedCrHelper: k8s.NewCRHelperImpl(k8sClient, logger).SetReader(k8sCache),
 		driveMgrClient: client,
+		drivesSnapshot: &driveSnapshot{},
 		acProvider:     common.NewACOperationsImpl(k8sClient, logger),
 		provisioners: map[p.VolumeType]p.Provisioner{
 			p.DriveBasedVolumeType: p.NewDriveProvisioner(executor, k8sClient, logger),
 			p.LVMBasedVolumeType:   p.NewLVMProvisioner(executor, k8sClient, logger),
 		},
 		fsOps:                  fsOps,
+		encOps:                 utilwrappers.NewEncryptionOperationsImpl(executor, logger),
 		lvmOps:                 lvmOps,
 		listBlk:                lsblk.NewLSBLK(logger),
 		partOps:                partImpl,
 		wbtOps:                 wbtOps,
+		cgroupOps:              cgroup.NewCgroup(cgroup.DefaultRoot, logger),
+		blkCopy:                blkcopy.NewBlkCopy(logger),
 		nodeID:                 nodeID,
 		nodeName:               nodeName,
 		log:                    logger.WithField("component", "VolumeManager"),
@@ -249,6 +290,11 @@ func (m *VolumeManager) SetProvisioners(provs map[p.VolumeType]p.Provisioner) {
 	m.provisioners = provs
 }
 
+// SetCgroupRoot sets mount point of the cgroup v2 hierarchy which is used for IO limits of the volumes
+func (m *VolumeManager) SetCgroupRoot(root string) {
+	m.cgroupOps = cgroup.NewCgroup(root, m.log.Logger)
+}
+
 // SetListBlk sets listBlk for current VolumeManager instance
 // uses in Sanity testing
 func (m *VolumeManager) SetListBlk(listBlk lsblk.WrapLsblk) {
@@ -335,6 +381,12 @@ func (m *VolumeManager) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		return m.handleExpandingStatus(ctx, volume)
 	}
 
+	// drive based volume is copied to another drive before release of its drive
+	if status := volume.Annotations[apiV1.VolumeAnnotationCopyStatus]; status == apiV1.VolumeCopyPending ||
+		status == apiV1.VolumeCopyWaiting || status == apiV1.VolumeCopyInProgress {
+		return m.handleVolumeCopy(ctx, volume)
+	}
+
 	if volume.Spec.Usage == apiV1.VolumeUsageReleasing {
 		// check for release annotation
 		releaseStatus := volume.Annotations[apiV1.VolumeAnnotationRelease]
@@ -418,59 +470,73 @@ func (m *VolumeManager) retryDriveUpdate(ctx context.Context, volume *volumecrd.
 }
 
 // handleCreatingVolumeInLVG handles volume CR that has storage class related to LogicalVolumeGroup and CSIStatus creating
-// check whether underlying LogicalVolumeGroup ready or not, add volume to LogicalVolumeGroup volumeRefs (if needed) and create real storage based on volume
+// check whether underlying LogicalVolumeGroup ready or not, add volume to LogicalVolumeGroup volumeRefs (if needed) and create real storage based on volume.
+// LogicalVolumeGroup of the volume cache is handled in the same way
 // uses as a step for Reconcile for Volume CR
 func (m *VolumeManager) handleCreatingVolumeInLVG(ctx context.Context, volume *volumecrd.Volume) (ctrl.Result, error) {
+	locations := []string{volume.Spec.Location}
+	if volume.Spec.CacheLocation != "" {
+		locations = append(locations, volume.Spec.CacheLocation)
+	}
+	for _, location := range locations {
+		if res, ready, err := m.addVolumeToLVG(ctx, volume, location); !ready {
+			return res, err
+		}
+	}
+	return m.prepareVolume(ctx, volume)
+}
+
+// addVolumeToLVG adds volume to volumeRefs of LogicalVolumeGroup with the provided name if it's ready,
+// ready is false if volume can't be created in LogicalVolumeGroup yet or volume status was set to failed
+func (m *VolumeManager) addVolumeToLVG(ctx context.Context, volume *volumecrd.Volume,
+	location string) (res ctrl.Result, ready bool, err error) {
 	ll := m.log.WithFields(logrus.Fields{
-		"method":   "handleCreatingVolumeInLVG",
+		"method":   "addVolumeToLVG",
 		"volumeID": volume.Spec.Id,
 	})
 
-	var (
-		lvg = &lvgcrd.LogicalVolumeGroup{}
-		err error
-	)
+	lvg := &lvgcrd.LogicalVolumeGroup{}
 
-	if err = m.k8sClient.ReadCR(ctx, volume.Spec.Location, "", lvg); err != nil {
-		ll.Errorf("Unable to read underlying LogicalVolumeGroup %s: %v", volume.Spec.Location, err)
+	if err = m.k8sClient.ReadCR(ctx, location, "", lvg); err != nil {
+		ll.Errorf("Unable to read underlying LogicalVolumeGroup %s: %v", location, err)
 		if k8sError.IsNotFound(err) {
 			volume.Spec.CSIStatus = apiV1.Failed
 			err = m.k8sClient.UpdateCR(ctx, volume)
 			if err == nil {
-				return ctrl.Result{}, nil // no need to retry
+				return ctrl.Result{}, false, nil // no need to retry
 			}
 			ll.Errorf("Unable to update volume CR and set status to failed: %v", err)
 		}
 		// retry because of LogicalVolumeGroup wasn't read or Volume status wasn't updated
-		return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, err
+		return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, false, err
 	}
 
 	switch lvg.Spec.Status {
 	case apiV1.Creating:
 		ll.Debugf("Underlying LogicalVolumeGroup %s is still being created", lvg.Name)
-		return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, nil
+		return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, false, nil
 	case apiV1.Failed:
 		ll.Errorf("Underlying LogicalVolumeGroup %s has reached failed status. Unable to create volume on failed lvg.", lvg.Name)
 		volume.Spec.CSIStatus = apiV1.Failed
 		if err = m.k8sClient.UpdateCR(ctx, volume); err != nil {
 			ll.Errorf("Unable to update volume CR and set status to failed: %v", err)
 			// retry because of volume status wasn't updated
-			return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, err
+			return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, false, err
 		}
-		return ctrl.Result{}, nil // no need to retry
+		return ctrl.Result{}, false, nil // no need to retry
 	case apiV1.Created:
 		// add volume ID to LogicalVolumeGroup.Spec.VolumeRefs
 		if !util.ContainsString(lvg.Spec.VolumeRefs, volume.Spec.Id) {
 			lvg.Spec.VolumeRefs = append(lvg.Spec.VolumeRefs, volume.Spec.Id)
 			if err = m.k8sClient.UpdateCR(ctx, lvg); err != nil {
 				ll.Errorf("Unable to add Volume ID to LogicalVolumeGroup %s volume refs: %v", lvg.Name, err)
-				return ctrl.Result{Requeue: true}, err
+				return ctrl.Result{Requeue: true}, false, err
 			}
 		}
-		return m.prepareVolume(ctx, volume)
+		return ctrl.Result{}, true, nil
 	default:
 		ll.Warnf("Unable to recognize LogicalVolumeGroup status. LogicalVolumeGroup - %v", lvg)
-		return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, nil
+		return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, false, nil
 	}
 }
 
@@ -482,6 +548,10 @@ func (m *VolumeManager) prepareVolume(ctx context.Context, volume *volumecrd.Vol
 		"volumeID": volume.Spec.Id,
 	})
 
+	if volume.Spec.SourceVolumeId != "" || volume.Spec.SourceSnapshotId != "" {
+		return m.prepareVolumeContent(ctx, volume)
+	}
+
 	newStatus := apiV1.Created
 
 	err := m.getProvisionerForVolume(&volume.Spec).PrepareVolume(&volume.Spec)
@@ -499,6 +569,119 @@ func (m *VolumeManager) prepareVolume(ctx context.Context, volume *volumecrd.Vol
 	return ctrl.Result{}, err
 }
 
+// prepareVolumeContent prepares real storage of the clone or restored volume and copies content of its source
+// in background, copy progress is reported in copy/progress annotation and events on the next reconciliations.
+// Volume CR's CSIStatus is updated when copy is finished, copy interrupted by restart of the node service
+// is started again
+func (m *VolumeManager) prepareVolumeContent(ctx context.Context, volume *volumecrd.Volume) (ctrl.Result, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "prepareVolumeContent",
+		"volumeID": volume.Spec.Id,
+	})
+	source := volume.Spec.SourceVolumeId
+	if source == "" {
+		source = volume.Spec.SourceSnapshotId
+	}
+
+	value, running := m.volumeCopies.Load(volume.Name)
+	if !running {
+		return m.startVolumeContentCopy(ctx, volume, source)
+	}
+
+	job := value.(*volumeCopy)
+	select {
+	case <-job.done:
+	default:
+		m.reportCopyProgress(ctx, volume, job)
+		return ctrl.Result{RequeueAfter: volumeCopyPollTimeout}, nil
+	}
+
+	newStatus := apiV1.Created
+	if job.err != nil {
+		ll.Errorf("Unable to copy content of %s: %v. Set volume status to Failed", source, job.err)
+		newStatus = apiV1.Failed
+	} else {
+		volume.Annotations[apiV1.VolumeAnnotationCopyProgress] = "100%"
+	}
+	volume.Spec.CSIStatus = newStatus
+	if err := m.k8sClient.UpdateCR(ctx, volume); err != nil {
+		ll.Errorf("Unable to update volume status to %s: %v", newStatus, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	m.volumeCopies.Delete(volume.Name)
+	if job.err != nil {
+		m.recorder.Eventf(volume, eventing.VolumeCopyFailed, "Failed to copy content of %s to volume %s: %v",
+			source, volume.Name, job.err)
+	} else {
+		m.recorder.Eventf(volume, eventing.VolumeCopyCompleted, "Content of %s was copied to volume %s",
+			source, volume.Name)
+	}
+	return ctrl.Result{}, job.err
+}
+
+// startVolumeContentCopy prepares real storage of the volume and starts copy of the source content to it in background
+func (m *VolumeManager) startVolumeContentCopy(ctx context.Context, volume *volumecrd.Volume,
+	source string) (ctrl.Result, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "startVolumeContentCopy",
+		"volumeID": volume.Spec.Id,
+	})
+
+	prov := m.getProvisionerForVolume(&volume.Spec)
+	copier, ok := prov.(p.ContentProvisioner)
+	err := fmt.Errorf("provisioner doesn't support copy of the volume content")
+	if ok {
+		err = prov.PrepareVolume(&volume.Spec)
+	}
+	if err != nil {
+		ll.Errorf("Unable to create volume size of %d bytes: %v. Set volume status to Failed", volume.Spec.Size, err)
+		volume.Spec.CSIStatus = apiV1.Failed
+		if updateErr := m.k8sClient.UpdateCR(ctx, volume); updateErr != nil {
+			ll.Errorf("Unable to update volume status to %s: %v", apiV1.Failed, updateErr)
+			return ctrl.Result{Requeue: true}, updateErr
+		}
+		return ctrl.Result{}, err
+	}
+
+	if volume.Annotations == nil {
+		volume.Annotations = map[string]string{}
+	}
+	volume.Annotations[apiV1.VolumeAnnotationCopyProgress] = "0%"
+	if err = m.k8sClient.UpdateCR(ctx, volume); err != nil {
+		ll.Errorf("Unable to update volume copy progress: %v", err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	m.recorder.Eventf(volume, eventing.VolumeCopyStarted, "Copying content of %s to volume %s", source, volume.Name)
+
+	job := &volumeCopy{target: volume.Spec, done: make(chan struct{})}
+	m.volumeCopies.Store(volume.Name, job)
+	go func() {
+		defer close(job.done)
+		job.err = copier.CopyVolumeContent(&job.target, func(copied, total int64) {
+			atomic.StoreInt64(&job.percent, copied*100/total)
+		})
+	}()
+	return ctrl.Result{RequeueAfter: volumeCopyPollTimeout}, nil
+}
+
+// reportCopyProgress reports progress of the volume copy running in background in copy/progress annotation
+// and event every volumeCopyProgressStep percents
+func (m *VolumeManager) reportCopyProgress(ctx context.Context, volume *volumecrd.Volume, job *volumeCopy) {
+	percent := atomic.LoadInt64(&job.percent)
+	if percent < job.reported+volumeCopyProgressStep || percent >= 100 {
+		return
+	}
+	job.reported = percent
+	volume.Annotations[apiV1.VolumeAnnotationCopyProgress] = fmt.Sprintf("%d%%", percent)
+	if err := m.k8sClient.UpdateCR(ctx, volume); err != nil {
+		m.log.WithFields(logrus.Fields{
+			"method":   "reportCopyProgress",
+			"volumeID": volume.Name,
+		}).Errorf("Unable to update volume copy progress: %v", err)
+	}
+	m.recorder.Eventf(volume, eventing.VolumeCopyProgress, "Copied %d%% of volume %s", percent, volume.Name)
+}
+
 // handleRemovingStatus handles volume CR with removing CSIStatus - removed real storage (partition/lv) and
 // update corresponding volume CR's CSIStatus
 // uses as a step for Reconcile for Volume CR
@@ -512,6 +695,7 @@ func (m *VolumeManager) handleRemovingStatus(ctx context.Context, volume *volume
 	if err != nil && newStatus == "" {
 		return ctrl.Result{Requeue: true}, err
 	}
+	ctx = contextAfterLongOperation(ctx, volume.Name)
 
 	volume.Spec.CSIStatus = newStatus
 	if updateErr := m.k8sClient.UpdateCR(ctx, volume); updateErr != nil {
@@ -546,7 +730,31 @@ func (m *VolumeManager) performVolumeRemoving(ctx context.Context, volume *volum
 	}
 	ll.Debugf("Got drive %+v", drive)
 
-	if err := m.getProvisionerForVolume(&volume.Spec).ReleaseVolume(&volume.Spec, &drive.Spec); err != nil {
+	wipeData := util.IsWipePolicyErasingData(volume.Spec.WipePolicy)
+	if policy := p.GetEffectiveWipePolicy(&volume.Spec); policy != volume.Spec.WipePolicy {
+		m.recorder.Eventf(volume, eventing.VolumeWipePolicyChanged,
+			"Wipe policy %s isn't applicable to volume %s in storage class %s, policy %s is used",
+			volume.Spec.WipePolicy, volume.Name, volume.Spec.StorageClass, policy)
+	}
+	if wipeData {
+		if err = m.startVolumeWipe(ctx, volume, drive); err != nil {
+			ll.Errorf("Unable to start wipe of the volume: %v", err)
+			return "", err
+		}
+	}
+
+	err = m.getProvisionerForVolume(&volume.Spec).ReleaseVolume(&volume.Spec, &drive.Spec)
+	if wipeData {
+		// wipe might take longer than reconcile timeout, results must be saved anyway
+		ctx = contextAfterLongOperation(ctx, volume.Name)
+		var finishErr error
+		if drive, finishErr = m.finishVolumeWipe(ctx, volume, err); finishErr != nil {
+			ll.Errorf("Unable to finish wipe of the volume: %v", finishErr)
+			return "", finishErr
+		}
+	}
+
+	if err != nil {
 		ll.Errorf("Failed to remove volume - %s. Error: %v. Set status to Failed", volume.Spec.Id, err)
 		drive.Spec.Usage = apiV1.DriveUsageFailed
 		if err := m.k8sClient.UpdateCR(ctx, drive); err != nil {
@@ -562,6 +770,232 @@ func (m *VolumeManager) performVolumeRemoving(ctx context.Context, volume *volum
 	return apiV1.Removed, nil
 }
 
+// startVolumeWipe marks wipe of the volume data as in progress. Drive of the drive based volume is marked as RELEASING
+// until wipe is finished, so it isn't used for the new volumes
+func (m *VolumeManager) startVolumeWipe(ctx context.Context, volume *volumecrd.Volume, drive *drivecrd.Drive) error {
+	if volume.Annotations == nil {
+		volume.Annotations = make(map[string]string)
+	}
+	volume.Annotations[apiV1.VolumeAnnotationWipeStatus] = apiV1.VolumeWipeInProgress
+	if err := m.k8sClient.UpdateCR(ctx, volume); err != nil {
+		return fmt.Errorf("unable to update volume wipe status: %w", err)
+	}
+
+	if util.IsStorageClassLVG(volume.Spec.StorageClass) || drive.Spec.Usage != apiV1.DriveUsageInUse {
+		return nil
+	}
+	if drive.Annotations == nil {
+		drive.Annotations = make(map[string]string)
+	}
+	drive.Annotations[apiV1.DriveAnnotationWipe] = volume.Name
+	drive.Spec.Usage = apiV1.DriveUsageReleasing
+	if err := m.k8sClient.UpdateCR(ctx, drive); err != nil {
+		return fmt.Errorf("unable to set drive %s usage to %s: %w", drive.Name, drive.Spec.Usage, err)
+	}
+	return nil
+}
+
+// finishVolumeWipe sets wipe status of the volume according to wipeErr and returns drive to IN_USE usage
+// if it was marked as RELEASING by startVolumeWipe. Volume CR isn't updated, it is saved with the new CSI status
+// Returns actual drive CR of the volume or error if drive wasn't updated
+func (m *VolumeManager) finishVolumeWipe(ctx context.Context, volume *volumecrd.Volume,
+	wipeErr error) (*drivecrd.Drive, error) {
+	volume.Annotations[apiV1.VolumeAnnotationWipeStatus] = apiV1.VolumeWipeDone
+	if wipeErr != nil {
+		volume.Annotations[apiV1.VolumeAnnotationWipeStatus] = apiV1.VolumeWipeFailed
+		if errors.Is(wipeErr, p.ErrWipeFailed) {
+			m.recorder.Eventf(volume, eventing.VolumeWipeFailed,
+				"Failed to wipe volume %s with policy %s: %v", volume.Name, volume.Spec.WipePolicy, wipeErr)
+		}
+	}
+
+	drive, err := m.crHelper.GetDriveCRByVolume(volume)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read drive CR: %w", err)
+	}
+	if drive.Annotations[apiV1.DriveAnnotationWipe] != volume.Name {
+		return drive, nil
+	}
+	delete(drive.Annotations, apiV1.DriveAnnotationWipe)
+	if drive.Spec.Usage == apiV1.DriveUsageReleasing {
+		drive.Spec.Usage = apiV1.DriveUsageInUse
+	}
+	if err = m.k8sClient.UpdateCR(ctx, drive); err != nil {
+		return nil, fmt.Errorf("unable to set drive %s usage to %s: %w", drive.Name, drive.Spec.Usage, err)
+	}
+	return drive, nil
+}
+
+// handleVolumeCopy copies data of the drive based volume to the drive from copy/target annotation. Volume must be
+// unstaged during copy, copy waiting for unstage is reported in copy/status annotation and event. Copy runs in
+// background, its progress is reported in copy/progress annotation and events on the next reconciliations.
+// Volume is moved to the target drive after successful copy, target drive is released if copy failed
+func (m *VolumeManager) handleVolumeCopy(ctx context.Context, volume *volumecrd.Volume) (ctrl.Result, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "handleVolumeCopy",
+		"volumeID": volume.Name,
+	})
+	targetUUID := volume.Annotations[apiV1.VolumeAnnotationCopyTarget]
+
+	value, running := m.volumeCopies.Load(volume.Name)
+	if !running {
+		if volume.Spec.CSIStatus != apiV1.Created {
+			return m.waitVolumeUnstage(ctx, volume)
+		}
+		return m.startVolumeCopy(ctx, volume)
+	}
+
+	job := value.(*volumeCopy)
+	select {
+	case <-job.done:
+		m.volumeCopies.Delete(volume.Name)
+	default:
+		m.reportCopyProgress(ctx, volume, job)
+		return ctrl.Result{RequeueAfter: volumeCopyPollTimeout}, nil
+	}
+
+	target := &drivecrd.Drive{}
+	if err := m.k8sClient.ReadCR(ctx, targetUUID, "", target); err != nil {
+		ll.Errorf("Unable to read target drive %s: %v", targetUUID, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	if job.err != nil {
+		ll.Errorf("Unable to copy volume to drive %s: %v", targetUUID, job.err)
+		m.recorder.Eventf(volume, eventing.VolumeCopyFailed, "Failed to copy volume %s to drive %s: %v",
+			volume.Name, targetUUID, job.err)
+		m.releaseCopyTarget(ctx, &job.target, target)
+		volume.Annotations[apiV1.VolumeAnnotationCopyStatus] = apiV1.VolumeCopyFailed
+		if err := m.k8sClient.UpdateCR(ctx, volume); err != nil {
+			ll.Errorf("Unable to update volume copy status: %v", err)
+			return ctrl.Result{Requeue: true}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	sourceUUID := volume.Spec.Location
+	volume.Spec.Location = targetUUID
+	volume.Spec.Health = target.Spec.Health
+	// data isn't lost, volume release isn't required anymore
+	if volume.Spec.Usage == apiV1.VolumeUsageReleasing {
+		volume.Spec.Usage = apiV1.VolumeUsageInUse
+	}
+	delete(volume.Annotations, apiV1.VolumeAnnotationCopyTarget)
+	volume.Annotations[apiV1.VolumeAnnotationCopySource] = sourceUUID
+	volume.Annotations[apiV1.VolumeAnnotationCopyStatus] = apiV1.VolumeCopyDone
+	volume.Annotations[apiV1.VolumeAnnotationCopyProgress] = "100%"
+	if err := m.k8sClient.UpdateCR(ctx, volume); err != nil {
+		ll.Errorf("Unable to move volume to drive %s: %v", targetUUID, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	ll.Infof("Volume was copied from drive %s to drive %s", sourceUUID, targetUUID)
+	m.recorder.Eventf(volume, eventing.VolumeCopyCompleted, "Volume %s was copied from drive %s to drive %s",
+		volume.Name, sourceUUID, targetUUID)
+	return ctrl.Result{}, nil
+}
+
+// waitVolumeUnstage marks copy of the staged volume as waiting for unstage, event is sent once
+func (m *VolumeManager) waitVolumeUnstage(ctx context.Context, volume *volumecrd.Volume) (ctrl.Result, error) {
+	targetUUID := volume.Annotations[apiV1.VolumeAnnotationCopyTarget]
+	m.log.WithFields(logrus.Fields{
+		"method":   "waitVolumeUnstage",
+		"volumeID": volume.Name,
+	}).Infof("Volume is %s, waiting until it's unstaged to copy it to drive %s", volume.Spec.CSIStatus, targetUUID)
+
+	if volume.Annotations[apiV1.VolumeAnnotationCopyStatus] != apiV1.VolumeCopyWaiting {
+		volume.Annotations[apiV1.VolumeAnnotationCopyStatus] = apiV1.VolumeCopyWaiting
+		if err := m.k8sClient.UpdateCR(ctx, volume); err != nil {
+			return ctrl.Result{Requeue: true}, err
+		}
+		m.recorder.Eventf(volume, eventing.VolumeCopyWaiting,
+			"Volume %s is %s, it must be unstaged to be copied to drive %s", volume.Name, volume.Spec.CSIStatus,
+			targetUUID)
+	}
+	return ctrl.Result{RequeueAfter: volumeCopyRetryTimeout}, nil
+}
+
+// startVolumeCopy starts copy of the volume to the target drive in background. Copy interrupted by restart of
+// the node service is started again
+func (m *VolumeManager) startVolumeCopy(ctx context.Context, volume *volumecrd.Volume) (ctrl.Result, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "startVolumeCopy",
+		"volumeID": volume.Name,
+	})
+	targetUUID := volume.Annotations[apiV1.VolumeAnnotationCopyTarget]
+
+	volume.Annotations[apiV1.VolumeAnnotationCopyStatus] = apiV1.VolumeCopyInProgress
+	volume.Annotations[apiV1.VolumeAnnotationCopyProgress] = "0%"
+	if err := m.k8sClient.UpdateCR(ctx, volume); err != nil {
+		ll.Errorf("Unable to update volume copy status: %v", err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	m.recorder.Eventf(volume, eventing.VolumeCopyStarted, "Copying volume %s from drive %s to drive %s",
+		volume.Name, volume.Spec.Location, targetUUID)
+
+	// target volume is created without FS and encryption, they are copied from the source
+	job := &volumeCopy{target: volume.Spec, done: make(chan struct{})}
+	job.target.Location = targetUUID
+	job.target.EncryptionSecret = ""
+	job.target.SourceVolumeId = ""
+	if job.target.Mode != apiV1.ModeRAW {
+		job.target.Mode = apiV1.ModeRAWPART
+	}
+	source := volume.Spec
+	m.volumeCopies.Store(volume.Name, job)
+	go func() {
+		defer close(job.done)
+		job.err = m.copyVolume(&source, job)
+	}()
+	return ctrl.Result{RequeueAfter: volumeCopyPollTimeout}, nil
+}
+
+// copyVolume creates partition of the target volume and copies content of the volume to it,
+// copy progress is saved in job
+func (m *VolumeManager) copyVolume(volume *api.Volume, job *volumeCopy) error {
+	prov := m.provisioners[p.DriveBasedVolumeType]
+	srcPath, err := prov.GetVolumePath(volume)
+	if err != nil {
+		return fmt.Errorf("unable to find device of the volume: %w", err)
+	}
+	if err = prov.PrepareVolume(&job.target); err != nil {
+		return fmt.Errorf("unable to prepare target device: %w", err)
+	}
+	dstPath, err := prov.GetVolumePath(&job.target)
+	if err != nil {
+		return fmt.Errorf("unable to find target device: %w", err)
+	}
+
+	return m.blkCopy.Copy(srcPath, dstPath, func(copied, total int64) {
+		atomic.StoreInt64(&job.percent, copied*100/total)
+	})
+}
+
+// releaseCopyTarget removes partially copied volume from the target drive and returns drive back to the clean state,
+// drive isn't returned if volume wasn't removed
+func (m *VolumeManager) releaseCopyTarget(ctx context.Context, targetVolume *api.Volume, target *drivecrd.Drive) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "releaseCopyTarget",
+		"volumeID": targetVolume.Id,
+	})
+	if err := m.provisioners[p.DriveBasedVolumeType].ReleaseVolume(targetVolume, &target.Spec); err != nil {
+		ll.Errorf("Unable to release target drive %s: %v", target.Name, err)
+		return
+	}
+	target.Spec.IsClean = true
+	if err := m.k8sClient.UpdateCR(ctx, target); err != nil {
+		ll.Errorf("Unable to update target drive %s: %v", target.Name, err)
+	}
+}
+
+// contextAfterLongOperation returns ctx if it isn't done yet or the new context otherwise.
+// Uses to save results of the operations which might take longer than reconcile timeout
+func contextAfterLongOperation(ctx context.Context, volumeID string) context.Context {
+	if ctx.Err() == nil {
+		return ctx
+	}
+	return context.WithValue(context.Background(), base.RequestUUID, volumeID)
+}
+
 // SetupWithManager registers VolumeManager to ControllerManager
 func (m *VolumeManager) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -600,26 +1034,31 @@ func (m *VolumeManager) isCorrespondedToNodePredicate(obj runtime.Object) bool {
 
 // Discover inspects actual drives structs from DriveManager and create volume object if partition exist on some of them
 // (in case of VolumeManager restart). Updates Drives CRs based on gathered from DriveManager information.
-// Also this method creates AC CRs. Performs at some intervals in a goroutine
+// Also this method creates AC CRs. Performs at some intervals in a goroutine and on drives changes from WatchDrives stream
+// Drives are taken from WatchDrives stream if it is alive, otherwise they are polled with GetDrivesList
 // Returns error if something went wrong during discovering
 func (m *VolumeManager) Discover() error {
 	ctx, cancelFn := context.WithTimeout(context.Background(), DiscoverDrivesTimeout)
 	defer cancelFn()
 
-	driveMgrDoneFunc := m.metricDriveMgrDuration.EvaluateDuration(prometheus.Labels{})
-	drivesResponse, err := m.driveMgrClient.GetDrivesList(ctx, &api.DrivesRequest{NodeId: m.nodeID})
-	driveMgrDoneFunc()
-	if err != nil {
-		if s, ok := status.FromError(err); ok {
-			m.log.WithField("response", s).Error("GetDrivesList returned an error")
-		} else {
-			m.log.Errorf("unable to parse a gRPC response, GetDrivesList returned err: %v", err)
+	drives, watched := m.drivesSnapshot.list()
+	if !watched {
+		driveMgrDoneFunc := m.metricDriveMgrDuration.EvaluateDuration(prometheus.Labels{})
+		drivesResponse, err := m.driveMgrClient.GetDrivesList(ctx, &api.DrivesRequest{NodeId: m.nodeID})
+		driveMgrDoneFunc()
+		if err != nil {
+			if s, ok := status.FromError(err); ok {
+				m.log.WithField("response", s).Error("GetDrivesList returned an error")
+			} else {
+				m.log.Errorf("unable to parse a gRPC response, GetDrivesList returned err: %v", err)
+			}
+			return err
 		}
-		return err
+		drives = drivesResponse.Disks
 	}
-	m.metricDriveMgrCount.Set(float64(len(drivesResponse.Disks)))
+	m.metricDriveMgrCount.Set(float64(len(drives)))
 
-	updates, err := m.updateDrivesCRs(ctx, drivesResponse.Disks)
+	updates, err := m.updateDrivesCRs(ctx, drives)
 	if err != nil {
 		return fmt.Errorf("updateDrivesCRs return error: %v", err)
 	}
@@ -632,6 +1071,11 @@ func (m *VolumeManager) Discover() error {
 		}
 	}
 
+	if err = m.discoverThinPoolsFreeSpace(); err != nil {
+		m.log.WithField("method", "Discover").
+			Errorf("unable to inspect thin pools: %v", err)
+	}
+
 	if err = m.discoverDataOnDrives(); err != nil {
 		return fmt.Errorf("discoverDataOnDrives return error: %v", err)
 	}
@@ -818,6 +1262,10 @@ func (m *VolumeManager) discoverDataOnDrives() error {
 	locations := make(map[string]struct{}, len(volumeCRs))
 	for _, v := range volumeCRs {
 		locations[v.Spec.Location] = struct{}{}
+		// cache drive is a PV of VG of the cached volume
+		if v.Spec.CacheLocation != "" {
+			locations[v.Spec.CacheLocation] = struct{}{}
+		}
 	}
 
 	for _, drive := range driveCRs {
@@ -975,6 +1423,78 @@ func (m *VolumeManager) updateLVGAnnotation(lvg *lvgcrd.LogicalVolumeGroup, vgFr
 	}
 }
 
+// discoverThinPoolsFreeSpace calculates free space of the thin pools based on the data and metadata usage of the pool
+// and virtual size of the volumes and snapshots allocated in it, and updates LVGFreeSpaceAnnotation of LVG CRs
+func (m *VolumeManager) discoverThinPoolsFreeSpace() error {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "discoverThinPoolsFreeSpace",
+	})
+
+	lvgs, err := m.cachedCrHelper.GetLVGCRs(m.nodeID)
+	if err != nil {
+		return err
+	}
+	var (
+		volumes   []volumecrd.Volume
+		snapshots []snapshotcrd.Snapshot
+	)
+	for _, lvg := range lvgs {
+		lvg := lvg
+		if lvg.Spec.ThinPool == "" || lvg.Spec.Status != apiV1.Created {
+			continue
+		}
+		// read volumes and snapshots only if there are thin pools on the node
+		if volumes == nil {
+			if volumes, err = m.cachedCrHelper.GetVolumeCRs(m.nodeID); err != nil {
+				return err
+			}
+			if snapshots, err = m.cachedCrHelper.GetSnapshotCRs(); err != nil {
+				return err
+			}
+		}
+
+		usage, err := m.lvmOps.GetThinPoolUsage(fmt.Sprintf("%s/%s", lvg.Spec.Name, lvg.Spec.ThinPool))
+		if err != nil {
+			ll.Errorf("Unable to get usage of thin pool in LVG %s: %v", lvg.Name, err)
+			continue
+		}
+		ratio, err := strconv.ParseFloat(lvg.Annotations[apiV1.LVGOvercommitRatioAnnotation], 64)
+		if err != nil {
+			ll.Warnf("Unable to parse overcommit ratio of LVG %s: %v. Assume that it's 1", lvg.Name, err)
+			ratio = 1
+		}
+
+		var allocated int64
+		for _, volume := range volumes {
+			if volume.Spec.Location == lvg.Name && volume.Spec.CSIStatus != apiV1.Removed {
+				allocated += volume.Spec.Size
+			}
+		}
+		for _, snapshot := range snapshots {
+			if snapshot.Spec.Location == lvg.Name && snapshot.Spec.CSIStatus != apiV1.Removed {
+				allocated += snapshot.Spec.Size
+			}
+		}
+
+		freeSpace := strconv.FormatInt(capacityplanner.GetThinPoolFreeSpace(usage.DataSize, allocated,
+			usage.DataPercent, usage.MetadataPercent, ratio), 10)
+		if lvg.Annotations[apiV1.LVGFreeSpaceAnnotation] == freeSpace {
+			continue
+		}
+		ll.Infof("Free space of thin pool in LVG %s is %s (data usage - %.2f%%, metadata usage - %.2f%%)",
+			lvg.Name, freeSpace, usage.DataPercent, usage.MetadataPercent)
+		if lvg.Annotations == nil {
+			lvg.Annotations = make(map[string]string, 1)
+		}
+		lvg.Annotations[apiV1.LVGFreeSpaceAnnotation] = freeSpace
+		ctx := context.WithValue(context.Background(), base.RequestUUID, lvg.Name)
+		if err = m.k8sClient.UpdateCR(ctx, &lvg); err != nil {
+			ll.Errorf("Unable to update LVG CR %s: %v", lvg.Name, err)
+		}
+	}
+	return nil
+}
+
 // getProvisionerForVolume returns appropriate Provisioner implementation for volume
 func (m *VolumeManager) getProvisionerForVolume(vol *api.Volume) p.Provisioner {
 	if util.IsStorageClassLVG(vol.StorageClass) {
@@ -1004,11 +1524,15 @@ func (m *VolumeManager) handleDriveStatusChange(ctx context.Context, drive updat
 	if lvg != nil {
 		llLVG := ll.WithField("lvg", *lvg)
 		name := lvg.Name
-		// TODO handle situation when LVG health is changing from Bad/Suspect to Good https://github.com/dell/csi-baremetal/issues/385
-		lvg.Spec.Health = cur.Health
-		llLVG.Info("Updating lvg CR's")
-		if err := m.k8sClient.UpdateCR(ctx, lvg); err != nil {
-			llLVG.Errorf("Failed to update lvg CR's %s health status: %v", name, err)
+		if lvg.Spec.RaidType != "" {
+			m.handleRaidMemberStatusChange(ctx, lvg, &cur)
+		} else {
+			// TODO handle situation when LVG health is changing from Bad/Suspect to Good https://github.com/dell/csi-baremetal/issues/385
+			lvg.Spec.Health = cur.Health
+			llLVG.Info("Updating lvg CR's")
+			if err := m.k8sClient.UpdateCR(ctx, lvg); err != nil {
+				llLVG.Errorf("Failed to update lvg CR's %s health status: %v", name, err)
+			}
 		}
 
 		// check for missing disk and re-activate volume group
@@ -1023,6 +1547,10 @@ func (m *VolumeManager) handleDriveStatusChange(ctx context.Context, drive updat
 		}
 		ll.Errorf(errMsg)
 	}
+	if lvg != nil && lvg.Spec.RaidType != "" {
+		// data of RAID volumes is kept on the other member drives, volumes stay healthy until LVG is repaired
+		return
+	}
 	// Set disk's health status to volume CR
 	volumes, _ := m.cachedCrHelper.GetVolumesByLocation(ctx, cur.UUID)
 	for _, vol := range volumes {
@@ -1036,8 +1564,9 @@ func (m *VolumeManager) handleDriveStatusChange(ctx context.Context, drive updat
 		// save previous health state
 		prevHealthState := vol.Spec.Health
 		vol.Spec.Health = cur.Health
-		// initiate volume release
-		if vol.Spec.Health == apiV1.HealthBad || vol.Spec.Health == apiV1.HealthSuspect {
+		// initiate volume release, data of SUSPECT drive might be moved by LVG migration or volume copy
+		dataMoved := isLVGMigrated(lvg, cur.UUID) || isVolumeCopied(vol, drive.CurrentState)
+		if vol.Spec.Health == apiV1.HealthBad || (vol.Spec.Health == apiV1.HealthSuspect && !dataMoved) {
 			if vol.Spec.Usage == apiV1.VolumeUsageInUse {
 				vol.Spec.Usage = apiV1.VolumeUsageReleasing
 			}
@@ -1056,6 +1585,52 @@ func (m *VolumeManager) handleDriveStatusChange(ctx context.Context, drive updat
 	}
 }
 
+// isLVGMigrated returns true if data of the drive is going to be moved from LVG by migration
+func isLVGMigrated(lvg *lvgcrd.LogicalVolumeGroup, driveUUID string) bool {
+	if lvg == nil {
+		return false
+	}
+	value, ok := lvg.Annotations[apiV1.LVGMigrateDriveAnnotation]
+	return ok && (value == apiV1.LVGMigrateDriveAuto || value == driveUUID)
+}
+
+// isVolumeCopied returns true if drive based volume is going to be copied to another drive
+func isVolumeCopied(volume *volumecrd.Volume, drive *drivecrd.Drive) bool {
+	if volume.Spec.LocationType != apiV1.LocationTypeDrive {
+		return false
+	}
+	if _, ok := drive.Annotations[apiV1.DriveAnnotationVolumeCopy]; ok {
+		return true
+	}
+	status := volume.Annotations[apiV1.VolumeAnnotationCopyStatus]
+	return status == apiV1.VolumeCopyPending || status == apiV1.VolumeCopyInProgress
+}
+
+// handleRaidMemberStatusChange marks RAID LVG as DEGRADED when its member drive becomes BAD or missing,
+// LVG gets back GOOD health after repair on the replacement drive
+func (m *VolumeManager) handleRaidMemberStatusChange(ctx context.Context, lvg *lvgcrd.LogicalVolumeGroup, drive *api.Drive) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "handleRaidMemberStatusChange",
+		"LVG":    lvg.Name,
+	})
+
+	if drive.Health != apiV1.HealthBad && drive.Status != apiV1.DriveStatusOffline {
+		return
+	}
+	if lvg.Spec.Health == apiV1.HealthDegraded {
+		return
+	}
+	ll.Warnf("Member drive %s of %s LVG is %s and %s", drive.UUID, lvg.Spec.RaidType, drive.Health, drive.Status)
+	lvg.Spec.Health = apiV1.HealthDegraded
+	if err := m.k8sClient.UpdateCR(ctx, lvg); err != nil {
+		ll.Errorf("Failed to update LVG CR health: %v", err)
+		return
+	}
+	m.recorder.Eventf(lvg, eventing.VolumeGroupDegraded,
+		"Member drive %s is %s and %s, %s volume group lost redundancy", drive.UUID, drive.Health, drive.Status,
+		lvg.Spec.RaidType)
+}
+
 func (m *VolumeManager) checkVGErrors(lvg *lvgcrd.LogicalVolumeGroup, drivePath string) {
 	ll := m.log.WithFields(logrus.Fields{
 		"method": "checkVGErrors",
@@ -1273,20 +1848,17 @@ func (m *VolumeManager) addVolumeStatusAnnotation(drive *drivecrd.Drive, volumeN
 	drive.Annotations[annotationKey] = status
 }
 
-// handleExpandingStatus handles volume CR with Resizing status, it calls ExpandLV to expand volume
-// To get logical volume name it use LVM provisioner function GetVolumePath
+// handleExpandingStatus handles volume CR with Resizing status, it calls ExpandVolume of the volume provisioner
+// to grow LV or partition of the volume
 // Receive context, volume CR
 // Return ctrl.DiscoverResult, error
 func (m *VolumeManager) handleExpandingStatus(ctx context.Context, volume *volumecrd.Volume) (ctrl.Result, error) {
 	ll := m.log.WithFields(logrus.Fields{
 		"method": "handleExpandingStatus",
 	})
-	volumePath, err := m.provisioners[p.LVMBasedVolumeType].GetVolumePath(&volume.Spec)
+	err := m.getProvisionerForVolume(&volume.Spec).ExpandVolume(&volume.Spec)
 	if err != nil {
-		ll.Errorf("Failed to get volume path, err: %v", err)
-		return ctrl.Result{Requeue: true}, err
-	}
-	if err = m.lvmOps.ExpandLV(volumePath, volume.Spec.Size); err != nil {
+		ll.Errorf("Failed to expand volume %s: %v", volume.Name, err)
 		volume.Spec.CSIStatus = apiV1.Failed
 	} else {
 		volume.Spec.CSIStatus = apiV1.Resized
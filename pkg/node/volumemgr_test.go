@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -43,9 +44,11 @@ import (
 	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/blkcopy"
 	dataDiscover "github.com/dell/csi-baremetal/pkg/base/linuxutils/datadiscover/types"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
 	"github.com/dell/csi-baremetal/pkg/base/logger/objects"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/eventing"
@@ -321,6 +324,108 @@ func TestVolumeManager_prepareVolume(t *testing.T) {
 	assert.Equal(t, volume.Spec.CSIStatus, apiV1.Failed)
 }
 
+func TestVolumeManager_prepareVolumeContent(t *testing.T) {
+	prepare := func(t *testing.T) (*VolumeManager, *vcrd.Volume, *mockProv.MockProvisioner) {
+		vm := prepareSuccessVolumeManager(t)
+		vol := volCR.DeepCopy()
+		vol.Spec.SourceVolumeId = "source-volume"
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, vol.Name, vol))
+		pMock := &mockProv.MockProvisioner{}
+		vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock})
+		return vm, vol, pMock
+	}
+
+	waitCopy := func(t *testing.T, vm *VolumeManager, volumeID string) {
+		job, ok := vm.volumeCopies.Load(volumeID)
+		assert.True(t, ok)
+		<-job.(*volumeCopy).done
+	}
+
+	t.Run("Content is copied", func(t *testing.T) {
+		vm, vol, pMock := prepare(t)
+		var (
+			recorder = &mocks.NoOpRecorder{}
+			reached  = make(chan struct{})
+			release  = make(chan struct{})
+		)
+		vm.recorder = recorder
+		pMock.On("PrepareVolume", mock.Anything).Return(nil)
+		pMock.On("CopyVolumeContent", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			progress := args.Get(1).(blkcopy.ProgressFunc)
+			progress(50, 100)
+			close(reached)
+			<-release
+			progress(100, 100)
+		}).Return(nil)
+
+		// volume is created and copy is started in background
+		res, err := vm.prepareVolume(testCtx, vol)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: volumeCopyPollTimeout}, res)
+		pMock.AssertCalled(t, "PrepareVolume", mock.Anything)
+		assert.Equal(t, eventing.VolumeCopyStarted, recorder.Calls[0].Event)
+
+		// progress is reported
+		<-reached
+		volume := &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, testNs, volume))
+		assert.Equal(t, apiV1.Creating, volume.Spec.CSIStatus)
+		res, err = vm.prepareVolume(testCtx, volume)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: volumeCopyPollTimeout}, res)
+		assert.Equal(t, "50%", volume.Annotations[apiV1.VolumeAnnotationCopyProgress])
+		assert.Equal(t, eventing.VolumeCopyProgress, recorder.Calls[len(recorder.Calls)-1].Event)
+
+		// copy is finished
+		close(release)
+		waitCopy(t, vm, vol.Name)
+		res, err = vm.prepareVolume(testCtx, volume)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		pMock.AssertNumberOfCalls(t, "PrepareVolume", 1)
+		assert.Equal(t, eventing.VolumeCopyCompleted, recorder.Calls[len(recorder.Calls)-1].Event)
+
+		volume = &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, testNs, volume))
+		assert.Equal(t, apiV1.Created, volume.Spec.CSIStatus)
+		assert.Equal(t, "100%", volume.Annotations[apiV1.VolumeAnnotationCopyProgress])
+		_, running := vm.volumeCopies.Load(vol.Name)
+		assert.False(t, running)
+	})
+
+	t.Run("Copy failed", func(t *testing.T) {
+		vm, vol, pMock := prepare(t)
+		pMock.On("PrepareVolume", mock.Anything).Return(nil)
+		pMock.On("CopyVolumeContent", mock.Anything, mock.Anything).Return(blkcopy.ErrChecksumMismatch)
+
+		res, err := vm.prepareVolume(testCtx, vol)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: volumeCopyPollTimeout}, res)
+		waitCopy(t, vm, vol.Name)
+		_, err = vm.prepareVolume(testCtx, vol)
+		assert.Equal(t, blkcopy.ErrChecksumMismatch, err)
+
+		volume := &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, testNs, volume))
+		assert.Equal(t, apiV1.Failed, volume.Spec.CSIStatus)
+	})
+
+	t.Run("PrepareVolume failed", func(t *testing.T) {
+		vm, vol, pMock := prepare(t)
+		pMock.On("PrepareVolume", mock.Anything).Return(testErr)
+
+		_, err := vm.prepareVolume(testCtx, vol)
+		assert.Equal(t, testErr, err)
+		pMock.AssertNotCalled(t, "CopyVolumeContent", mock.Anything, mock.Anything)
+		_, running := vm.volumeCopies.Load(vol.Name)
+		assert.False(t, running)
+
+		volume := &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, testNs, volume))
+		assert.Equal(t, apiV1.Failed, volume.Spec.CSIStatus)
+	})
+}
+
 func TestVolumeManager_handleRemovingStatus(t *testing.T) {
 	var (
 		vm  *VolumeManager
@@ -396,6 +501,62 @@ func TestVolumeManager_handleRemovingStatus(t *testing.T) {
 		assert.Equal(t, volume.Spec.CSIStatus, apiV1.Failed)
 	})
 
+	t.Run("wipe data of drive based volume", func(t *testing.T) {
+		vm = prepareSuccessVolumeManager(t)
+		testVol := volCR.DeepCopy()
+		testVol.Spec.CSIStatus = apiV1.Removing
+		testVol.Spec.WipePolicy = apiV1.WipePolicyZero
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testVol.Name, testVol))
+		drive := testDriveCR.DeepCopy()
+		drive.Spec.Usage = apiV1.DriveUsageInUse
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testVol.Spec.Location, drive))
+		pMock := &mockProv.MockProvisioner{}
+		pMock.On("ReleaseVolume", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			// drive must be RELEASING while wipe is in progress
+			assert.Equal(t, apiV1.DriveUsageReleasing, args.Get(1).(*api.Drive).Usage)
+		}).Return(nil)
+		vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock})
+
+		res, err = vm.handleRemovingStatus(testCtx, testVol)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		volume := &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, req.Name, testNs, volume))
+		assert.Equal(t, apiV1.Removed, volume.Spec.CSIStatus)
+		assert.Equal(t, apiV1.VolumeWipeDone, volume.Annotations[apiV1.VolumeAnnotationWipeStatus])
+		resDrive := &drivecrd.Drive{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive.Name, "", resDrive))
+		assert.Equal(t, apiV1.DriveUsageInUse, resDrive.Spec.Usage)
+		assert.Empty(t, resDrive.Annotations[apiV1.DriveAnnotationWipe])
+	})
+
+	t.Run("wipe data failed", func(t *testing.T) {
+		vm = prepareSuccessVolumeManager(t)
+		testVol := volCR.DeepCopy()
+		testVol.Spec.CSIStatus = apiV1.Removing
+		testVol.Spec.WipePolicy = apiV1.WipePolicyDiscard
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testVol.Name, testVol))
+		drive := testDriveCR.DeepCopy()
+		drive.Spec.Usage = apiV1.DriveUsageInUse
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testVol.Spec.Location, drive))
+		pMock := &mockProv.MockProvisioner{}
+		pMock.On("ReleaseVolume", mock.Anything, mock.Anything).
+			Return(fmt.Errorf("%w: blkdiscard failed", p.ErrWipeFailed))
+		vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock})
+
+		res, err = vm.handleRemovingStatus(testCtx, testVol)
+		assert.True(t, errors.Is(err, p.ErrWipeFailed))
+		assert.Equal(t, ctrl.Result{}, res)
+		volume := &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, req.Name, testNs, volume))
+		assert.Equal(t, apiV1.Failed, volume.Spec.CSIStatus)
+		assert.Equal(t, apiV1.VolumeWipeFailed, volume.Annotations[apiV1.VolumeAnnotationWipeStatus])
+		resDrive := &drivecrd.Drive{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive.Name, "", resDrive))
+		assert.Equal(t, apiV1.DriveUsageFailed, resDrive.Spec.Usage)
+		assert.Empty(t, resDrive.Annotations[apiV1.DriveAnnotationWipe])
+	})
+
 	t.Run("Volume missing", func(t *testing.T) {
 		vm = prepareSuccessVolumeManager(t)
 		testVol := volCR.DeepCopy()
@@ -599,6 +760,41 @@ func TestVolumeManager_handleCreatingVolumeInLVG(t *testing.T) {
 	res, err = vm.handleCreatingVolumeInLVG(testCtx, testVol)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedResRequeue, res)
+
+	// LogicalVolumeGroup of the volume cache is still being created
+	vm = prepareSuccessVolumeManager(t)
+	testVol = testVolumeLVGCR.DeepCopy()
+	testVol.Spec.CacheLocation = "cache-lvg"
+	pMock = &mockProv.MockProvisioner{}
+	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.LVMBasedVolumeType: pMock})
+	testLVG = testLVGCR
+	testLVG.Spec.Status = apiV1.Created
+	cacheLVG := testLVGCR.DeepCopy()
+	cacheLVG.Name = testVol.Spec.CacheLocation
+	cacheLVG.Spec.Status = apiV1.Creating
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testLVG.Name, &testLVG))
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, cacheLVG.Name, cacheLVG))
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testVol.Name, testVol))
+
+	res, err = vm.handleCreatingVolumeInLVG(testCtx, testVol)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResRequeue, res)
+	pMock.AssertNotCalled(t, "PrepareVolume", mock.Anything)
+
+	// both LogicalVolumeGroups are created, volume is added to volumeRefs of both of them
+	cacheLVG.Spec.Status = apiV1.Created
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, cacheLVG))
+	pMock.On("PrepareVolume", mock.Anything).Return(nil)
+
+	res, err = vm.handleCreatingVolumeInLVG(testCtx, testVol)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{}, res)
+
+	lvg = &lvgcrd.LogicalVolumeGroup{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, testLVG.Name, "", lvg))
+	assert.True(t, util.ContainsString(lvg.Spec.VolumeRefs, testVol.Spec.Id))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, cacheLVG.Name, "", lvg))
+	assert.True(t, util.ContainsString(lvg.Spec.VolumeRefs, testVol.Spec.Id))
 }
 
 func TestReconcile_ReconcileDefaultStatus(t *testing.T) {
@@ -954,6 +1150,254 @@ func TestVolumeManager_handleDriveStatusChange(t *testing.T) {
 	assert.Equal(t, apiV1.HealthBad, updatedLVG.Spec.Health)
 }
 
+func TestVolumeManager_handleDriveStatusChange_Raid(t *testing.T) {
+	vm := prepareSuccessVolumeManagerWithDrives(nil, t)
+
+	lvg := testLVGCR.DeepCopy()
+	lvg.Spec.Locations = []string{driveUUID, drive2.UUID}
+	lvg.Spec.RaidType = apiV1.RaidType1
+	lvg.Spec.Health = apiV1.HealthGood
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testLVGName, lvg))
+	vol := volCR.DeepCopy()
+	vol.Spec.Location = testLVGName
+	vol.Spec.StorageClass = apiV1.StorageClassHDDLVGRaid1
+	vol.Spec.LocationType = apiV1.LocationTypeLVM
+	vol.Spec.Health = apiV1.HealthGood
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testID, vol))
+
+	drive := drive1
+	drive.UUID = driveUUID
+	drive.Health = apiV1.HealthSuspect
+	update := updatedDrive{
+		PreviousState: &drivecrd.Drive{Spec: drive},
+		CurrentState:  &drivecrd.Drive{Spec: drive},
+	}
+
+	// SUSPECT member drive still keeps the data
+	vm.handleDriveStatusChange(testCtx, update)
+	updatedLVG := &lvgcrd.LogicalVolumeGroup{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, testLVGName, "", updatedLVG))
+	assert.Equal(t, apiV1.HealthGood, updatedLVG.Spec.Health)
+
+	update.CurrentState.Spec.Health = apiV1.HealthBad
+	vm.handleDriveStatusChange(testCtx, update)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, testLVGName, "", updatedLVG))
+	assert.Equal(t, apiV1.HealthDegraded, updatedLVG.Spec.Health)
+
+	// volume isn't released
+	rVolume := &vcrd.Volume{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, testID, vol.Namespace, rVolume))
+	assert.Equal(t, apiV1.HealthGood, rVolume.Spec.Health)
+	assert.Equal(t, vol.Spec.Usage, rVolume.Spec.Usage)
+}
+
+func TestVolumeManager_handleDriveStatusChange_VolumeCopy(t *testing.T) {
+	vm := prepareSuccessVolumeManagerWithDrives(nil, t)
+
+	vol := volCR.DeepCopy()
+	vol.Spec.Location = driveUUID
+	vol.Spec.LocationType = apiV1.LocationTypeDrive
+	vol.Spec.Health = apiV1.HealthGood
+	vol.Spec.Usage = apiV1.VolumeUsageInUse
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testID, vol))
+
+	drive := drive1
+	drive.UUID = driveUUID
+	drive.Health = apiV1.HealthSuspect
+	driveCR := &drivecrd.Drive{Spec: drive}
+	driveCR.Annotations = map[string]string{apiV1.DriveAnnotationVolumeCopy: apiV1.DriveAnnotationVolumeCopyAuto}
+	update := updatedDrive{PreviousState: driveCR, CurrentState: driveCR}
+
+	// data of SUSPECT drive is copied to another drive, volume isn't released
+	vm.handleDriveStatusChange(testCtx, update)
+	rVolume := &vcrd.Volume{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, testID, vol.Namespace, rVolume))
+	assert.Equal(t, apiV1.HealthSuspect, rVolume.Spec.Health)
+	assert.Equal(t, apiV1.VolumeUsageInUse, rVolume.Spec.Usage)
+}
+
+func TestVolumeManager_handleVolumeCopy(t *testing.T) {
+	prepare := func(t *testing.T) (*VolumeManager, *vcrd.Volume, *mockProv.MockProvisioner, *mocklu.MockWrapBlkCopy) {
+		vm := prepareSuccessVolumeManager(t)
+		source := testDriveCR.DeepCopy()
+		source.Spec.Health = apiV1.HealthSuspect
+		target := drivecrd.Drive{
+			TypeMeta:   v1.TypeMeta{Kind: "Drive", APIVersion: apiV1.APIV1Version},
+			ObjectMeta: v1.ObjectMeta{Name: drive2.UUID},
+			Spec:       drive2,
+		}
+		addDriveCRs(vm.k8sClient, source, &target)
+		vol := volCR.DeepCopy()
+		vol.Spec.CSIStatus = apiV1.Created
+		vol.Spec.Health = apiV1.HealthSuspect
+		vol.Spec.Usage = apiV1.VolumeUsageReleasing
+		vol.Annotations = map[string]string{
+			apiV1.VolumeAnnotationCopyTarget: drive2UUID,
+			apiV1.VolumeAnnotationCopyStatus: apiV1.VolumeCopyPending,
+		}
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, vol.Name, vol))
+
+		pMock := &mockProv.MockProvisioner{}
+		vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock})
+		blkCopy := &mocklu.MockWrapBlkCopy{}
+		vm.blkCopy = blkCopy
+		return vm, vol, pMock, blkCopy
+	}
+
+	// waitCopy waits until copy of the volume running in background is finished
+	waitCopy := func(t *testing.T, vm *VolumeManager, volumeID string) {
+		job, ok := vm.volumeCopies.Load(volumeID)
+		assert.True(t, ok)
+		<-job.(*volumeCopy).done
+	}
+
+	t.Run("Volume is staged", func(t *testing.T) {
+		vm, vol, _, blkCopy := prepare(t)
+		recorder := &mocks.NoOpRecorder{}
+		vm.recorder = recorder
+		vol.Spec.CSIStatus = apiV1.Published
+
+		res, err := vm.handleVolumeCopy(testCtx, vol)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: volumeCopyRetryTimeout}, res)
+		blkCopy.AssertNotCalled(t, "Copy", mock.Anything, mock.Anything, mock.Anything)
+
+		volume := &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, testNs, volume))
+		assert.Equal(t, apiV1.VolumeCopyWaiting, volume.Annotations[apiV1.VolumeAnnotationCopyStatus])
+		assert.Equal(t, 1, len(recorder.Calls))
+		assert.Equal(t, eventing.VolumeCopyWaiting, recorder.Calls[0].Event)
+
+		// event is sent once
+		res, err = vm.handleVolumeCopy(testCtx, volume)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: volumeCopyRetryTimeout}, res)
+		assert.Equal(t, 1, len(recorder.Calls))
+	})
+
+	t.Run("Volume is copied", func(t *testing.T) {
+		vm, vol, pMock, blkCopy := prepare(t)
+		var (
+			recorder = &mocks.NoOpRecorder{}
+			reached  = make(chan struct{})
+			release  = make(chan struct{})
+		)
+		vm.recorder = recorder
+		pMock.On("GetVolumePath", &vol.Spec).Return("/dev/sda1", nil)
+		pMock.On("PrepareVolume", mock.Anything).Return(nil)
+		pMock.On("GetVolumePath", mock.Anything).Return("/dev/sdb1", nil)
+		blkCopy.On("Copy", "/dev/sda1", "/dev/sdb1", mock.Anything).Run(func(args mock.Arguments) {
+			progress := args.Get(2).(blkcopy.ProgressFunc)
+			progress(5, 100)
+			progress(50, 100)
+			close(reached)
+			<-release
+			progress(100, 100)
+		}).Return(nil)
+
+		// copy is started in background
+		res, err := vm.handleVolumeCopy(testCtx, vol)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: volumeCopyPollTimeout}, res)
+
+		// progress is reported
+		<-reached
+		volume := &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, testNs, volume))
+		assert.Equal(t, apiV1.VolumeCopyInProgress, volume.Annotations[apiV1.VolumeAnnotationCopyStatus])
+		res, err = vm.handleVolumeCopy(testCtx, volume)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: volumeCopyPollTimeout}, res)
+		assert.Equal(t, "50%", volume.Annotations[apiV1.VolumeAnnotationCopyProgress])
+		assert.Equal(t, eventing.VolumeCopyProgress, recorder.Calls[len(recorder.Calls)-1].Event)
+
+		// copy is finished
+		close(release)
+		waitCopy(t, vm, vol.Name)
+		res, err = vm.handleVolumeCopy(testCtx, volume)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		targetVolume := pMock.Calls[1].Arguments.Get(0).(*api.Volume)
+		assert.Equal(t, drive2UUID, targetVolume.Location)
+		assert.Equal(t, apiV1.ModeRAWPART, targetVolume.Mode)
+
+		volume = &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, testNs, volume))
+		assert.Equal(t, drive2UUID, volume.Spec.Location)
+		assert.Equal(t, apiV1.HealthGood, volume.Spec.Health)
+		assert.Equal(t, apiV1.VolumeUsageInUse, volume.Spec.Usage)
+		assert.Equal(t, apiV1.VolumeCopyDone, volume.Annotations[apiV1.VolumeAnnotationCopyStatus])
+		assert.Equal(t, "100%", volume.Annotations[apiV1.VolumeAnnotationCopyProgress])
+		assert.Equal(t, drive1UUID, volume.Annotations[apiV1.VolumeAnnotationCopySource])
+		assert.Empty(t, volume.Annotations[apiV1.VolumeAnnotationCopyTarget])
+		_, running := vm.volumeCopies.Load(vol.Name)
+		assert.False(t, running)
+	})
+
+	t.Run("Copy failed", func(t *testing.T) {
+		vm, vol, pMock, blkCopy := prepare(t)
+		pMock.On("GetVolumePath", &vol.Spec).Return("/dev/sda1", nil)
+		pMock.On("PrepareVolume", mock.Anything).Return(nil)
+		pMock.On("GetVolumePath", mock.Anything).Return("/dev/sdb1", nil)
+		pMock.On("ReleaseVolume", mock.Anything, mock.Anything).Return(nil)
+		blkCopy.On("Copy", "/dev/sda1", "/dev/sdb1", mock.Anything).Return(blkcopy.ErrChecksumMismatch)
+
+		res, err := vm.handleVolumeCopy(testCtx, vol)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: volumeCopyPollTimeout}, res)
+		waitCopy(t, vm, vol.Name)
+		res, err = vm.handleVolumeCopy(testCtx, vol)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		pMock.AssertCalled(t, "ReleaseVolume", mock.Anything, mock.Anything)
+
+		volume := &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, testNs, volume))
+		assert.Equal(t, drive1UUID, volume.Spec.Location)
+		assert.Equal(t, apiV1.VolumeCopyFailed, volume.Annotations[apiV1.VolumeAnnotationCopyStatus])
+		target := &drivecrd.Drive{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive2UUID, "", target))
+		assert.True(t, target.Spec.IsClean)
+	})
+}
+
+func TestVolumeManager_handleDriveStatusChange_LVGMigration(t *testing.T) {
+	vm := prepareSuccessVolumeManagerWithDrives(nil, t)
+
+	lvg := testLVGCR.DeepCopy()
+	lvg.Spec.Locations = []string{driveUUID}
+	lvg.Annotations = map[string]string{apiV1.LVGMigrateDriveAnnotation: apiV1.LVGMigrateDriveAuto}
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testLVGName, lvg))
+	vol := volCR.DeepCopy()
+	vol.Spec.Location = testLVGName
+	vol.Spec.StorageClass = apiV1.StorageClassHDDLVG
+	vol.Spec.LocationType = apiV1.LocationTypeLVM
+	vol.Spec.Health = apiV1.HealthGood
+	vol.Spec.Usage = apiV1.VolumeUsageInUse
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testID, vol))
+
+	drive := drive1
+	drive.UUID = driveUUID
+	drive.Health = apiV1.HealthSuspect
+	update := updatedDrive{
+		PreviousState: &drivecrd.Drive{Spec: drive},
+		CurrentState:  &drivecrd.Drive{Spec: drive},
+	}
+
+	// data of SUSPECT drive is moved by migration, volume isn't released
+	vm.handleDriveStatusChange(testCtx, update)
+	rVolume := &vcrd.Volume{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, testID, vol.Namespace, rVolume))
+	assert.Equal(t, apiV1.HealthSuspect, rVolume.Spec.Health)
+	assert.Equal(t, apiV1.VolumeUsageInUse, rVolume.Spec.Usage)
+
+	update.CurrentState.Spec.Health = apiV1.HealthBad
+	vm.handleDriveStatusChange(testCtx, update)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, testID, vol.Namespace, rVolume))
+	assert.Equal(t, apiV1.HealthBad, rVolume.Spec.Health)
+	assert.Equal(t, apiV1.VolumeUsageReleasing, rVolume.Spec.Usage)
+}
+
 func Test_discoverLVGOnSystemDrive_LVGAlreadyExists(t *testing.T) {
 	var (
 		m     = prepareSuccessVolumeManager(t)
@@ -999,6 +1443,64 @@ func Test_discoverLVGOnSystemDrive_LVGAlreadyExists(t *testing.T) {
 
 }
 
+func Test_discoverThinPoolsFreeSpace(t *testing.T) {
+	var (
+		m     = prepareSuccessVolumeManager(t)
+		lvgCR = m.k8sClient.ConstructLVGCR("thin-lvg", "", api.LogicalVolumeGroup{
+			Name:      "thin-lvg",
+			Node:      m.nodeID,
+			Locations: []string{"some-uuid"},
+			Status:    apiV1.Created,
+			ThinPool:  apiV1.ThinPoolName,
+		})
+		volumeCR = m.k8sClient.ConstructVolumeCR("thin-volume", testNs, nil, api.Volume{
+			Id:        "thin-volume",
+			NodeId:    m.nodeID,
+			Location:  lvgCR.Name,
+			Size:      int64(50 * util.GBYTE),
+			CSIStatus: apiV1.Created,
+		})
+		snapshotCR = m.k8sClient.ConstructSnapshotCR("thin-snapshot", api.Snapshot{
+			Id:        "thin-snapshot",
+			VolumeId:  volumeCR.Name,
+			NodeId:    m.nodeID,
+			Location:  lvgCR.Name,
+			Size:      int64(10 * util.GBYTE),
+			CSIStatus: apiV1.Created,
+		})
+		pool     = fmt.Sprintf("%s/%s", lvgCR.Spec.Name, apiV1.ThinPoolName)
+		dataSize = int64(100 * util.GBYTE)
+		lvg      = &lvgcrd.LogicalVolumeGroup{}
+	)
+	lvgCR.Annotations = map[string]string{apiV1.LVGOvercommitRatioAnnotation: "2"}
+	assert.Nil(t, m.k8sClient.CreateCR(testCtx, lvgCR.Name, lvgCR))
+	assert.Nil(t, m.k8sClient.CreateCR(testCtx, volumeCR.Name, volumeCR))
+	assert.Nil(t, m.k8sClient.CreateCR(testCtx, snapshotCR.Name, snapshotCR))
+
+	lvmOps := &mocklu.MockWrapLVM{}
+	m.lvmOps = lvmOps
+
+	// free space is limited by virtual size of the pool: 200G - 50G - 10G
+	lvmOps.On("GetThinPoolUsage", pool).
+		Return(&lvm.ThinPoolUsage{DataSize: dataSize, DataPercent: 25, MetadataPercent: 10}, nil).Times(1)
+	assert.Nil(t, m.discoverThinPoolsFreeSpace())
+	assert.Nil(t, m.k8sClient.ReadCR(testCtx, lvgCR.Name, "", lvg))
+	assert.Equal(t, strconv.FormatInt(int64(140*util.GBYTE), 10), lvg.Annotations[apiV1.LVGFreeSpaceAnnotation])
+
+	// free space is limited by physical usage of the pool: (100G - 40%) * 2
+	lvmOps.On("GetThinPoolUsage", pool).
+		Return(&lvm.ThinPoolUsage{DataSize: dataSize, DataPercent: 10, MetadataPercent: 40}, nil).Times(1)
+	assert.Nil(t, m.discoverThinPoolsFreeSpace())
+	assert.Nil(t, m.k8sClient.ReadCR(testCtx, lvgCR.Name, "", lvg))
+	assert.Equal(t, strconv.FormatInt(int64(120*util.GBYTE), 10), lvg.Annotations[apiV1.LVGFreeSpaceAnnotation])
+
+	// usage of the pool is unknown, annotation stays the same
+	lvmOps.On("GetThinPoolUsage", pool).Return(nil, errors.New("error")).Times(1)
+	assert.Nil(t, m.discoverThinPoolsFreeSpace())
+	assert.Nil(t, m.k8sClient.ReadCR(testCtx, lvgCR.Name, "", lvg))
+	assert.Equal(t, strconv.FormatInt(int64(120*util.GBYTE), 10), lvg.Annotations[apiV1.LVGFreeSpaceAnnotation])
+}
+
 func Test_discoverLVGOnSystemDrive_LVGCreatedACNo(t *testing.T) {
 	var (
 		m             = prepareSuccessVolumeManager(t)
@@ -1291,21 +1793,12 @@ func TestVolumeManager_handleExpandingStatus(t *testing.T) {
 	)
 
 	vm = prepareSuccessVolumeManager(t)
-	pMock = &mockProv.MockProvisioner{}
-	pMock.On("GetVolumePath", &testVol.Spec).Return("path", testErr)
-	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.LVMBasedVolumeType: pMock})
-	res, err = vm.handleExpandingStatus(testCtx, &testVol)
-	assert.NotNil(t, err)
-
 	testVol = testVolumeLVGCR
 	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testVol.Name, &testVol))
 
 	pMock = &mockProv.MockProvisioner{}
-	pMock.On("GetVolumePath", &testVol.Spec).Return("path", nil)
+	pMock.On("ExpandVolume", &testVol.Spec).Return(fmt.Errorf("error")).Once()
 	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.LVMBasedVolumeType: pMock})
-	lvmOps := &mocklu.MockWrapLVM{}
-	lvmOps.On("ExpandLV", "path", testVol.Spec.Size).Return(fmt.Errorf("error"))
-	vm.lvmOps = lvmOps
 	res, err = vm.handleExpandingStatus(testCtx, &testVol)
 	assert.NotNil(t, err)
 	assert.Equal(t, ctrl.Result{}, res)
@@ -1314,11 +1807,7 @@ func TestVolumeManager_handleExpandingStatus(t *testing.T) {
 	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, testVol.Name, testVol.Namespace, vol))
 	assert.Equal(t, apiV1.Failed, vol.Spec.CSIStatus)
 
-	pMock.On("GetVolumePath", &vol.Spec).Return("path", nil)
-
-	lvmOps = &mocklu.MockWrapLVM{}
-	lvmOps.On("ExpandLV", "path", vol.Spec.Size).Return(nil)
-	vm.lvmOps = lvmOps
+	pMock.On("ExpandVolume", &testVol.Spec).Return(nil)
 	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, &testVol))
 	res, err = vm.handleExpandingStatus(testCtx, &testVol)
 	assert.Nil(t, err)
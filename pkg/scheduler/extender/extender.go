@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -34,11 +35,13 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	schedulerapi "k8s.io/kube-scheduler/extender/v1"
 
 	genV1 "github.com/dell/csi-baremetal/api/generated/v1"
 	v1 "github.com/dell/csi-baremetal/api/v1"
 	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	volcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
@@ -52,6 +55,14 @@ import (
 	"github.com/dell/csi-baremetal/pkg/metrics/common"
 )
 
+const (
+	pvcKind                   = "PersistentVolumeClaim"
+	volumeSnapshotKind        = "VolumeSnapshot"
+	volumeSnapshotContentKind = "VolumeSnapshotContent"
+	volumeSnapshotGroup       = "snapshot.storage.k8s.io"
+	volumeSnapshotVersion     = "v1"
+)
+
 // Extender holds http handlers for scheduler extender endpoints and implements logic for nodes filtering
 // based on pod volumes requirements and Available Capacities
 type Extender struct {
@@ -282,13 +293,18 @@ func (e *Extender) gatherCapacityRequestsByProvisioner(ctx context.Context, pod
 				ll.Infof("SC %s is not provisioned by CSI Baremetal driver, skip this volume", *claimSpec.StorageClassName)
 				continue
 			case managedSC:
-				requests = append(requests, createRequestFromPVCSpec(
+				request := createRequestFromPVCSpec(
 					generateEphemeralVolumeName(pod.GetName(), v.Name),
 					storageType,
 					v.Ephemeral.VolumeClaimTemplate.Labels[v1.StorageGroupLabelKey],
 					claimSpec.Resources,
 					ll,
-				))
+				)
+				request.Stripes = scs.stripes[*claimSpec.StorageClassName]
+				requests = append(requests, request)
+				if cache, ok := scs.caches[*claimSpec.StorageClassName]; ok {
+					requests = append(requests, createCacheRequest(request, cache))
+				}
 			default:
 				return nil, fmt.Errorf("scChecker return code is unfound: %d", scType)
 			}
@@ -333,13 +349,23 @@ func (e *Extender) gatherCapacityRequestsByProvisioner(ctx context.Context, pod
 				ll.Infof("SC %s is not provisioned by CSI Baremetal driver, skip PVC %s", *pvc.Spec.StorageClassName, pvc.Name)
 				continue
 			case managedSC:
-				requests = append(requests, createRequestFromPVCSpec(
+				request := createRequestFromPVCSpec(
 					pvc.Name,
 					storageType,
 					pvc.Labels[v1.StorageGroupLabelKey],
 					pvc.Spec.Resources,
 					ll,
-				))
+				)
+				request.Stripes = scs.stripes[*pvc.Spec.StorageClassName]
+				// volume with data source must be placed on the node of the source
+				if request.NodeId, err = e.getDataSourceNode(ctx, pvc); err != nil {
+					ll.Errorf("Unable to find node of data source of PVC %s: %v", pvc.Name, err)
+					return nil, err
+				}
+				requests = append(requests, request)
+				if cache, ok := scs.caches[*pvc.Spec.StorageClassName]; ok {
+					requests = append(requests, createCacheRequest(request, cache))
+				}
 			default:
 				return nil, fmt.Errorf("scChecker return code is unfound: %d", scType)
 			}
@@ -348,6 +374,97 @@ func (e *Extender) gatherCapacityRequestsByProvisioner(ctx context.Context, pod
 	return requests, nil
 }
 
+// parseCacheCapacity returns capacity of the volume cache on the drives of cacheType with the size from cacheSize
+func parseCacheCapacity(cacheType, cacheSize string) (cacheCapacity, error) {
+	cache := cacheCapacity{storageClass: util.GetCacheStorageClass(cacheType)}
+	if cache.storageClass == "" {
+		return cache, fmt.Errorf("volume cache can't be placed on %s drives", cacheType)
+	}
+	size, err := util.StrToBytes(cacheSize)
+	if err != nil {
+		return cache, err
+	}
+	if size <= 0 {
+		return cache, fmt.Errorf("cache size must be positive, got %s", cacheSize)
+	}
+	cache.size = size
+	return cache, nil
+}
+
+// createCacheRequest constructs capacity request of the volume cache, which must be placed on the same node as
+// the volume, so the planner reserves capacity for both of them or for none. Size of the cache LV is aligned by PE
+func createCacheRequest(request *genV1.CapacityRequest, cache cacheCapacity) *genV1.CapacityRequest {
+	return &genV1.CapacityRequest{
+		Name:         util.GetCacheRequestName(request.Name),
+		StorageClass: cache.storageClass,
+		Size:         capacityplanner.AlignSizeByPE(cache.size),
+		NodeId:       request.NodeId,
+	}
+}
+
+// getDataSourceNode returns ID of the node on which data source (PVC or VolumeSnapshot) of the pvc is located
+// returns empty string if pvc doesn't have data source or data source isn't provisioned by CSI Baremetal driver
+func (e *Extender) getDataSourceNode(ctx context.Context, pvc *coreV1.PersistentVolumeClaim) (string, error) {
+	dataSource := pvc.Spec.DataSource
+	if dataSource == nil {
+		return "", nil
+	}
+
+	switch {
+	case dataSource.Kind == pvcKind && dataSource.APIGroup == nil:
+		sourcePVC := &coreV1.PersistentVolumeClaim{}
+		if err := e.k8sCache.ReadCR(ctx, dataSource.Name, pvc.Namespace, sourcePVC); err != nil {
+			return "", err
+		}
+		if sourcePVC.Spec.VolumeName == "" {
+			return "", fmt.Errorf("source PVC %s isn't bound", sourcePVC.Name)
+		}
+		volume := &volcrd.Volume{}
+		if err := e.k8sClient.ReadCR(ctx, sourcePVC.Spec.VolumeName, pvc.Namespace, volume); err != nil {
+			return "", err
+		}
+		return volume.Spec.NodeId, nil
+	case dataSource.Kind == volumeSnapshotKind && dataSource.APIGroup != nil && *dataSource.APIGroup == volumeSnapshotGroup:
+		snapshotHandle, err := e.getSnapshotHandle(ctx, dataSource.Name, pvc.Namespace)
+		if err != nil {
+			return "", err
+		}
+		snapshot := &snapshotcrd.Snapshot{}
+		if err = e.k8sClient.ReadCR(ctx, snapshotHandle, "", snapshot); err != nil {
+			return "", err
+		}
+		return snapshot.Spec.NodeId, nil
+	default:
+		return "", nil
+	}
+}
+
+// getSnapshotHandle returns ID of the Snapshot CR bound to VolumeSnapshot with provided name and namespace
+func (e *Extender) getSnapshotHandle(ctx context.Context, name, namespace string) (string, error) {
+	volumeSnapshot := &unstructured.Unstructured{}
+	volumeSnapshot.SetAPIVersion(volumeSnapshotGroup + "/" + volumeSnapshotVersion)
+	volumeSnapshot.SetKind(volumeSnapshotKind)
+	if err := e.k8sClient.ReadCR(ctx, name, namespace, volumeSnapshot); err != nil {
+		return "", err
+	}
+	contentName, _, _ := unstructured.NestedString(volumeSnapshot.Object, "status", "boundVolumeSnapshotContentName")
+	if contentName == "" {
+		return "", fmt.Errorf("volume snapshot %s isn't bound", name)
+	}
+
+	content := &unstructured.Unstructured{}
+	content.SetAPIVersion(volumeSnapshotGroup + "/" + volumeSnapshotVersion)
+	content.SetKind(volumeSnapshotContentKind)
+	if err := e.k8sClient.ReadCR(ctx, contentName, "", content); err != nil {
+		return "", err
+	}
+	snapshotHandle, _, _ := unstructured.NestedString(content.Object, "status", "snapshotHandle")
+	if snapshotHandle == "" {
+		return "", fmt.Errorf("volume snapshot content %s isn't ready", contentName)
+	}
+	return snapshotHandle, nil
+}
+
 // createCapacityRequest constructs genV1.CapacityRequest based on coreV1.Volume.Name and fields from coreV1.Volume.CSI
 func (e *Extender) createCapacityRequest(ctx context.Context, podName string, volume coreV1.Volume) (request *genV1.CapacityRequest, err error) {
 	ll := e.logger.WithFields(logrus.Fields{
@@ -705,6 +822,16 @@ func calculate(events []coreV1.Event) (float64, float64, error) {
 type scChecker struct {
 	managedSCs   map[string]string
 	unmanagedSCs map[string]bool
+	// number of drives of the striped LVG requested by the related SCs
+	stripes map[string]int32
+	// volume cache requested by the related SCs
+	caches map[string]cacheCapacity
+}
+
+// cacheCapacity describes capacity of the volume cache placed on the fast drives
+type cacheCapacity struct {
+	storageClass string
+	size         int64
 }
 
 // buildSCChecker creates an instance of scChecker
@@ -714,8 +841,9 @@ func (e *Extender) buildSCChecker(ctx context.Context, log *logrus.Entry) (*scCh
 	})
 
 	var (
-		result = &scChecker{managedSCs: map[string]string{}, unmanagedSCs: map[string]bool{}}
-		scs    = storageV1.StorageClassList{}
+		result = &scChecker{managedSCs: map[string]string{}, unmanagedSCs: map[string]bool{},
+			stripes: map[string]int32{}, caches: map[string]cacheCapacity{}}
+		scs = storageV1.StorageClassList{}
 	)
 
 	if err := e.k8sCache.ReadList(ctx, &scs); err != nil {
@@ -725,6 +853,22 @@ func (e *Extender) buildSCChecker(ctx context.Context, log *logrus.Entry) (*scCh
 	for _, sc := range scs.Items {
 		if sc.Provisioner == e.provisioner {
 			result.managedSCs[sc.Name] = strings.ToUpper(sc.Parameters[base.StorageTypeKey])
+			if value, ok := sc.Parameters[base.StripesKey]; ok {
+				stripes, err := strconv.ParseInt(value, 10, 32)
+				if err != nil {
+					ll.Warningf("Unable to parse %s parameter of SC %s: %v", base.StripesKey, sc.Name, err)
+					continue
+				}
+				result.stripes[sc.Name] = int32(stripes)
+			}
+			if value, ok := sc.Parameters[base.CacheKey]; ok {
+				cache, err := parseCacheCapacity(value, sc.Parameters[base.CacheSizeKey])
+				if err != nil {
+					ll.Warningf("Unable to parse cache parameters of SC %s: %v", sc.Name, err)
+					continue
+				}
+				result.caches[sc.Name] = cache
+			}
 		} else {
 			result.unmanagedSCs[sc.Name] = true
 		}
@@ -31,6 +31,7 @@ import (
 	storageV1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -224,6 +225,38 @@ func TestExtender_gatherVolumesByProvisioner_Success(t *testing.T) {
 	assert.Equal(t, 2, len(volumes))
 }
 
+func TestExtender_gatherVolumesByProvisioner_Cache(t *testing.T) {
+	e := setup(t)
+	pod := testPod.DeepCopy()
+	pod.Spec.Volumes = append(pod.Spec.Volumes, coreV1.Volume{
+		VolumeSource: coreV1.VolumeSource{
+			PersistentVolumeClaim: &coreV1.PersistentVolumeClaimVolumeSource{
+				ClaimName: testPVC1Name,
+			},
+		},
+	})
+	sc := testSC1.DeepCopy()
+	sc.Parameters = map[string]string{base.StorageTypeKey: v1.StorageClassHDDLVG,
+		base.CacheKey: "nvme", base.CacheSizeKey: "10Gi"}
+	applyObjs(t, e.k8sClient, testPVC1.DeepCopy(), sc)
+
+	requests, err := e.gatherCapacityRequestsByProvisioner(testCtx, pod)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(requests))
+	assert.Equal(t, testPVC1Name, requests[0].Name)
+	assert.Equal(t, v1.StorageClassHDDLVG, requests[0].StorageClass)
+	assert.Equal(t, util.GetCacheRequestName(testPVC1Name), requests[1].Name)
+	assert.Equal(t, v1.StorageClassNVMe, requests[1].StorageClass)
+	assert.Equal(t, int64(10*util.GBYTE), requests[1].Size)
+
+	// cache on HDD isn't supported, so SC is handled as without cache
+	sc.Parameters[base.CacheKey] = v1.DriveTypeHDD
+	assert.Nil(t, e.k8sClient.UpdateCR(testCtx, sc))
+	requests, err = e.gatherCapacityRequestsByProvisioner(testCtx, pod)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(requests))
+}
+
 func TestExtender_gatherVolumesByProvisioner_Fail(t *testing.T) {
 	e := setup(t)
 
@@ -275,6 +308,69 @@ func TestExtender_gatherVolumesByProvisioner_Fail(t *testing.T) {
 	assert.Equal(t, int64(0), volumes[0].Size)
 }
 
+func TestExtender_getDataSourceNode(t *testing.T) {
+	var (
+		e          = setup(t)
+		apiGroup   = volumeSnapshotGroup
+		sourcePVC  = testPVC1.DeepCopy()
+		clonePVC   = testPVC1.DeepCopy()
+		restorePVC = testPVC1.DeepCopy()
+		volume     = &volcrd.Volume{
+			TypeMeta:   metaV1.TypeMeta{Kind: "Volume", APIVersion: v1.APIV1Version},
+			ObjectMeta: metaV1.ObjectMeta{Name: "pvc-source", Namespace: testNs},
+			Spec:       genV1.Volume{Id: "pvc-source", NodeId: "node-1"},
+		}
+		snapshot = e.k8sClient.ConstructSnapshotCR("snapshot-1", genV1.Snapshot{Id: "snapshot-1", NodeId: "node-2"})
+	)
+	clonePVC.Name = "clone-pvc"
+	clonePVC.Spec.DataSource = &coreV1.TypedLocalObjectReference{Kind: pvcKind, Name: sourcePVC.Name}
+	restorePVC.Name = "restore-pvc"
+	restorePVC.Spec.DataSource = &coreV1.TypedLocalObjectReference{
+		APIGroup: &apiGroup, Kind: volumeSnapshotKind, Name: "volume-snapshot"}
+
+	// PVC without data source
+	nodeID, err := e.getDataSourceNode(testCtx, sourcePVC)
+	assert.Nil(t, err)
+	assert.Empty(t, nodeID)
+
+	// source PVC doesn't exist
+	_, err = e.getDataSourceNode(testCtx, clonePVC)
+	assert.NotNil(t, err)
+
+	// source PVC isn't bound
+	applyObjs(t, e.k8sClient, sourcePVC)
+	_, err = e.getDataSourceNode(testCtx, clonePVC)
+	assert.NotNil(t, err)
+
+	sourcePVC.Spec.VolumeName = volume.Name
+	assert.Nil(t, e.k8sClient.Update(testCtx, sourcePVC))
+	applyObjs(t, e.k8sClient, volume)
+	nodeID, err = e.getDataSourceNode(testCtx, clonePVC)
+	assert.Nil(t, err)
+	assert.Equal(t, volume.Spec.NodeId, nodeID)
+
+	// volume snapshot doesn't exist
+	_, err = e.getDataSourceNode(testCtx, restorePVC)
+	assert.NotNil(t, err)
+
+	volumeSnapshot := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": volumeSnapshotGroup + "/" + volumeSnapshotVersion,
+		"kind":       volumeSnapshotKind,
+		"metadata":   map[string]interface{}{"name": "volume-snapshot", "namespace": testNs},
+		"status":     map[string]interface{}{"boundVolumeSnapshotContentName": "snapcontent-1"},
+	}}
+	content := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": volumeSnapshotGroup + "/" + volumeSnapshotVersion,
+		"kind":       volumeSnapshotContentKind,
+		"metadata":   map[string]interface{}{"name": "snapcontent-1", "namespace": testNs},
+		"status":     map[string]interface{}{"snapshotHandle": snapshot.Name},
+	}}
+	applyObjs(t, e.k8sClient, volumeSnapshot, content, snapshot)
+	nodeID, err = e.getDataSourceNode(testCtx, restorePVC)
+	assert.Nil(t, err)
+	assert.Equal(t, snapshot.Spec.NodeId, nodeID)
+}
+
 func TestExtender_constructVolumeFromCSISource_Success(t *testing.T) {
 	e := setup(t)
 	expectedSize, err := util.StrToBytes(testSizeStr)
@@ -630,6 +726,18 @@ func TestExtender_getSCNameStorageType_Success(t *testing.T) {
 	assert.Equal(t, m.managedSCs[testSCName1], testStorageType)
 }
 
+func TestExtender_buildSCChecker_Stripes(t *testing.T) {
+	e := setup(t)
+	sc := testSC1.DeepCopy()
+	sc.Parameters = map[string]string{base.StorageTypeKey: v1.StorageClassHDDLVGStriped, base.StripesKey: "3"}
+	applyObjs(t, e.k8sClient, sc)
+
+	m, err := e.buildSCChecker(testCtx, testLogger.WithField("test", "buildSCChecker"))
+	assert.Nil(t, err)
+	assert.Equal(t, v1.StorageClassHDDLVGStriped, m.managedSCs[testSCName1])
+	assert.Equal(t, int32(3), m.stripes[testSCName1])
+}
+
 func TestExtender_buildSCChecker_Fail(t *testing.T) {
 	e := setup(t)
 